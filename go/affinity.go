@@ -0,0 +1,109 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"sync"
+)
+
+// affinityTransport keeps requests to the SQL endpoint on the same backend
+// when it sits behind a load balancer or gateway that pins clients with a
+// cookie or header. Cookies set by the server are replayed like a browser
+// would, and the values of the named response headers are sent back on
+// later requests to the same host. The affinity is shared by all the
+// sessions of a database, since they share one HTTP client.
+type affinityTransport struct {
+	base    http.RoundTripper
+	jar     http.CookieJar
+	headers []string
+
+	mu     sync.Mutex
+	values map[string]http.Header // by host
+}
+
+// newAffinityTransport wraps base, replaying cookies if cookies is set and
+// the given response headers.
+func newAffinityTransport(base http.RoundTripper, cookies bool, headers []string) *affinityTransport {
+	t := &affinityTransport{base: base, values: make(map[string]http.Header)}
+	if cookies {
+		// cookiejar.New only fails for an invalid public suffix list
+		t.jar, _ = cookiejar.New(nil)
+	}
+	for _, name := range headers {
+		t.headers = append(t.headers, http.CanonicalHeaderKey(name))
+	}
+	return t
+}
+
+func (t *affinityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given
+	out := req.Clone(req.Context())
+	if t.jar != nil {
+		for _, cookie := range t.jar.Cookies(req.URL) {
+			if _, err := req.Cookie(cookie.Name); err == http.ErrNoCookie {
+				out.AddCookie(cookie)
+			}
+		}
+	}
+	t.mu.Lock()
+	for name, values := range t.values[req.URL.Host] {
+		if out.Header.Get(name) == "" {
+			out.Header[name] = values
+		}
+	}
+	t.mu.Unlock()
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		return resp, err
+	}
+	if t.jar != nil {
+		if cookies := resp.Cookies(); len(cookies) > 0 {
+			t.jar.SetCookies(req.URL, cookies)
+		}
+	}
+	t.remember(req.URL.Host, resp.Header)
+	return resp, nil
+}
+
+// remember stores the affinity headers in a response from host.
+func (t *affinityTransport) remember(host string, header http.Header) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range t.headers {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		if t.values[host] == nil {
+			t.values[host] = make(http.Header)
+		}
+		t.values[host][name] = append([]string(nil), values...)
+	}
+}
+
+// parseHeaderList splits a comma-separated list of header names.
+func parseHeaderList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffinityTransport(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if len(requests) == 1 {
+			http.SetCookie(w, &http.Cookie{Name: "lb-route", Value: "backend-2", Path: "/"})
+			w.Header().Set("X-Route-Token", "route-7")
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newAffinityTransport(http.DefaultTransport, true, []string{"x-route-token"})}
+	for range 3 {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/sql/1.0/warehouses/abc", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Empty(t, req.Header.Get("Cookie"), "the caller's request must not be modified")
+	}
+
+	require.Len(t, requests, 3)
+	_, err := requests[0].Cookie("lb-route")
+	assert.ErrorIs(t, err, http.ErrNoCookie)
+	assert.Empty(t, requests[0].Header.Get("X-Route-Token"))
+	for _, r := range requests[1:] {
+		cookie, err := r.Cookie("lb-route")
+		require.NoError(t, err)
+		assert.Equal(t, "backend-2", cookie.Value)
+		assert.Equal(t, "route-7", r.Header.Get("X-Route-Token"))
+	}
+}
+
+func TestAffinityTransportDisabledCookies(t *testing.T) {
+	var cookies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies = append(cookies, r.Header.Get("Cookie"))
+		http.SetCookie(w, &http.Cookie{Name: "lb-route", Value: "backend-2"})
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newAffinityTransport(http.DefaultTransport, false, nil)}
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Equal(t, []string{"", ""}, cookies)
+}
+
+func TestParseHeaderList(t *testing.T) {
+	assert.Equal(t, []string{"X-Route", "X-Backend"}, parseHeaderList(" X-Route, ,X-Backend "))
+	assert.Empty(t, parseHeaderList(""))
+}
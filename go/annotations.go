@@ -0,0 +1,88 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type annotationsKey struct{}
+
+// WithStatementAnnotations returns a copy of ctx carrying annotations (for
+// example a job name or a trace ID) for the statements executed with it.
+// Annotations are merged with any already present in ctx, with the new
+// values taking precedence.
+//
+// The driver appends the annotations to the SQL text as a comment in the
+// sqlcommenter format, e.g. "SELECT 1\n/*job='nightly',trace_id='abc'*/",
+// so that they show up in the Databricks query history, and adds them to
+// the driver's log lines for the statement.
+func WithStatementAnnotations(ctx context.Context, annotations map[string]string) context.Context {
+	merged := maps.Clone(StatementAnnotations(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(annotations))
+	}
+	maps.Copy(merged, annotations)
+	return context.WithValue(ctx, annotationsKey{}, merged)
+}
+
+// StatementAnnotations returns the annotations carried by ctx, or nil. The
+// returned map must not be modified.
+func StatementAnnotations(ctx context.Context) map[string]string {
+	annotations, _ := ctx.Value(annotationsKey{}).(map[string]string)
+	return annotations
+}
+
+// annotateQuery appends the annotations carried by ctx to query as a
+// comment. The comment starts on a new line so that a trailing "--"
+// comment in the query cannot swallow it.
+func annotateQuery(ctx context.Context, query string) string {
+	annotations := StatementAnnotations(ctx)
+	if len(annotations) == 0 {
+		return query
+	}
+
+	var b strings.Builder
+	b.WriteString(query)
+	b.WriteString("\n/*")
+	for i, key := range slices.Sorted(maps.Keys(annotations)) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		// Percent-encoding escapes quotes, '*' and '/', so the comment
+		// can't be terminated early
+		b.WriteString(url.PathEscape(key))
+		b.WriteString("='")
+		b.WriteString(url.PathEscape(annotations[key]))
+		b.WriteByte('\'')
+	}
+	b.WriteString("*/")
+	return b.String()
+}
+
+// annotationAttrs returns the annotations carried by ctx as log attributes.
+func annotationAttrs(ctx context.Context) []any {
+	annotations := StatementAnnotations(ctx)
+	attrs := make([]any, 0, len(annotations))
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		attrs = append(attrs, slog.String(key, annotations[key]))
+	}
+	return attrs
+}
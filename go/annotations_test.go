@@ -0,0 +1,48 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotateQuery(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "SELECT 1", annotateQuery(ctx, "SELECT 1"))
+
+	ctx = WithStatementAnnotations(ctx, map[string]string{
+		"trace_id": "abc123",
+		"job":      "nightly load",
+	})
+	assert.Equal(t, "SELECT 1 -- note\n/*job='nightly%20load',trace_id='abc123'*/",
+		annotateQuery(ctx, "SELECT 1 -- note"))
+
+	// Values can't close the comment or the quotes
+	ctx = WithStatementAnnotations(ctx, map[string]string{"job": "it's */ done"})
+	assert.Equal(t, "SELECT 1\n/*job='it%27s%20%2A%2F%20done',trace_id='abc123'*/",
+		annotateQuery(ctx, "SELECT 1"))
+}
+
+func TestWithStatementAnnotationsDoesNotModifyParent(t *testing.T) {
+	parent := WithStatementAnnotations(context.Background(), map[string]string{"a": "1"})
+	child := WithStatementAnnotations(parent, map[string]string{"a": "2", "b": "3"})
+
+	assert.Equal(t, map[string]string{"a": "1"}, StatementAnnotations(parent))
+	assert.Equal(t, map[string]string{"a": "2", "b": "3"}, StatementAnnotations(child))
+	assert.Nil(t, StatementAnnotations(context.Background()))
+}
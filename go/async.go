@@ -0,0 +1,148 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// AsyncExecutor is implemented by the statements of this driver.
+//
+// With OptionExecutionAsync set, ExecuteQuery submits the query and returns
+// at once with an empty result, leaving the query running on the
+// warehouse; its ID is in OptionStatementQueryID. QueryStatus then polls
+// the query, QueryResult waits for it to finish and reads its result, and
+// CancelQuery cancels it. All three fail with StatusInvalidState if no
+// query has been submitted since the statement's query was last set.
+// Cancelling the context of ExecuteQuery doesn't cancel a submitted query,
+// nor does closing the statement.
+type AsyncExecutor interface {
+	QueryStatus(ctx context.Context) (QueryStatus, error)
+	QueryResult(ctx context.Context) (array.RecordReader, error)
+	CancelQuery(ctx context.Context) error
+}
+
+// QueryStatus is the state of a query submitted with OptionExecutionAsync.
+type QueryStatus struct {
+	QueryID string
+	// The state the Statement Execution API reports: PENDING, RUNNING,
+	// SUCCEEDED, FAILED, CANCELED or CLOSED
+	State string
+	// Whether the query has finished, in whichever state
+	Done bool
+	// Why the query failed, if it did
+	Error string
+}
+
+// executeAsync submits query through the Statement Execution API without
+// waiting for it, for OptionExecutionAsync, and returns an empty result.
+func (s *statementImpl) executeAsync(ctx context.Context, query string, args []driver.NamedValue, pending []boundQuery) (array.RecordReader, int64, error) {
+	api := s.conn.statementAPI
+	if api == nil {
+		return nil, -1, s.conn.unsupported(FeatureAsyncExecution)
+	}
+	if len(args) > 0 || len(pending) > 0 {
+		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "bound parameters can't be used with %s", OptionExecutionAsync)
+	}
+
+	resp, err := api.submit(ctx, query, s.conn.catalog, s.conn.dbSchema, "0s")
+	if err != nil {
+		return nil, -1, err
+	}
+	reportQueryID(ctx, resp.StatementID)
+	s.asyncQuery = resp.StatementID
+	s.logExecution(ctx, "submitted query", "statement_id", resp.StatementID, "state", resp.Status.State)
+
+	rdr, err := array.NewRecordReader(arrow.NewSchema(nil, nil), nil)
+	if err != nil {
+		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create record reader: %v", err)
+	}
+	return rdr, -1, nil
+}
+
+// submittedQuery returns the ID of the query submitted with
+// OptionExecutionAsync.
+func (s *statementImpl) submittedQuery() (string, error) {
+	if s.conn == nil {
+		return "", s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement already closed")
+	}
+	if s.asyncQuery == "" {
+		return "", s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query submitted with %s", OptionExecutionAsync)
+	}
+	return s.asyncQuery, nil
+}
+
+func (s *statementImpl) QueryStatus(ctx context.Context) (QueryStatus, error) {
+	id, err := s.submittedQuery()
+	if err != nil {
+		return QueryStatus{}, err
+	}
+	resp, err := s.conn.statementAPI.get(ctx, id)
+	if err != nil {
+		return QueryStatus{}, s.recordExecution(ctx, "QueryStatus", id, err)
+	}
+	status := QueryStatus{QueryID: id, State: resp.Status.State, Done: !resp.running()}
+	if resp.Status.Error != nil {
+		status.Error = resp.Status.Error.Message
+	}
+	return status, nil
+}
+
+func (s *statementImpl) QueryResult(ctx context.Context) (rdr array.RecordReader, err error) {
+	id, err := s.submittedQuery()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = s.recordExecution(ctx, "QueryResult", id, err) }()
+
+	api := s.conn.statementAPI
+	resp, err := api.get(ctx, id)
+	for err == nil && resp.running() {
+		select {
+		case <-ctx.Done():
+			return nil, adbc.Error{Code: adbc.StatusCancelled, Msg: ctx.Err().Error()}
+		case <-api.clock.After(statementPollInterval):
+		}
+		resp, err = api.get(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.finished(); err != nil {
+		return nil, err
+	}
+	if err := s.checkResultSize(resp); err != nil {
+		return nil, err
+	}
+	reader, err := s.conn.newResultReader(s.laneContext(ctx, resp), s.alloc, resp)
+	if err != nil {
+		return nil, err
+	}
+	s.resultProtocol = OptionValueProtocolREST
+	return s.conn.trackResult(reader), nil
+}
+
+func (s *statementImpl) CancelQuery(ctx context.Context) error {
+	id, err := s.submittedQuery()
+	if err != nil {
+		return err
+	}
+	return s.recordExecution(ctx, "CancelQuery", id, s.conn.statementAPI.cancelContext(ctx, id))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteAsync(t *testing.T) {
+	defer func(interval time.Duration) { statementPollInterval = interval }(statementPollInterval)
+	statementPollInterval = time.Millisecond
+
+	var polls, cancels atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/2.0/sql/statements", func(w http.ResponseWriter, r *http.Request) {
+		var req statementRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "SELECT count(*) AS n FROM big", req.Statement)
+		assert.Equal(t, "0s", req.WaitTimeout)
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "PENDING"}}`)
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 3 {
+			_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "RUNNING"}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "SUCCEEDED"},
+			"manifest": {"total_chunk_count": 0, "schema": {"columns": [{"name": "n", "type_text": "BIGINT"}]}}}`)
+	})
+	mux.HandleFunc("POST /api/2.0/sql/statements/stmt-1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		cancels.Add(1)
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cnxn := &connectionImpl{
+		statementAPI: &statementAPI{
+			client:      server.Client(),
+			baseURL:     server.URL,
+			auth:        &pat.PATAuth{AccessToken: "token"},
+			warehouseID: "wh",
+		},
+	}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	stmt := &statementImpl{conn: cnxn, alloc: newTrackingAllocator(memory.DefaultAllocator), fetchLane: DefaultFetchLane}
+
+	// Nothing has been submitted yet
+	_, err := stmt.QueryStatus(context.Background())
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+
+	rdr, _, err := stmt.executeAsync(context.Background(), "SELECT count(*) AS n FROM big", nil, nil)
+	require.NoError(t, err)
+	assert.Zero(t, rdr.Schema().NumFields())
+	assert.False(t, rdr.Next())
+	rdr.Release()
+
+	status, err := stmt.QueryStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, QueryStatus{QueryID: "stmt-1", State: "RUNNING"}, status)
+
+	rdr, err = stmt.QueryResult(context.Background())
+	require.NoError(t, err)
+	defer rdr.Release()
+	assert.Equal(t, "n", rdr.Schema().Field(0).Name)
+	assert.EqualValues(t, 3, polls.Load())
+
+	status, err = stmt.QueryStatus(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.Done)
+
+	require.NoError(t, stmt.CancelQuery(context.Background()))
+	assert.EqualValues(t, 1, cancels.Load())
+
+	// Setting a new query forgets the submitted one
+	require.NoError(t, stmt.resetQueryState())
+	err = stmt.CancelQuery(context.Background())
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+
+	// Bound parameters aren't submitted
+	_, _, err = stmt.executeAsync(context.Background(), "SELECT ?", nil, []boundQuery{{query: "SELECT 1"}})
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+}
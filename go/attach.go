@@ -0,0 +1,193 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// ResultAttacher is implemented by the connections of this driver.
+//
+// AttachResult reads the result of a query that has already finished, by
+// the ID the server assigned to it (see OptionStatementQueryID), so a query
+// can be submitted by one process and its result fetched by another. The
+// query must have run on a SQL warehouse and its result must still be
+// retained by the server; a query that is still running is reported as
+// StatusInvalidState, and one whose result is gone as StatusNotFound.
+type ResultAttacher interface {
+	AttachResult(ctx context.Context, queryID string) (array.RecordReader, error)
+}
+
+func (c *connectionImpl) AttachResult(ctx context.Context, queryID string) (rdr array.RecordReader, err error) {
+	defer func() { err = c.recordError("AttachResult", queryID, err) }()
+
+	if queryID == "" {
+		return nil, c.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "no query ID given")
+	}
+	api := c.statementAPI
+	if api == nil {
+		return nil, c.unsupported(FeatureAttachResults)
+	}
+	resp, err := api.get(ctx, queryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.finished(); err != nil {
+		return nil, err
+	}
+	return c.newResultReader(ctx, c.Alloc, resp)
+}
+
+// newResultReader returns a reader over the result of a finished statement,
+// with record batches allocated from mem.
+func (c *connectionImpl) newResultReader(ctx context.Context, mem memory.Allocator, resp *statementResponse) (array.RecordReader, error) {
+	result, err := c.newResultReaderFrom(ctx, mem, resp, 0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newResultReaderFrom is newResultReader, starting at the chunk with index
+// first.
+func (c *connectionImpl) newResultReaderFrom(ctx context.Context, mem memory.Allocator, resp *statementResponse, first int64) (*resultReader, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	result := &resultReader{
+		refCount:    1,
+		ctx:         ctx,
+		cancel:      cancel,
+		conn:        c,
+		mem:         mem,
+		statementID: resp.StatementID,
+		chunkCount:  resp.Manifest.TotalChunkCount,
+		declared:    declaredManifestTypes(resp.Manifest),
+		next:        first,
+	}
+	if result.chunkCount > first {
+		// The schema comes from the first chunk read, as for partitions
+		if err := result.openNext(); err != nil {
+			result.Release()
+			return nil, err
+		}
+		result.schema = result.current.Schema()
+		return result, nil
+	}
+	schema, err := manifestSchema(resp.Manifest)
+	if err != nil {
+		result.Release()
+		return nil, c.ErrorHelper.Errorf(adbc.StatusInternal, "%v", err)
+	}
+	result.schema = schema
+	return result, nil
+}
+
+// resultReader reads every chunk of a finished statement's result in order.
+type resultReader struct {
+	refCount    int64
+	ctx         context.Context
+	cancel      context.CancelFunc
+	conn        *connectionImpl
+	mem         memory.Allocator
+	statementID string
+	chunkCount  int64
+	schema      *arrow.Schema
+	declared    []arrow.DataType
+
+	next    int64
+	current *chunkReader
+	err     error
+}
+
+// openNext opens the next chunk in place of the current one.
+func (r *resultReader) openNext() error {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	rdr, err := r.conn.readChunk(r.ctx, r.mem, r.statementID, r.next)
+	if err != nil {
+		return err
+	}
+	r.next++
+	r.current = rdr
+	return nil
+}
+
+func (r *resultReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *resultReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		// Stop a download in progress rather than waiting for it
+		r.cancel()
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+	}
+}
+
+func (r *resultReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *resultReader) Next() bool {
+	for r.err == nil && r.current != nil {
+		if r.current.Next() {
+			return true
+		}
+		if r.err = r.current.Err(); r.err != nil {
+			return false
+		}
+		if r.next >= r.chunkCount {
+			return false
+		}
+		r.err = r.openNext()
+	}
+	return false
+}
+
+func (r *resultReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *resultReader) RecordBatch() arrow.RecordBatch {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.RecordBatch()
+}
+
+func (r *resultReader) declaredTypes() []arrow.DataType {
+	return r.declared
+}
+
+func (r *resultReader) batchStats() batchStats {
+	if r.current == nil {
+		return batchStats{}
+	}
+	return r.current.batchStats()
+}
+
+func (r *resultReader) Err() error {
+	return classifyError(r.err)
+}
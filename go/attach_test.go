@@ -0,0 +1,109 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachResult(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	batch := makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"})
+	chunk := writeIPCStream(t, concatTestSchema, batch)
+	batch.Release()
+
+	f := newFakeStatementAPI(t, chunk, 0)
+	cnxn := &connectionImpl{statementAPI: f.client()}
+	cnxn.Alloc = mem
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+
+	t.Run("query ID of a finished query", func(t *testing.T) {
+		adbcStmt, err := cnxn.NewStatement()
+		require.NoError(t, err)
+		stmt := adbcStmt.(*statementImpl)
+		defer func() { require.NoError(t, stmt.Close()) }()
+
+		queryID, err := stmt.GetOption(OptionStatementQueryID)
+		require.NoError(t, err)
+		assert.Empty(t, queryID)
+
+		require.NoError(t, stmt.SetSqlQuery("SELECT 1"))
+		_, _, _, err = stmt.ExecutePartitions(context.Background())
+		require.NoError(t, err)
+		queryID, err = stmt.GetOption(OptionStatementQueryID)
+		require.NoError(t, err)
+		assert.Equal(t, "stmt-1", queryID)
+	})
+
+	t.Run("attach", func(t *testing.T) {
+		rdr, err := cnxn.AttachResult(context.Background(), "stmt-1")
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		assert.True(t, concatTestSchema.Equal(rdr.Schema()))
+		require.True(t, rdr.Next())
+		assert.Equal(t, []int64{1, 2}, rdr.RecordBatch().Column(0).(*array.Int64).Int64Values())
+		assert.False(t, rdr.Next())
+		require.NoError(t, rdr.Err())
+	})
+
+	for _, tc := range []struct {
+		name    string
+		cnxn    *connectionImpl
+		queryID string
+		code    adbc.Status
+	}{
+		{"unknown query", cnxn, "stmt-2", adbc.StatusNotFound},
+		{"no query ID", cnxn, "", adbc.StatusInvalidArgument},
+		{"no warehouse", &connectionImpl{}, "stmt-1", adbc.StatusNotImplemented},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cnxn.AttachResult(context.Background(), tc.queryID)
+			var adbcErr adbc.Error
+			require.ErrorAs(t, err, &adbcErr)
+			assert.Equal(t, tc.code, adbcErr.Code)
+		})
+	}
+}
+
+func TestStatementFinished(t *testing.T) {
+	for _, tc := range []struct {
+		state string
+		code  adbc.Status
+	}{
+		{statementStatePending, adbc.StatusInvalidState},
+		{statementStateRunning, adbc.StatusInvalidState},
+		{statementStateClosed, adbc.StatusNotFound},
+		{"FAILED", adbc.StatusInternal},
+	} {
+		t.Run(tc.state, func(t *testing.T) {
+			resp := statementResponse{StatementID: "stmt-1"}
+			resp.Status.State = tc.state
+			var adbcErr adbc.Error
+			require.ErrorAs(t, resp.finished(), &adbcErr)
+			assert.Equal(t, tc.code, adbcErr.Code)
+		})
+	}
+}
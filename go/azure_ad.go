@@ -0,0 +1,104 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+const (
+	// azureDatabricksScope requests a token for the Azure Databricks
+	// application, which every Azure Databricks workspace accepts.
+	azureDatabricksScope = "2ff814a6-4cb4-4f7b-9a4f-cba5c4d0a4d7/.default"
+	// azureRefreshMargin is how long before it expires a Microsoft Entra
+	// ID token is replaced.
+	azureRefreshMargin = time.Minute
+)
+
+// azureAuth authenticates requests as a Microsoft Entra ID (Azure AD)
+// service principal, with tokens from the tenant's token endpoint
+// obtained with the client credentials grant. Azure Databricks accepts
+// these tokens directly, so no exchange with the workspace is needed.
+type azureAuth struct {
+	client       *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	clock        driverClock
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// newAzureAuth returns the authenticator of OptionOAuthAzureTenantID.
+func (d *databaseImpl) newAzureAuth() *azureAuth {
+	return &azureAuth{
+		client:       &http.Client{Transport: d.httpTransport()},
+		tokenURL:     strings.TrimSuffix(d.azureLoginEndpoint, "/") + "/" + url.PathEscape(d.azureTenantID) + "/oauth2/v2.0/token",
+		clientID:     d.oauthClientID,
+		clientSecret: d.oauthClientSecret,
+		clock:        d.clock,
+	}
+}
+
+func (a *azureAuth) Authenticate(r *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token == "" || a.clock.Now().Add(azureRefreshMargin).After(a.expiry) {
+		if err := a.refresh(r.Context()); err != nil {
+			return err
+		}
+	}
+	r.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// refresh replaces the token with a new one from the token endpoint.
+func (a *azureAuth) refresh(ctx context.Context) error {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.clientID},
+		"client_secret": {a.clientSecret},
+		"scope":         {azureDatabricksScope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create Microsoft Entra ID token request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := doTokenRequest(a.client, req, &out); err != nil {
+		return err
+	}
+	if out.AccessToken == "" {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: "Microsoft Entra ID returned no access token"}
+	}
+	a.token = out.AccessToken
+	a.expiry = a.clock.Now().Add(time.Duration(out.ExpiresIn) * time.Second)
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureAuth(t *testing.T) {
+	requests := 0
+	expiresIn := 30
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tenant-id/oauth2/v2.0/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "app-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "app-secret", r.PostForm.Get("client_secret"))
+		assert.Equal(t, azureDatabricksScope, r.PostForm.Get("scope"))
+		requests++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "entra-token",
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	defer server.Close()
+
+	db := &databaseImpl{
+		oauthClientID:      "app-id",
+		oauthClientSecret:  "app-secret",
+		azureTenantID:      "tenant-id",
+		azureLoginEndpoint: server.URL + "/",
+	}
+	clock := newFakeClock()
+	db.SetClock(clock)
+	authenticator := db.newAzureAuth()
+	authenticator.client = server.Client()
+	assert.Equal(t, server.URL+"/tenant-id/oauth2/v2.0/token", authenticator.tokenURL)
+
+	// A token expiring within the refresh margin is replaced on every use
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, authenticator.Authenticate(req))
+		assert.Equal(t, "Bearer entra-token", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, 2, requests)
+
+	// Otherwise it is reused
+	expiresIn = 3600
+	for range 2 {
+		require.NoError(t, authenticator.Authenticate(httptest.NewRequest(http.MethodGet, "https://example.com", nil)))
+	}
+	assert.Equal(t, 3, requests)
+
+	// Until it is about to expire
+	clock.Advance(time.Hour - azureRefreshMargin + time.Second)
+	require.NoError(t, authenticator.Authenticate(httptest.NewRequest(http.MethodGet, "https://example.com", nil)))
+	assert.Equal(t, 4, requests)
+}
+
+func TestAzureOptions(t *testing.T) {
+	db := &databaseImpl{azureLoginEndpoint: DefaultAzureLoginEndpoint}
+	require.NoError(t, db.SetOption(OptionOAuthAzureTenantID, "tenant-id"))
+	require.NoError(t, db.SetOption(OptionOAuthAzureLoginEndpoint, "https://login.microsoftonline.us"))
+	value, err := db.GetOption(OptionOAuthAzureLoginEndpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "https://login.microsoftonline.us", value)
+	assert.Error(t, db.SetOption(OptionOAuthAzureLoginEndpoint, "http://login.microsoftonline.com"))
+
+	// The tenant's service principal needs a client secret
+	endpoint := workspaceEndpoint{ServerHostname: "adb-1.azuredatabricks.net", HTTPPath: "/sql/1.0/warehouses/abc"}
+	db.oauthClientID = "app-id"
+	_, err = db.resolveConnectionOptions(endpoint)
+	assert.ErrorContains(t, err, OptionOAuthClientSecret)
+
+	db.oauthU2M = true
+	_, err = db.resolveConnectionOptions(endpoint)
+	assert.ErrorContains(t, err, "Microsoft Entra ID")
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/util"
+)
+
+// Schema metadata keys set on each result batch with
+// OptionFetchBatchMetadata. Integers are in decimal. The chunk and timing
+// keys are only set for results read as Arrow, over Thrift or REST.
+const (
+	// BatchMetadataRowOffset is the number of rows returned before the
+	// batch.
+	BatchMetadataRowOffset = "databricks.batch.row_offset"
+	// BatchMetadataChunkIndex is the index of the result chunk (Thrift
+	// Arrow stream or REST chunk) the batch was read from.
+	BatchMetadataChunkIndex = "databricks.batch.chunk_index"
+	// BatchMetadataBytes is the size of the batch's buffers.
+	BatchMetadataBytes = "databricks.batch.bytes"
+	// BatchMetadataDownloadNanos is the time spent fetching the batch's
+	// chunk, in nanoseconds. Every batch of a chunk reports the same value.
+	BatchMetadataDownloadNanos = "databricks.batch.download_ns"
+	// BatchMetadataDecodeNanos is the time spent decoding the batch, in
+	// nanoseconds.
+	BatchMetadataDecodeNanos = "databricks.batch.decode_ns"
+)
+
+// batchStats describes the batch a reader returned last.
+type batchStats struct {
+	chunkIndex int64
+	download   time.Duration
+	decode     time.Duration
+	// Bytes of the chunk read from the network so far
+	bytes int64
+	// Hash of the download link of a REST chunk, and how often its links
+	// were resolved again after expiring
+	urlHash string
+	retries int
+}
+
+// batchStatsSource is implemented by the readers that know which chunk
+// their batches come from.
+type batchStatsSource interface {
+	batchStats() batchStats
+}
+
+// batchMetadataReader returns the batches of rdr with the BatchMetadata*
+// keys added to their schema metadata. The chunk and timings come from
+// stats, which is the reader rdr reads from, if it is known.
+type batchMetadataReader struct {
+	refCount  int64
+	rdr       array.RecordReader
+	stats     batchStatsSource
+	rowOffset int64
+	current   arrow.RecordBatch
+}
+
+// newBatchMetadataReader wraps rdr, taking ownership of it. stats may be
+// nil.
+func newBatchMetadataReader(rdr array.RecordReader, stats batchStatsSource) *batchMetadataReader {
+	return &batchMetadataReader{refCount: 1, rdr: rdr, stats: stats}
+}
+
+func (r *batchMetadataReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *batchMetadataReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.releaseCurrent()
+		r.rdr.Release()
+	}
+}
+
+func (r *batchMetadataReader) releaseCurrent() {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+}
+
+func (r *batchMetadataReader) Schema() *arrow.Schema {
+	return r.rdr.Schema()
+}
+
+func (r *batchMetadataReader) Next() bool {
+	r.releaseCurrent()
+	if !r.rdr.Next() {
+		return false
+	}
+	batch := r.rdr.RecordBatch()
+
+	schema := batch.Schema()
+	md := schema.Metadata().ToMap()
+	md[BatchMetadataRowOffset] = strconv.FormatInt(r.rowOffset, 10)
+	md[BatchMetadataBytes] = strconv.FormatInt(util.TotalRecordSize(batch), 10)
+	if r.stats != nil {
+		stats := r.stats.batchStats()
+		md[BatchMetadataChunkIndex] = strconv.FormatInt(stats.chunkIndex, 10)
+		md[BatchMetadataDownloadNanos] = strconv.FormatInt(stats.download.Nanoseconds(), 10)
+		md[BatchMetadataDecodeNanos] = strconv.FormatInt(stats.decode.Nanoseconds(), 10)
+	}
+	r.rowOffset += batch.NumRows()
+
+	metadata := arrow.MetadataFrom(md)
+	r.current = array.NewRecordBatch(arrow.NewSchema(schema.Fields(), &metadata), batch.Columns(), batch.NumRows())
+	return true
+}
+
+func (r *batchMetadataReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *batchMetadataReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *batchMetadataReader) Err() error {
+	return r.rdr.Err()
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedBatchStats batchStats
+
+func (s fixedBatchStats) batchStats() batchStats {
+	return batchStats(s)
+}
+
+func TestBatchMetadataReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	first := makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"})
+	second := makeConcatTestBatch(t, mem, []int64{3}, []string{"c"})
+	src, err := array.NewRecordReader(concatTestSchema, []arrow.RecordBatch{first, second})
+	require.NoError(t, err)
+	first.Release()
+	second.Release()
+
+	stats := fixedBatchStats{chunkIndex: 4, download: 2 * time.Millisecond, decode: time.Microsecond}
+	rdr := newBatchMetadataReader(src, stats)
+	defer rdr.Release()
+	assert.Equal(t, 0, rdr.Schema().Metadata().Len())
+
+	require.True(t, rdr.Next())
+	md := rdr.RecordBatch().Schema().Metadata()
+	assert.Equal(t, "0", metadataValue(md, BatchMetadataRowOffset))
+	assert.Equal(t, "4", metadataValue(md, BatchMetadataChunkIndex))
+	assert.Equal(t, "2000000", metadataValue(md, BatchMetadataDownloadNanos))
+	assert.Equal(t, "1000", metadataValue(md, BatchMetadataDecodeNanos))
+	assert.NotEmpty(t, metadataValue(md, BatchMetadataBytes))
+	assert.EqualValues(t, 2, rdr.RecordBatch().NumRows())
+
+	require.True(t, rdr.Next())
+	md = rdr.RecordBatch().Schema().Metadata()
+	assert.Equal(t, "2", metadataValue(md, BatchMetadataRowOffset))
+	assert.False(t, rdr.Next())
+	assert.NoError(t, rdr.Err())
+}
+
+func TestBatchMetadataReaderWithoutStats(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	batch := makeConcatTestBatch(t, mem, []int64{1}, []string{"a"})
+	src, err := array.NewRecordReader(concatTestSchema, []arrow.RecordBatch{batch})
+	require.NoError(t, err)
+	batch.Release()
+
+	rdr := newBatchMetadataReader(src, nil)
+	defer rdr.Release()
+	require.True(t, rdr.Next())
+	md := rdr.RecordBatch().Schema().Metadata()
+	assert.Equal(t, "0", metadataValue(md, BatchMetadataRowOffset))
+	assert.Equal(t, -1, md.FindKey(BatchMetadataChunkIndex))
+}
+
+func metadataValue(md arrow.Metadata, key string) string {
+	value, _ := md.GetValue(key)
+	return value
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/util"
+)
+
+// prefetchedBatch is a record batch decoded ahead of the reader, with the
+// stats it was decoded with.
+type prefetchedBatch struct {
+	rec   arrow.RecordBatch
+	stats batchStats
+	size  int64
+}
+
+// batchPrefetcher decodes record batches ahead of the reader, for
+// OptionFetchPrefetchMaxBytes. One goroutine calls decode until it returns
+// no batch, queueing the batches for next while they hold no more than
+// maxBytes; a single batch larger than that is still decoded once the
+// queue is empty, so the reader always makes progress. Once the queue is
+// full, decoding resumes only when the reader has drained it to
+// resumeBytes (see OptionFetchPrefetchDrainPercent), so that downloads are
+// made in bursts rather than one for each batch taken.
+type batchPrefetcher struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// Decoded batches the reader hasn't taken, and their total size
+	queue       []prefetchedBatch
+	held        int64
+	maxBytes    int64
+	resumeBytes int64
+	// Whether decode has returned its last batch or an error, and the
+	// error
+	finished bool
+	err      error
+	// Whether the reader has stopped taking batches
+	stopped bool
+	// Closed once the goroutine no longer calls decode
+	done chan struct{}
+}
+
+// newBatchPrefetcher starts calling decode in a goroutine of g, which then
+// owns decode until stop returns. decode returns nil after the last batch.
+// Once full, the queue must be drained by drainPercent of maxBytes before
+// decoding resumes. If g is closed, abort is called to hurry decode and
+// next fails once the batches already queued have been taken.
+func newBatchPrefetcher(g *goroutineGroup, maxBytes int64, drainPercent int, decode func() (arrow.RecordBatch, batchStats, error), abort func()) *batchPrefetcher {
+	resumeBytes := maxBytes - int64(float64(maxBytes)*float64(drainPercent)/100)
+	p := &batchPrefetcher{maxBytes: maxBytes, resumeBytes: resumeBytes, done: make(chan struct{})}
+	p.cond = sync.NewCond(&p.mu)
+	g.Go(func(closing context.Context) {
+		defer context.AfterFunc(closing, func() {
+			p.finish(closing.Err())
+			abort()
+		})()
+		p.run(decode)
+	})
+	return p
+}
+
+// finish stops decoding, with err for next once the queue is empty unless
+// decode has already returned its last batch or an error.
+func (p *batchPrefetcher) finish(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+	if !p.finished {
+		p.finished = true
+		p.err = err
+	}
+	p.cond.Broadcast()
+}
+
+func (p *batchPrefetcher) run(decode func() (arrow.RecordBatch, batchStats, error)) {
+	defer close(p.done)
+	for {
+		p.mu.Lock()
+		stopped := p.stopped
+		p.mu.Unlock()
+		if stopped {
+			return
+		}
+		rec, stats, err := decode()
+
+		p.mu.Lock()
+		if err != nil || rec == nil {
+			if !p.finished {
+				p.finished = true
+				p.err = err
+			}
+			p.cond.Broadcast()
+			p.mu.Unlock()
+			if rec != nil {
+				rec.Release()
+			}
+			return
+		}
+		size := int64(util.TotalRecordSize(rec))
+		if p.held > 0 && p.held+size > p.maxBytes {
+			for !p.stopped && p.held > 0 && (p.held+size > p.maxBytes || p.held > p.resumeBytes) {
+				p.cond.Wait()
+			}
+		}
+		if p.stopped {
+			p.mu.Unlock()
+			rec.Release()
+			return
+		}
+		p.queue = append(p.queue, prefetchedBatch{rec: rec, stats: stats, size: size})
+		p.held += size
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// next waits for the next batch and returns it, passing ownership of its
+// record to the caller. After the last batch the record is nil.
+func (p *batchPrefetcher) next() (prefetchedBatch, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.queue) == 0 && !p.finished {
+		p.cond.Wait()
+	}
+	if len(p.queue) == 0 {
+		return prefetchedBatch{}, p.err
+	}
+	batch := p.queue[0]
+	p.queue[0] = prefetchedBatch{}
+	p.queue = p.queue[1:]
+	p.held -= batch.size
+	p.cond.Broadcast()
+	return batch, nil
+}
+
+// stop waits for the goroutine to finish its current decode, which the
+// caller may hurry by cancelling the downloads behind it, and releases the
+// batches nobody took.
+func (p *batchPrefetcher) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	<-p.done
+
+	for _, batch := range p.queue {
+		batch.rec.Release()
+	}
+	p.queue = nil
+	p.held = 0
+}
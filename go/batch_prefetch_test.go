@@ -0,0 +1,115 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDecoder returns a decode function yielding n batches of one row,
+// then failing with err if it isn't nil, counting its calls in calls.
+func countingDecoder(t *testing.T, mem memory.Allocator, n int64, err error, calls *atomic.Int64) func() (arrow.RecordBatch, batchStats, error) {
+	return func() (arrow.RecordBatch, batchStats, error) {
+		i := calls.Add(1) - 1
+		if i >= n {
+			return nil, batchStats{}, err
+		}
+		return makeConcatTestBatch(t, mem, []int64{i}, []string{"a"}), batchStats{chunkIndex: i}, nil
+	}
+}
+
+func TestBatchPrefetcher(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	sample := makeConcatTestBatch(t, mem, []int64{0}, []string{"a"})
+	size := int64(util.TotalRecordSize(sample))
+	sample.Release()
+	g := checkGoroutines(t)
+
+	// Two batches fit the budget; the third waits for room
+	var calls atomic.Int64
+	p := newBatchPrefetcher(g, 2*size, 0, countingDecoder(t, mem, 10, nil, &calls), func() {})
+	require.Eventually(t, func() bool { return calls.Load() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.EqualValues(t, 3, calls.Load())
+
+	for i := range int64(10) {
+		batch, err := p.next()
+		require.NoError(t, err)
+		require.NotNil(t, batch.rec)
+		assert.Equal(t, i, batch.stats.chunkIndex)
+		batch.rec.Release()
+	}
+	batch, err := p.next()
+	require.NoError(t, err)
+	assert.Nil(t, batch.rec)
+	p.stop()
+
+	// A batch over the budget is still decoded once the queue is empty
+	calls.Store(0)
+	p = newBatchPrefetcher(g, 1, 0, countingDecoder(t, mem, 2, errors.New("boom"), &calls), func() {})
+	for range 2 {
+		batch, err := p.next()
+		require.NoError(t, err)
+		batch.rec.Release()
+	}
+	_, err = p.next()
+	assert.ErrorContains(t, err, "boom")
+	p.stop()
+
+	// Stopping releases the batches nobody took
+	calls.Store(0)
+	p = newBatchPrefetcher(g, 1<<20, 0, countingDecoder(t, mem, 5, nil, &calls), func() {})
+	require.Eventually(t, func() bool { return calls.Load() == 6 }, time.Second, time.Millisecond)
+	p.stop()
+}
+
+func TestBatchPrefetcherDrain(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	sample := makeConcatTestBatch(t, mem, []int64{0}, []string{"a"})
+	size := int64(util.TotalRecordSize(sample))
+	sample.Release()
+	g := checkGoroutines(t)
+
+	// With four batches queued, decoding only resumes once half of them
+	// have been taken
+	var calls atomic.Int64
+	p := newBatchPrefetcher(g, 4*size, 50, countingDecoder(t, mem, 20, nil, &calls), func() {})
+	defer p.stop()
+	require.Eventually(t, func() bool { return calls.Load() == 5 }, time.Second, time.Millisecond)
+
+	batch, err := p.next()
+	require.NoError(t, err)
+	batch.rec.Release()
+	time.Sleep(10 * time.Millisecond)
+	assert.EqualValues(t, 5, calls.Load())
+
+	batch, err = p.next()
+	require.NoError(t, err)
+	batch.rec.Release()
+	require.Eventually(t, func() bool { return calls.Load() == 7 }, time.Second, time.Millisecond)
+}
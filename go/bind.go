@@ -0,0 +1,98 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	dbsql "github.com/databricks/databricks-sql-go"
+)
+
+const (
+	// Databricks timestamps have microsecond precision, so anything finer
+	// is dropped when rendering parameters.
+	bindDateLayout          = "2006-01-02"
+	bindTimestampLayout     = "2006-01-02 15:04:05.999999"
+	bindTimestampLayoutZone = "2006-01-02 15:04:05.999999Z07:00"
+)
+
+// temporalBinding controls how date and time values are rendered when they
+// are sent to Databricks as query parameters.
+//
+// Databricks parses TIMESTAMP parameters in the session timezone. Values
+// that carry an explicit offset are therefore stored as the same instant
+// regardless of the session, while values without an offset keep their
+// wall clock reading (which is what TIMESTAMP_NTZ columns want).
+type temporalBinding struct {
+	// mode is one of the OptionValueTimestampMode* constants.
+	mode string
+	// location is the session timezone, used to render instants.
+	location *time.Location
+}
+
+func newTemporalBinding(mode string, location *time.Location) temporalBinding {
+	if mode == "" {
+		mode = OptionValueTimestampModeAuto
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return temporalBinding{mode: mode, location: location}
+}
+
+// date renders a calendar date. Dates are never shifted by the session
+// timezone, so they are bound as DATE rather than TIMESTAMP.
+func (b temporalBinding) date(t time.Time) dbsql.Parameter {
+	return dbsql.Parameter{
+		Type:  dbsql.SqlDate,
+		Value: t.UTC().Format(bindDateLayout),
+	}
+}
+
+// timestamp renders an Arrow timestamp value of the given type.
+func (b temporalBinding) timestamp(value arrow.Timestamp, dt *arrow.TimestampType) (dbsql.Parameter, error) {
+	t := value.ToTime(dt.Unit)
+	if dt.TimeZone == "" {
+		// Timezone-naive: the stored fields are a wall clock reading.
+		if b.mode == OptionValueTimestampModeInstant {
+			return b.instant(t), nil
+		}
+		return b.wallClock(t), nil
+	}
+
+	loc, err := dt.GetZone()
+	if err != nil {
+		return dbsql.Parameter{}, err
+	}
+	if b.mode == OptionValueTimestampModeWallClock {
+		return b.wallClock(t.In(loc)), nil
+	}
+	return b.instant(t), nil
+}
+
+func (b temporalBinding) instant(t time.Time) dbsql.Parameter {
+	return dbsql.Parameter{
+		Type:  dbsql.SqlTimestamp,
+		Value: t.In(b.location).Format(bindTimestampLayoutZone),
+	}
+}
+
+func (b temporalBinding) wallClock(t time.Time) dbsql.Parameter {
+	return dbsql.Parameter{
+		Type:  dbsql.SqlTimestamp,
+		Value: t.Format(bindTimestampLayout),
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// ParameterSerializer customizes how bound Arrow values are sent to
+// Databricks, for types the driver doesn't support (such as extension
+// types) or renders differently than wanted (such as UUIDs held as 16-byte
+// binary values, which the driver binds as hex strings).
+//
+// SerializeParameter returns the value to bind for row idx of arr, or
+// ok=false to have the driver render it as usual. The value must be nil,
+// a bool, int64, float64, string or []byte, or a dbsql.Parameter to give
+// its SQL type explicitly. It is bound as a native parameter, or rendered
+// as a SQL literal for servers without native parameters. Null values are
+// passed to the serializer too.
+type ParameterSerializer interface {
+	SerializeParameter(arr arrow.Array, idx int) (value any, ok bool, err error)
+}
+
+// ParameterSerializerSetter is implemented by the statements of this
+// driver. SetParameterSerializer makes the statement render its bound
+// values with ser first; nil restores the driver's rendering. Clones of the
+// statement keep the serializer.
+type ParameterSerializerSetter interface {
+	SetParameterSerializer(ser ParameterSerializer)
+}
+
+func (s *statementImpl) SetParameterSerializer(ser ParameterSerializer) {
+	s.paramSerializer = ser
+}
+
+// parameterValue returns the value to bind for row idx of arr.
+func (s *statementImpl) parameterValue(arr arrow.Array, idx int) (any, error) {
+	if s.paramSerializer != nil {
+		value, ok, err := s.paramSerializer.SerializeParameter(arr, idx)
+		if err != nil || ok {
+			return value, err
+		}
+	}
+	return extractGoValue(arr, idx, s.conn.temporalBinding)
+}
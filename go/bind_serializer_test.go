@@ -0,0 +1,94 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uuidSerializer binds 16-byte binary values as canonical UUID strings.
+type uuidSerializer struct{}
+
+func (uuidSerializer) SerializeParameter(arr arrow.Array, idx int) (any, bool, error) {
+	fsb, ok := arr.(*array.FixedSizeBinary)
+	if !ok || fsb.IsNull(idx) {
+		return nil, false, nil
+	}
+	b := fsb.Value(idx)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), true, nil
+}
+
+type failingSerializer struct{}
+
+func (failingSerializer) SerializeParameter(arrow.Array, int) (any, bool, error) {
+	return nil, false, errors.New("unsupported value")
+}
+
+func TestParameterValue(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	id := []byte{0x0b, 0x3a, 0x5c, 0x1e, 0x6f, 0x1d, 0x4b, 0x7a, 0x9d, 0x2e, 0x3c, 0x4f, 0x5a, 0x6b, 0x7c, 0x8d}
+	bldr := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: 16})
+	defer bldr.Release()
+	bldr.Append(id)
+	bldr.AppendNull()
+	ids := bldr.NewArray()
+	defer ids.Release()
+
+	ints := array.NewInt32Builder(mem)
+	defer ints.Release()
+	ints.Append(7)
+	nums := ints.NewArray()
+	defer nums.Release()
+
+	var s statementImpl
+	s.conn = &connectionImpl{temporalBinding: newTemporalBinding("", nil)}
+	var _ ParameterSerializerSetter = &s
+
+	// The driver's rendering
+	value, err := s.parameterValue(ids, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0b3a5c1e6f1d4b7a9d2e3c4f5a6b7c8d", value)
+
+	s.SetParameterSerializer(uuidSerializer{})
+	value, err = s.parameterValue(ids, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0b3a5c1e-6f1d-4b7a-9d2e-3c4f5a6b7c8d", value)
+	// Values the serializer passes on are rendered as usual
+	value, err = s.parameterValue(ids, 1)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = s.parameterValue(nums, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), value)
+
+	s.SetParameterSerializer(failingSerializer{})
+	_, err = s.parameterValue(nums, 0)
+	assert.ErrorContains(t, err, "unsupported value")
+
+	s.SetParameterSerializer(nil)
+	value, err = s.parameterValue(ids, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0b3a5c1e6f1d4b7a9d2e3c4f5a6b7c8d", value)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	dbsql "github.com/databricks/databricks-sql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestTemporalBindingDate(t *testing.T) {
+	la := mustLoadLocation(t, "America/Los_Angeles")
+	tb := newTemporalBinding("", la)
+
+	// Midnight UTC must not slide to the previous day in a negative-offset
+	// session timezone.
+	d := arrow.Date32FromTime(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	p := tb.date(d.ToTime())
+	assert.Equal(t, dbsql.SqlDate, p.Type)
+	assert.Equal(t, "2024-03-10", p.Value)
+}
+
+func TestTemporalBindingTimestamp(t *testing.T) {
+	la := mustLoadLocation(t, "America/Los_Angeles")
+
+	naive := &arrow.TimestampType{Unit: arrow.Microsecond}
+	utc := &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	newYork := &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "America/New_York"}
+	nanos := &arrow.TimestampType{Unit: arrow.Nanosecond}
+
+	ts := func(dt *arrow.TimestampType, tm time.Time) arrow.Timestamp {
+		v, err := arrow.TimestampFromTime(tm, dt.Unit)
+		require.NoError(t, err)
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		dt       *arrow.TimestampType
+		value    time.Time
+		expected string
+	}{
+		{
+			// 02:30 does not exist in Los Angeles on this day, but a
+			// naive value must be sent verbatim.
+			name:     "naive in spring-forward gap",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       naive,
+			value:    time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC),
+			expected: "2024-03-10 02:30:00",
+		},
+		{
+			name:     "aware before spring forward",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       utc,
+			value:    time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC),
+			expected: "2024-03-10 01:30:00-08:00",
+		},
+		{
+			name:     "aware after spring forward",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       utc,
+			value:    time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC),
+			expected: "2024-03-10 03:30:00-07:00",
+		},
+		{
+			// 01:30 happens twice in Los Angeles; the offset keeps the
+			// two instants apart.
+			name:     "aware first fall-back hour",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       utc,
+			value:    time.Date(2024, 11, 3, 8, 30, 0, 0, time.UTC),
+			expected: "2024-11-03 01:30:00-07:00",
+		},
+		{
+			name:     "aware second fall-back hour",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       utc,
+			value:    time.Date(2024, 11, 3, 9, 30, 0, 0, time.UTC),
+			expected: "2024-11-03 01:30:00-08:00",
+		},
+		{
+			name:     "naive as instant",
+			mode:     OptionValueTimestampModeInstant,
+			dt:       naive,
+			value:    time.Date(2024, 11, 3, 9, 30, 0, 0, time.UTC),
+			expected: "2024-11-03 01:30:00-08:00",
+		},
+		{
+			name:     "aware as wall clock",
+			mode:     OptionValueTimestampModeWallClock,
+			dt:       newYork,
+			value:    time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC),
+			expected: "2024-03-10 03:30:00",
+		},
+		{
+			name:     "naive as wall clock",
+			mode:     OptionValueTimestampModeWallClock,
+			dt:       naive,
+			value:    time.Date(2024, 11, 3, 1, 30, 0, 0, time.UTC),
+			expected: "2024-11-03 01:30:00",
+		},
+		{
+			name:     "truncated to microseconds",
+			mode:     OptionValueTimestampModeAuto,
+			dt:       nanos,
+			value:    time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.UTC),
+			expected: "2024-01-01 12:00:00.123456",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := newTemporalBinding(tc.mode, la)
+			p, err := tb.timestamp(ts(tc.dt, tc.value), tc.dt)
+			require.NoError(t, err)
+			assert.Equal(t, dbsql.SqlTimestamp, p.Type)
+			assert.Equal(t, tc.expected, p.Value)
+		})
+	}
+}
+
+func TestTemporalBindingDefaultsToUTC(t *testing.T) {
+	tb := newTemporalBinding("", nil)
+	dt := &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Europe/Berlin"}
+	v, err := arrow.TimestampFromTime(time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC), arrow.Second)
+	require.NoError(t, err)
+
+	p, err := tb.timestamp(v, dt)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-31 01:30:00Z", p.Value)
+}
+
+func TestTemporalBindingInvalidZone(t *testing.T) {
+	tb := newTemporalBinding("", nil)
+	dt := &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Not/AZone"}
+	_, err := tb.timestamp(arrow.Timestamp(0), dt)
+	assert.Error(t, err)
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	dbsql "github.com/databricks/databricks-sql-go"
+)
+
+// parameterBinding maps the columns of bound data to the parameter
+// markers of a query: positional markers (?) take the columns in order,
+// named markers (:name) the column of the same name.
+type parameterBinding struct {
+	// Marker names, in column order; empty for positional markers
+	names []string
+	// Column bound to each marker
+	columns []int
+	// IN lists staged in temporary tables instead (see stageInLists)
+	inLists []stagedInList
+}
+
+// newParameterBinding matches the columns of schema to the markers of
+// query. Every marker needs a column and every column a marker, since a
+// column bound to nothing is most likely a misspelt name.
+func (s *statementImpl) newParameterBinding(query string, schema *arrow.Schema) (*parameterBinding, error) {
+	markers := queryParameters(query)
+	named := slices.ContainsFunc(markers, func(p queryParameter) bool { return p.name != "" })
+	positional := slices.ContainsFunc(markers, func(p queryParameter) bool { return p.name == "" })
+	switch {
+	case named && positional:
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "query mixes named (:name) and positional (?) parameters")
+	case len(markers) != schema.NumFields():
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+			"query has %d parameters but %d columns are bound", len(markers), schema.NumFields())
+	}
+
+	b := &parameterBinding{names: make([]string, len(markers)), columns: make([]int, len(markers))}
+	for i, marker := range markers {
+		if !named {
+			b.columns[i] = i
+			continue
+		}
+		indices := schema.FieldIndices(marker.name)
+		if len(indices) != 1 {
+			return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+				"parameter :%s needs exactly one bound column named %s, found %d", marker.name, marker.name, len(indices))
+		}
+		b.names[i] = marker.name
+		b.columns[i] = indices[0]
+	}
+	return b, nil
+}
+
+// boundQuery is one execution of a query with bound parameters.
+type boundQuery struct {
+	query string
+	args  []driver.NamedValue
+	// IN lists to stage before the query runs
+	inLists []boundInList
+}
+
+// bindingArgs returns the arguments binding rows start to end of rec, in
+// order, to a query with the markers of b repeated for each row.
+func (s *statementImpl) bindingArgs(b *parameterBinding, rec arrow.RecordBatch, start, end int) ([]driver.NamedValue, error) {
+	args := make([]driver.NamedValue, 0, (end-start)*len(b.columns))
+	for idx := start; idx < end; idx++ {
+		for i, col := range b.columns {
+			value, err := s.parameterValue(rec.Column(col), idx)
+			if err != nil {
+				return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "failed to bind column %d: %v", col, err)
+			}
+			if param, ok := value.(dbsql.Parameter); ok && b.names[i] != "" {
+				param.Name = b.names[i]
+				value = param
+			}
+			args = append(args, driver.NamedValue{Name: b.names[i], Ordinal: len(args) + 1, Value: value})
+		}
+	}
+	return args, nil
+}
+
+// bindRows returns the execution of query binding rows start to end of
+// rec. A server without native parameters gets the values of positional
+// parameters inlined into the query instead.
+func (s *statementImpl) bindRows(b *parameterBinding, query string, rec arrow.RecordBatch, start, end int, inline bool) (boundQuery, error) {
+	args, err := s.bindingArgs(b, rec, start, end)
+	if err != nil {
+		return boundQuery{}, err
+	}
+	var inLists []boundInList
+	for i := range b.inLists {
+		values, err := s.bindingArgs(b.inLists[i].binding, rec, start, end)
+		if err != nil {
+			return boundQuery{}, err
+		}
+		inLists = append(inLists, boundInList{stagedInList: &b.inLists[i], values: values})
+	}
+	if !inline {
+		return boundQuery{query: query, args: args, inLists: inLists}, nil
+	}
+	inlined, err := inlineParameters(query, valuesToInterfaces(args))
+	if err != nil {
+		return boundQuery{}, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "failed to inline parameters: %v", err)
+	}
+	return boundQuery{query: inlined, inLists: inLists}, nil
+}
+
+// bindingFor returns the binding of bound data with schema to query, and
+// whether its values must be inlined.
+func (s *statementImpl) bindingFor(query string, schema *arrow.Schema) (*parameterBinding, bool, error) {
+	binding, err := s.newParameterBinding(query, schema)
+	if err != nil {
+		return nil, false, err
+	}
+	inline := !s.conn.sessionCapabilities().NativeParameters
+	if inline && slices.ContainsFunc(binding.names, func(name string) bool { return name != "" }) {
+		return nil, false, s.ErrorHelper.Errorf(adbc.StatusNotImplemented,
+			"named parameters need a server that supports native query parameters")
+	}
+	return binding, inline, nil
+}
+
+// boundQueries reads the bound data, releasing it, and returns an
+// execution of query for each of its rows.
+func (s *statementImpl) boundQueries(query string) ([]boundQuery, error) {
+	stream := s.boundStream
+	s.boundStream = nil
+	defer stream.Release()
+
+	binding, inline, err := s.bindingFor(query, stream.Schema())
+	if err != nil {
+		return nil, err
+	}
+	if query, binding, err = s.stageInLists(query, binding, stream.Schema()); err != nil {
+		return nil, err
+	}
+	var queries []boundQuery
+	for stream.Next() {
+		rec := stream.RecordBatch()
+		for idx := range int(rec.NumRows()) {
+			bound, err := s.bindRows(binding, query, rec, idx, idx+1, inline)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, bound)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusIO, "failed to read bound parameters: %v", err)
+	}
+	return queries, nil
+}
+
+// maxBatchParameters caps the parameters of one statement inserting
+// several rows of bound data, which keeps each request to the server
+// small.
+const maxBatchParameters = 256
+
+// multiRowInsert splits query, if it is an INSERT INTO ... VALUES with a
+// single row of positional markers and no markers elsewhere, into the
+// part before that row and the row itself, so that the row can be
+// repeated to insert several rows at once.
+func multiRowInsert(query string) (head, row string, ok bool) {
+	query = strings.TrimSpace(query)
+	fields := strings.Fields(query)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "INSERT") || !strings.EqualFold(fields[1], "INTO") {
+		return "", "", false
+	}
+	open := strings.LastIndexByte(query, '(')
+	row = query[open+1:]
+	if open < 0 || !strings.HasSuffix(row, ")") {
+		return "", "", false
+	}
+	markers := strings.Trim(row[:len(row)-1], "?, \t\r\n")
+	if markers != "" || !strings.Contains(row, "?") {
+		return "", "", false
+	}
+	head = strings.TrimRightFunc(query[:open], unicode.IsSpace)
+	keyword := head[max(0, len(head)-len("VALUES")):]
+	if !strings.EqualFold(keyword, "VALUES") || len(head) == len(keyword) || !unicode.IsSpace(rune(head[len(head)-len(keyword)-1])) {
+		return "", "", false
+	}
+	if len(queryParameters(query)) != strings.Count(row, "?") {
+		return "", "", false
+	}
+	return head + " ", "(" + row, true
+}
+
+// executeBoundUpdate runs the statement's query with the bound data and
+// returns the total number of rows affected. The bound data is read one
+// batch at a time, each batch executed before the next is read: an INSERT
+// of one row of values inserts up to maxBatchParameters values per
+// statement, any other query runs once per row.
+func (s *statementImpl) executeBoundUpdate(ctx context.Context) (int64, error) {
+	if s.query == "" {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "bound data provided but no query or ingest target set")
+	}
+	stream := s.boundStream
+	s.boundStream = nil
+	defer stream.Release()
+
+	conn, err := s.session(ctx)
+	if err != nil {
+		return -1, err
+	}
+	binding, inline, err := s.bindingFor(s.query, stream.Schema())
+	if err != nil {
+		return -1, err
+	}
+	staged, binding, err := s.stageInLists(s.query, binding, stream.Schema())
+	if err != nil {
+		return -1, err
+	}
+	head, row, multiRow := multiRowInsert(s.query)
+	rowsPerStatement := 1
+	if multiRow {
+		rowsPerStatement = max(1, maxBatchParameters/len(binding.columns))
+	}
+
+	total := int64(0)
+	for batch := 0; stream.Next(); batch++ {
+		rec := stream.RecordBatch()
+		numRows := int(rec.NumRows())
+		s.logExecution(ctx, "executing update with bound parameters", "batch", batch, "rows", numRows)
+		for start := 0; start < numRows; start += rowsPerStatement {
+			end := min(start+rowsPerStatement, numRows)
+			query := staged
+			if multiRow {
+				query = head + strings.Repeat(row+", ", end-start-1) + row
+			}
+			bound, err := s.bindRows(binding, query, rec, start, end, inline)
+			if err != nil {
+				return total, err
+			}
+			if err := s.writeInLists(ctx, conn, bound.inLists); err != nil {
+				return total, err
+			}
+			rows, err := s.execBound(ctx, conn, bound)
+			if err != nil {
+				return total, err
+			}
+			total += rows
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return total, s.ErrorHelper.Errorf(adbc.StatusIO, "failed to read bound parameters: %v", err)
+	}
+	if changesNamespace(s.query) {
+		s.conn.invalidateNamespace()
+	}
+	return total, nil
+}
+
+// execBound runs b on conn and returns the number of rows it affected.
+func (s *statementImpl) execBound(ctx context.Context, conn *sql.Conn, b boundQuery) (int64, error) {
+	args := make([]any, len(b.args))
+	for i, arg := range b.args {
+		if arg.Name != "" {
+			args[i] = sql.Named(arg.Name, arg.Value)
+		} else {
+			args[i] = arg.Value
+		}
+	}
+	result, err := conn.ExecContext(ctx, annotateQuery(ctx, b.query), args...)
+	if err != nil {
+		return 0, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute update: %v", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// boundResultReader reads the results of a query run once per row of
+// bound parameters, one after the other, as a single result. Each
+// execution after the first runs once the previous result has been read.
+type boundResultReader struct {
+	refCount int64
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	// Runs the next execution
+	execute func(boundQuery) (array.RecordReader, error)
+	pending []boundQuery
+	err     error
+}
+
+// newBoundResultReader continues rdr, the result of the first execution,
+// with those of pending, taking ownership of rdr.
+func newBoundResultReader(rdr array.RecordReader, pending []boundQuery, execute func(boundQuery) (array.RecordReader, error)) *boundResultReader {
+	return &boundResultReader{
+		refCount: 1,
+		rdr:      rdr,
+		schema:   rdr.Schema(),
+		execute:  execute,
+		pending:  pending,
+	}
+}
+
+func (r *boundResultReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *boundResultReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 && r.rdr != nil {
+		r.rdr.Release()
+		r.rdr = nil
+	}
+}
+
+func (r *boundResultReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *boundResultReader) Next() bool {
+	for r.err == nil && r.rdr != nil {
+		if r.rdr.Next() {
+			return true
+		}
+		if r.err = r.rdr.Err(); r.err != nil || len(r.pending) == 0 {
+			return false
+		}
+		r.rdr.Release()
+		r.rdr, r.err = r.execute(r.pending[0])
+		r.pending = r.pending[1:]
+		if r.err == nil && !r.rdr.Schema().Equal(r.schema) {
+			r.err = adbc.Error{
+				Code: adbc.StatusInvalidData,
+				Msg:  fmt.Sprintf("result schema changed between rows of bound parameters: %s, then %s", r.schema, r.rdr.Schema()),
+			}
+		}
+	}
+	return false
+}
+
+func (r *boundResultReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *boundResultReader) RecordBatch() arrow.RecordBatch {
+	if r.rdr == nil || r.err != nil {
+		return nil
+	}
+	return r.rdr.RecordBatch()
+}
+
+func (r *boundResultReader) Err() error {
+	return r.err
+}
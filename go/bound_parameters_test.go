@@ -0,0 +1,195 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterBinding(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	s := &statementImpl{}
+
+	b, err := s.newParameterBinding("SELECT * FROM t WHERE a = ? AND b = ?", schema)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, b.columns)
+	assert.Equal(t, []string{"", ""}, b.names)
+
+	// Named markers take the column of the same name, in any order
+	b, err = s.newParameterBinding("SELECT * FROM t WHERE id = :id AND name = :name OR id < :id", schema)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, b.columns)
+	assert.Equal(t, []string{"id", "name"}, b.names)
+
+	for _, query := range []string{
+		"SELECT * FROM t WHERE id = :id AND name = ?",
+		"SELECT * FROM t WHERE id = ?",
+		"SELECT * FROM t WHERE id = :id AND name = :nme",
+		"SELECT ':id', :name",
+	} {
+		_, err := s.newParameterBinding(query, schema)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, query)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code, query)
+	}
+}
+
+func TestBoundQueries(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	bind := func(s *statementImpl) {
+		rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(`[{"id": 1, "name": "a"}, {"id": 2, "name": null}]`))
+		require.NoError(t, err)
+		defer rec.Release()
+		require.NoError(t, s.Bind(t.Context(), rec))
+	}
+
+	cnxn := &connectionImpl{capabilities: assumedCapabilities()}
+	s := &statementImpl{conn: cnxn}
+	bind(s)
+	bound, err := s.boundQueries("SELECT :name, :id")
+	require.NoError(t, err)
+	assert.Nil(t, s.boundStream)
+	assert.Equal(t, []boundQuery{
+		{query: "SELECT :name, :id", args: []driver.NamedValue{{Name: "name", Ordinal: 1, Value: "a"}, {Name: "id", Ordinal: 2, Value: int64(1)}}},
+		{query: "SELECT :name, :id", args: []driver.NamedValue{{Name: "name", Ordinal: 1, Value: nil}, {Name: "id", Ordinal: 2, Value: int64(2)}}},
+	}, bound)
+
+	// Without native parameters the values are inlined
+	cnxn.capabilities.NativeParameters = false
+	bind(s)
+	bound, err = s.boundQueries("INSERT INTO t VALUES (?, ?)")
+	require.NoError(t, err)
+	assert.Equal(t, []boundQuery{
+		{query: "INSERT INTO t VALUES (1, 'a')"},
+		{query: "INSERT INTO t VALUES (2, NULL)"},
+	}, bound)
+
+	bind(s)
+	_, err = s.boundQueries("SELECT :name, :id")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+	assert.Nil(t, s.boundStream)
+}
+
+func TestMultiRowInsert(t *testing.T) {
+	_, _, ok := multiRowInsert("insert into t (a, b) values (?, ?);")
+	assert.False(t, ok)
+	head, row, ok := multiRowInsert("insert into t (a, b) values\n(?, ?)\n")
+	require.True(t, ok)
+	assert.Equal(t, "insert into t (a, b) values ", head)
+	assert.Equal(t, "(?, ?)", row)
+
+	for _, query := range []string{
+		"INSERT OVERWRITE t VALUES (?, ?)",
+		"UPDATE t SET a = ? WHERE b = ?",
+		"INSERT INTO t VALUES (?, upper(?))",
+		"INSERT INTO t VALUES (:a, :b)",
+		"INSERT INTO t SELECT * FROM s WHERE a = ? AND b IN (?)",
+		"INSERT INTO t_values (?)",
+		"INSERT INTO t VALUES (1, 2)",
+	} {
+		_, _, ok := multiRowInsert(query)
+		assert.False(t, ok, query)
+	}
+}
+
+func TestBindRows(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(`[{"id": 1, "name": "a"}, {"id": 2, "name": null}, {"id": 3, "name": "c"}]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	s := &statementImpl{conn: &connectionImpl{capabilities: assumedCapabilities()}}
+	binding, inline, err := s.bindingFor("INSERT INTO t VALUES (?, ?)", schema)
+	require.NoError(t, err)
+	require.False(t, inline)
+
+	// The markers of each row follow those of the previous one
+	query := "INSERT INTO t VALUES (?, ?), (?, ?)"
+	bound, err := s.bindRows(binding, query, rec, 1, 3, inline)
+	require.NoError(t, err)
+	assert.Equal(t, boundQuery{query: query, args: []driver.NamedValue{
+		{Ordinal: 1, Value: int64(2)}, {Ordinal: 2, Value: nil},
+		{Ordinal: 3, Value: int64(3)}, {Ordinal: 4, Value: "c"},
+	}}, bound)
+
+	bound, err = s.bindRows(binding, query, rec, 0, 2, true)
+	require.NoError(t, err)
+	assert.Equal(t, boundQuery{query: "INSERT INTO t VALUES (1, 'a'), (2, NULL)"}, bound)
+}
+
+func TestBoundResultReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	result := func(schema *arrow.Schema, rows string) array.RecordReader {
+		rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(rows))
+		require.NoError(t, err)
+		defer rec.Release()
+		rdr, err := array.NewRecordReader(schema, []arrow.RecordBatch{rec})
+		require.NoError(t, err)
+		return rdr
+	}
+	ints := arrow.NewSchema([]arrow.Field{{Name: "n", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	strs := arrow.NewSchema([]arrow.Field{{Name: "n", Type: arrow.BinaryTypes.String}}, nil)
+
+	var executed []string
+	rdr := newBoundResultReader(result(ints, `[{"n": 1}]`), []boundQuery{{query: "2"}, {query: "x"}},
+		func(b boundQuery) (array.RecordReader, error) {
+			executed = append(executed, b.query)
+			if b.query == "x" {
+				return result(strs, `[{"n": "x"}]`), nil
+			}
+			return result(ints, `[{"n": `+b.query+`}]`), nil
+		})
+	defer rdr.Release()
+
+	// Later executions run as the result is read
+	assert.Empty(t, executed)
+	var values []int64
+	for rdr.Next() {
+		values = append(values, rdr.RecordBatch().Column(0).(*array.Int64).Value(0))
+	}
+	assert.Equal(t, []int64{1, 2}, values)
+	assert.Equal(t, []string{"2", "x"}, executed)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, rdr.Err(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidData, adbcErr.Code)
+}
@@ -16,6 +16,7 @@ package databricks
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"strings"
@@ -26,7 +27,10 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/array"
 )
 
-// executeIngest performs bulk insert using parameterized INSERT statements
+// executeIngest performs bulk insert using parameterized INSERT statements,
+// or COPY INTO from OptionIngestStagingLocation if set, resumably with
+// OptionIngestManifest. The upsert mode merges the rows instead. Rows that
+// break OptionIngestValidationRules are left out first.
 func (s *statementImpl) executeIngest(ctx context.Context) (int64, error) {
 	if s.boundStream == nil {
 		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no data bound for ingestion")
@@ -37,44 +41,49 @@ func (s *statementImpl) executeIngest(ctx context.Context) (int64, error) {
 		s.boundStream = nil
 	}()
 
+	s.rejectedRows = nil
+	if len(s.ingestRules) > 0 {
+		s.rejectedRows = &rejectedRows{}
+		validated, err := newValidatingReader(s.alloc, s.boundStream, s.ingestRules, s.ingestMaxRejected, s.rejectedRows)
+		if err != nil {
+			return -1, err
+		}
+		s.boundStream = validated
+	}
+
 	opts := &s.bulkIngestOptions
 
 	tableName := buildTableName(opts.CatalogName, opts.SchemaName, opts.TableName)
+	if s.ingestManifest != "" {
+		return s.resumableIngest(ctx, tableName)
+	}
 
 	if err := s.createTableIfNeeded(ctx, tableName, s.boundStream.Schema(), opts); err != nil {
 		return -1, err
 	}
 
+	conn, err := s.session(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if opts.Mode == OptionValueIngestModeUpsert {
+		return s.mergeIntoTable(ctx, conn, tableName)
+	}
+	if s.ingestStaging != "" {
+		return s.copyIntoTable(ctx, conn, tableName)
+	}
+
 	insertSQL, err := buildInsertSQL(tableName, s.boundStream.Schema())
 	if err != nil {
 		return -1, err
 	}
 
 	totalRows := int64(0)
-	params := make([]driver.NamedValue, s.boundStream.Schema().NumFields())
-
 	for s.boundStream.Next() {
-		recordBatch := s.boundStream.RecordBatch()
-
-		for rowIdx := range int(recordBatch.NumRows()) {
-			// Extract Go values from Arrow columns
-			for colIdx := range int(recordBatch.NumCols()) {
-				arr := recordBatch.Column(colIdx)
-				val, err := extractGoValue(arr, rowIdx)
-				if err != nil {
-					return totalRows, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to extract go value: %v", err)
-				}
-				params[colIdx].Value = val
-			}
-
-			// Use ExecContext directly instead of PrepareContext because Databricks doesn't do server-side statement preparation
-			result, err := s.conn.conn.ExecContext(ctx, insertSQL, valuesToInterfaces(params)...)
-			if err != nil {
-				return totalRows, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute the query: %v", err)
-			}
-
-			rows, _ := result.RowsAffected()
-			totalRows += rows
+		rows, err := s.insertBatch(ctx, conn, insertSQL, s.boundStream.RecordBatch())
+		totalRows += rows
+		if err != nil {
+			return totalRows, err
 		}
 	}
 
@@ -85,6 +94,51 @@ func (s *statementImpl) executeIngest(ctx context.Context) (int64, error) {
 	return totalRows, nil
 }
 
+// insertBatch inserts the rows of a record batch one INSERT at a time and
+// returns the number of rows inserted.
+func (s *statementImpl) insertBatch(ctx context.Context, conn *sql.Conn, insertSQL string, recordBatch arrow.RecordBatch) (int64, error) {
+	// Servers without native parameters get the values as literals
+	inline := !s.conn.sessionCapabilities().NativeParameters
+	annotatedSQL := annotateQuery(ctx, insertSQL)
+
+	totalRows := int64(0)
+	params := make([]driver.NamedValue, recordBatch.NumCols())
+
+	for rowIdx := range int(recordBatch.NumRows()) {
+		// Extract Go values from Arrow columns
+		for colIdx := range int(recordBatch.NumCols()) {
+			arr := recordBatch.Column(colIdx)
+			val, err := s.parameterValue(arr, rowIdx)
+			if err != nil {
+				return totalRows, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to extract go value: %v", err)
+			}
+			params[colIdx].Value = val
+		}
+
+		// Use ExecContext directly instead of PrepareContext because Databricks doesn't do server-side statement preparation
+		var (
+			result sql.Result
+			err    error
+		)
+		if inline {
+			var query string
+			if query, err = inlineParameters(insertSQL, valuesToInterfaces(params)); err != nil {
+				return totalRows, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to inline parameters: %v", err)
+			}
+			result, err = conn.ExecContext(ctx, annotateQuery(ctx, query))
+		} else {
+			result, err = conn.ExecContext(ctx, annotatedSQL, valuesToInterfaces(params)...)
+		}
+		if err != nil {
+			return totalRows, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute the query: %v", err)
+		}
+
+		rows, _ := result.RowsAffected()
+		totalRows += rows
+	}
+	return totalRows, nil
+}
+
 // createTableIfNeeded creates/drops table based on ingest mode
 func (s *statementImpl) createTableIfNeeded(ctx context.Context, tableName string, schema *arrow.Schema, opts *driverbase.BulkIngestOptions) error {
 	switch opts.Mode {
@@ -95,8 +149,12 @@ func (s *statementImpl) createTableIfNeeded(ctx context.Context, tableName strin
 		return s.createTable(ctx, tableName, schema, true)
 
 	case adbc.OptionValueIngestModeReplace:
+		conn, err := s.session(ctx)
+		if err != nil {
+			return err
+		}
 		dropSQL := fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName)
-		if _, err := s.conn.conn.ExecContext(ctx, dropSQL); err != nil {
+		if _, err := conn.ExecContext(ctx, annotateQuery(ctx, dropSQL)); err != nil {
 			return s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to drop the table: %v", err)
 		}
 		return s.createTable(ctx, tableName, schema, false)
@@ -104,6 +162,9 @@ func (s *statementImpl) createTableIfNeeded(ctx context.Context, tableName strin
 	case adbc.OptionValueIngestModeAppend:
 		return nil
 
+	case OptionValueIngestModeUpsert:
+		return s.createTable(ctx, tableName, schema, true)
+
 	default:
 		return s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "invalid ingest mode: %s", opts.Mode)
 	}
@@ -132,7 +193,11 @@ func (s *statementImpl) createTable(ctx context.Context, tableName string, schem
 	}
 	sql.WriteString(")")
 
-	_, err := s.conn.conn.ExecContext(ctx, sql.String())
+	conn, err := s.session(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, annotateQuery(ctx, sql.String()))
 	if err != nil {
 		return s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create table: %v", err)
 	}
@@ -154,8 +219,16 @@ func buildInsertSQL(tableName string, schema *arrow.Schema) (string, error) {
 		sql.WriteString(quoteIdentifier(field.Name))
 	}
 
-	sql.WriteString(") VALUES (")
+	sql.WriteString(") VALUES ")
+	sql.WriteString(rowMarkers(schema))
+	return sql.String(), nil
+}
 
+// rowMarkers returns the parenthesized parameter markers of one row of
+// values with schema.
+func rowMarkers(schema *arrow.Schema) string {
+	var sql strings.Builder
+	sql.WriteString("(")
 	for i, field := range schema.Fields() {
 		if i > 0 {
 			sql.WriteString(", ")
@@ -168,9 +241,8 @@ func buildInsertSQL(tableName string, schema *arrow.Schema) (string, error) {
 			sql.WriteString("?")
 		}
 	}
-
 	sql.WriteString(")")
-	return sql.String(), nil
+	return sql.String()
 }
 
 // buildTableName constructs catalog.schema.table name
@@ -201,8 +273,9 @@ func valuesToInterfaces(params []driver.NamedValue) []any {
 	return result
 }
 
-// extractGoValue extracts a Go value from an Arrow array at the given index
-func extractGoValue(arr arrow.Array, idx int) (any, error) {
+// extractGoValue extracts a Go value from an Arrow array at the given index.
+// Date and time values are rendered according to tb.
+func extractGoValue(arr arrow.Array, idx int, tb temporalBinding) (any, error) {
 	if arr.IsNull(idx) {
 		return nil, nil
 	}
@@ -263,13 +336,13 @@ func extractGoValue(arr arrow.Array, idx int) (any, error) {
 		return fmt.Sprintf("%x", arr.(*array.FixedSizeBinary).Value(idx)), nil
 
 	case arrow.DATE32:
-		return arr.(*array.Date32).Value(idx).ToTime(), nil
+		return tb.date(arr.(*array.Date32).Value(idx).ToTime()), nil
 	case arrow.DATE64:
-		return arr.(*array.Date64).Value(idx).ToTime(), nil
+		return tb.date(arr.(*array.Date64).Value(idx).ToTime()), nil
 
 	case arrow.TIMESTAMP:
 		ts := arr.DataType().(*arrow.TimestampType)
-		return arr.(*array.Timestamp).Value(idx).ToTime(ts.Unit), nil
+		return tb.timestamp(arr.(*array.Timestamp).Value(idx), ts)
 
 	case arrow.DECIMAL128:
 		dec := arr.(*array.Decimal128)
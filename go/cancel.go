@@ -0,0 +1,53 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+)
+
+// StatementCanceller is implemented by the statements of this driver, as
+// the ADBC Go API has no counterpart of AdbcStatementCancel.
+//
+// Cancel may be called from any goroutine. It cancels the statement's
+// latest ExecuteQuery, ExecuteUpdate or ExecutePartitions as cancelling
+// their context would: a query still running is cancelled on the server,
+// so that it stops using the warehouse, the execution fails with
+// StatusCancelled, and so does reading its result. It does nothing once
+// the result has been read, and doesn't cancel queries submitted with
+// OptionExecutionAsync; see AsyncExecutor.CancelQuery.
+type StatementCanceller interface {
+	Cancel() error
+}
+
+func (s *statementImpl) Cancel() error {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	if s.cancelExecution != nil {
+		s.cancelExecution(nil)
+		s.cancelExecution = nil
+	}
+	return nil
+}
+
+// cancellable returns the context of a new execution, which Cancel
+// cancels until the next execution starts, and its cancel function.
+func (s *statementImpl) cancellable(ctx context.Context) (context.Context, context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	s.cancelMu.Lock()
+	s.cancelExecution = cancel
+	s.cancelMu.Unlock()
+	return ctx, cancel
+}
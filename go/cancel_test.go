@@ -0,0 +1,112 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCancel(t *testing.T) {
+	s := &statementImpl{}
+	require.NoError(t, s.Cancel())
+
+	ctx, _ := s.cancellable(context.Background())
+	require.NoError(t, s.Cancel())
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.NoError(t, s.Cancel())
+
+	// Only the latest execution is cancelled
+	first, _ := s.cancellable(context.Background())
+	second, _ := s.cancellable(context.Background())
+	require.NoError(t, s.Cancel())
+	assert.NoError(t, first.Err())
+	assert.ErrorIs(t, second.Err(), context.Canceled)
+}
+
+func TestStatementAPICancelsAbandonedStatements(t *testing.T) {
+	defer func(interval time.Duration) { statementPollInterval = interval }(statementPollInterval)
+	statementPollInterval = time.Millisecond
+
+	var posts, cancels atomic.Int32
+	posted := make(chan struct{}, 1)
+	release := make(chan struct{})
+	polling := make(chan struct{}, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/2.0/sql/statements", func(w http.ResponseWriter, r *http.Request) {
+		if posts.Add(1) == 1 {
+			posted <- struct{}{}
+			<-release
+		}
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "RUNNING"}}`)
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case polling <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("POST /api/2.0/sql/statements/stmt-1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		cancels.Add(1)
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api := &statementAPI{
+		client:      server.Client(),
+		baseURL:     server.URL,
+		auth:        &pat.PATAuth{AccessToken: "token"},
+		warehouseID: "wh",
+		goroutines:  checkGoroutines(t),
+	}
+
+	// Cancelled while the statement is being submitted: the statement is
+	// cancelled once the server names it
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-posted
+		cancel()
+	}()
+	_, err := api.execute(ctx, "SELECT 1", "", "")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusCancelled, adbcErr.Code)
+	assert.EqualValues(t, 0, cancels.Load())
+	close(release)
+	require.Eventually(t, func() bool { return cancels.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+	// Cancelled while polling
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-polling
+		cancel()
+	}()
+	_, err = api.execute(ctx, "SELECT 1", "", "")
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusCancelled, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "stmt-1")
+	assert.EqualValues(t, 2, cancels.Load())
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	dbsql "github.com/databricks/databricks-sql-go"
+)
+
+// capabilities records which optional server features a session supports.
+// Features are assumed to be supported until a probe shows otherwise.
+type capabilities struct {
+	// Probed is false if the features were assumed rather than probed.
+	Probed bool `json:"probed"`
+	// NativeParameters is whether the server accepts query parameters
+	// sent alongside the query. Without them, bulk ingestion inlines the
+	// values as SQL literals.
+	NativeParameters bool `json:"native_parameters"`
+	// Variant is whether the server has the VARIANT type.
+	Variant bool `json:"variant"`
+}
+
+func assumedCapabilities() capabilities {
+	return capabilities{NativeParameters: true, Variant: true}
+}
+
+// probeCapabilities checks which optional features conn's session supports,
+// logging a warning for each one that is missing. Arrow LZ4 compression is
+// not probed since databricks-sql-go never requests it.
+func probeCapabilities(ctx context.Context, conn *sql.Conn, logger *slog.Logger) capabilities {
+	caps := capabilities{Probed: true}
+
+	var one int64
+	if err := conn.QueryRowContext(ctx, "SELECT ?", int64(1)).Scan(&one); err != nil {
+		logger.WarnContext(ctx, "server does not support native query parameters; bulk ingestion will inline values as SQL literals",
+			slog.Any("error", err))
+	} else {
+		caps.NativeParameters = true
+	}
+
+	var typeName string
+	if err := conn.QueryRowContext(ctx, "SELECT typeof(parse_json('0'))").Scan(&typeName); err != nil {
+		logger.WarnContext(ctx, "server does not support the VARIANT type; queries using it will fail",
+			slog.Any("error", err))
+	} else {
+		caps.Variant = true
+	}
+
+	return caps
+}
+
+func (c capabilities) json() (string, error) {
+	out, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// inlineParameters replaces each ? placeholder in query, outside of
+// backquoted identifiers, with the next of args rendered as a SQL literal.
+func inlineParameters(query string, args []any) (string, error) {
+	var b strings.Builder
+	next := 0
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '`':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			if next >= len(args) {
+				return "", fmt.Errorf("query has more placeholders than the %d parameters", len(args))
+			}
+			literal, err := sqlLiteral(args[next])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(literal)
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if next != len(args) {
+		return "", fmt.Errorf("query has %d placeholders but %d parameters", next, len(args))
+	}
+	return b.String(), nil
+}
+
+// sqlLiteral renders a value produced by extractGoValue as a SQL literal.
+func sqlLiteral(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return "CAST(" + stringLiteral(strconv.FormatFloat(v, 'g', -1, 64)) + " AS DOUBLE)", nil
+	case string:
+		return stringLiteral(v), nil
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'", nil
+	case dbsql.Parameter:
+		if v.Value == nil {
+			return "NULL", nil
+		}
+		return fmt.Sprintf("%s %s", v.Type, stringLiteral(fmt.Sprint(v.Value))), nil
+	default:
+		return "", fmt.Errorf("cannot render %T as a SQL literal", v)
+	}
+}
+
+// stringLiteral quotes s as a Databricks string literal, in which
+// backslashes are escape characters.
+func stringLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
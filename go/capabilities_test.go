@@ -0,0 +1,68 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"math"
+	"testing"
+
+	dbsql "github.com/databricks/databricks-sql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLLiteral(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "NULL"},
+		{true, "TRUE"},
+		{false, "FALSE"},
+		{int64(-42), "-42"},
+		{1.5, "CAST('1.5' AS DOUBLE)"},
+		{math.Inf(1), "CAST('+Inf' AS DOUBLE)"},
+		{"plain", "'plain'"},
+		{`it's a \ test`, `'it\'s a \\ test'`},
+		{[]byte{0xde, 0xad}, "X'dead'"},
+		{dbsql.Parameter{Type: dbsql.SqlDate, Value: "2024-01-02"}, "DATE '2024-01-02'"},
+		{dbsql.Parameter{Type: dbsql.SqlTimestamp, Value: "2024-01-02 03:04:05+01:00"}, "TIMESTAMP '2024-01-02 03:04:05+01:00'"},
+	}
+	for _, tt := range tests {
+		literal, err := sqlLiteral(tt.value)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, literal)
+	}
+
+	_, err := sqlLiteral(struct{}{})
+	assert.Error(t, err)
+}
+
+func TestInlineParameters(t *testing.T) {
+	query, err := inlineParameters("INSERT INTO `t?` (`a`, `b?`) VALUES (?, UNHEX(?))", []any{int64(1), "ff"})
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `t?` (`a`, `b?`) VALUES (1, UNHEX('ff'))", query)
+
+	_, err = inlineParameters("VALUES (?, ?)", []any{int64(1)})
+	assert.Error(t, err)
+	_, err = inlineParameters("VALUES (?)", []any{int64(1), int64(2)})
+	assert.Error(t, err)
+}
+
+func TestAssumedCapabilities(t *testing.T) {
+	out, err := assumedCapabilities().json()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"probed": false, "native_parameters": true, "variant": true}`, out)
+}
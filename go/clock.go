@@ -0,0 +1,104 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+)
+
+// Clock is the source of time of the driver's waits and expiries: the
+// backoff between retries of metadata queries and result chunks, waiting
+// for a cluster to start, polling running statements and job runs,
+// refreshing OAuth access tokens before they expire, closing idle
+// sessions, flushing ingest streams on their interval and the schema
+// cache's TTL. Timings that are only measured, such as download
+// durations, and the times recorded in events and error history use the
+// system clock regardless.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// ClockSetter is implemented by the databases of this driver. SetClock
+// makes the connections opened afterwards, and the database's own waits
+// and OAuth authenticators created afterwards, use clock; nil restores the
+// system clock. It is meant for tests and for programs that run the
+// driver in virtual time.
+type ClockSetter interface {
+	SetClock(clock Clock)
+}
+
+func (d *databaseImpl) SetClock(clock Clock) {
+	d.clock = driverClock{clock}
+}
+
+// clockedDatabase is the database the driver returns: the driverbase
+// wrapper only exposes the methods of driverbase.Database.
+type clockedDatabase struct {
+	driverbase.Database
+	impl *databaseImpl
+}
+
+func (d *clockedDatabase) SetClock(clock Clock) {
+	d.impl.SetClock(clock)
+}
+
+// driverClock is a Clock whose zero value is the system clock.
+type driverClock struct {
+	Clock
+}
+
+func (c driverClock) Now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+	return c.Clock.Now()
+}
+
+func (c driverClock) After(d time.Duration) <-chan time.Time {
+	if c.Clock == nil {
+		return time.After(d)
+	}
+	return c.Clock.After(d)
+}
+
+// AfterFunc calls f in its own goroutine once d has passed, unless the
+// returned function is called first.
+func (c driverClock) AfterFunc(d time.Duration, f func()) (stop func()) {
+	if c.Clock == nil {
+		timer := time.AfterFunc(d, f)
+		return func() { timer.Stop() }
+	}
+	fired := c.Clock.After(d)
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-fired:
+			f()
+		case <-stopped:
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(stopped) }) }
+}
+
+// Since returns the time passed since t.
+func (c driverClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
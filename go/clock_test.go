@@ -0,0 +1,88 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose time only moves when advanced. After moves it
+// forward by the wait at once, so waits take no real time; the waits are
+// recorded.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestDriverClock(t *testing.T) {
+	// The zero value is the system clock
+	var system driverClock
+	assert.WithinDuration(t, time.Now(), system.Now(), time.Minute)
+	select {
+	case <-system.After(time.Millisecond):
+	case <-time.After(time.Minute):
+		t.Fatal("system clock never fired")
+	}
+	// A stopped AfterFunc never calls its function
+	stop := system.AfterFunc(time.Millisecond, func() { t.Error("stopped AfterFunc fired") })
+	stop()
+	time.Sleep(5 * time.Millisecond)
+
+	fake := newFakeClock()
+	db := &databaseImpl{}
+	db.SetClock(fake)
+	start := db.clock.Now()
+	<-db.clock.After(time.Hour)
+	assert.Equal(t, time.Hour, db.clock.Since(start))
+	fired := make(chan struct{})
+	db.clock.AfterFunc(time.Minute, func() { close(fired) })
+	<-fired
+	assert.Equal(t, time.Hour+time.Minute, db.clock.Since(start))
+	assert.Equal(t, []time.Duration{time.Hour, time.Minute}, fake.waits)
+
+	db.SetClock(nil)
+	assert.Nil(t, db.clock.Clock)
+}
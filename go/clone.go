@@ -0,0 +1,120 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// StatementCloner is implemented by the statements of this driver.
+//
+// Clone returns a new statement on the same connection with the same query,
+// options and ingestion target, sharing the prepared statement (if any) so
+// that it needn't be prepared again. Bound parameters are not copied. The
+// clone and the original can be used from different goroutines; they share
+// the connection's session, so the server runs their queries concurrently
+// within it. The prepared statement is closed with the last statement
+// sharing it.
+type StatementCloner interface {
+	Clone() (adbc.Statement, error)
+}
+
+// preparedStmt is a prepared statement shared by a statement and its
+// clones.
+type preparedStmt struct {
+	*sql.Stmt
+	refs atomic.Int32
+}
+
+func newPreparedStmt(stmt *sql.Stmt) *preparedStmt {
+	p := &preparedStmt{Stmt: stmt}
+	p.refs.Store(1)
+	return p
+}
+
+func (p *preparedStmt) retain() *preparedStmt {
+	p.refs.Add(1)
+	return p
+}
+
+// release closes the prepared statement once no statement uses it.
+func (p *preparedStmt) release() error {
+	if p.refs.Add(-1) == 0 {
+		return p.Close()
+	}
+	return nil
+}
+
+func (s *statementImpl) Clone() (adbc.Statement, error) {
+	if s.conn == nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement already closed")
+	}
+	clone := &statementImpl{
+		StatementImplBase:    driverbase.NewStatementImplBase(&s.conn.ConnectionImplBase, s.conn.ErrorHelper),
+		conn:                 s.conn,
+		query:                s.query,
+		bulkIngestOptions:    s.bulkIngestOptions,
+		validateOnly:         s.validateOnly,
+		async:                s.async,
+		queryTimeout:         s.queryTimeout,
+		concatResult:         s.concatResult,
+		concatMaxBytes:       s.concatMaxBytes,
+		largeTypes:           s.largeTypes,
+		decimals:             s.decimals,
+		decimalMaxPrecision:  s.decimalMaxPrecision,
+		decimalOverflow:      s.decimalOverflow,
+		alloc:                newTrackingAllocator(s.conn.Alloc),
+		memoryLimit:          s.memoryLimit,
+		prefetchMaxBytes:     s.prefetchMaxBytes,
+		prefetchDrainPercent: s.prefetchDrainPercent,
+		rejectOverBytes:      s.rejectOverBytes,
+		fetchLane:            s.fetchLane,
+		sampleFraction:       s.sampleFraction,
+		sampleRows:           s.sampleRows,
+		sampleSeed:           s.sampleSeed,
+		extractTable:         s.extractTable,
+		windowStart:          s.windowStart,
+		windowEnd:            s.windowEnd,
+		extractWindowColumn:  s.extractWindowColumn,
+		extractColumn:        s.extractColumn,
+		resultMode:           s.resultMode,
+		schemaDrift:          s.schemaDrift,
+		protocols:            s.protocols,
+		batchMetadata:        s.batchMetadata,
+		normalizeCommands:    s.normalizeCommands,
+		traceFile:            s.traceFile,
+		queryTags:            s.queryTags,
+		ingestStaging:        s.ingestStaging,
+		ingestMergeKeys:      s.ingestMergeKeys,
+		ingestFileSize:       s.ingestFileSize,
+		ingestUploadParallel: s.ingestUploadParallel,
+		ingestManifest:       s.ingestManifest,
+		ingestRulesSpec:      s.ingestRulesSpec,
+		ingestRules:          s.ingestRules,
+		ingestMaxRejected:    s.ingestMaxRejected,
+		ingestStreamMaxRows:  s.ingestStreamMaxRows,
+		ingestStreamMaxBytes: s.ingestStreamMaxBytes,
+		ingestStreamInterval: s.ingestStreamInterval,
+		paramSerializer:      s.paramSerializer,
+	}
+	if s.prepared != nil {
+		clone.prepared = s.prepared.retain()
+	}
+	return clone, nil
+}
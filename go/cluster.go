@@ -0,0 +1,80 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// clusterStartPollInterval is how long to wait between attempts to open a
+// session while a cluster starts. A variable so that tests can shorten it.
+var clusterStartPollInterval = 15 * time.Second
+
+// computeTypeFromHTTPPath returns the kind of compute an HTTP path points
+// at: OptionValueComputeTypeWarehouse for /sql/1.0/warehouses/<id>,
+// OptionValueComputeTypeCluster for /sql/protocolv1/o/<org>/<cluster>, or
+// "" for other paths.
+func computeTypeFromHTTPPath(httpPath string) string {
+	if warehouseIDFromHTTPPath(httpPath) != "" {
+		return OptionValueComputeTypeWarehouse
+	}
+	parts := strings.Split(strings.Trim(httpPath, "/"), "/")
+	if len(parts) == 5 && parts[0] == "sql" && parts[1] == "protocolv1" && parts[2] == "o" && parts[4] != "" {
+		return OptionValueComputeTypeCluster
+	}
+	return ""
+}
+
+// isClusterStarting reports whether err is the response of a cluster that
+// is not running yet. The gateway answers with 503 TEMPORARILY_UNAVAILABLE
+// until the cluster has started.
+func isClusterStarting(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "temporarily_unavailable") ||
+		strings.Contains(msg, "503 service unavailable") ||
+		strings.Contains(msg, "cluster is starting")
+}
+
+// waitForCluster calls open until it succeeds, fails for a reason other
+// than the cluster starting, or timeout has passed. A zero timeout calls
+// open once. started reports whether open succeeded after waiting for the
+// cluster to start. If open fails after being called again, the error
+// carries ErrorDetailRetryHistory.
+func waitForCluster(ctx context.Context, clock driverClock, timeout time.Duration, logger *slog.Logger, open func(context.Context) error) (started bool, err error) {
+	deadline := clock.Now().Add(timeout)
+	history := newRetryHistory("wait for cluster", clock)
+	for waited := false; ; waited = true {
+		err := open(ctx)
+		if err == nil {
+			return waited, nil
+		}
+		if !isClusterStarting(err) || clock.Now().Add(clusterStartPollInterval).After(deadline) {
+			history.record(err, 0)
+			return false, history.fail(err)
+		}
+		history.record(err, clusterStartPollInterval)
+		logger.InfoContext(ctx, "cluster is starting; waiting before opening the session again",
+			slog.Duration("retry_in", clusterStartPollInterval), slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return false, history.fail(ctx.Err())
+		case <-clock.After(clusterStartPollInterval):
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTypeFromHTTPPath(t *testing.T) {
+	assert.Equal(t, OptionValueComputeTypeWarehouse, computeTypeFromHTTPPath("/sql/1.0/warehouses/abc123"))
+	assert.Equal(t, OptionValueComputeTypeWarehouse, computeTypeFromHTTPPath("/sql/1.0/endpoints/abc123"))
+	assert.Equal(t, OptionValueComputeTypeCluster, computeTypeFromHTTPPath("/sql/protocolv1/o/1234567890/0123-456789-abcdefgh"))
+	assert.Equal(t, OptionValueComputeTypeCluster, computeTypeFromHTTPPath("sql/protocolv1/o/1234567890/0123-456789-abcdefgh/"))
+	assert.Equal(t, "", computeTypeFromHTTPPath("/sql/protocolv1/o/1234567890"))
+	assert.Equal(t, "", computeTypeFromHTTPPath("/cliservice"))
+}
+
+func TestWaitForCluster(t *testing.T) {
+	clock := newFakeClock()
+	starting := errors.New("unexpected HTTP status 503 Service Unavailable: TEMPORARILY_UNAVAILABLE")
+
+	// Retries while the cluster starts
+	attempts := 0
+	started, err := waitForCluster(context.Background(), driverClock{clock}, time.Minute, slog.Default(), func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return starting
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, 3, attempts)
+
+	// A running cluster didn't need to start
+	started, err = waitForCluster(context.Background(), driverClock{clock}, time.Minute, slog.Default(), func(context.Context) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, started)
+
+	// Other errors aren't retried
+	attempts = 0
+	_, err = waitForCluster(context.Background(), driverClock{clock}, time.Minute, slog.Default(), func(context.Context) error {
+		attempts++
+		return errors.New("invalid access token")
+	})
+	assert.EqualError(t, err, "invalid access token")
+	assert.Equal(t, 1, attempts)
+
+	// Nor is anything without a timeout
+	attempts = 0
+	_, err = waitForCluster(context.Background(), driverClock{clock}, 0, slog.Default(), func(context.Context) error {
+		attempts++
+		return starting
+	})
+	assert.ErrorIs(t, err, starting)
+	assert.Equal(t, 1, attempts)
+
+	// Polling stops at the timeout, which the retry history spans
+	attempts = 0
+	clock.waits = nil
+	_, err = waitForCluster(context.Background(), driverClock{clock}, time.Minute, slog.Default(), func(context.Context) error {
+		attempts++
+		return starting
+	})
+	assert.ErrorIs(t, err, starting)
+	assert.Equal(t, 5, attempts)
+	assert.Equal(t, []time.Duration{clusterStartPollInterval, clusterStartPollInterval, clusterStartPollInterval, clusterStartPollInterval}, clock.waits)
+	details := retryDetails(err)
+	require.Len(t, details, 1)
+	detail, _ := details[0].Serialize()
+	assert.Contains(t, string(detail), `"total_backoff_ms":60000,"elapsed_ms":60000`)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// collationSessionParam is the session parameter holding the collation
+// of string literals and expressions that don't name one.
+const collationSessionParam = "spark.sql.session.collation.default"
+
+// builtinCollationPrefix qualifies the names of built-in collations on
+// recent runtimes.
+const builtinCollationPrefix = "SYSTEM.BUILTIN."
+
+// parseCollation checks the name of a collation, such as UTF8_BINARY,
+// UTF8_LCASE, UNICODE_CI or de_CI_AI, leaving it to the server to reject
+// unknown ones.
+func parseCollation(key, value string) (string, error) {
+	name := value
+	if len(name) > len(builtinCollationPrefix) && strings.EqualFold(name[:len(builtinCollationPrefix)], builtinCollationPrefix) {
+		name = name[len(builtinCollationPrefix):]
+	}
+	if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isIdentPart(r) }) >= 0 {
+		return "", invalidOption(key, value, "a collation name such as UTF8_BINARY, UTF8_LCASE or UNICODE_CI")
+	}
+	return name, nil
+}
+
+// prepareSessionInfo registers the session's collation and timezone as
+// InfoSessionCollation and InfoSessionTimezone, as the server reports
+// them, so that they are known whether or not they were configured.
+func (c *connectionImpl) prepareSessionInfo(ctx context.Context) error {
+	var collation, timezone string
+	err := c.retryMetadata(ctx, "get session settings", func() error {
+		conn, err := c.sqlConn(ctx)
+		if err != nil {
+			return err
+		}
+		if err := conn.QueryRowContext(ctx, "SELECT current_timezone()").Scan(&timezone); err != nil {
+			return adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to get session timezone: %v", err),
+			}
+		}
+		if err := conn.QueryRowContext(ctx, "SELECT collation('')").Scan(&collation); err != nil {
+			// Runtimes without collations compare strings byte by byte
+			collation = "UTF8_BINARY"
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.DriverInfo.RegisterInfoCode(InfoSessionCollation, strings.TrimPrefix(collation, builtinCollationPrefix)); err != nil {
+		return err
+	}
+	return c.DriverInfo.RegisterInfoCode(InfoSessionTimezone, timezone)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCollation(t *testing.T) {
+	for value, expected := range map[string]string{
+		"UTF8_LCASE":                 "UTF8_LCASE",
+		"unicode_ci":                 "unicode_ci",
+		"de_CI_AI":                   "de_CI_AI",
+		"SYSTEM.BUILTIN.UNICODE_CI":  "UNICODE_CI",
+		"system.builtin.UTF8_BINARY": "UTF8_BINARY",
+	} {
+		collation, err := parseCollation(OptionSessionCollation, value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, collation, value)
+	}
+	for _, value := range []string{"", "UTF8 LCASE", "x'; DROP TABLE t", "SYSTEM.BUILTIN.", "other.UNICODE"} {
+		_, err := parseCollation(OptionSessionCollation, value)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, value)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code, value)
+	}
+}
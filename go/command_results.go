@@ -0,0 +1,188 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// commandColumnAliases renames columns (after snake-casing) that runtime
+// versions have given different names for the same thing.
+var commandColumnAliases = map[string]string{
+	// SHOW TABLES, SHOW VIEWS and SHOW SCHEMAS
+	"database":      "schema_name",
+	"database_name": "schema_name",
+	"namespace":     "schema_name",
+	// SHOW CATALOGS
+	"catalog": "catalog_name",
+	// DESCRIBE TABLE and SHOW COLUMNS
+	"col_name": "column_name",
+	// SHOW FUNCTIONS
+	"function": "function_name",
+	// SHOW CREATE TABLE
+	"createtab_stmt": "statement",
+}
+
+// isCommand reports whether query is a SHOW, DESCRIBE or EXPLAIN command,
+// whose results OptionFetchNormalizeCommands normalizes.
+func isCommand(query string) bool {
+	switch leadingKeyword(query) {
+	case "SHOW", "DESCRIBE", "DESC", "EXPLAIN":
+		return true
+	}
+	return false
+}
+
+// commandColumnName returns the stable name of a command result column:
+// name in snake_case, with the aliases of commandColumnAliases resolved.
+func commandColumnName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	snake := b.String()
+	if alias, ok := commandColumnAliases[snake]; ok {
+		return alias
+	}
+	return snake
+}
+
+// commandResultSchema returns the normalized schema of a command result,
+// and which of its columns are flags sent as "true"/"false" strings to be
+// converted to Boolean.
+func commandResultSchema(schema *arrow.Schema) (*arrow.Schema, []bool) {
+	fields := schema.Fields()
+	flags := make([]bool, len(fields))
+	for i, field := range fields {
+		fields[i].Name = commandColumnName(field.Name)
+		if strings.HasPrefix(fields[i].Name, "is_") && field.Type.ID() == arrow.STRING {
+			fields[i].Type = arrow.FixedWidthTypes.Boolean
+			flags[i] = true
+		}
+	}
+	md := schema.Metadata()
+	return arrow.NewSchema(fields, &md), flags
+}
+
+// parseFlags converts a String array of "true"/"false" flags to Boolean.
+// Other values become null.
+func parseFlags(mem memory.Allocator, arr *array.String) arrow.Array {
+	b := array.NewBooleanBuilder(mem)
+	defer b.Release()
+	b.Reserve(arr.Len())
+	for i := range arr.Len() {
+		switch {
+		case arr.IsNull(i):
+			b.AppendNull()
+		case strings.EqualFold(arr.Value(i), "true"):
+			b.Append(true)
+		case strings.EqualFold(arr.Value(i), "false"):
+			b.Append(false)
+		default:
+			b.AppendNull()
+		}
+	}
+	return b.NewArray()
+}
+
+// commandResultReader gives the batches of a SHOW, DESCRIBE or EXPLAIN
+// result read from rdr the schema of commandResultSchema.
+type commandResultReader struct {
+	refCount int64
+	mem      memory.Allocator
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	flags    []bool
+	current  arrow.RecordBatch
+}
+
+// newCommandResultReader wraps rdr, taking ownership of it.
+func newCommandResultReader(mem memory.Allocator, rdr array.RecordReader) *commandResultReader {
+	schema, flags := commandResultSchema(rdr.Schema())
+	return &commandResultReader{
+		refCount: 1,
+		mem:      mem,
+		rdr:      rdr,
+		schema:   schema,
+		flags:    flags,
+	}
+}
+
+func (r *commandResultReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *commandResultReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.rdr.Release()
+	}
+}
+
+func (r *commandResultReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *commandResultReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if !r.rdr.Next() {
+		return false
+	}
+	batch := r.rdr.RecordBatch()
+	cols := make([]arrow.Array, batch.NumCols())
+	for i, col := range batch.Columns() {
+		if r.flags[i] {
+			cols[i] = parseFlags(r.mem, col.(*array.String))
+		} else {
+			col.Retain()
+			cols[i] = col
+		}
+	}
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	r.current = array.NewRecordBatch(r.schema, cols, batch.NumRows())
+	return true
+}
+
+func (r *commandResultReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *commandResultReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *commandResultReader) Err() error {
+	return r.rdr.Err()
+}
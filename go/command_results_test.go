@@ -0,0 +1,94 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, isCommand("SHOW TABLES"))
+	assert.True(t, isCommand("-- schemas\nshow schemas in main"))
+	assert.True(t, isCommand("DESC TABLE t"))
+	assert.True(t, isCommand("describe history t"))
+	assert.True(t, isCommand("EXPLAIN SELECT 1"))
+	assert.False(t, isCommand("SELECT * FROM show"))
+}
+
+func TestCommandColumnName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"database":       "schema_name",
+		"namespace":      "schema_name",
+		"databaseName":   "schema_name",
+		"tableName":      "table_name",
+		"isTemporary":    "is_temporary",
+		"catalog":        "catalog_name",
+		"col_name":       "column_name",
+		"data_type":      "data_type",
+		"ActionType":     "action_type",
+		"function":       "function_name",
+		"createtab_stmt": "statement",
+		"plan":           "plan",
+	} {
+		assert.Equal(t, expected, commandColumnName(name), name)
+	}
+}
+
+func TestCommandResultReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// SHOW TABLES as older runtimes return it
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "database", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "tableName", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "isTemporary", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	batch, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(`[
+		{"database": "sales", "tableName": "orders", "isTemporary": "false"},
+		{"database": "", "tableName": "scratch", "isTemporary": "true"}
+	]`))
+	require.NoError(t, err)
+	defer batch.Release()
+	src, err := array.NewRecordReader(schema, []arrow.RecordBatch{batch})
+	require.NoError(t, err)
+
+	rdr := newCommandResultReader(mem, src)
+	defer rdr.Release()
+
+	expected := arrow.NewSchema([]arrow.Field{
+		{Name: "schema_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "table_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "is_temporary", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	}, nil)
+	assert.True(t, expected.Equal(rdr.Schema()))
+
+	require.True(t, rdr.Next())
+	got := rdr.RecordBatch()
+	assert.True(t, expected.Equal(got.Schema()))
+	assert.Equal(t, "orders", got.Column(1).(*array.String).Value(0))
+	flags := got.Column(2).(*array.Boolean)
+	assert.False(t, flags.Value(0))
+	assert.True(t, flags.Value(1))
+	assert.False(t, rdr.Next())
+	require.NoError(t, rdr.Err())
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// CommentEditor is implemented by the connections of this driver.
+//
+// Comments documents a table and its columns; the same comments are
+// reported as COMMENT metadata by GetTableSchema and as column remarks by
+// GetObjects. SetTableComment and SetColumnComment replace a comment, or
+// remove it if comment is empty. As with GetTableSchema, a nil catalog or
+// schema means the current one.
+type CommentEditor interface {
+	Comments(ctx context.Context, catalog, dbSchema *string, tableName string) (*TableComments, error)
+	SetTableComment(ctx context.Context, catalog, dbSchema *string, tableName, comment string) error
+	SetColumnComment(ctx context.Context, catalog, dbSchema *string, tableName, columnName, comment string) error
+}
+
+// TableComments is the result of Comments.
+type TableComments struct {
+	// Table is the table's comment, or empty if it has none.
+	Table string
+	// Columns maps the names of the columns that have a comment to it.
+	Columns map[string]string
+}
+
+func (c *connectionImpl) Comments(ctx context.Context, catalog, dbSchema *string, tableName string) (comments *TableComments, err error) {
+	defer func() { err = c.recordError("Comments", "", err) }()
+
+	name, err := c.qualifyTableName(catalog, dbSchema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	// Not through the schema cache, which wouldn't notice a comment
+	// changed by another client
+	schema, err := c.describeTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	comments = &TableComments{Columns: map[string]string{}}
+	comments.Table, _ = schema.Metadata().GetValue("COMMENT")
+	for _, field := range schema.Fields() {
+		if comment, ok := field.Metadata.GetValue("COMMENT"); ok {
+			comments.Columns[field.Name] = comment
+		}
+	}
+	return comments, nil
+}
+
+func (c *connectionImpl) SetTableComment(ctx context.Context, catalog, dbSchema *string, tableName, comment string) (err error) {
+	defer func() { err = c.recordError("SetTableComment", "", err) }()
+
+	name, err := c.qualifyTableName(catalog, dbSchema, tableName)
+	if err != nil {
+		return err
+	}
+	return c.execComment(ctx, name, tableCommentSQL(name, comment))
+}
+
+func (c *connectionImpl) SetColumnComment(ctx context.Context, catalog, dbSchema *string, tableName, columnName, comment string) (err error) {
+	defer func() { err = c.recordError("SetColumnComment", "", err) }()
+
+	if columnName == "" {
+		return c.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "no column name given")
+	}
+	name, err := c.qualifyTableName(catalog, dbSchema, tableName)
+	if err != nil {
+		return err
+	}
+	return c.execComment(ctx, name, columnCommentSQL(name, columnName, comment))
+}
+
+// execComment runs a statement changing a comment of the table name, and
+// drops the table's cached schema, whose metadata carries the comments.
+func (c *connectionImpl) execComment(ctx context.Context, name, query string) error {
+	defer c.busy()()
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return c.ErrorHelper.Errorf(adbc.StatusInternal, "failed to set comment on %s: %v", name, err)
+	}
+	if c.schemaCache != nil {
+		c.schemaCache.remove(name)
+	}
+	return nil
+}
+
+// tableCommentSQL returns the statement setting the comment of the table
+// name, which must already be quoted.
+func tableCommentSQL(name, comment string) string {
+	if comment == "" {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS NULL", name)
+	}
+	return fmt.Sprintf("COMMENT ON TABLE %s IS %s", name, stringLiteral(comment))
+}
+
+// columnCommentSQL returns the statement setting the comment of a column
+// of the table name, which must already be quoted. COMMENT ON COLUMN needs
+// a recent runtime, so ALTER COLUMN is used instead; an empty comment
+// removes it.
+func columnCommentSQL(name, column, comment string) string {
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT %s", name, quoteIdentifier(column), stringLiteral(comment))
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentSQL(t *testing.T) {
+	name := qualifiedTableName("main", "default", "orders")
+	assert.Equal(t, "COMMENT ON TABLE `main`.`default`.`orders` IS 'it\\'s the orders'", tableCommentSQL(name, "it's the orders"))
+	assert.Equal(t, "COMMENT ON TABLE `main`.`default`.`orders` IS NULL", tableCommentSQL(name, ""))
+	assert.Equal(t, "ALTER TABLE `main`.`default`.`orders` ALTER COLUMN `odd``id` COMMENT 'key'", columnCommentSQL(name, "odd`id", "key"))
+	assert.Equal(t, "ALTER TABLE `main`.`default`.`orders` ALTER COLUMN `id` COMMENT ''", columnCommentSQL(name, "id", ""))
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/util"
+)
+
+// concatRecordReader reads all batches from rdr and concatenates them into
+// a single record batch, which has no rows if rdr has no batches. It fails
+// with StatusInvalidState once the batches read exceed maxBytes, unless
+// maxBytes is zero. If widen is set, String and Binary columns whose
+// values would overflow 32-bit offsets are returned as LargeString and
+// LargeBinary. rdr is released.
+func concatRecordReader(mem memory.Allocator, rdr array.RecordReader, maxBytes int64, widen bool) (arrow.RecordBatch, error) {
+	defer rdr.Release()
+	schema := rdr.Schema()
+
+	var batches []arrow.RecordBatch
+	defer func() {
+		for _, batch := range batches {
+			batch.Release()
+		}
+	}()
+
+	var size, numRows int64
+	for rdr.Next() {
+		batch := rdr.RecordBatch()
+		size += util.TotalRecordSize(batch)
+		if maxBytes > 0 && size > maxBytes {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidState,
+				Msg: fmt.Sprintf("result exceeds %d bytes; raise %s or disable %s to stream it",
+					maxBytes, OptionFetchConcatResultMaxBytes, OptionFetchConcatResult),
+			}
+		}
+		batch.Retain()
+		batches = append(batches, batch)
+		numRows += batch.NumRows()
+	}
+	if err := rdr.Err(); err != nil {
+		return nil, err
+	}
+
+	cols := make([]arrow.Array, schema.NumFields())
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+	chunks := make([]arrow.Array, len(batches))
+	fields := schema.Fields()
+	for i, field := range fields {
+		if len(batches) == 0 {
+			cols[i] = array.MakeArrayOfNull(mem, field.Type, 0)
+			continue
+		}
+		for j, batch := range batches {
+			chunks[j] = batch.Column(i)
+		}
+		// Concatenating would overflow the 32-bit offsets, so switch the
+		// column to the large type first
+		widened := widen && largeType(field.Type) != nil && valueBytes(chunks) > maxSmallOffset
+		if widened {
+			fields[i].Type = largeType(field.Type)
+			for j, chunk := range chunks {
+				chunks[j] = widenArray(mem, chunk)
+			}
+		}
+		col, err := array.Concatenate(chunks, mem)
+		if widened {
+			for _, chunk := range chunks {
+				chunk.Release()
+			}
+		}
+		if err != nil {
+			return nil, adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to concatenate column %s: %v", field.Name, err),
+			}
+		}
+		cols[i] = col
+	}
+	md := schema.Metadata()
+	return array.NewRecordBatch(arrow.NewSchema(fields, &md), cols, numRows), nil
+}
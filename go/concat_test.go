@@ -0,0 +1,97 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var concatTestSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+func makeConcatTestBatch(t *testing.T, mem memory.Allocator, ids []int64, names []string) arrow.RecordBatch {
+	t.Helper()
+	bldr := array.NewRecordBuilder(mem, concatTestSchema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	bldr.Field(1).(*array.StringBuilder).AppendValues(names, nil)
+	return bldr.NewRecordBatch()
+}
+
+func makeConcatTestReader(t *testing.T, mem memory.Allocator, batches ...arrow.RecordBatch) array.RecordReader {
+	t.Helper()
+	rdr, err := array.NewRecordReader(concatTestSchema, batches)
+	require.NoError(t, err)
+	for _, batch := range batches {
+		batch.Release()
+	}
+	return rdr
+}
+
+func TestConcatRecordReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rdr := makeConcatTestReader(t, mem,
+		makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"}),
+		makeConcatTestBatch(t, mem, []int64{3}, []string{"c"}),
+	)
+
+	rec, err := concatRecordReader(mem, rdr, 0, false)
+	require.NoError(t, err)
+	defer rec.Release()
+
+	assert.True(t, concatTestSchema.Equal(rec.Schema()))
+	assert.Equal(t, int64(3), rec.NumRows())
+	assert.Equal(t, []int64{1, 2, 3}, rec.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, "c", rec.Column(1).(*array.String).Value(2))
+}
+
+func TestConcatRecordReaderEmpty(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rec, err := concatRecordReader(mem, makeConcatTestReader(t, mem), 0, false)
+	require.NoError(t, err)
+	defer rec.Release()
+
+	assert.True(t, concatTestSchema.Equal(rec.Schema()))
+	assert.Equal(t, int64(0), rec.NumRows())
+}
+
+func TestConcatRecordReaderMaxBytes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rdr := makeConcatTestReader(t, mem,
+		makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"}),
+		makeConcatTestBatch(t, mem, []int64{3}, []string{"c"}),
+	)
+
+	_, err := concatRecordReader(mem, rdr, 1, false)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, OptionFetchConcatResultMaxBytes)
+}
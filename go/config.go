@@ -0,0 +1,173 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// Config is a typed alternative to the string options, for Go programs
+// that embed the driver. Build one with NewConfig and open it with
+// NewDatabaseFromConfig. Zero fields are left at the driver's defaults.
+type Config struct {
+	ServerHostname string
+	HTTPPath       string
+	// Port defaults to DefaultPort.
+	Port int
+
+	// Either AccessToken, or OAuthClientID and OAuthClientSecret for OAuth
+	// machine-to-machine authentication.
+	AccessToken       string
+	OAuthClientID     string
+	OAuthClientSecret string
+
+	// Initial catalog and schema of each connection.
+	Catalog string
+	Schema  string
+
+	Timeouts Timeouts
+
+	// Clock replaces the system clock; see ClockSetter.
+	Clock Clock
+
+	// Options holds string options that have no field, e.g.
+	// OptionFetchMaxRowsPerRequest. They are applied after the fields.
+	Options map[string]string
+}
+
+// Timeouts bounds how long the driver waits on the server. Zero means no
+// limit.
+type Timeouts struct {
+	// Query is how long a query may run; see OptionQueryTimeout.
+	Query time.Duration
+}
+
+// ConfigOption sets a field of a Config.
+type ConfigOption func(*Config)
+
+// NewConfig returns a Config with the given options applied in order.
+func NewConfig(opts ...ConfigOption) Config {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithHostname sets the workspace hostname, e.g.
+// "dbc-a1b2c3d4-e5f6.cloud.databricks.com".
+func WithHostname(hostname string) ConfigOption {
+	return func(cfg *Config) { cfg.ServerHostname = hostname }
+}
+
+// WithHTTPPath sets the HTTP path of the SQL warehouse or cluster, e.g.
+// "/sql/1.0/warehouses/abc123".
+func WithHTTPPath(httpPath string) ConfigOption {
+	return func(cfg *Config) { cfg.HTTPPath = httpPath }
+}
+
+// WithPort sets the port, if not DefaultPort.
+func WithPort(port int) ConfigOption {
+	return func(cfg *Config) { cfg.Port = port }
+}
+
+// WithAccessToken authenticates with a personal access token.
+func WithAccessToken(token string) ConfigOption {
+	return func(cfg *Config) { cfg.AccessToken = token }
+}
+
+// WithOAuthM2M authenticates as a service principal with OAuth
+// machine-to-machine credentials.
+func WithOAuthM2M(clientID, clientSecret string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.OAuthClientID = clientID
+		cfg.OAuthClientSecret = clientSecret
+	}
+}
+
+// WithNamespace sets the initial catalog and schema of each connection.
+func WithNamespace(catalog, schema string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.Catalog = catalog
+		cfg.Schema = schema
+	}
+}
+
+// WithTimeouts sets the timeouts.
+func WithTimeouts(timeouts Timeouts) ConfigOption {
+	return func(cfg *Config) { cfg.Timeouts = timeouts }
+}
+
+// WithClock makes the database use clock instead of the system clock.
+func WithClock(clock Clock) ConfigOption {
+	return func(cfg *Config) { cfg.Clock = clock }
+}
+
+// WithOption sets a string option that has no field in Config.
+func WithOption(key, value string) ConfigOption {
+	return func(cfg *Config) {
+		if cfg.Options == nil {
+			cfg.Options = make(map[string]string)
+		}
+		cfg.Options[key] = value
+	}
+}
+
+// StringOptions converts the config to the equivalent string options.
+func (cfg Config) StringOptions() map[string]string {
+	opts := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			opts[key] = value
+		}
+	}
+	set(OptionServerHostname, cfg.ServerHostname)
+	set(OptionHTTPPath, cfg.HTTPPath)
+	if cfg.Port != 0 {
+		opts[OptionPort] = strconv.Itoa(cfg.Port)
+	}
+	set(OptionAccessToken, cfg.AccessToken)
+	set(OptionOAuthClientID, cfg.OAuthClientID)
+	set(OptionOAuthClientSecret, cfg.OAuthClientSecret)
+	set(OptionCatalog, cfg.Catalog)
+	set(OptionSchema, cfg.Schema)
+	if cfg.Timeouts.Query > 0 {
+		opts[OptionQueryTimeout] = cfg.Timeouts.Query.String()
+	}
+	maps.Copy(opts, cfg.Options)
+	return opts
+}
+
+// NewDatabaseFromConfig creates a database of drv, which must come from
+// NewDriver, configured by cfg.
+func NewDatabaseFromConfig(ctx context.Context, drv adbc.Driver, cfg Config) (db adbc.Database, err error) {
+	if ctxDrv, ok := drv.(adbc.DriverWithContext); ok {
+		db, err = ctxDrv.NewDatabaseWithContext(ctx, cfg.StringOptions())
+	} else {
+		db, err = drv.NewDatabase(cfg.StringOptions())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if setter, ok := db.(ClockSetter); ok && cfg.Clock != nil {
+		setter.SetClock(cfg.Clock)
+	}
+	return db, nil
+}
@@ -28,10 +28,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/adbc-drivers/driverbase-go/driverbase"
 	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
 	_ "github.com/databricks/databricks-sql-go"
 	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
 )
@@ -39,29 +45,288 @@ import (
 type connectionImpl struct {
 	driverbase.ConnectionImplBase
 
-	// Connection settings
+	// Connection settings. The current catalog and schema are those set
+	// through the connection or last looked up, and are cleared when a
+	// statement may have switched them; see namespace.go
 	catalog  string
 	dbSchema string
+	// Whether GetCurrentCatalog and GetCurrentDbSchema return the known
+	// catalog and schema rather than asking the server
+	namespaceCache bool
 
-	// Database connection
-	conn *sql.Conn
+	// Database connection. When the connection is opened lazily, conn
+	// stays nil until the first operation that needs a session.
+	db     *sql.DB
+	conn   *sql.Conn
+	connMu sync.Mutex
+
+	// Optional server features, probed when the session is opened if
+	// probeCapabilities is set. Guarded by connMu.
+	probeCapabilities bool
+	capabilities      capabilities
+	// How long opening the session waits for the cluster to start
+	clusterStartTimeout time.Duration
+	// The query tags set on the session by the last statement, in the form
+	// of OptionQueryTags. Guarded by connMu.
+	queryTags string
+	// The STATEMENT_TIMEOUT set on the session by the last statement, zero
+	// if none has set it. Guarded by connMu.
+	statementTimeout time.Duration
+	// Tags identifying the calling tool, added to every statement's own
+	lineageTags []queryTag
+	// Session configuration parameters SET on each session the connection
+	// opens, by name; see OptionSessionConfPrefix. Guarded by connMu.
+	sessionConfs map[string]string
+	// Run time after which queries are handed off to a job run, and the
+	// schema (catalog.schema) the run writes its result to
+	jobsHandoffAfter time.Duration
+	jobsOutputSchema string
+	// Closing of the session once it goes unused; see idle.go. Guarded by
+	// connMu.
+	idleTimeout time.Duration
+	idle        idleState
+
+	// Client for partitioned execution; nil unless the database points at
+	// a SQL warehouse by hostname and HTTP path
+	statementAPI *statementAPI
+	// Client for the workspace's other REST APIs (staged ingestion); nil
+	// unless the database points at the workspace by hostname
+	workspaceAPI *statementAPI
+	// Temporary files of the database, e.g. data staged for ingestion
+	tempStore *tempStore
+
+	// How date/time parameters are rendered
+	temporalBinding temporalBinding
+
+	// Table versions pinned for OptionReadSnapshot; nil when disabled
+	snapshot *snapshot
+	// Schema where OptionSelfTest probes write permission
+	selfTestSchema string
+
+	// Recent errors, reported through OptionErrorHistory
+	errorHistory *errorHistory
+	// Length beyond which error messages are truncated
+	errorMaxLength atomic.Int64
+
+	// Table schemas shared with the other connections of the database; nil
+	// when caching is disabled
+	schemaCache    *schemaCache
+	schemaCacheTTL atomic.Int64 // time.Duration
+	// Where column metadata is read from; see OptionInformationSchema
+	informationSchema string
+	// How empty catalog and schema filters are treated; see
+	// OptionMetadataFilters
+	metadataFilters string
+	// Whether GetObjects filters are LIKE patterns or names; see
+	// OptionMetadataFilterMatch
+	metadataFilterMatch string
+	// How often metadata queries failing with transient errors are retried
+	metadataRetries atomic.Int64
+	// Time source of retries and polling; see ClockSetter
+	clock driverClock
+	// Owner of the background goroutines of the connection's statements
+	// and readers; the database's
+	goroutines *goroutineGroup
+
+	// Listeners for the connection's lifecycle events, and the function
+	// that stops reporting OAuth token refreshes to them
+	events         *connectionEvents
+	stopAuthEvents func()
+	// Stops the database passing option changes on to the connection
+	stopLiveOptions func()
 }
 
 func (c *connectionImpl) Close() error {
-	if c.conn == nil {
+	// Listeners are called once the lock is released, so that they may
+	// use the connection
+	closed := false
+	defer func() {
+		if closed {
+			c.events.emit(ConnectionEventClosed)
+		}
+	}()
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.db == nil {
 		return adbc.Error{Code: adbc.StatusInvalidState}
 	}
+	c.db = nil
+	closed = true
+	c.idle.stop()
+	if c.stopAuthEvents != nil {
+		c.stopAuthEvents()
+	}
+	if c.stopLiveOptions != nil {
+		c.stopLiveOptions()
+	}
+	if c.conn == nil {
+		// The session was never established, or was closed while idle
+		return nil
+	}
 	defer func() {
 		c.conn = nil
 	}()
 	return c.conn.Close()
 }
 
+// sqlConn returns the session for this connection, establishing it first if
+// the connection was opened lazily.
+func (c *connectionImpl) sqlConn(ctx context.Context) (*sql.Conn, error) {
+	// Events are sent once the lock is released
+	var events []ConnectionEventKind
+	defer func() {
+		for _, kind := range events {
+			c.events.emit(kind)
+		}
+	}()
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.conn != nil {
+		c.touchLocked()
+		return c.conn, nil
+	}
+	if c.db == nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidState,
+			Msg:  "connection is closed",
+		}
+	}
+
+	var conn *sql.Conn
+	started, err := waitForCluster(ctx, c.clock, c.clusterStartTimeout, c.Logger, func(ctx context.Context) (err error) {
+		conn, err = c.db.Conn(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, adbc.Error{
+			Code:    adbc.StatusIO,
+			Msg:     fmt.Sprintf("failed to open session: %v", err),
+			Details: retryDetails(err),
+		}
+	}
+	if started {
+		events = append(events, ConnectionEventWarehouseStarted)
+	}
+	if err := applySessionConfs(ctx, conn, c.sessionConfs); err != nil {
+		discardSession(conn)
+		return nil, err
+	}
+	if c.idle.closed {
+		if err := c.restoreNamespace(ctx, conn); err != nil {
+			discardSession(conn)
+			return nil, err
+		}
+		c.idle.closed = false
+		events = append(events, ConnectionEventSessionRecovered)
+	}
+	c.conn = conn
+	c.touchLocked()
+	if c.probeCapabilities {
+		c.capabilities = probeCapabilities(ctx, conn, c.Logger)
+	}
+	return conn, nil
+}
+
+// sessionCapabilities returns the optional server features available to
+// the connection's session.
+func (c *connectionImpl) sessionCapabilities() capabilities {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.capabilities
+}
+
+// recordError classifies err, if any, truncates its message to
+// OptionErrorMaxMessageLength, adds it to the connection's error history
+// and returns it.
+func (c *connectionImpl) recordError(operation, queryID string, err error) error {
+	err = classifyError(err)
+	if c == nil || err == nil {
+		return err
+	}
+	err = truncateError(err, queryID, int(c.errorMaxLength.Load()))
+	c.errorHistory.add(newErrorRecord(operation, queryID, err))
+	return err
+}
+
+func (c *connectionImpl) GetOption(key string) (string, error) {
+	switch key {
+	case OptionErrorHistory:
+		return c.errorHistory.json()
+	case OptionCapabilities:
+		return c.sessionCapabilities().json()
+	case OptionErrorHistorySize:
+		if c.errorHistory == nil {
+			return "0", nil
+		}
+		return strconv.Itoa(c.errorHistory.size), nil
+	case OptionReadSnapshot:
+		return formatBoolOption(c.snapshot != nil), nil
+	case OptionSelfTest:
+		return c.selfTestJSON()
+	case OptionSelfTestSchema:
+		return c.selfTestSchema, nil
+	default:
+		if name, ok := strings.CutPrefix(key, OptionSessionConfPrefix); ok {
+			c.connMu.Lock()
+			defer c.connMu.Unlock()
+			return c.sessionConfs[name], nil
+		}
+		return c.ConnectionImplBase.GetOption(key)
+	}
+}
+
+func (c *connectionImpl) SetOption(key, value string) error {
+	switch key {
+	case OptionReadSnapshot:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		c.snapshot = nil
+		if enabled {
+			c.snapshot = newSnapshot()
+		}
+		return nil
+	case OptionSelfTestSchema:
+		if value != "" {
+			if parts, err := splitQualifiedName(value); err != nil || len(parts) != 2 {
+				return invalidOption(key, value, "a schema name of the form catalog.schema")
+			}
+		}
+		c.selfTestSchema = value
+		return nil
+	default:
+		name, ok, err := parseSessionConf(key, value)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return c.setSessionConf(name, value)
+		}
+		return c.ConnectionImplBase.SetOption(key, value)
+	}
+}
+
 func (c *connectionImpl) NewStatement() (adbc.Statement, error) {
 	return &statementImpl{
-		StatementImplBase: driverbase.NewStatementImplBase(&c.ConnectionImplBase, c.ErrorHelper),
-		conn:              c,
-		bulkIngestOptions: driverbase.NewBulkIngestOptions(),
+		StatementImplBase:    driverbase.NewStatementImplBase(&c.ConnectionImplBase, c.ErrorHelper),
+		conn:                 c,
+		bulkIngestOptions:    driverbase.NewBulkIngestOptions(),
+		concatMaxBytes:       DefaultConcatResultMaxBytes,
+		largeTypes:           DefaultLargeTypes,
+		decimals:             DefaultDecimals,
+		decimalMaxPrecision:  DefaultDecimalMaxPrecision,
+		decimalOverflow:      DefaultDecimalOverflow,
+		resultMode:           DefaultResultMode,
+		fetchLane:            DefaultFetchLane,
+		schemaDrift:          DefaultSchemaDrift,
+		protocols:            strings.Split(DefaultFetchProtocols, ","),
+		ingestStreamMaxRows:  DefaultIngestStreamMaxRows,
+		ingestStreamMaxBytes: DefaultIngestStreamMaxBytes,
+		ingestStreamInterval: DefaultIngestStreamInterval,
+		ingestFileSize:       DefaultIngestUploadFileSize,
+		ingestUploadParallel: DefaultIngestUploadMaxParallel,
+		alloc:                newTrackingAllocator(c.Alloc),
 	}, nil
 }
 
@@ -69,29 +334,24 @@ func (c *connectionImpl) SetAutocommit(autocommit bool) error {
 	// Databricks SQL doesn't support explicit transaction control in the same way
 	// as traditional databases. Most operations are implicitly committed.
 	if !autocommit {
-		return adbc.Error{
-			Code: adbc.StatusNotImplemented,
-			Msg:  "disabling autocommit is not supported",
-		}
+		return c.unsupported(FeatureTransactions)
 	}
 	return nil
 }
 
 // CurrentNamespacer interface implementation
-func (c *connectionImpl) GetCurrentCatalog() (string, error) {
-	if c.catalog != "" {
+func (c *connectionImpl) GetCurrentCatalog() (catalog string, err error) {
+	if c.namespaceCache && c.catalog != "" {
 		return c.catalog, nil
 	}
+	defer func() { err = c.recordError("GetCurrentCatalog", "", err) }()
 
-	if c.conn == nil {
-		return "", adbc.Error{
-			Code: adbc.StatusInvalidState,
-			Msg:  "connection is nil",
-		}
+	conn, err := c.sqlConn(context.Background())
+	if err != nil {
+		return "", err
 	}
 
-	var catalog string
-	err := c.conn.QueryRowContext(context.Background(), "SELECT current_catalog()").Scan(&catalog)
+	err = conn.QueryRowContext(context.Background(), "SELECT current_catalog()").Scan(&catalog)
 	if err != nil {
 		return "", adbc.Error{
 			Code: adbc.StatusInternal,
@@ -99,23 +359,22 @@ func (c *connectionImpl) GetCurrentCatalog() (string, error) {
 		}
 	}
 
+	c.catalog = catalog
 	return catalog, nil
 }
 
-func (c *connectionImpl) GetCurrentDbSchema() (string, error) {
-	if c.dbSchema != "" {
+func (c *connectionImpl) GetCurrentDbSchema() (schema string, err error) {
+	if c.namespaceCache && c.dbSchema != "" {
 		return c.dbSchema, nil
 	}
+	defer func() { err = c.recordError("GetCurrentDbSchema", "", err) }()
 
-	if c.conn == nil {
-		return "", adbc.Error{
-			Code: adbc.StatusInvalidState,
-			Msg:  "connection is nil",
-		}
+	conn, err := c.sqlConn(context.Background())
+	if err != nil {
+		return "", err
 	}
 
-	var schema string
-	err := c.conn.QueryRowContext(context.Background(), "SELECT current_schema()").Scan(&schema)
+	err = conn.QueryRowContext(context.Background(), "SELECT current_schema()").Scan(&schema)
 	if err != nil {
 		return "", adbc.Error{
 			Code: adbc.StatusInternal,
@@ -123,55 +382,82 @@ func (c *connectionImpl) GetCurrentDbSchema() (string, error) {
 		}
 	}
 
+	c.dbSchema = schema
 	return schema, nil
 }
 
-func (c *connectionImpl) SetCurrentCatalog(catalog string) error {
+func (c *connectionImpl) SetCurrentCatalog(catalog string) (err error) {
+	defer func() { err = c.recordError("SetCurrentCatalog", "", err) }()
+
 	if catalog == "" {
 		return adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "catalog cannot be empty",
 		}
 	}
-	if c.conn == nil {
-		return adbc.Error{
-			Code: adbc.StatusInvalidState,
-			Msg:  "failed to set catalog: connection is nil",
-		}
+	conn, err := c.sqlConn(context.Background())
+	if err != nil {
+		return err
 	}
-	escapedCatalog := strings.ReplaceAll(catalog, "`", "``")
-	_, err := c.conn.ExecContext(context.Background(), fmt.Sprintf("USE CATALOG `%s`", escapedCatalog))
+	_, err = conn.ExecContext(context.Background(), useCatalogQuery(catalog))
 	if err != nil {
 		return adbc.Error{
 			Code: adbc.StatusInternal,
 			Msg:  fmt.Sprintf("failed to set catalog: %v", err),
 		}
 	}
-	c.catalog = catalog
+	// USE CATALOG also switches to the catalog's default schema
+	c.catalog, c.dbSchema = catalog, ""
 	return nil
 }
 
-func (c *connectionImpl) SetCurrentDbSchema(schema string) error {
+// useCatalogQuery returns the statement making catalog current.
+func useCatalogQuery(catalog string) string {
+	return "USE CATALOG " + quoteIdentifier(catalog)
+}
+
+// useSchemaQuery returns the statement making schema current, and catalog
+// too unless it is empty.
+func useSchemaQuery(catalog, schema string) string {
+	if catalog == "" {
+		return "USE SCHEMA " + quoteIdentifier(schema)
+	}
+	// USE SCHEMA also switches the catalog when the name is qualified
+	return "USE SCHEMA " + quoteIdentifier(catalog) + "." + quoteIdentifier(schema)
+}
+
+// SetCurrentDbSchema switches the current schema within the current
+// catalog. The name is taken as is, dots included; use
+// SetCurrentCatalogAndSchema to switch both.
+func (c *connectionImpl) SetCurrentDbSchema(schema string) (err error) {
+	defer func() { err = c.recordError("SetCurrentDbSchema", "", err) }()
+
 	if schema == "" {
 		return adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "schema cannot be empty",
 		}
 	}
-	if c.conn == nil {
-		return adbc.Error{
-			Code: adbc.StatusInvalidState,
-			Msg:  "failed to set db schema: connection is nil",
-		}
+	return c.useSchema("", schema)
+}
+
+// useSchema runs USE SCHEMA and records the new namespace. catalog is
+// switched too unless it is empty.
+func (c *connectionImpl) useSchema(catalog, schema string) error {
+	conn, err := c.sqlConn(context.Background())
+	if err != nil {
+		return err
 	}
-	escapedSchema := strings.ReplaceAll(schema, "`", "``")
-	_, err := c.conn.ExecContext(context.Background(), fmt.Sprintf("USE SCHEMA `%s`", escapedSchema))
+	_, err = conn.ExecContext(context.Background(), useSchemaQuery(catalog, schema))
 	if err != nil {
 		return adbc.Error{
 			Code: adbc.StatusInternal,
 			Msg:  fmt.Sprintf("failed to set schema: %v", err),
 		}
 	}
+	if catalog != "" {
+		c.catalog = catalog
+	}
 	c.dbSchema = schema
 	return nil
 }
@@ -185,36 +471,45 @@ func (c *connectionImpl) ListTableTypes(ctx context.Context) ([]string, error) {
 // Transaction methods (Databricks has limited transaction support)
 func (c *connectionImpl) Commit(ctx context.Context) error {
 	// Most operations are auto-committed.
-	return adbc.Error{
-		Code: adbc.StatusNotImplemented,
-		Msg:  "Commit is not supported",
-	}
+	return c.unsupported(FeatureTransactions)
 }
 
 func (c *connectionImpl) Rollback(ctx context.Context) error {
 	// Databricks SQL doesn't support explicit transactions in the traditional sense.
 	// Most operations are auto-committed.
-	return adbc.Error{
-		Code: adbc.StatusNotImplemented,
-		Msg:  "rollback is not supported",
-	}
+	return c.unsupported(FeatureTransactions)
 }
 
 // DbObjectsEnumerator interface implementation
+// objectFilter applies OptionMetadataFilters to a catalog or schema
+// filter, returning the filter to send to the server, or true if nothing
+// can match it.
+func (c *connectionImpl) objectFilter(filter *string) (*string, bool) {
+	if filter == nil || *filter != "" {
+		return filter, false
+	}
+	if c.metadataFilters == OptionValueMetadataFiltersJDBC {
+		return nil, false
+	}
+	// Every Databricks object is in a catalog and a schema
+	return nil, true
+}
+
 func (c *connectionImpl) GetCatalogs(ctx context.Context, catalogFilter *string) (catalogs []string, err error) {
+	defer func() { err = c.recordError("GetCatalogs", "", err) }()
+
 	catalogs = []string{}
-	query := "SHOW CATALOGS"
-	if catalogFilter != nil {
-		escapedFilter := strings.ReplaceAll(*catalogFilter, "'", "''")
-		query += fmt.Sprintf(" LIKE '%s'", escapedFilter)
+	catalogFilter, none := c.objectFilter(catalogFilter)
+	if none {
+		return catalogs, nil
 	}
-	var rows *sql.Rows
-	rows, err = c.conn.QueryContext(ctx, query)
+	// SHOW CATALOGS LIKE takes its own pattern syntax, so the filter is
+	// applied here
+	query := "SHOW CATALOGS"
+	match := c.nameMatcher(catalogFilter)
+	rows, err := c.queryMetadata(ctx, "catalogs", query)
 	if err != nil {
-		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to query catalogs: %v", err),
-		}
+		return nil, err
 	}
 	defer func() {
 		err = errors.Join(err, rows.Close())
@@ -228,6 +523,9 @@ func (c *connectionImpl) GetCatalogs(ctx context.Context, catalogFilter *string)
 				Msg:  fmt.Sprintf("failed to scan catalog: %v", err),
 			}
 		}
+		if match != nil && !match(catalog) {
+			continue
+		}
 		catalogs = append(catalogs, catalog)
 	}
 
@@ -235,21 +533,18 @@ func (c *connectionImpl) GetCatalogs(ctx context.Context, catalogFilter *string)
 }
 
 func (c *connectionImpl) GetDBSchemasForCatalog(ctx context.Context, catalog string, schemaFilter *string) (schemas []string, err error) {
+	defer func() { err = c.recordError("GetDBSchemas", "", err) }()
+
 	schemas = []string{}
-	escapedCatalog := strings.ReplaceAll(catalog, "`", "``")
-	query := fmt.Sprintf("SHOW SCHEMAS IN `%s`", escapedCatalog)
-	if schemaFilter != nil {
-		escapedFilter := strings.ReplaceAll(*schemaFilter, "'", "''")
-		query += fmt.Sprintf(" LIKE '%s'", escapedFilter)
+	schemaFilter, none := c.objectFilter(schemaFilter)
+	if none {
+		return schemas, nil
 	}
+	match := c.nameMatcher(schemaFilter)
 
-	var rows *sql.Rows
-	rows, err = c.conn.QueryContext(ctx, query)
+	rows, err := c.queryMetadata(ctx, "schemas", "SHOW SCHEMAS IN "+quoteIdentifier(catalog))
 	if err != nil {
-		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to query schemas: %v", err),
-		}
+		return nil, err
 	}
 	defer func() {
 		err = errors.Join(err, rows.Close())
@@ -262,6 +557,9 @@ func (c *connectionImpl) GetDBSchemasForCatalog(ctx context.Context, catalog str
 				Msg:  fmt.Sprintf("failed to scan schema: %v", err),
 			}
 		}
+		if match != nil && !match(schema) {
+			continue
+		}
 		schemas = append(schemas, schema)
 	}
 
@@ -270,26 +568,18 @@ func (c *connectionImpl) GetDBSchemasForCatalog(ctx context.Context, catalog str
 }
 
 func (c *connectionImpl) GetTablesForDBSchema(ctx context.Context, catalog string, schema string, tableFilter *string, columnFilter *string, includeColumns bool) (tables []driverbase.TableInfo, err error) {
+	defer func() { err = c.recordError("GetTables", "", err) }()
+
 	if includeColumns {
 		return c.getTablesWithColumns(ctx, catalog, schema, tableFilter, columnFilter)
 	}
 
 	tables = []driverbase.TableInfo{}
-	escapedCatalog := strings.ReplaceAll(catalog, "`", "``")
-	escapedSchema := strings.ReplaceAll(schema, "`", "``")
-	query := fmt.Sprintf("SHOW TABLES IN `%s`.`%s`", escapedCatalog, escapedSchema)
-	if tableFilter != nil {
-		escapedFilter := strings.ReplaceAll(*tableFilter, "'", "''")
-		query += fmt.Sprintf(" LIKE '%s'", escapedFilter)
-	}
+	match := c.nameMatcher(tableFilter)
 
-	var rows *sql.Rows
-	rows, err = c.conn.QueryContext(ctx, query)
+	rows, err := c.queryMetadata(ctx, "tables", "SHOW TABLES IN "+quoteIdentifier(catalog)+"."+quoteIdentifier(schema))
 	if err != nil {
-		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to query tables: %v", err),
-		}
+		return nil, err
 	}
 	defer func() {
 		err = errors.Join(err, rows.Close())
@@ -303,6 +593,9 @@ func (c *connectionImpl) GetTablesForDBSchema(ctx context.Context, catalog strin
 			}
 		}
 
+		if match != nil && !match(tableName) {
+			continue
+		}
 		tableInfo := driverbase.TableInfo{
 			TableName:        tableName,
 			TableType:        "TABLE", // Default to TABLE, could be improved with more detailed queries
@@ -316,45 +609,75 @@ func (c *connectionImpl) GetTablesForDBSchema(ctx context.Context, catalog strin
 	return tables, errors.Join(err, rows.Err())
 }
 
-// getTablesWithColumns retrieves complete table and column information using INFORMATION_SCHEMA
-func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog string, schema string, tableFilter *string, columnFilter *string) (tables []driverbase.TableInfo, err error) {
-	tables = []driverbase.TableInfo{}
-
-	lowerCatalog := strings.ToLower(catalog)
-
-	// Skip internal catalogs that do not support metadata queries
-	if lowerCatalog == "__databricks_internal" {
-		return tables, nil
+// useSystemInformationSchema reports whether catalog's metadata is read
+// from system.information_schema rather than its own information_schema.
+func (c *connectionImpl) useSystemInformationSchema(catalog string) bool {
+	switch strings.ToLower(catalog) {
+	case "hive_metastore", "system":
+		// Their metadata is only available via the system-level
+		// information_schema
+		return true
 	}
+	return c.informationSchema == OptionValueInformationSchemaSystem
+}
 
+// tablesWithColumnsQuery returns the INFORMATION_SCHEMA query listing the
+// columns of schema's tables that match the filters.
+func (c *connectionImpl) tablesWithColumnsQuery(catalog, schema string, tableFilter, columnFilter *string) string {
 	var queryBuilder strings.Builder
-	queryBuilder.WriteString("SELECT DISTINCT c.TABLE_NAME, c.ordinal_position, c.COLUMN_NAME, c.DATA_TYPE, c.IS_NULLABLE FROM ")
-	if lowerCatalog == "hive_metastore" || lowerCatalog == "system" {
-		// Hive Metastore and system catalog metadata are only available via the system-level information_schema
+	queryBuilder.WriteString("SELECT DISTINCT c.TABLE_NAME, c.ordinal_position, c.COLUMN_NAME, c.DATA_TYPE, c.IS_NULLABLE, c.COMMENT FROM ")
+	if c.useSystemInformationSchema(catalog) {
 		queryBuilder.WriteString("system.information_schema.COLUMNS c ")
 		queryBuilder.WriteString("WHERE c.table_catalog = ")
-		queryBuilder.WriteString(quoteString(catalog))
+		queryBuilder.WriteString(stringLiteral(catalog))
 		queryBuilder.WriteString(" AND c.TABLE_SCHEMA = ")
-		queryBuilder.WriteString(quoteString(schema))
+		queryBuilder.WriteString(stringLiteral(schema))
 	} else {
 		// Unity Catalog catalogs have their own information_schema
 		queryBuilder.WriteString(quoteIdentifier(catalog))
 		queryBuilder.WriteString(".information_schema.COLUMNS c WHERE c.TABLE_SCHEMA = ")
-		queryBuilder.WriteString(quoteString(schema))
+		queryBuilder.WriteString(stringLiteral(schema))
 	}
 
 	if tableFilter != nil {
-		queryBuilder.WriteString(" AND c.TABLE_NAME LIKE ")
-		queryBuilder.WriteString(quoteString(*tableFilter))
+		queryBuilder.WriteString(" AND ")
+		queryBuilder.WriteString(c.likeCondition("c.TABLE_NAME", *tableFilter))
 	}
 	if columnFilter != nil {
-		queryBuilder.WriteString(" AND c.COLUMN_NAME LIKE ")
-		queryBuilder.WriteString(quoteString(*columnFilter))
+		queryBuilder.WriteString(" AND ")
+		queryBuilder.WriteString(c.likeCondition("c.COLUMN_NAME", *columnFilter))
 	}
 
 	queryBuilder.WriteString(" ORDER BY c.TABLE_NAME, c.ordinal_position")
+	return queryBuilder.String()
+}
+
+// getTablesWithColumns retrieves complete table and column information using INFORMATION_SCHEMA
+func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog string, schema string, tableFilter *string, columnFilter *string) (tables []driverbase.TableInfo, err error) {
+	tables = []driverbase.TableInfo{}
 
-	rows, err := c.conn.QueryContext(ctx, queryBuilder.String())
+	lowerCatalog := strings.ToLower(catalog)
+
+	// Skip internal catalogs that do not support metadata queries
+	if lowerCatalog == "__databricks_internal" {
+		return tables, nil
+	}
+
+	query := c.tablesWithColumnsQuery(catalog, schema, tableFilter, columnFilter)
+	var rows *sql.Rows
+	var connErr error
+	err = c.retryMetadata(ctx, "query tables with columns", func() error {
+		conn, err := c.sqlConn(ctx)
+		if err != nil {
+			connErr = err
+			return err
+		}
+		rows, err = conn.QueryContext(ctx, query)
+		return err
+	})
+	if connErr != nil {
+		return nil, connErr
+	}
 	if err != nil {
 		// If we don't have permissions on the catalog, this will
 		// error. Catch that and simply return no tables instead of
@@ -364,8 +687,9 @@ func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog strin
 			return tables, nil
 		}
 		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to query tables with columns: %v", err),
+			Code:    adbc.StatusInternal,
+			Msg:     fmt.Sprintf("failed to query tables with columns: %v", err),
+			Details: retryDetails(err),
 		}
 	}
 	defer func() {
@@ -377,11 +701,12 @@ func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog strin
 	for rows.Next() {
 		var tableName, columnName, dataType, isNullable string
 		var ordinalPosition sql.NullInt32
+		var comment sql.NullString
 
 		if err := rows.Scan(
 			&tableName,
 			&ordinalPosition, &columnName,
-			&dataType, &isNullable,
+			&dataType, &isNullable, &comment,
 		); err != nil {
 			return nil, adbc.Error{
 				Code: adbc.StatusInternal,
@@ -419,6 +744,9 @@ func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog strin
 			XdbcNullable:   nullable,
 			XdbcIsNullable: isNullablePtr,
 		}
+		if comment.Valid && comment.String != "" {
+			columnInfo.Remarks = &comment.String
+		}
 
 		if ordinalPosition.Valid {
 			// Databricks uses 0-based indexing
@@ -432,15 +760,323 @@ func (c *connectionImpl) getTablesWithColumns(ctx context.Context, catalog strin
 	return tables, errors.Join(err, rows.Err())
 }
 
-// PrepareDriverInfo implements driverbase.DriverInfoPreparer.
-func (c *connectionImpl) PrepareDriverInfo(ctx context.Context, infoCodes []adbc.InfoCode) error {
-	var versionJSON string
-	err := c.conn.QueryRowContext(ctx, "SELECT current_version()").Scan(&versionJSON)
+// GetTableSchema returns the schema of a table as reported by DESCRIBE TABLE.
+// DESCRIBE does not report nullability, so every field is nullable.
+//
+// Schemas of Delta tables are cached, keyed by the table's full name and
+// Delta version. A cached schema is reused without a DESCRIBE for as long as
+// the table's version does not change, and without any round trip at all
+// within OptionSchemaCacheTTL of the last check. Tables found to have no
+// Delta history (e.g. views) are described every time, and only checked
+// again once OptionSchemaCacheTTL has passed.
+func (c *connectionImpl) GetTableSchema(ctx context.Context, catalog *string, dbSchema *string, tableName string) (schema *arrow.Schema, err error) {
+	defer func() { err = c.recordError("GetTableSchema", "", err) }()
+
+	// Always qualify the name fully, so that the cache key does not depend
+	// on the current namespace
+	name, err := c.qualifyTableName(catalog, dbSchema, tableName)
 	if err != nil {
-		return adbc.Error{
+		return nil, err
+	}
+	if c.schemaCache == nil {
+		return c.describeTable(ctx, name)
+	}
+
+	entry, cached := c.schemaCache.get(name)
+	if cached && c.clock.Since(entry.verified) < time.Duration(c.schemaCacheTTL.Load()) {
+		if entry.unversioned {
+			return c.describeTable(ctx, name)
+		}
+		return entry.schema, nil
+	}
+
+	// Read the version before describing the table. If the table changes
+	// in between, the new schema is stored under the old version and is
+	// simply described again next time.
+	version, err := c.tableVersion(ctx, name)
+	if errors.Is(err, errNoTableHistory) {
+		// Not a Delta table (e.g. a view), so changes can't be detected
+		c.schemaCache.put(name, schemaCacheEntry{unversioned: true, verified: c.clock.Now()})
+		return c.describeTable(ctx, name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the version of %s: %w", name, err)
+	}
+	if cached && !entry.unversioned && entry.version == version {
+		entry.verified = c.clock.Now()
+		c.schemaCache.put(name, entry)
+		return entry.schema, nil
+	}
+
+	schema, err = c.describeTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	c.schemaCache.put(name, schemaCacheEntry{
+		schema:   schema,
+		version:  version,
+		verified: c.clock.Now(),
+	})
+	return schema, nil
+}
+
+// describeTable reads the columns of a table with DESCRIBE TABLE EXTENDED.
+// Each field carries the Databricks type name (DATA_TYPE), whether the
+// column is part of the primary key (PRIMARY_KEY, "Y" or "N") and its
+// comment, if any (COMMENT), as metadata. The table's comment, if any, is
+// the schema's COMMENT metadata.
+func (c *connectionImpl) describeTable(ctx context.Context, name string) (schema *arrow.Schema, err error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, "DESCRIBE TABLE EXTENDED "+name)
+	if err != nil {
+		var dbExecutionErr dbsqlerr.DBExecutionError
+		if errors.As(err, &dbExecutionErr) && dbExecutionErr.SqlState() == "42P01" {
+			return nil, adbc.Error{
+				Code: adbc.StatusNotFound,
+				Msg:  fmt.Sprintf("table not found: %s", name),
+			}
+		}
+		return nil, adbc.Error{
 			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to get vendor version: %v", err),
+			Msg:  fmt.Sprintf("failed to describe table %s: %v", name, err),
+		}
+	}
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	fields := []arrow.Field{}
+	typeNames := []string{}
+	comments := []string{}
+	primaryKey := map[string]bool{}
+	var tableComment string
+	// The columns come first, followed by a blank row and sections such as
+	// "# Partition Information" and "# Constraints"
+	section := "columns"
+	for rows.Next() {
+		var colName string
+		var dataType, comment sql.NullString
+		if err := rows.Scan(&colName, &dataType, &comment); err != nil {
+			return nil, adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to scan column of %s: %v", name, err),
+			}
+		}
+
+		switch {
+		case colName == "":
+			section = ""
+		case strings.HasPrefix(colName, "#"):
+			section = strings.TrimSpace(colName)
+		case section == "columns":
+			dt, err := parseDatabricksType(dataType.String)
+			if err != nil {
+				return nil, adbc.Error{
+					Code: adbc.StatusInternal,
+					Msg:  fmt.Sprintf("failed to convert type of column %s: %v", colName, err),
+				}
+			}
+			fields = append(fields, arrow.Field{Name: colName, Type: dt, Nullable: true})
+			typeNames = append(typeNames, dataType.String)
+			comments = append(comments, comment.String)
+		case section == "# Detailed Table Information" && colName == "Comment":
+			tableComment = dataType.String
+		case section == "# Constraints":
+			for _, col := range primaryKeyColumns(dataType.String) {
+				primaryKey[col] = true
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to describe table %s: %v", name, err),
+		}
+	}
+
+	for i := range fields {
+		isKey := "N"
+		if primaryKey[fields[i].Name] {
+			isKey = "Y"
+		}
+		md := map[string]string{
+			"DATA_TYPE":   displayTypeName(typeNames[i]),
+			"PRIMARY_KEY": isKey,
+		}
+		if comments[i] != "" {
+			md["COMMENT"] = comments[i]
+		}
+		fields[i].Metadata = arrow.MetadataFrom(md)
+	}
+	if tableComment == "" {
+		return arrow.NewSchema(fields, nil), nil
+	}
+	md := arrow.MetadataFrom(map[string]string{"COMMENT": tableComment})
+	return arrow.NewSchema(fields, &md), nil
+}
+
+// displayTypeName upper-cases a type name as printed by DESCRIBE, except
+// for nested types whose field names must keep their case.
+func displayTypeName(typeName string) string {
+	if strings.ContainsRune(typeName, '<') {
+		return typeName
+	}
+	return strings.ToUpper(typeName)
+}
+
+// primaryKeyColumns extracts the column names from a constraint definition
+// such as "PRIMARY KEY (`id`, `region`)". It returns nil for other kinds of
+// constraint.
+func primaryKeyColumns(definition string) []string {
+	definition = strings.TrimSpace(definition)
+	if !strings.HasPrefix(strings.ToUpper(definition), "PRIMARY KEY") {
+		return nil
+	}
+	start, end := strings.Index(definition, "("), strings.LastIndex(definition, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	var columns []string
+	for _, col := range strings.Split(definition[start+1:end], ",") {
+		col = strings.TrimSpace(col)
+		if len(col) >= 2 && col[0] == '`' && col[len(col)-1] == '`' {
+			col = strings.ReplaceAll(col[1:len(col)-1], "``", "`")
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
+
+// errNoTableHistory is returned by tableVersion for relations that have no
+// Delta history, such as views and tables in other formats.
+var errNoTableHistory = errors.New("the table has no Delta history")
+
+// noHistoryErrorClasses are the error classes with which DESCRIBE HISTORY
+// fails for relations that have no Delta history.
+var noHistoryErrorClasses = []string{
+	"[EXPECT_TABLE_NOT_VIEW",
+	"[DELTA_TABLE_ONLY_OPERATION]",
+	"[DELTA_MISSING_DELTA_TABLE]",
+}
+
+// tableVersion returns the current Delta version of a table. The error
+// matches errNoTableHistory if name has no Delta history, e.g. because it
+// is a view; any other error says nothing about the table.
+func (c *connectionImpl) tableVersion(ctx context.Context, name string) (version int64, err error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := conn.QueryContext(ctx, "DESCRIBE HISTORY "+name+" LIMIT 1")
+	if err != nil {
+		for _, class := range noHistoryErrorClasses {
+			if strings.Contains(err.Error(), class) {
+				return 0, fmt.Errorf("%w: %w", errNoTableHistory, err)
+			}
+		}
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) == 0 || columns[0] != "version" {
+		return 0, errNoTableHistory
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errNoTableHistory
+	}
+	dest := make([]any, len(columns))
+	dest[0] = &version
+	for i := 1; i < len(dest); i++ {
+		dest[i] = new(any)
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// qualifyTableName quotes and fully qualifies a table name, taking a
+// missing catalog or schema from the current namespace.
+func (c *connectionImpl) qualifyTableName(catalog, dbSchema *string, tableName string) (string, error) {
+	if catalog == nil {
+		current, err := c.GetCurrentCatalog()
+		if err != nil {
+			return "", err
+		}
+		catalog = &current
+	}
+	if dbSchema == nil {
+		current, err := c.GetCurrentDbSchema()
+		if err != nil {
+			return "", err
 		}
+		dbSchema = &current
+	}
+	return qualifiedTableName(*catalog, *dbSchema, tableName), nil
+}
+
+// qualifiedTableName quotes and joins the parts of a table name.
+func qualifiedTableName(catalog, dbSchema, tableName string) string {
+	return quoteIdentifier(catalog) + "." + quoteIdentifier(dbSchema) + "." + quoteIdentifier(tableName)
+}
+
+// PrepareDriverInfo implements driverbase.DriverInfoPreparer.
+func (c *connectionImpl) PrepareDriverInfo(ctx context.Context, infoCodes []adbc.InfoCode) (err error) {
+	defer func() { err = c.recordError("GetInfo", "", err) }()
+
+	// Only the vendor version and the session's settings need a session;
+	// the identifier quoting codes are often requested before any query is
+	// run
+	requested := func(codes ...adbc.InfoCode) bool {
+		return len(infoCodes) == 0 || slices.ContainsFunc(codes, func(code adbc.InfoCode) bool {
+			return slices.Contains(infoCodes, code)
+		})
+	}
+	if requested(InfoDriverFeatures) {
+		features, err := c.featuresJSON()
+		if err != nil {
+			return err
+		}
+		if err := c.DriverInfo.RegisterInfoCode(InfoDriverFeatures, features); err != nil {
+			return err
+		}
+	}
+	if requested(InfoSessionCollation, InfoSessionTimezone) {
+		if err := c.prepareSessionInfo(ctx); err != nil {
+			return err
+		}
+	}
+	if !requested(adbc.InfoVendorVersion) {
+		return nil
+	}
+
+	var versionJSON string
+	err = c.retryMetadata(ctx, "get vendor version", func() error {
+		conn, err := c.sqlConn(ctx)
+		if err != nil {
+			return err
+		}
+		if err := conn.QueryRowContext(ctx, "SELECT current_version()").Scan(&versionJSON); err != nil {
+			return adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to get vendor version: %v", err),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	var versionData map[string]any
@@ -458,7 +1094,42 @@ func (c *connectionImpl) PrepareDriverInfo(ctx context.Context, infoCodes []adbc
 	return c.DriverInfo.RegisterInfoCode(adbc.InfoVendorVersion, version)
 }
 
-// quoteString escapes string literals using single quotes
-func quoteString(value string) string {
-	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+// splitQualifiedName splits a dotted name such as "main.`my.schema`" into
+// its parts. Parts quoted with backquotes may contain dots.
+func splitQualifiedName(name string) ([]string, error) {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		switch {
+		case ch == '`' && quoted && i+1 < len(name) && name[i+1] == '`':
+			part.WriteByte('`')
+			i++
+		case ch == '`':
+			quoted = !quoted
+		case ch == '.' && !quoted:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(ch)
+		}
+	}
+	parts = append(parts, part.String())
+
+	if quoted {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("invalid name '%s': unterminated backquote", name),
+		}
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidArgument,
+				Msg:  fmt.Sprintf("invalid name '%s': empty part", name),
+			}
+		}
+	}
+	return parts, nil
 }
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitQualifiedName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"default", []string{"default"}},
+		{"main.default", []string{"main", "default"}},
+		{"`my catalog`.`my.schema`", []string{"my catalog", "my.schema"}},
+		{"`odd``name`", []string{"odd`name"}},
+		{"a.b.c", []string{"a", "b", "c"}},
+	}
+	for _, tc := range tests {
+		parts, err := splitQualifiedName(tc.name)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, parts, tc.name)
+	}
+
+	for _, name := range []string{"main.", ".default", "`unterminated"} {
+		_, err := splitQualifiedName(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPrimaryKeyColumns(t *testing.T) {
+	assert.Equal(t, []string{"id"}, primaryKeyColumns("PRIMARY KEY (`id`)"))
+	assert.Equal(t, []string{"id", "odd`name"}, primaryKeyColumns("primary key (`id`, `odd``name`)"))
+	assert.Nil(t, primaryKeyColumns("FOREIGN KEY (`id`) REFERENCES `main`.`s`.`t` (`id`)"))
+}
+
+func TestDisplayTypeName(t *testing.T) {
+	assert.Equal(t, "DECIMAL(10,2)", displayTypeName("decimal(10,2)"))
+	assert.Equal(t, "struct<Name:string>", displayTypeName("struct<Name:string>"))
+}
+
+func TestUseSystemInformationSchema(t *testing.T) {
+	c := &connectionImpl{informationSchema: OptionValueInformationSchemaCatalog}
+	assert.False(t, c.useSystemInformationSchema("main"))
+	assert.True(t, c.useSystemInformationSchema("hive_metastore"))
+	assert.True(t, c.useSystemInformationSchema("SYSTEM"))
+
+	c.informationSchema = OptionValueInformationSchemaSystem
+	assert.True(t, c.useSystemInformationSchema("main"))
+}
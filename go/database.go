@@ -27,6 +27,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"math"
 	"net"
 	"strconv"
 	"strings"
@@ -35,11 +38,15 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/adbc-drivers/driverbase-go/driverbase"
 	"github.com/apache/arrow-adbc/go/adbc"
 	dbsql "github.com/databricks/databricks-sql-go"
+	"github.com/databricks/databricks-sql-go/auth"
+	"github.com/databricks/databricks-sql-go/auth/oauth/m2m"
+	"github.com/databricks/databricks-sql-go/auth/pat"
 )
 
 const (
@@ -51,8 +58,8 @@ const (
 type databaseImpl struct {
 	driverbase.DatabaseImplBase
 
-	// Connection Pool
-	db           *sql.DB
+	// Connection pools, by workspace name ("" for the default workspace)
+	pools        map[string]*sql.DB
 	needsRefresh bool // Whether we need to re-initialize
 
 	// Connection parameters
@@ -63,34 +70,131 @@ type databaseImpl struct {
 	port           int
 	catalog        string
 	schema         string
+	// Other workspaces connections may be routed to, by name, and the one
+	// they are routed to unless Open's context says otherwise
+	workspaces map[string]workspaceEndpoint
+	workspace  string
+
+	// The OptionProfile in effect, and the options set explicitly, which
+	// it doesn't override
+	profile         string
+	explicitOptions map[string]struct{}
 
 	// Query options
-	queryTimeout        time.Duration
-	maxRows             int
-	queryRetryCount     int
+	queryTimeout    time.Duration
+	maxRows         int // rows per FetchResults request
+	queryRetryCount int
+	// CloudFetch downloads an Arrow result runs at once, or zero for
+	// databricks-sql-go's default
 	downloadThreadCount int
 
+	// Session options
+	sessionTimezone string
+	sessionLocation *time.Location
+	// Collation of string literals in the session; empty for the server's
+	sessionCollation string
+	// Session configuration parameters SET on each session, by name
+	sessionConfs map[string]string
+
+	// Parameter binding options
+	timestampBindMode string
+
+	// Connection establishment options
+	connectLazy       bool
+	connectValidate   bool
+	capabilitiesProbe bool
+	// How long to wait for an all-purpose cluster to start
+	clusterStartTimeout time.Duration
+	// How long a connection's session may go unused before it is closed
+	sessionIdleTimeout time.Duration
+	// Query tags identifying the calling tool and its run
+	lineageTool  string
+	lineageRunID string
+	// When long queries are handed off to a job run, and where it writes
+	// its result
+	jobsHandoffAfter time.Duration
+	jobsOutputSchema string
+	// How a warehouse is picked when no HTTP path is given, and the HTTP
+	// paths picked, by workspace
+	warehousePolicy    string
+	warehouseTags      []queryTag
+	selectedWarehouses map[string]string
+
+	// Metadata options
+	schemaCacheEnabled  bool
+	schemaCacheTTL      time.Duration
+	namespaceCache      bool
+	schemaCache         *schemaCache
+	informationSchema   string
+	metadataFilters     string
+	metadataFilterMatch string
+	metadataRetryCount  int
+
+	// Connections to cloud storage for result downloads, shared by the
+	// database's connections and replaced along with the connection pool
+	storagePool *storagePool
+
+	// Temporary files, under tempDir (or the system default) and limited
+	// to tempMaxBytes. Replaced along with the connection pool, which
+	// deletes the previous store's files
+	tempDir      string
+	tempMaxBytes int64
+	tempStore    *tempStore
+
+	// Diagnostics options
+	errorHistorySize int
+	errorMaxLength   int
+	debugHTTP        bool
+
+	// Time source of waits and token expiries; see ClockSetter
+	clock driverClock
+	// Background goroutines of the database and everything opened from it
+	goroutines *goroutineGroup
+
+	// Load balancer affinity options
+	affinityCookies bool
+	affinityHeaders []string
+
 	// TLS/SSL options
 	sslMode     string
 	sslRootCert string
 	sslCertPool *x509.CertPool
 	sslInsecure bool
 
-	// OAuth options (for future expansion)
+	// OAuth options
 	oauthClientID     string
 	oauthClientSecret string
 	oauthRefreshToken string
+	// Browser sign-in: the redirect listener's port and the refresh token
+	// cache
+	oauthU2M          bool
+	oauthRedirectPort int
+	oauthTokenCache   string
+	// Workload identity federation: where the OIDC token comes from
+	federationTokenSource string
+	federationTokenEnv    string
+	federationTokenFile   string
+	federationAudience    string
+	// Microsoft Entra ID service principal: its tenant and the authority
+	// issuing its tokens
+	azureTenantID      string
+	azureLoginEndpoint string
+	// OAuth authenticators by host, shared by the connection pools and
+	// Statement Execution API clients and replaced along with the pools
+	authenticators map[string]*eventAuthenticator
+	// Connections that options in liveOptions are passed on to
+	openConns openConnections
 }
 
-func (d *databaseImpl) resolveConnectionOptions() ([]dbsql.ConnOption, error) {
-	if d.serverHostname == "" {
+func (d *databaseImpl) resolveConnectionOptions(endpoint workspaceEndpoint) ([]dbsql.ConnOption, error) {
+	if endpoint.ServerHostname == "" {
 		return nil, adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "server hostname is required",
 		}
 	}
 
-	if d.httpPath == "" {
+	if endpoint.HTTPPath == "" {
 		return nil, adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "HTTP path is required",
@@ -98,33 +202,54 @@ func (d *databaseImpl) resolveConnectionOptions() ([]dbsql.ConnOption, error) {
 	}
 
 	// FIXME: Support other auth methods
-	if d.accessToken == "" && d.oauthClientID == "" && d.oauthClientSecret == "" {
+	federated := d.federationTokenSource != ""
+	if d.accessToken == "" && d.oauthClientID == "" && d.oauthClientSecret == "" && !federated && !d.oauthU2M {
 		return nil, adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "[db] access token or OAuth config is required",
 		}
-	} else if d.accessToken != "" && (d.oauthClientID != "" || d.oauthClientSecret != "") {
+	} else if d.accessToken != "" && (d.oauthClientID != "" || d.oauthClientSecret != "" || federated || d.oauthU2M) {
 		return nil, adbc.Error{
 			Code: adbc.StatusInvalidArgument,
 			Msg:  "[db] cannot specify both access token and OAuth config",
 		}
+	} else if federated && d.oauthClientSecret != "" {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  "[db] cannot specify both an OAuth client secret and workload identity federation",
+		}
+	} else if d.oauthU2M && (federated || d.oauthClientSecret != "") {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  "[db] browser sign-in cannot be combined with an OAuth client secret or workload identity federation",
+		}
+	} else if d.azureTenantID != "" && (federated || d.oauthU2M) {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  "[db] a Microsoft Entra ID tenant cannot be combined with browser sign-in or workload identity federation",
+		}
+	} else if d.accessToken == "" && !federated && !d.oauthU2M && (d.oauthClientID == "" || d.oauthClientSecret == "") {
+		return nil, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("[db] OAuth machine-to-machine authentication requires both %s and %s", OptionOAuthClientID, OptionOAuthClientSecret),
+		}
 	}
 
 	opts := []dbsql.ConnOption{
-		dbsql.WithServerHostname(d.serverHostname),
-		dbsql.WithHTTPPath(d.httpPath),
+		dbsql.WithServerHostname(endpoint.ServerHostname),
+		dbsql.WithHTTPPath(endpoint.HTTPPath),
 	}
 
 	if d.accessToken != "" {
 		opts = append(opts, dbsql.WithAccessToken(d.accessToken))
 	} else {
-		opts = append(opts, dbsql.WithClientCredentials(d.oauthClientID, d.oauthClientSecret))
+		opts = append(opts, dbsql.WithAuthenticator(d.oauthAuthenticator(endpoint)))
 	}
 
 	// Validate and set custom port
 	// Defaults to 443
-	if d.port != 0 {
-		opts = append(opts, dbsql.WithPort(d.port))
+	if endpoint.Port != 0 {
+		opts = append(opts, dbsql.WithPort(endpoint.Port))
 	} else {
 		opts = append(opts, dbsql.WithPort(DEFAULT_PORT))
 	}
@@ -147,47 +272,187 @@ func (d *databaseImpl) resolveConnectionOptions() ([]dbsql.ConnOption, error) {
 	if d.downloadThreadCount > 0 {
 		opts = append(opts, dbsql.WithMaxDownloadThreads(d.downloadThreadCount))
 	}
+	sessionParams := map[string]string{}
+	if d.sessionTimezone != "" {
+		sessionParams["timezone"] = d.sessionTimezone
+	}
+	if d.sessionCollation != "" {
+		sessionParams[collationSessionParam] = d.sessionCollation
+	}
+	if len(sessionParams) > 0 {
+		opts = append(opts, dbsql.WithSessionParams(sessionParams))
+	}
+
+	if transport := d.httpTransport(); transport != nil {
+		opts = append(opts, dbsql.WithTransport(transport))
+	}
+
+	return opts, nil
+}
+
+// newStatementAPI returns a Statement Execution API client for the
+// endpoint's warehouse, or nil if the endpoint isn't the hostname and HTTP
+// path of a SQL warehouse.
+func (d *databaseImpl) newStatementAPI(endpoint workspaceEndpoint) *statementAPI {
+	warehouseID := warehouseIDFromHTTPPath(endpoint.HTTPPath)
+	if d.uri != "" || endpoint.ServerHostname == "" || warehouseID == "" {
+		return nil
+	}
 
+	return &statementAPI{
+		client:      &http.Client{Transport: d.httpTransport()},
+		storage:     &http.Client{Transport: d.storagePool},
+		baseURL:     endpoint.baseURL(),
+		auth:        d.restAuthenticator(endpoint),
+		warehouseID: warehouseID,
+		clock:       d.clock,
+		goroutines:  d.goroutines,
+	}
+}
+
+// newWorkspaceAPI returns a client for the REST APIs of the endpoint's
+// workspace, or nil if the database isn't configured by hostname.
+func (d *databaseImpl) newWorkspaceAPI(endpoint workspaceEndpoint) *statementAPI {
+	if d.uri != "" || endpoint.ServerHostname == "" {
+		return nil
+	}
+	return &statementAPI{
+		client:     &http.Client{Transport: d.httpTransport()},
+		baseURL:    endpoint.baseURL(),
+		auth:       d.restAuthenticator(endpoint),
+		clock:      d.clock,
+		goroutines: d.goroutines,
+	}
+}
+
+// restAuthenticator returns the authenticator of REST requests to the
+// endpoint's workspace.
+func (d *databaseImpl) restAuthenticator(endpoint workspaceEndpoint) auth.Authenticator {
+	if d.accessToken != "" {
+		return &pat.PATAuth{AccessToken: d.accessToken}
+	}
+	return d.oauthAuthenticator(endpoint)
+}
+
+// oauthAuthenticator returns the OAuth authenticator for the endpoint's
+// host, creating it on first use: workload identity federation if
+// OptionOAuthFederationTokenSource is set, browser sign-in with
+// OptionOAuthU2M, a Microsoft Entra ID service principal with
+// OptionOAuthAzureTenantID, machine-to-machine OAuth with the client ID and
+// secret otherwise.
+func (d *databaseImpl) oauthAuthenticator(endpoint workspaceEndpoint) *eventAuthenticator {
+	host := endpoint.ServerHostname
+	if a, ok := d.authenticators[host]; ok {
+		return a
+	}
+	a := &eventAuthenticator{}
+	if d.federationTokenSource != "" {
+		client := &http.Client{Transport: d.httpTransport()}
+		a.Authenticator = &federationAuth{
+			client:       client,
+			tokenURL:     endpoint.baseURL() + "/oidc/v1/token",
+			clientID:     d.oauthClientID,
+			subjectToken: d.federationSubjectToken(client),
+			clock:        d.clock,
+		}
+	} else if d.oauthU2M {
+		clientID := d.oauthClientID
+		if clientID == "" {
+			clientID = DefaultOAuthU2MClientID
+		}
+		a.Authenticator = &u2mAuth{
+			client:       &http.Client{Transport: d.httpTransport()},
+			baseURL:      endpoint.baseURL(),
+			clientID:     clientID,
+			port:         d.oauthRedirectPort,
+			cache:        newU2MTokenCache(d.oauthTokenCache),
+			logger:       d.Logger,
+			openBrowser:  openBrowser,
+			refreshToken: d.oauthRefreshToken,
+			clock:        d.clock,
+			goroutines:   d.goroutines,
+		}
+	} else if d.azureTenantID != "" {
+		a.Authenticator = d.newAzureAuth()
+	} else {
+		a.Authenticator = m2m.NewAuthenticator(d.oauthClientID, d.oauthClientSecret, host)
+	}
+	if d.authenticators == nil {
+		d.authenticators = make(map[string]*eventAuthenticator)
+	}
+	d.authenticators[host] = a
+	return a
+}
+
+// httpTransport returns the transport for requests to the workspace, or
+// nil if databricks-sql-go's default one will do.
+func (d *databaseImpl) httpTransport() http.RoundTripper {
 	// TLS/SSL handling
 	// Configure a custom transport with proper timeout settings when custom
 	// TLS config is needed. These settings match the defaults from
 	// databricks-sql-go's PooledTransport to ensure reliable connections
 	// for large result set downloads.
-	if d.sslCertPool != nil || d.sslInsecure {
-		tlsConfig := &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		}
+	var transport http.RoundTripper
+	if tlsConfig := d.tlsConfig(); tlsConfig != nil {
+		transport = newHTTPTransport(tlsConfig)
+	}
 
-		if d.sslCertPool != nil {
-			tlsConfig.RootCAs = d.sslCertPool
+	if d.debugHTTP {
+		if transport == nil {
+			transport = newHTTPTransport(nil)
 		}
+		transport = &debugTransport{base: transport, logger: d.Logger}
+	}
 
-		if d.sslInsecure {
-			tlsConfig.InsecureSkipVerify = true
+	if d.affinityCookies || len(d.affinityHeaders) > 0 {
+		if transport == nil {
+			transport = newHTTPTransport(nil)
 		}
+		transport = newAffinityTransport(transport, d.affinityCookies, d.affinityHeaders)
+	}
 
-		transport := &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			TLSClientConfig:       tlsConfig,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       180 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			MaxIdleConnsPerHost:   10,
-			MaxConnsPerHost:       100,
-		}
-		opts = append(opts, dbsql.WithTransport(transport))
+	return transport
+}
+
+// tlsConfig returns the TLS settings for the SSL options, or nil for the
+// defaults.
+func (d *databaseImpl) tlsConfig() *tls.Config {
+	if d.sslCertPool == nil && !d.sslInsecure {
+		return nil
 	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if d.sslCertPool != nil {
+		tlsConfig.RootCAs = d.sslCertPool
+	}
+	if d.sslInsecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return tlsConfig
+}
 
-	return opts, nil
+// newHTTPTransport returns a transport with the same settings as
+// databricks-sql-go's default one.
+func newHTTPTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       180 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		MaxIdleConnsPerHost:   10,
+		MaxConnsPerHost:       100,
+	}
 }
 
-func (d *databaseImpl) initializeConnectionPool(ctx context.Context) (*sql.DB, error) {
+func (d *databaseImpl) initializeConnectionPool(ctx context.Context, endpoint workspaceEndpoint, events *connectionEvents) (*sql.DB, error) {
 	var db *sql.DB
 
 	// Use URI if provided
@@ -198,7 +463,7 @@ func (d *databaseImpl) initializeConnectionPool(ctx context.Context) (*sql.DB, e
 			return nil, err
 		}
 	} else {
-		opts, err := d.resolveConnectionOptions()
+		opts, err := d.resolveConnectionOptions(endpoint)
 		if err != nil {
 			return nil, err
 		}
@@ -211,67 +476,175 @@ func (d *databaseImpl) initializeConnectionPool(ctx context.Context) (*sql.DB, e
 		db = sql.OpenDB(connector)
 	}
 
-	// Test the connection
-	if err := db.PingContext(ctx); err != nil {
+	// Test the connection, unless session creation is deferred
+	if d.connectLazy {
+		return db, nil
+	}
+	started, err := waitForCluster(ctx, d.clock, d.clusterStartWait(endpoint), d.Logger, db.PingContext)
+	if err != nil {
 		err = errors.Join(err, db.Close())
 		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  fmt.Sprintf("failed to ping database: %v", err),
+			Code:    adbc.StatusInternal,
+			Msg:     fmt.Sprintf("failed to ping database: %v", err),
+			Details: retryDetails(err),
 		}
 	}
+	if started {
+		events.emit(ConnectionEventWarehouseStarted)
+	}
 
 	return db, nil
 }
 
+// clusterStartWait returns how long opening a session waits for the
+// endpoint's compute to start: OptionClusterStartTimeout on clusters, zero
+// otherwise.
+func (d *databaseImpl) clusterStartWait(endpoint workspaceEndpoint) time.Duration {
+	if d.uri != "" || computeTypeFromHTTPPath(endpoint.HTTPPath) != OptionValueComputeTypeCluster {
+		return 0
+	}
+	return d.clusterStartTimeout
+}
+
 func (d *databaseImpl) Open(ctx context.Context) (adbc.Connection, error) {
-	// Re-initialize the connection pool and settings if anything
+	name, endpoint, err := d.workspaceEndpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint, err = d.selectWarehouse(ctx, name, endpoint); err != nil {
+		return nil, err
+	}
+	events := newConnectionEvents(ctx, name)
+
+	// Re-initialize the connection pools and settings if anything
 	// has changed, or we have not initialized yet
-	if d.needsRefresh || d.db == nil {
-		db, err := d.initializeConnectionPool(ctx)
+	if d.needsRefresh || d.pools == nil {
+		d.authenticators = nil
+		db, err := d.initializeConnectionPool(ctx, endpoint, events)
 
 		if err != nil {
 			return nil, err
 		}
 
-		// Close the existing connection pool
-		if d.db != nil {
-			err = d.db.Close()
-			if err != nil {
-				return nil, err
-			}
+		// Close the existing connection pools
+		if err := d.closePools(); err != nil {
+			_ = db.Close()
+			return nil, err
 		}
 
-		d.db = db
+		d.pools = map[string]*sql.DB{name: db}
+		d.needsRefresh = false
+		// The options may now point at a different workspace
+		d.schemaCache.clear()
+		if d.storagePool != nil {
+			d.storagePool.CloseIdleConnections()
+		}
+		d.storagePool = newStoragePool(d.tlsConfig())
+		if d.tempStore != nil {
+			if err := d.tempStore.close(); err != nil {
+				d.Logger.Warn("failed to remove temporary files", slog.Any("error", err))
+			}
+		}
+		d.tempStore = newTempStore(d.tempDir, d.tempMaxBytes, d.Logger)
+	} else if d.pools[name] == nil {
+		db, err := d.initializeConnectionPool(ctx, endpoint, events)
+		if err != nil {
+			return nil, err
+		}
+		d.pools[name] = db
 	}
 
-	c, err := d.db.Conn(ctx)
-
-	if err != nil {
-		return nil, err
+	conn := &connectionImpl{
+		ConnectionImplBase:  driverbase.NewConnectionImplBase(&d.DatabaseImplBase),
+		catalog:             d.catalog,
+		dbSchema:            d.schema,
+		db:                  d.pools[name],
+		temporalBinding:     newTemporalBinding(d.timestampBindMode, d.sessionLocation),
+		errorHistory:        newErrorHistory(d.errorHistorySize),
+		probeCapabilities:   d.capabilitiesProbe,
+		clusterStartTimeout: d.clusterStartWait(endpoint),
+		idleTimeout:         d.sessionIdleTimeout,
+		lineageTags:         lineageTags(d.lineageTool, d.lineageRunID),
+		sessionConfs:        maps.Clone(d.sessionConfs),
+		jobsHandoffAfter:    d.jobsHandoffAfter,
+		jobsOutputSchema:    d.jobsOutputSchema,
+		informationSchema:   d.informationSchema,
+		metadataFilters:     d.metadataFilters,
+		metadataFilterMatch: d.metadataFilterMatch,
+		namespaceCache:      d.namespaceCache,
+		clock:               d.clock,
+		goroutines:          d.goroutines,
+		statementAPI:        d.newStatementAPI(endpoint),
+		workspaceAPI:        d.newWorkspaceAPI(endpoint),
+		tempStore:           d.tempStore,
+		capabilities:        assumedCapabilities(),
+		events:              events,
+	}
+	conn.stopAuthEvents = d.authenticators[endpoint.ServerHostname].subscribe(events)
+	conn.errorMaxLength.Store(int64(d.errorMaxLength))
+	conn.metadataRetries.Store(int64(d.metadataRetryCount))
+	conn.schemaCacheTTL.Store(int64(d.schemaCacheTTL))
+	if d.schemaCacheEnabled {
+		conn.schemaCache = d.schemaCache
 	}
 
-	conn := &connectionImpl{
-		ConnectionImplBase: driverbase.NewConnectionImplBase(&d.DatabaseImplBase),
-		catalog:            d.catalog,
-		dbSchema:           d.schema,
-		conn:               c,
+	if !d.connectLazy || d.connectValidate {
+		c, err := conn.sqlConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if d.connectValidate {
+			if err := c.PingContext(ctx); err != nil {
+				err = errors.Join(err, conn.Close())
+				return nil, adbc.Error{
+					Code: adbc.StatusIO,
+					Msg:  fmt.Sprintf("failed to validate connection: %v", err),
+				}
+			}
+		}
 	}
 
-	return driverbase.NewConnectionBuilder(conn).
+	conn.stopLiveOptions = d.openConns.add(conn)
+	cnxn := driverbase.NewConnectionBuilder(conn).
 		WithAutocommitSetter(conn).
 		WithCurrentNamespacer(conn).
 		WithTableTypeLister(conn).
 		WithDbObjectsEnumerator(conn).
 		WithDriverInfoPreparer(conn).
-		Connection(), nil
+		Connection()
+	events.emit(ConnectionEventOpened)
+	return &statisticsConnection{baseConnection: cnxn.(baseConnection), impl: conn}, nil
 }
 
 func (d *databaseImpl) Close() error {
 	defer func() {
 		d.needsRefresh = true
-		d.db = nil
 	}()
-	return d.db.Close()
+	// Stop the goroutines of the connections, statements and readers
+	// still open, and start afresh should the database be used again
+	d.goroutines.close()
+	d.goroutines = newGoroutineGroup()
+	if d.storagePool != nil {
+		d.storagePool.CloseIdleConnections()
+	}
+	if d.tempStore != nil {
+		if err := d.tempStore.close(); err != nil {
+			d.Logger.Warn("failed to remove temporary files", slog.Any("error", err))
+		}
+		d.tempStore = nil
+	}
+	return d.closePools()
+}
+
+// closePools closes the connection pools of every workspace.
+func (d *databaseImpl) closePools() error {
+	var errs []error
+	for _, db := range d.pools {
+		errs = append(errs, db.Close())
+	}
+	d.pools = nil
+	return errors.Join(errs...)
 }
 
 func (d *databaseImpl) GetOption(key string) (string, error) {
@@ -288,6 +661,22 @@ func (d *databaseImpl) GetOption(key string) (string, error) {
 		return strconv.Itoa(d.port), nil
 	case OptionCatalog:
 		return d.catalog, nil
+	case OptionConnectLazy:
+		return formatBoolOption(d.connectLazy), nil
+	case OptionConnectValidate:
+		return formatBoolOption(d.connectValidate), nil
+	case OptionCapabilitiesProbe:
+		return formatBoolOption(d.capabilitiesProbe), nil
+	case OptionComputeType:
+		return computeTypeFromHTTPPath(d.httpPath), nil
+	case OptionTempDir:
+		return d.tempDir, nil
+	case OptionProfile:
+		return d.profile, nil
+	case OptionTempMaxBytes:
+		return strconv.FormatInt(d.tempMaxBytes, 10), nil
+	case OptionClusterStartTimeout:
+		return d.clusterStartTimeout.String(), nil
 	case OptionSchema:
 		return d.schema, nil
 	case OptionQueryTimeout:
@@ -295,7 +684,7 @@ func (d *databaseImpl) GetOption(key string) (string, error) {
 			return d.queryTimeout.String(), nil
 		}
 		return "", nil
-	case OptionMaxRows:
+	case OptionMaxRows, OptionFetchMaxRowsPerRequest:
 		if d.maxRows > 0 {
 			return strconv.Itoa(d.maxRows), nil
 		}
@@ -310,6 +699,62 @@ func (d *databaseImpl) GetOption(key string) (string, error) {
 			return strconv.Itoa(d.downloadThreadCount), nil
 		}
 		return "", nil
+	case OptionCloudFetchMaxParallel:
+		if d.downloadThreadCount > 0 {
+			return strconv.Itoa(d.downloadThreadCount), nil
+		}
+		return strconv.Itoa(DefaultCloudFetchMaxParallel), nil
+	case OptionSessionTimezone:
+		return d.sessionTimezone, nil
+	case OptionSessionCollation:
+		return d.sessionCollation, nil
+	case OptionSessionIdleTimeout:
+		return d.sessionIdleTimeout.String(), nil
+	case OptionLineageTool:
+		return d.lineageTool, nil
+	case OptionLineageRunID:
+		return d.lineageRunID, nil
+	case OptionWorkspaces:
+		return formatWorkspaces(d.workspaces)
+	case OptionWorkspace:
+		return d.workspace, nil
+	case OptionJobsHandoffAfter:
+		return d.jobsHandoffAfter.String(), nil
+	case OptionJobsOutputSchema:
+		return d.jobsOutputSchema, nil
+	case OptionWarehousePolicy:
+		return d.warehousePolicy, nil
+	case OptionWarehouseTags:
+		return formatQueryTags(d.warehouseTags), nil
+	case OptionTimestampBindMode:
+		if d.timestampBindMode == "" {
+			return OptionValueTimestampModeAuto, nil
+		}
+		return d.timestampBindMode, nil
+	case OptionSchemaCacheEnabled:
+		return formatBoolOption(d.schemaCacheEnabled), nil
+	case OptionSchemaCacheTTL:
+		return d.schemaCacheTTL.String(), nil
+	case OptionNamespaceCacheEnabled:
+		return formatBoolOption(d.namespaceCache), nil
+	case OptionInformationSchema:
+		return d.informationSchema, nil
+	case OptionMetadataFilters:
+		return d.metadataFilters, nil
+	case OptionMetadataFilterMatch:
+		return d.metadataFilterMatch, nil
+	case OptionMetadataRetryCount:
+		return strconv.Itoa(d.metadataRetryCount), nil
+	case OptionErrorHistorySize:
+		return strconv.Itoa(d.errorHistorySize), nil
+	case OptionErrorMaxMessageLength:
+		return strconv.Itoa(d.errorMaxLength), nil
+	case OptionDebugHTTP:
+		return formatBoolOption(d.debugHTTP), nil
+	case OptionAffinityCookies:
+		return formatBoolOption(d.affinityCookies), nil
+	case OptionAffinityHeaders:
+		return strings.Join(d.affinityHeaders, ","), nil
 	case OptionSSLMode:
 		return d.sslMode, nil
 	case OptionSSLRootCert:
@@ -320,11 +765,67 @@ func (d *databaseImpl) GetOption(key string) (string, error) {
 		return d.oauthClientSecret, nil
 	case OptionOAuthRefreshToken:
 		return d.oauthRefreshToken, nil
+	case OptionOAuthU2M:
+		return formatBoolOption(d.oauthU2M), nil
+	case OptionOAuthU2MRedirectPort:
+		return strconv.Itoa(d.oauthRedirectPort), nil
+	case OptionOAuthU2MTokenCache:
+		return d.oauthTokenCache, nil
+	case OptionOAuthFederationTokenSource:
+		return d.federationTokenSource, nil
+	case OptionOAuthFederationTokenEnv:
+		return d.federationTokenEnv, nil
+	case OptionOAuthFederationTokenFile:
+		return d.federationTokenFile, nil
+	case OptionOAuthFederationAudience:
+		return d.federationAudience, nil
+	case OptionOAuthAzureTenantID:
+		return d.azureTenantID, nil
+	case OptionOAuthAzureLoginEndpoint:
+		return d.azureLoginEndpoint, nil
 	default:
+		if name, ok := strings.CutPrefix(key, OptionSessionConfPrefix); ok {
+			return d.sessionConfs[name], nil
+		}
 		return d.DatabaseImplBase.GetOption(key)
 	}
 }
 
+func (d *databaseImpl) GetOptionInt(key string) (int64, error) {
+	switch key {
+	case OptionPort:
+		return int64(d.port), nil
+	case OptionQueryTimeout:
+		return int64(d.queryTimeout / time.Second), nil
+	case OptionMaxRows, OptionFetchMaxRowsPerRequest:
+		return int64(d.maxRows), nil
+	case OptionQueryRetryCount:
+		return int64(d.queryRetryCount), nil
+	case OptionDownloadThreadCount:
+		return int64(d.downloadThreadCount), nil
+	case OptionCloudFetchMaxParallel:
+		if d.downloadThreadCount > 0 {
+			return int64(d.downloadThreadCount), nil
+		}
+		return DefaultCloudFetchMaxParallel, nil
+	case OptionErrorHistorySize:
+		return int64(d.errorHistorySize), nil
+	case OptionErrorMaxMessageLength:
+		return int64(d.errorMaxLength), nil
+	default:
+		return d.DatabaseImplBase.GetOptionInt(key)
+	}
+}
+
+func (d *databaseImpl) SetOptionInt(key string, value int64) error {
+	switch key {
+	case OptionPort, OptionQueryTimeout, OptionMaxRows, OptionFetchMaxRowsPerRequest, OptionQueryRetryCount, OptionDownloadThreadCount, OptionCloudFetchMaxParallel, OptionErrorHistorySize, OptionErrorMaxMessageLength:
+		return d.SetOption(key, strconv.FormatInt(value, 10))
+	default:
+		return d.DatabaseImplBase.SetOptionInt(key, value)
+	}
+}
+
 func (d *databaseImpl) SetOptions(options map[string]string) error {
 	// We need to re-initialize the db/connection pool if options change
 	d.needsRefresh = true
@@ -357,6 +858,18 @@ func (d *databaseImpl) SetOptions(options map[string]string) error {
 }
 
 func (d *databaseImpl) SetOption(key, value string) error {
+	if key == OptionProfile {
+		return d.applyProfile(value)
+	}
+	if err := d.setOption(key, value); err != nil {
+		return err
+	}
+	d.markExplicit(key)
+	d.applyLiveOption(key)
+	return nil
+}
+
+func (d *databaseImpl) setOption(key, value string) error {
 	// We need to re-initialize the db/connection pool if options change
 	d.needsRefresh = true
 	switch key {
@@ -377,82 +890,258 @@ func (d *databaseImpl) SetOption(key, value string) error {
 	case OptionAccessToken:
 		d.accessToken = value
 	case OptionPort:
-		port, err := strconv.Atoi(value)
-		if err != nil || port < 1 || port > 65535 {
-			return adbc.Error{
-				Code: adbc.StatusInvalidArgument,
-				Msg:  "invalid port number",
-			}
+		port, err := parseIntOption(key, value, 1, 65535)
+		if err != nil {
+			return err
 		}
 		d.port = port
 	case OptionCatalog:
 		d.catalog = value
+	case OptionConnectLazy:
+		lazy, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.connectLazy = lazy
+	case OptionConnectValidate:
+		validate, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.connectValidate = validate
+	case OptionCapabilitiesProbe:
+		probe, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.capabilitiesProbe = probe
+	case OptionClusterStartTimeout:
+		timeout, err := parseDurationOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.clusterStartTimeout = timeout
+	case OptionTempDir:
+		d.tempDir = value
+	case OptionTempMaxBytes:
+		maxBytes, err := parseIntOption(key, value, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		d.tempMaxBytes = int64(maxBytes)
 	case OptionSchema:
 		d.schema = value
 	case OptionQueryTimeout:
 		if value != "" {
-			timeout, err := time.ParseDuration(value)
+			timeout, err := parseDurationOption(key, value)
 			if err != nil {
-				return adbc.Error{
-					Code: adbc.StatusInvalidArgument,
-					Msg:  fmt.Sprintf("invalid query timeout: %v", err),
-				}
+				return err
 			}
 			d.queryTimeout = timeout
 		}
 	case OptionMaxRows:
+		// The deprecated key keeps accepting any integer, as it always has;
+		// values below 1 keep the library's default
 		if value != "" {
-			maxRows, err := strconv.Atoi(value)
+			maxRows, err := strconv.Atoi(strings.TrimSpace(value))
 			if err != nil {
-				return adbc.Error{
-					Code: adbc.StatusInvalidArgument,
-					Msg:  fmt.Sprintf("invalid max rows: %v", err),
-				}
+				return invalidOption(key, value, "an integer")
+			}
+			d.maxRows = maxRows
+		}
+	case OptionFetchMaxRowsPerRequest:
+		if value != "" {
+			maxRows, err := parseIntOption(key, value, 1, MaxFetchRowsPerRequest)
+			if err != nil {
+				return err
 			}
 			d.maxRows = maxRows
 		}
 	case OptionQueryRetryCount:
 		if value != "" {
-			retryCount, err := strconv.Atoi(value)
+			retryCount, err := parseIntOption(key, value, 0, math.MaxInt32)
 			if err != nil {
-				return adbc.Error{
-					Code: adbc.StatusInvalidArgument,
-					Msg:  fmt.Sprintf("invalid query retry count: %v", err),
-				}
+				return err
 			}
 			d.queryRetryCount = retryCount
 		}
 	case OptionDownloadThreadCount:
 		if value != "" {
-			threadCount, err := strconv.Atoi(value)
+			threadCount, err := parseIntOption(key, value, 1, math.MaxInt32)
 			if err != nil {
-				return adbc.Error{
-					Code: adbc.StatusInvalidArgument,
-					Msg:  fmt.Sprintf("invalid download thread count: %v", err),
-				}
+				return err
 			}
 			d.downloadThreadCount = threadCount
 		}
-	case OptionSSLMode:
+	case OptionCloudFetchMaxParallel:
+		parallel, err := parseIntOption(key, value, 1, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		d.downloadThreadCount = parallel
+	case OptionSessionTimezone:
 		if value != "" {
-			lowerValue := strings.ToLower(value)
-			switch lowerValue {
-			case "insecure":
-				d.sslMode = lowerValue
-				d.sslInsecure = true
-			case "require":
-				d.sslMode = lowerValue
-				d.sslInsecure = false
-			default:
+			loc, err := time.LoadLocation(value)
+			if err != nil {
 				return adbc.Error{
 					Code: adbc.StatusInvalidArgument,
-					Msg:  fmt.Sprintf("invalid SSL mode: %s (supported: 'require', 'insecure')", value),
+					Msg:  fmt.Sprintf("invalid session timezone: %v", err),
 				}
 			}
+			d.sessionLocation = loc
 		} else {
+			d.sessionLocation = nil
+		}
+		d.sessionTimezone = value
+	case OptionSessionCollation:
+		if value == "" {
+			d.sessionCollation = ""
+			break
+		}
+		collation, err := parseCollation(key, value)
+		if err != nil {
+			return err
+		}
+		d.sessionCollation = collation
+	case OptionSessionIdleTimeout:
+		timeout, err := parseDurationOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.sessionIdleTimeout = timeout
+	case OptionLineageTool:
+		d.lineageTool = value
+	case OptionLineageRunID:
+		d.lineageRunID = value
+	case OptionWorkspaces:
+		workspaces, err := parseWorkspaces(value)
+		if err != nil {
+			return err
+		}
+		d.workspaces = workspaces
+	case OptionWorkspace:
+		d.workspace = value
+	case OptionJobsHandoffAfter:
+		after, err := parseDurationOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.jobsHandoffAfter = after
+	case OptionJobsOutputSchema:
+		if value != "" {
+			if parts, err := splitQualifiedName(value); err != nil || len(parts) != 2 {
+				return invalidOption(key, value, "a schema name of the form catalog.schema")
+			}
+		}
+		d.jobsOutputSchema = value
+	case OptionWarehousePolicy:
+		if value == "" {
+			d.warehousePolicy = ""
+			break
+		}
+		policy, err := parseEnumOption(key, value,
+			OptionValueWarehousePolicySmallestRunning, OptionValueWarehousePolicyTagged)
+		if err != nil {
+			return err
+		}
+		d.warehousePolicy = policy
+	case OptionWarehouseTags:
+		tags, err := parseQueryTags(key, value)
+		if err != nil {
+			return err
+		}
+		d.warehouseTags = tags
+	case OptionTimestampBindMode:
+		if value == "" {
+			d.timestampBindMode = ""
+			break
+		}
+		mode, err := parseEnumOption(key, value,
+			OptionValueTimestampModeAuto, OptionValueTimestampModeInstant, OptionValueTimestampModeWallClock)
+		if err != nil {
+			return err
+		}
+		d.timestampBindMode = mode
+	case OptionSchemaCacheEnabled:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.schemaCacheEnabled = enabled
+	case OptionSchemaCacheTTL:
+		ttl, err := parseDurationOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.schemaCacheTTL = ttl
+	case OptionNamespaceCacheEnabled:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.namespaceCache = enabled
+	case OptionInformationSchema:
+		scope, err := parseEnumOption(key, value, OptionValueInformationSchemaCatalog, OptionValueInformationSchemaSystem)
+		if err != nil {
+			return err
+		}
+		d.informationSchema = scope
+	case OptionMetadataFilters:
+		semantics, err := parseEnumOption(key, value, OptionValueMetadataFiltersADBC, OptionValueMetadataFiltersJDBC)
+		if err != nil {
+			return err
+		}
+		d.metadataFilters = semantics
+	case OptionMetadataFilterMatch:
+		match, err := parseEnumOption(key, value, OptionValueFilterMatchPattern, OptionValueFilterMatchLiteral)
+		if err != nil {
+			return err
+		}
+		d.metadataFilterMatch = match
+	case OptionMetadataRetryCount:
+		retries, err := parseIntOption(key, value, 0, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		d.metadataRetryCount = retries
+	case OptionDebugHTTP:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.debugHTTP = enabled
+	case OptionAffinityCookies:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.affinityCookies = enabled
+	case OptionAffinityHeaders:
+		d.affinityHeaders = parseHeaderList(value)
+	case OptionErrorHistorySize:
+		size, err := parseIntOption(key, value, 0, maxErrorHistorySize)
+		if err != nil {
+			return err
+		}
+		d.errorHistorySize = size
+	case OptionErrorMaxMessageLength:
+		length, err := parseIntOption(key, value, 0, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		d.errorMaxLength = length
+	case OptionSSLMode:
+		if value == "" {
 			d.sslMode = value
 			d.sslInsecure = false
+			break
+		}
+		mode, err := parseEnumOption(key, value, OptionValueSSLModeRequire, OptionValueSSLModeInsecure)
+		if err != nil {
+			return err
 		}
+		d.sslMode = mode
+		d.sslInsecure = mode == OptionValueSSLModeInsecure
 	case OptionSSLRootCert:
 		if value != "" {
 			// Validate that the certificate file exists and can be read.
@@ -486,8 +1175,67 @@ func (d *databaseImpl) SetOption(key, value string) error {
 		d.oauthClientSecret = value
 	case OptionOAuthRefreshToken:
 		d.oauthRefreshToken = value
+	case OptionOAuthU2M:
+		enabled, err := parseBoolOption(key, value)
+		if err != nil {
+			return err
+		}
+		d.oauthU2M = enabled
+	case OptionOAuthU2MRedirectPort:
+		port, err := parseIntOption(key, value, 1, 65535)
+		if err != nil {
+			return err
+		}
+		d.oauthRedirectPort = port
+	case OptionOAuthU2MTokenCache:
+		d.oauthTokenCache = value
+	case OptionOAuthFederationTokenSource:
+		if value == "" {
+			d.federationTokenSource = value
+			break
+		}
+		source, err := parseEnumOption(key, value,
+			OptionValueTokenSourceGitHubActions, OptionValueTokenSourceEnv, OptionValueTokenSourceFile)
+		if err != nil {
+			return err
+		}
+		d.federationTokenSource = source
+	case OptionOAuthFederationTokenEnv:
+		if value == "" {
+			return invalidOption(key, value, "an environment variable name")
+		}
+		d.federationTokenEnv = value
+	case OptionOAuthFederationTokenFile:
+		if value == "" {
+			return invalidOption(key, value, "a file path")
+		}
+		d.federationTokenFile = value
+	case OptionOAuthFederationAudience:
+		d.federationAudience = value
+	case OptionOAuthAzureTenantID:
+		d.azureTenantID = value
+	case OptionOAuthAzureLoginEndpoint:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return invalidOption(key, value, "an https URL")
+		}
+		d.azureLoginEndpoint = value
 	default:
-		return d.DatabaseImplBase.SetOption(key, value)
+		name, ok, err := parseSessionConf(key, value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return d.DatabaseImplBase.SetOption(key, value)
+		}
+		if value == "" {
+			delete(d.sessionConfs, name)
+			break
+		}
+		if d.sessionConfs == nil {
+			d.sessionConfs = map[string]string{}
+		}
+		d.sessionConfs[name] = value
 	}
 	return nil
 }
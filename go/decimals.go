@@ -0,0 +1,247 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// declaredTypesSource is implemented by the readers that know the SQL
+// types of their result's columns, beyond the Arrow types they are sent
+// as. declaredTypes holds the DECIMAL columns' types, and nil for the
+// other columns.
+type declaredTypesSource interface {
+	declaredTypes() []arrow.DataType
+}
+
+// declaredRowsTypes returns the declared types of the DECIMAL columns of
+// rows, for declaredTypesSource.
+func declaredRowsTypes(rows driver.Rows) []arrow.DataType {
+	typed, ok := rows.(driver.RowsColumnTypeDatabaseTypeName)
+	if !ok {
+		return nil
+	}
+	scaled, ok := rows.(driver.RowsColumnTypePrecisionScale)
+	if !ok {
+		return nil
+	}
+	types := make([]arrow.DataType, len(rows.Columns()))
+	for i := range types {
+		if typed.ColumnTypeDatabaseTypeName(i) != "DECIMAL" {
+			continue
+		}
+		if precision, scale, ok := scaled.ColumnTypePrecisionScale(i); ok {
+			types[i] = &arrow.Decimal128Type{Precision: int32(precision), Scale: int32(scale)}
+		}
+	}
+	return types
+}
+
+// declaredManifestTypes returns the declared types of the DECIMAL columns
+// of a Statement Execution API result, for declaredTypesSource.
+func declaredManifestTypes(manifest *resultManifest) []arrow.DataType {
+	types := make([]arrow.DataType, len(manifest.Schema.Columns))
+	for i, col := range manifest.Schema.Columns {
+		if dt, err := parseDatabricksType(col.TypeText); err == nil && dt.ID() == arrow.DECIMAL128 {
+			types[i] = dt
+		}
+	}
+	return types
+}
+
+// decimalColumn is how decimalReader converts one column.
+type decimalColumn struct {
+	// The type converted to, or nil to leave the column as it is
+	target *arrow.Decimal128Type
+	// Whether values that don't fit target become null rather than
+	// failing the fetch, and whether one has
+	nullOnOverflow bool
+	nulled         bool
+}
+
+// decimalColumns decides how each column of schema is converted, given the
+// declared types of the result's columns, the largest precision the
+// consumer accepts and what to do with columns that may not fit it
+// (OptionFetchDecimalOverflow). Only DECIMAL columns sent as strings are
+// converted.
+func decimalColumns(schema *arrow.Schema, declared []arrow.DataType, maxPrecision int32, overflow string) []decimalColumn {
+	columns := make([]decimalColumn, schema.NumFields())
+	for i, field := range schema.Fields() {
+		if i >= len(declared) || declared[i] == nil || field.Type.ID() != arrow.STRING {
+			continue
+		}
+		dec := declared[i].(*arrow.Decimal128Type)
+		if dec.Precision <= maxPrecision {
+			columns[i].target = dec
+			continue
+		}
+		switch overflow {
+		case OptionValueDecimalOverflowString:
+			// Left as it is
+		default:
+			columns[i].target = &arrow.Decimal128Type{Precision: maxPrecision, Scale: min(dec.Scale, maxPrecision)}
+			columns[i].nullOnOverflow = overflow == OptionValueDecimalOverflowNull
+		}
+	}
+	return columns
+}
+
+// decimalReader converts the DECIMAL columns of the batches read from rdr,
+// which the server sends as strings, to Decimal128.
+type decimalReader struct {
+	refCount int64
+	mem      memory.Allocator
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	columns  []decimalColumn
+	warnings *statementWarnings
+	current  arrow.RecordBatch
+	err      error
+}
+
+// newDecimalReader wraps rdr, taking ownership of it. Columns returned
+// with less precision than declared, or as strings, are reported to
+// warnings, as are the first values of a column that become null.
+func newDecimalReader(mem memory.Allocator, rdr array.RecordReader, declared []arrow.DataType, maxPrecision int32, overflow string, warnings *statementWarnings) *decimalReader {
+	schema := rdr.Schema()
+	columns := decimalColumns(schema, declared, maxPrecision, overflow)
+	fields := schema.Fields()
+	for i, col := range columns {
+		if col.target != nil {
+			fields[i].Type = col.target
+		}
+		switch {
+		case i >= len(declared) || declared[i] == nil:
+		case col.target == nil && fields[i].Type.ID() == arrow.STRING:
+			warnings.add(WarningDecimalAsString, "column %s is %s, wider than %s allows, and is returned as strings",
+				fields[i].Name, declared[i], OptionFetchDecimalMaxPrecision)
+		case col.target != nil && !arrow.TypeEqual(col.target, declared[i]):
+			warnings.add(WarningDecimalNarrowed, "column %s is %s and is returned as %s", fields[i].Name, declared[i], col.target)
+		}
+	}
+	md := schema.Metadata()
+	return &decimalReader{
+		refCount: 1,
+		mem:      mem,
+		rdr:      rdr,
+		schema:   arrow.NewSchema(fields, &md),
+		columns:  columns,
+		warnings: warnings,
+	}
+}
+
+func (r *decimalReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *decimalReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.rdr.Release()
+	}
+}
+
+func (r *decimalReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *decimalReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if r.err != nil || !r.rdr.Next() {
+		return false
+	}
+	batch := r.rdr.RecordBatch()
+	cols := make([]arrow.Array, 0, batch.NumCols())
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for i, col := range batch.Columns() {
+		if r.columns[i].target == nil {
+			col.Retain()
+			cols = append(cols, col)
+			continue
+		}
+		converted, err := r.convert(r.schema.Field(i).Name, &r.columns[i], col.(*array.String))
+		if err != nil {
+			r.err = err
+			return false
+		}
+		cols = append(cols, converted)
+	}
+	r.current = array.NewRecordBatch(r.schema, cols, batch.NumRows())
+	return true
+}
+
+// convert parses the values of a DECIMAL column sent as strings.
+func (r *decimalReader) convert(name string, col *decimalColumn, arr *array.String) (arrow.Array, error) {
+	b := array.NewDecimal128Builder(r.mem, col.target)
+	defer b.Release()
+	b.Reserve(arr.Len())
+	for i := range arr.Len() {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		value, err := decimal128.FromString(arr.Value(i), col.target.Precision, col.target.Scale)
+		if err == nil {
+			b.Append(value)
+		} else if col.nullOnOverflow {
+			if !col.nulled {
+				col.nulled = true
+				r.warnings.add(WarningDecimalOverflowNull, "values of column %s that don't fit %s are returned as null",
+					name, col.target)
+			}
+			b.AppendNull()
+		} else {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidData,
+				Msg: fmt.Sprintf("value %s of column %s does not fit DECIMAL(%d,%d): %v",
+					arr.Value(i), name, col.target.Precision, col.target.Scale, err),
+			}
+		}
+	}
+	return b.NewArray(), nil
+}
+
+func (r *decimalReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *decimalReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *decimalReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.rdr.Err()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var decimalTestSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "price", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "total", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+var decimalTestDeclared = []arrow.DataType{
+	nil,
+	&arrow.Decimal128Type{Precision: 10, Scale: 2},
+	&arrow.Decimal128Type{Precision: 38, Scale: 4},
+}
+
+func makeDecimalTestReader(t *testing.T, mem memory.Allocator) array.RecordReader {
+	t.Helper()
+	batch, _, err := array.RecordFromJSON(mem, decimalTestSchema, strings.NewReader(`[
+		{"id": 1, "price": "12.50", "total": "1234.5678"},
+		{"id": 2, "price": null, "total": "12345678901234567890123.0001"}
+	]`))
+	require.NoError(t, err)
+	defer batch.Release()
+	rdr, err := array.NewRecordReader(decimalTestSchema, []arrow.RecordBatch{batch})
+	require.NoError(t, err)
+	return rdr
+}
+
+func TestDecimalColumns(t *testing.T) {
+	assert.Equal(t, []decimalColumn{
+		{},
+		{target: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{target: &arrow.Decimal128Type{Precision: 38, Scale: 4}},
+	}, decimalColumns(decimalTestSchema, decimalTestDeclared, 38, OptionValueDecimalOverflowError))
+	assert.Equal(t, []decimalColumn{
+		{},
+		{target: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{target: &arrow.Decimal128Type{Precision: 18, Scale: 4}, nullOnOverflow: true},
+	}, decimalColumns(decimalTestSchema, decimalTestDeclared, 18, OptionValueDecimalOverflowNull))
+	assert.Equal(t, []decimalColumn{
+		{},
+		{target: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{},
+	}, decimalColumns(decimalTestSchema, decimalTestDeclared, 18, OptionValueDecimalOverflowString))
+	// Without declared types nothing is converted
+	assert.Equal(t, make([]decimalColumn, 3), decimalColumns(decimalTestSchema, nil, 38, OptionValueDecimalOverflowError))
+}
+
+func TestDecimalReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rdr := newDecimalReader(mem, makeDecimalTestReader(t, mem), decimalTestDeclared, 38, OptionValueDecimalOverflowError, nil)
+	defer rdr.Release()
+	assert.Equal(t, "decimal(10, 2)", rdr.Schema().Field(1).Type.String())
+	require.True(t, rdr.Next())
+	price := rdr.RecordBatch().Column(1).(*array.Decimal128)
+	assert.Equal(t, "12.50", price.Value(0).ToString(2))
+	assert.True(t, price.IsNull(1))
+	total := rdr.RecordBatch().Column(2).(*array.Decimal128)
+	assert.Equal(t, "12345678901234567890123.0001", total.ValueStr(1))
+	assert.False(t, rdr.Next())
+	require.NoError(t, rdr.Err())
+}
+
+func TestDecimalReaderOverflow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// The second total needs 27 digits
+	rdr := newDecimalReader(mem, makeDecimalTestReader(t, mem), decimalTestDeclared, 18, OptionValueDecimalOverflowError, nil)
+	assert.False(t, rdr.Next())
+	var adbcErr adbc.Error
+	require.ErrorAs(t, rdr.Err(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidData, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "column total does not fit DECIMAL(18,4)")
+	rdr.Release()
+
+	warnings := &statementWarnings{}
+	rdr = newDecimalReader(mem, makeDecimalTestReader(t, mem), decimalTestDeclared, 18, OptionValueDecimalOverflowNull, warnings)
+	require.True(t, rdr.Next())
+	total := rdr.RecordBatch().Column(2).(*array.Decimal128)
+	assert.Equal(t, "1234.5678", total.ValueStr(0))
+	assert.True(t, total.IsNull(1))
+	rdr.Release()
+	codes := []string{}
+	for _, w := range warnings.warnings {
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []string{WarningDecimalNarrowed, WarningDecimalOverflowNull}, codes)
+
+	warnings = &statementWarnings{}
+	rdr = newDecimalReader(mem, makeDecimalTestReader(t, mem), decimalTestDeclared, 18, OptionValueDecimalOverflowString, warnings)
+	require.True(t, rdr.Next())
+	assert.Equal(t, arrow.STRING, rdr.RecordBatch().Column(2).DataType().ID())
+	rdr.Release()
+	require.Len(t, warnings.warnings, 1)
+	assert.Equal(t, WarningDecimalAsString, warnings.warnings[0].Code)
+	assert.Contains(t, warnings.warnings[0].Message, "column total")
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dialect generates Databricks SQL for the schema operations that
+// tools such as the dbt adapter run through the driver: creating and
+// dropping schemas, renaming and swapping tables, and setting comments.
+//
+// Names are quoted and comments escaped, so any name or text may be
+// passed. Where the syntax depends on the Databricks Runtime version, a
+// Dialect for that version picks it; the zero Dialect sticks to syntax
+// every supported runtime accepts:
+//
+//	d := dialect.Dialect{Runtime: dialect.Version{Major: 15, Minor: 4}}
+//	stmt := d.ColumnComment(dialect.Name{"main", "sales", "orders"}, "id", "Order ID")
+package dialect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a Databricks Runtime version. The zero Version stands for an
+// unknown runtime.
+type Version struct {
+	Major, Minor int
+}
+
+// ParseVersion parses a runtime version as reported by the server or
+// shown in the workspace, e.g. "15.4", "15.4.x-scala2.12" or "16.1 LTS".
+func ParseVersion(s string) (Version, error) {
+	major, rest, ok := strings.Cut(strings.TrimSpace(s), ".")
+	if !ok {
+		return Version{}, fmt.Errorf("invalid runtime version %q", s)
+	}
+	end := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(rest)
+	}
+	v := Version{}
+	var err error
+	if v.Major, err = strconv.Atoi(major); err != nil || v.Major <= 0 {
+		return Version{}, fmt.Errorf("invalid runtime version %q", s)
+	}
+	if v.Minor, err = strconv.Atoi(rest[:end]); err != nil {
+		return Version{}, fmt.Errorf("invalid runtime version %q", s)
+	}
+	return v, nil
+}
+
+// AtLeast reports whether v is known to be major.minor or later.
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+func (v Version) String() string {
+	if v == (Version{}) {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Name is a table or view name. Empty parts are left out, so that the
+// current catalog and schema apply.
+type Name struct {
+	Catalog, Schema, Table string
+}
+
+// String returns the name quoted for use in SQL.
+func (n Name) String() string {
+	var parts []string
+	for _, part := range []string{n.Catalog, n.Schema, n.Table} {
+		if part != "" {
+			parts = append(parts, QuoteIdentifier(part))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// QuoteIdentifier quotes a catalog, schema, table or column name.
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// StringLiteral quotes s as a SQL string literal.
+func StringLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// schemaName quotes a schema name, qualified with catalog unless it is
+// empty.
+func schemaName(catalog, schema string) string {
+	if catalog == "" {
+		return QuoteIdentifier(schema)
+	}
+	return QuoteIdentifier(catalog) + "." + QuoteIdentifier(schema)
+}
+
+// Dialect generates SQL for one Databricks Runtime version.
+type Dialect struct {
+	// Runtime is the version of the runtime the SQL is for. SQL for the
+	// zero Version runs on every supported runtime.
+	Runtime Version
+}
+
+// CreateSchema returns the statement creating a schema unless it exists.
+// catalog may be empty for the current catalog.
+func (d Dialect) CreateSchema(catalog, schema string) string {
+	return "CREATE SCHEMA IF NOT EXISTS " + schemaName(catalog, schema)
+}
+
+// DropSchema returns the statement dropping a schema, if it exists,
+// along with the tables, views and functions in it.
+func (d Dialect) DropSchema(catalog, schema string) string {
+	return "DROP SCHEMA IF EXISTS " + schemaName(catalog, schema) + " CASCADE"
+}
+
+// DropTable returns the statement dropping a table if it exists.
+// Databricks has no DROP TABLE ... CASCADE; views on the table are left
+// and fail when queried.
+func (d Dialect) DropTable(name Name) string {
+	return "DROP TABLE IF EXISTS " + name.String()
+}
+
+// DropView returns the statement dropping a view if it exists.
+func (d Dialect) DropView(name Name) string {
+	return "DROP VIEW IF EXISTS " + name.String()
+}
+
+// RenameTable returns the statement renaming a table. Unity Catalog only
+// renames within a schema, so to should name the same catalog and schema.
+func (d Dialect) RenameTable(from, to Name) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s", from, to)
+}
+
+// RenameView returns the statement renaming a view.
+func (d Dialect) RenameView(from, to Name) string {
+	return fmt.Sprintf("ALTER VIEW %s RENAME TO %s", from, to)
+}
+
+// SwapTables returns the statements exchanging the names of tables a and
+// b of one schema, renaming a out of the way to tmp first. Databricks has
+// no atomic swap: the statements run one by one, and readers may see
+// either table missing in between.
+func (d Dialect) SwapTables(a, b, tmp Name) []string {
+	return []string{d.RenameTable(a, tmp), d.RenameTable(b, a), d.RenameTable(tmp, b)}
+}
+
+// SchemaComment returns the statement setting the comment of a schema;
+// an empty comment removes it.
+func (d Dialect) SchemaComment(catalog, schema, comment string) string {
+	return "COMMENT ON SCHEMA " + schemaName(catalog, schema) + " IS " + commentLiteral(comment)
+}
+
+// TableComment returns the statement setting the comment of a table or
+// view; an empty comment removes it.
+func (d Dialect) TableComment(name Name, comment string) string {
+	return "COMMENT ON TABLE " + name.String() + " IS " + commentLiteral(comment)
+}
+
+// ColumnComment returns the statement setting the comment of a column; an
+// empty comment removes it. COMMENT ON COLUMN, which also works for views,
+// needs Databricks Runtime 16.1; earlier runtimes use ALTER TABLE, which
+// only works for tables.
+func (d Dialect) ColumnComment(name Name, column, comment string) string {
+	if d.Runtime.AtLeast(16, 1) {
+		column := name.String() + "." + QuoteIdentifier(column)
+		return "COMMENT ON COLUMN " + column + " IS " + commentLiteral(comment)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT %s", name, QuoteIdentifier(column), StringLiteral(comment))
+}
+
+// commentLiteral renders a comment for COMMENT ON, where NULL removes it.
+func commentLiteral(comment string) string {
+	if comment == "" {
+		return "NULL"
+	}
+	return StringLiteral(comment)
+}
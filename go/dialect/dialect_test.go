@@ -0,0 +1,104 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runtimes are the Databricks Runtime versions every helper is checked
+// against: unknown, the LTS releases still in support and the first
+// release with COMMENT ON COLUMN.
+var runtimes = []Version{{}, {13, 3}, {14, 3}, {15, 4}, {16, 1}, {17, 0}}
+
+func TestParseVersion(t *testing.T) {
+	for input, expected := range map[string]Version{
+		"15.4":             {15, 4},
+		"15.4.x-scala2.12": {15, 4},
+		"16.1 LTS":         {16, 1},
+		" 13.3 ":           {13, 3},
+	} {
+		v, err := ParseVersion(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+	for _, input := range []string{"", "15", "x.4", "15.x", "0.1"} {
+		_, err := ParseVersion(input)
+		assert.Error(t, err, input)
+	}
+	assert.Equal(t, "unknown", Version{}.String())
+	assert.Equal(t, "15.4", Version{15, 4}.String())
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "`main`.`sales`.`orders`", Name{"main", "sales", "orders"}.String())
+	assert.Equal(t, "`sales`.`orders`", Name{Schema: "sales", Table: "orders"}.String())
+	assert.Equal(t, "`my``table`", Name{Table: "my`table"}.String())
+	assert.Equal(t, `'it\'s a \\ test'`, StringLiteral(`it's a \ test`))
+}
+
+func TestSchemaStatements(t *testing.T) {
+	for _, runtime := range runtimes {
+		d := Dialect{Runtime: runtime}
+		t.Run(runtime.String(), func(t *testing.T) {
+			assert.Equal(t, "CREATE SCHEMA IF NOT EXISTS `main`.`dbt_test`", d.CreateSchema("main", "dbt_test"))
+			assert.Equal(t, "CREATE SCHEMA IF NOT EXISTS `dbt_test`", d.CreateSchema("", "dbt_test"))
+			assert.Equal(t, "DROP SCHEMA IF EXISTS `main`.`dbt_test` CASCADE", d.DropSchema("main", "dbt_test"))
+			assert.Equal(t, "COMMENT ON SCHEMA `main`.`dbt_test` IS 'Scratch'", d.SchemaComment("main", "dbt_test", "Scratch"))
+			assert.Equal(t, "COMMENT ON SCHEMA `main`.`dbt_test` IS NULL", d.SchemaComment("main", "dbt_test", ""))
+		})
+	}
+}
+
+func TestTableStatements(t *testing.T) {
+	orders := Name{"main", "sales", "orders"}
+	staging := Name{"main", "sales", "orders__new"}
+	tmp := Name{"main", "sales", "orders__old"}
+	for _, runtime := range runtimes {
+		d := Dialect{Runtime: runtime}
+		t.Run(runtime.String(), func(t *testing.T) {
+			assert.Equal(t, "DROP TABLE IF EXISTS `main`.`sales`.`orders`", d.DropTable(orders))
+			assert.Equal(t, "DROP VIEW IF EXISTS `main`.`sales`.`orders`", d.DropView(orders))
+			assert.Equal(t, "ALTER TABLE `main`.`sales`.`orders__new` RENAME TO `main`.`sales`.`orders`", d.RenameTable(staging, orders))
+			assert.Equal(t, "ALTER VIEW `main`.`sales`.`orders__new` RENAME TO `main`.`sales`.`orders`", d.RenameView(staging, orders))
+			assert.Equal(t, []string{
+				"ALTER TABLE `main`.`sales`.`orders` RENAME TO `main`.`sales`.`orders__old`",
+				"ALTER TABLE `main`.`sales`.`orders__new` RENAME TO `main`.`sales`.`orders`",
+				"ALTER TABLE `main`.`sales`.`orders__old` RENAME TO `main`.`sales`.`orders__new`",
+			}, d.SwapTables(orders, staging, tmp))
+			assert.Equal(t, "COMMENT ON TABLE `main`.`sales`.`orders` IS 'One row per order'", d.TableComment(orders, "One row per order"))
+			assert.Equal(t, "COMMENT ON TABLE `main`.`sales`.`orders` IS NULL", d.TableComment(orders, ""))
+		})
+	}
+}
+
+func TestColumnComment(t *testing.T) {
+	orders := Name{"main", "sales", "orders"}
+	for _, runtime := range runtimes {
+		d := Dialect{Runtime: runtime}
+		t.Run(runtime.String(), func(t *testing.T) {
+			if runtime.AtLeast(16, 1) {
+				assert.Equal(t, "COMMENT ON COLUMN `main`.`sales`.`orders`.`id` IS 'Order ID'", d.ColumnComment(orders, "id", "Order ID"))
+				assert.Equal(t, "COMMENT ON COLUMN `main`.`sales`.`orders`.`id` IS NULL", d.ColumnComment(orders, "id", ""))
+			} else {
+				assert.Equal(t, "ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `id` COMMENT 'Order ID'", d.ColumnComment(orders, "id", "Order ID"))
+				assert.Equal(t, "ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `id` COMMENT ''", d.ColumnComment(orders, "id", ""))
+			}
+		})
+	}
+}
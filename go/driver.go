@@ -36,6 +36,7 @@ package databricks
 
 import (
 	"context"
+	"time"
 
 	"github.com/adbc-drivers/driverbase-go/driverbase"
 	"github.com/apache/arrow-adbc/go/adbc"
@@ -52,24 +53,874 @@ const (
 	OptionCatalog        = "databricks.catalog"
 	OptionSchema         = "databricks.schema"
 
+	// OptionConnectLazy defers opening the server-side session until the
+	// connection is first used, so Open itself makes no round trips.
+	OptionConnectLazy = "databricks.connect.lazy"
+	// OptionConnectValidate runs a cheap query when the connection is
+	// opened so that bad credentials or an unreachable warehouse fail
+	// Open rather than the first statement. It takes precedence over
+	// OptionConnectLazy.
+	OptionConnectValidate = "databricks.connect.validate"
+	// OptionCapabilitiesProbe checks which optional server features
+	// (native query parameters, the VARIANT type) are available when a
+	// session is opened, at the cost of two small queries. Missing features
+	// are logged as warnings and, where possible, worked around. The result
+	// is reported by OptionCapabilities.
+	OptionCapabilitiesProbe = "databricks.capabilities.probe"
+	// OptionCapabilities is a read-only connection option holding the
+	// session's capabilities as a JSON object with the fields probed,
+	// native_parameters and variant. Before the session is opened, or when
+	// probing is disabled, every feature is reported as supported.
+	OptionCapabilities = "databricks.capabilities"
+	// OptionComputeType is a read-only database option reporting what
+	// OptionHTTPPath points at: OptionValueComputeTypeWarehouse,
+	// OptionValueComputeTypeCluster (an all-purpose cluster), or "" if the
+	// path has neither form.
+	OptionComputeType = "databricks.compute_type"
+	// OptionClusterStartTimeout sets how long opening a session on an
+	// all-purpose cluster keeps retrying while the cluster auto-starts, as
+	// a Go duration or a number of seconds. Zero fails at once. It has no
+	// effect on SQL warehouses.
+	OptionClusterStartTimeout = "databricks.cluster.start_timeout"
+
+	// OptionProfile tunes the fetch size, query timeout, retry budget and
+	// download parallelism for a kind of workload: one of
+	// OptionValueProfileETL, OptionValueProfileInteractive or
+	// OptionValueProfileBI. Options set explicitly take precedence over the
+	// profile's, whichever is set first.
+	OptionProfile = "databricks.profile"
+
 	// Query options
-	OptionQueryTimeout        = "databricks.query.timeout"
+	OptionQueryTimeout = "databricks.query.timeout"
+	// Deprecated: despite its name this sets the number of rows fetched per
+	// request rather than a row limit. Use OptionFetchMaxRowsPerRequest,
+	// which unlike this key rejects values outside 1 to
+	// MaxFetchRowsPerRequest.
 	OptionMaxRows             = "databricks.query.max_rows"
 	OptionQueryRetryCount     = "databricks.query.retry_count"
 	OptionDownloadThreadCount = "databricks.download_thread_count"
+	// OptionCloudFetchMaxParallel sets how many CloudFetch result files
+	// an Arrow result downloads at once, ahead of the one being read, and
+	// so how many it holds in memory. It is passed to databricks-sql-go,
+	// which makes the downloads, as its download thread count, and is
+	// another name for OptionDownloadThreadCount: whichever is set last
+	// applies.
+	OptionCloudFetchMaxParallel = "databricks.cloudfetch.max_parallel"
+
+	// Fetch options
+	//
+	// OptionFetchMaxRowsPerRequest sets the maximum number of rows returned by
+	// each Thrift FetchResults call (and with the direct results of the
+	// initial ExecuteStatement). Larger values mean fewer round trips for
+	// narrow rows; smaller values bound memory for wide rows.
+	OptionFetchMaxRowsPerRequest = "databricks.fetch.max_rows_per_request"
+	// OptionFetchConcatResult is a statement option that makes ExecuteQuery
+	// read the whole result and return it as a single record batch. Meant
+	// for small results; see OptionFetchConcatResultMaxBytes.
+	OptionFetchConcatResult = "databricks.fetch.concat_result"
+	// OptionFetchConcatResultMaxBytes caps the size of a result read with
+	// OptionFetchConcatResult. Larger results fail with StatusInvalidState
+	// instead of exhausting memory. Zero removes the cap.
+	OptionFetchConcatResultMaxBytes = "databricks.fetch.concat_result.max_bytes"
+	// OptionFetchLargeTypes is a statement option controlling when String
+	// and Binary result columns are returned as LargeString and LargeBinary
+	// (64-bit offsets). One of OptionValueLargeTypesAuto (the default),
+	// OptionValueLargeTypesAlways or OptionValueLargeTypesNever. Results are
+	// always decoded to native byte order.
+	OptionFetchLargeTypes = "databricks.fetch.large_types"
+	// OptionFetchDecimals is a statement option controlling how DECIMAL
+	// columns are returned. OptionValueDecimalsString (the default) keeps
+	// the strings the server sends; OptionValueDecimalsNative converts
+	// them to Decimal128 with the column's precision and scale.
+	OptionFetchDecimals = "databricks.fetch.decimals"
+	// OptionFetchDecimalMaxPrecision is the largest DECIMAL precision the
+	// consumer accepts with OptionValueDecimalsNative, from 1 to 38 (the
+	// default). Wider columns are handled as OptionFetchDecimalOverflow
+	// says.
+	OptionFetchDecimalMaxPrecision = "databricks.fetch.decimal.max_precision"
+	// OptionFetchDecimalOverflow is what OptionValueDecimalsNative does with
+	// DECIMAL columns wider than OptionFetchDecimalMaxPrecision, which are
+	// converted with the maximum precision: with
+	// OptionValueDecimalOverflowError (the default) a value that doesn't
+	// fit fails the fetch with StatusInvalidData, naming the column; with
+	// OptionValueDecimalOverflowNull it becomes null; with
+	// OptionValueDecimalOverflowString the column is left as strings.
+	OptionFetchDecimalOverflow = "databricks.fetch.decimal.overflow"
+	// OptionFetchMemoryLimit is a statement option that cancels a fetch
+	// with StatusCancelled once the Arrow memory held by the statement's
+	// results (batches not yet released by the caller, across all of its
+	// executions) exceeds this many bytes. Zero, the default, disables the
+	// limit.
+	OptionFetchMemoryLimit = "databricks.fetch.memory_limit"
+	// OptionFetchPrefetchMaxBytes is a statement option that decodes the
+	// record batches of Arrow results read over Thrift in the background,
+	// ahead of the caller, holding up to this many bytes of them, so that a
+	// caller slow to ask for the next batch doesn't then wait on the
+	// network. A batch larger than the budget is still decoded once the
+	// previous ones are taken. Zero, the default, decodes each batch when
+	// it is asked for.
+	OptionFetchPrefetchMaxBytes = "databricks.fetch.prefetch.max_bytes"
+	// OptionFetchPrefetchDrainPercent is a statement option setting how
+	// much of OptionFetchPrefetchMaxBytes, in percent, the caller must take
+	// once the prefetched batches fill it before more are decoded and
+	// downloaded. Zero, the default, decodes the next batch as soon as it
+	// fits; 100 waits until every prefetched batch has been taken.
+	OptionFetchPrefetchDrainPercent = "databricks.fetch.prefetch.drain_percent"
+	// OptionFetchRejectOverBytes is a statement option failing queries
+	// whose result is larger than this many bytes with StatusInvalidState,
+	// before it is downloaded where the server reports its size. Zero, the
+	// default, disables the check.
+	OptionFetchRejectOverBytes = "databricks.fetch.reject_over_bytes"
+	// OptionFetchLane is a statement option choosing the storage
+	// connections results read through the Statement Execution API are
+	// downloaded through: OptionValueFetchLaneInteractive,
+	// OptionValueFetchLaneBulk or OptionValueFetchLaneAuto (the default).
+	OptionFetchLane = "databricks.fetch.lane"
+	// OptionFetchResultMode is a statement option controlling what
+	// ExecuteQuery does with result chunks that can't be downloaded.
+	// OptionValueResultModeStrict (the default) fails the fetch.
+	// OptionValueResultModeLenient skips them and carries on; it runs the
+	// query through the Statement Execution API, so it needs a SQL
+	// warehouse configured with OptionServerHostname and OptionHTTPPath.
+	OptionFetchResultMode = "databricks.fetch.result_mode"
+	// OptionFetchSkippedRows is a read-only statement option listing the
+	// rows left out of the last ExecuteQuery result in lenient mode, as a
+	// JSON array of objects with the fields chunk_index, row_offset,
+	// row_count and error. It grows as the result is read. Offsets and
+	// counts are -1 if the server didn't report the chunk's rows.
+	OptionFetchSkippedRows = "databricks.fetch.skipped_rows"
+	// OptionFetchSchemaDrift is a statement option controlling what
+	// ExecuteQuery does with a result batch whose schema differs from the
+	// one the result reported, e.g. because a chunk has an extra field.
+	// OptionValueSchemaDriftFail (the default) fails the fetch with a
+	// description of the difference. OptionValueSchemaDriftUnify returns
+	// the batch with the reported schema instead.
+	OptionFetchSchemaDrift = "databricks.fetch.schema_drift"
+	// OptionFetchProtocols is a statement option listing, in order of
+	// preference, the paths ExecuteQuery may read results through, as a
+	// comma-separated list of OptionValueProtocolArrow,
+	// OptionValueProtocolREST and OptionValueProtocolRows. A path that is
+	// unavailable (REST without a SQL warehouse, Arrow for a result the
+	// server sent in columnar form) is skipped for the next one; the query
+	// itself runs at most once, so REST is only tried before the Thrift
+	// paths. If no path is available, ExecuteQuery fails with the reasons.
+	OptionFetchProtocols = "databricks.fetch.protocols"
+	// OptionFetchResultProtocol is a read-only statement option naming the
+	// path the last ExecuteQuery result was read through.
+	OptionFetchResultProtocol = "databricks.fetch.result_protocol"
+	// OptionFetchBatchMetadata is a statement option that annotates each
+	// batch returned by ExecuteQuery with where it came from and what it
+	// cost, in the metadata of the batch's schema under the BatchMetadata*
+	// keys, for profiling slow results. Off by default; it has no effect
+	// with OptionFetchConcatResult.
+	OptionFetchBatchMetadata = "databricks.fetch.batch_metadata"
+	// OptionFetchNormalizeCommands is a statement option that gives the
+	// results of SHOW, DESCRIBE and EXPLAIN the same shape whatever the
+	// runtime version: columns get stable snake_case names (e.g. the
+	// database, namespace or databaseName column of SHOW TABLES and SHOW
+	// SCHEMAS is always schema_name) and flags such as is_temporary are
+	// Boolean rather than strings. Off by default.
+	OptionFetchNormalizeCommands = "databricks.fetch.normalize_commands"
+	// OptionFetchTraceFile is a statement option naming a file to which
+	// each ExecuteQuery appends a manifest of how its result was fetched,
+	// as a line of JSON written once the result is released: the query ID,
+	// protocol and row count, and per chunk its index, a hash of its
+	// download link, bytes, rows, batches, download and decode times and
+	// retries. For offline analysis of slow fetches; empty (the default)
+	// disables it.
+	OptionFetchTraceFile = "databricks.fetch.trace_file"
+
+	// Temporary file options
+	//
+	// OptionTempDir is the directory under which features that spill to
+	// disk (large results, ingest staging, result caching) keep their
+	// files, in a subdirectory only the current user can read. It defaults
+	// to the system temporary directory. Directories left behind by
+	// processes that crashed are removed the next time a file is needed.
+	OptionTempDir = "databricks.temp.dir"
+	// OptionTempMaxBytes caps the total size of a database's temporary
+	// files. Writes past it fail. Zero removes the cap.
+	OptionTempMaxBytes = "databricks.temp.max_bytes"
+
+	// Session options
+	OptionSessionTimezone = "databricks.session.timezone"
+	// OptionSessionCollation sets the collation of the sessions' string
+	// literals and of expressions that don't name one, e.g. UTF8_LCASE or
+	// UNICODE_CI to compare and sort strings without regard to case, or a
+	// locale collation such as de_CI_AI. It is sent as a session parameter
+	// when the session is opened; runtimes without collation support fail
+	// to open it. Empty, the default, keeps the server's (UTF8_BINARY,
+	// which compares bytes). The session's collation and timezone are
+	// reported by GetInfo as InfoSessionCollation and InfoSessionTimezone.
+	OptionSessionCollation = "databricks.session.collation"
+	// OptionSessionIdleTimeout closes a connection's server-side session
+	// once it has gone unused this long, as a Go duration or a number of
+	// seconds, so that pooled connections don't hold warehouse sessions
+	// open. The next operation opens a new session with the same current
+	// catalog and schema and OptionSessionConfPrefix parameters; other
+	// session state (e.g. parameters SET by queries) is lost. A session
+	// stays open while a query runs or its result is read. Zero, the
+	// default, keeps sessions open.
+	OptionSessionIdleTimeout = "databricks.session.idle_timeout"
+	// OptionSessionConfPrefix followed by the name of a session
+	// configuration parameter, e.g. databricks.session.conf.ansi_mode, sets
+	// that parameter with SET whenever a session is opened, including when
+	// a connection reopens its session. As a database option it applies to
+	// the connections opened afterwards; as a connection option it also
+	// applies to the connection's open session. An empty value drops the
+	// parameter (a connection RESETs it). Values are passed verbatim and
+	// can't contain semicolons or line breaks.
+	OptionSessionConfPrefix = "databricks.session.conf."
+
+	// Workspace routing options
+	//
+	// OptionWorkspaces defines other workspaces the connections of one
+	// database may be routed to (e.g. one per region or tenant), as a JSON
+	// object mapping names to objects with the fields server_hostname,
+	// http_path and optionally port. They share the database's credentials
+	// (access token or OAuth client) and all of its other options; each
+	// gets its own connection pool. OptionWorkspace names the workspace
+	// Open connects to, and WithWorkspace overrides it for one Open. Both
+	// default to the workspace of OptionServerHostname and OptionHTTPPath.
+	OptionWorkspaces = "databricks.workspaces"
+	OptionWorkspace  = "databricks.workspace"
+
+	// Lineage options
+	//
+	// OptionLineageTool names the tool using the driver (e.g. "dbt"), and
+	// OptionLineageRunID identifies the tool's run. When set, they are
+	// attached to the queries of every statement as the query tags
+	// adbc_tool and adbc_run_id, alongside the statement's OptionQueryTags
+	// (which take precedence). Lineage recorded for the tables those
+	// queries read and write can then be traced back to the tool's run
+	// through system.query.history.
+	OptionLineageTool  = "databricks.lineage.tool"
+	OptionLineageRunID = "databricks.lineage.run_id"
+
+	// Jobs options
+	//
+	// OptionJobsHandoffAfter hands queries that run longer than this off
+	// to a one-time Databricks job run, so that they aren't cut short by
+	// gateway timeouts on long requests. The query runs through the
+	// Statement Execution API (so it needs a SQL warehouse configured with
+	// OptionServerHostname and OptionHTTPPath); if it hasn't finished in
+	// time it is cancelled and run again as the SQL task of a job run,
+	// writing its result to a table in OptionJobsOutputSchema, which
+	// ExecuteQuery then reads and drops. Zero, the default, disables the
+	// hand-off. The task's saved query and the result table are removed
+	// once the result has been read.
+	OptionJobsHandoffAfter = "databricks.jobs.handoff_after"
+	// OptionJobsOutputSchema is the schema, as catalog.schema, where job
+	// runs write their results. It is required with OptionJobsHandoffAfter.
+	OptionJobsOutputSchema = "databricks.jobs.output_schema"
+
+	// Warehouse selection options
+	//
+	// OptionWarehousePolicy picks a SQL warehouse from the workspace when
+	// OptionHTTPPath is not set. With "smallest_running" it picks the
+	// smallest warehouse, preferring running ones over starting or stopped
+	// ones; with "tagged" it picks the same way among the warehouses
+	// carrying every tag in OptionWarehouseTags. The pick is kept until the
+	// connection settings change. Unset, the default, requires an HTTP path.
+	OptionWarehousePolicy                     = "databricks.warehouse.policy"
+	OptionValueWarehousePolicySmallestRunning = "smallest_running"
+	OptionValueWarehousePolicyTagged          = "tagged"
+	// OptionWarehouseTags is a comma-separated list of key=value custom
+	// tags a warehouse must carry to be picked. It is required with the
+	// "tagged" policy and narrows "smallest_running" otherwise.
+	OptionWarehouseTags = "databricks.warehouse.tags"
+
+	// Parameter binding options
+	OptionTimestampBindMode = "databricks.bind.timestamp_mode"
+
+	// Metadata options
+	//
+	// OptionSchemaCacheEnabled caches the result of GetTableSchema for Delta
+	// tables, keyed by table name and Delta version.
+	OptionSchemaCacheEnabled = "databricks.schema_cache.enabled"
+	// OptionSchemaCacheTTL is how long a cached schema is returned without
+	// checking whether the table's version has changed. The default of zero
+	// checks the version on every call. Changes apply to open connections
+	// too.
+	OptionSchemaCacheTTL = "databricks.schema_cache.ttl"
+	// OptionNamespaceCacheEnabled caches the current catalog and schema
+	// once GetCurrentCatalog or GetCurrentDbSchema has looked them up. USE
+	// and SET statements executed through the driver clear the cache.
+	// Disable it to look them up on every call, e.g. when the namespace is
+	// switched behind the driver's back.
+	OptionNamespaceCacheEnabled = "databricks.namespace_cache.enabled"
+	// OptionInformationSchema selects where column metadata (GetObjects at
+	// column depth, ingest schema checks) is read from.
+	// OptionValueInformationSchemaCatalog (the default) reads each Unity
+	// Catalog catalog's own information_schema.
+	// OptionValueInformationSchemaSystem reads system.information_schema,
+	// filtered by catalog, for users who can't read the catalogs' own.
+	// Hive Metastore and the system catalog always use the latter.
+	OptionInformationSchema = "databricks.metadata.information_schema"
+	// OptionMetadataFilters selects how GetObjects treats an empty catalog
+	// or schema filter. With OptionValueMetadataFiltersADBC (the default),
+	// as the ADBC spec says, NULL matches everything and an empty string
+	// only objects without a catalog (or schema), of which Databricks has
+	// none. OptionValueMetadataFiltersJDBC treats an empty string like
+	// NULL, for tools written against JDBC drivers that do.
+	OptionMetadataFilters = "databricks.metadata.filters"
+	// OptionMetadataFilterMatch selects how GetObjects matches names
+	// against its filters. With OptionValueFilterMatchPattern (the
+	// default) filters are LIKE patterns, where % and _ are wildcards
+	// unless escaped with a backslash (see InfoSearchStringEscape).
+	// OptionValueFilterMatchLiteral matches names equal to the filter, for
+	// tools that pass names verbatim. Matching ignores case either way.
+	OptionMetadataFilterMatch = "databricks.metadata.filter_match"
+	// OptionMetadataRetryCount is how many times metadata queries
+	// (GetObjects, GetInfo) are retried after failing with a transient
+	// error, such as a 502 or 503 from the gateway while a warehouse
+	// scales, waiting a jittered, growing backoff in between. They are
+	// idempotent, so the budget is higher than OptionQueryRetryCount's.
+	// Zero disables these retries. Changes apply to open connections too.
+	OptionMetadataRetryCount = "databricks.metadata.retry_count"
+
+	// Read options
+	//
+	// OptionReadSnapshot is a connection option that makes the
+	// connection's queries read a consistent snapshot of the Delta tables
+	// they use, as a transaction would. The first query reading a table
+	// pins its current version, and that query and all later ones read the
+	// table as of that version (VERSION AS OF). Only queries (SELECT and
+	// WITH) are rewritten; writes go to the latest version. Views and
+	// tables without Delta versions are read as they are, and a query
+	// fails if a table's version can't be read. Setting the option to true
+	// again starts a new snapshot; false, the default, reads the latest
+	// versions.
+	OptionReadSnapshot = "databricks.read.snapshot"
+	// OptionSampleFraction is a statement option that makes its queries
+	// return a random sample of about this fraction of their rows (with
+	// TABLESAMPLE), for tools that only need representative data. Zero,
+	// the default, returns every row.
+	OptionSampleFraction = "databricks.sample.fraction"
+	// OptionSampleRows is a statement option that makes its queries return
+	// at most this many rows (with LIMIT), after OptionSampleFraction if
+	// both are set. Zero, the default, disables the limit.
+	OptionSampleRows = "databricks.sample.rows"
+	// OptionSampleSeed is a statement option seeding OptionSampleFraction,
+	// so that the same data gives the same sample (REPEATABLE). Unset, the
+	// default, samples differently every time. Only queries (SELECT and
+	// WITH) are sampled.
+	OptionSampleSeed = "databricks.sample.seed"
+	// OptionExtractTable is a statement option naming the table its
+	// queries extract from, optionally qualified with its schema and
+	// catalog, for OptionExtractWindowStart and OptionExtractWindowEnd.
+	OptionExtractTable = "databricks.extract.table"
+	// OptionExtractWindowStart and OptionExtractWindowEnd are statement
+	// options declaring the time window [start, end) of an extract, as
+	// dates (2006-01-02) or RFC 3339 timestamps; either may be left unset.
+	// Queries (SELECT and WITH) are then filtered to the rows of the
+	// window on a date or timestamp partition or clustering column of
+	// OptionExtractTable, found from the table's metadata, so that the
+	// server can skip the files outside the window instead of scanning
+	// the whole table. The query must return that column. On a DATE
+	// column, a window bounded by times covers whole days.
+	OptionExtractWindowStart = "databricks.extract.window.start"
+	OptionExtractWindowEnd   = "databricks.extract.window.end"
+	// OptionExtractWindowColumn is a statement option naming the column
+	// the extract window filters on, instead of the one found from the
+	// table's partitioning and clustering.
+	OptionExtractWindowColumn = "databricks.extract.window.column"
+	// OptionExtractPredicate is a read-only statement option holding the
+	// predicate the extract window added to the last query, if any.
+	OptionExtractPredicate = "databricks.extract.predicate"
+
+	// Statement options
+	//
+	// OptionValidateOnly makes ExecuteQuery and ExecuteUpdate compile the
+	// query with EXPLAIN instead of running it, so no warehouse compute is
+	// used. Compile errors are returned as StatusInvalidArgument.
+	// ExecuteQuery returns an empty result with the schema the query would
+	// produce (empty for statements without a result set); ExecuteUpdate
+	// returns -1. Bulk ingestion is not affected.
+	OptionValidateOnly = "databricks.statement.validate_only"
+	// OptionExecutionAsync makes ExecuteQuery submit the query through the
+	// Statement Execution API and return at once with an empty result,
+	// instead of waiting for the query's result; see AsyncExecutor. It
+	// needs a SQL warehouse configured with OptionServerHostname and
+	// OptionHTTPPath, and can't be used with bound parameters.
+	OptionExecutionAsync = "databricks.execution.async"
+	// OptionStatementQueryTimeout is how many seconds the statement's
+	// queries may run, zero (the default) for no limit. It is set as the
+	// session's STATEMENT_TIMEOUT, so that the server stops the query, and
+	// also bounds ExecuteQuery, ExecuteUpdate and ExecutePartitions on the
+	// client, which covers queries run outside the session (e.g. through
+	// the Statement Execution API) and cancels them on the server. Reading
+	// the result isn't bounded. A query that runs past it fails with
+	// StatusTimeout, with its ID in ErrorDetailQueryID.
+	OptionStatementQueryTimeout = "databricks.statement.query_timeout_seconds"
+	// OptionStatementMemoryInUse is a read-only statement option holding the
+	// number of bytes of Arrow memory currently allocated for the
+	// statement's results.
+	OptionStatementMemoryInUse = "databricks.statement.memory_in_use"
+	// OptionUpdateMetrics is a read-only statement option holding the counts
+	// reported by the last COPY INTO or MERGE run with ExecuteUpdate, as a
+	// JSON object keyed by the server's column names (num_affected_rows,
+	// num_inserted_rows, num_updated_rows, num_deleted_rows,
+	// num_skipped_corrupt_files, ...). It is "{}" after any other update.
+	OptionUpdateMetrics = "databricks.statement.update_metrics"
+	// OptionStatementWarnings is a read-only statement option listing the
+	// conditions that didn't fail the last execution but changed what it
+	// returned, such as a result protocol fallback, a truncated result or
+	// a DECIMAL column returned with less precision: a JSON array of
+	// objects with the fields code (one of the Warning constants) and
+	// message. It grows as the result is read.
+	OptionStatementWarnings = "databricks.statement.warnings"
+	// OptionStatementQueryID is a read-only statement option holding the ID
+	// the server assigned to the statement's last query, or "" if none is
+	// known yet. On a SQL warehouse, the result of a finished query can be
+	// read again by this ID (see ResultAttacher).
+	OptionStatementQueryID = "databricks.statement.query_id"
+	// OptionQueryTags is a statement option attaching tags, given as
+	// "key1=value1,key2=value2", to the statement's queries. They appear in
+	// the query_tags column of system.query.history, for cost attribution.
+	// The tags are set on the connection's session (SET QUERY_TAGS) before
+	// the statement runs and replaced when a statement with other tags
+	// runs. Queries run through the Statement Execution API (partitioned
+	// or lenient results) are not tagged.
+	OptionQueryTags = "databricks.statement.query_tags"
+	// OptionInListStageThreshold is a statement option staging IN lists of
+	// more than this many positional parameters, as in "id IN (?, ?, ...)",
+	// in a temporary table of the session that the query reads instead,
+	// which keeps long lists out of the query text. Zero, the default,
+	// never stages them.
+	OptionInListStageThreshold = "databricks.statement.in_list.stage_threshold"
+
+	// Bulk ingest options
+	//
+	// OptionIngestStagingLocation is a statement option making bulk
+	// ingestion (the adbc.ingest.* options) load the bound data with COPY
+	// INTO rather than one INSERT per row: the data is written to Parquet
+	// files, uploaded to the location, copied into the table and deleted.
+	// The location is a Unity Catalog volume directory
+	// (/Volumes/<catalog>/<schema>/<volume>[/<dir>]) or a DBFS directory
+	// (dbfs:/<dir>) the user can write to, and needs OptionServerHostname.
+	// Empty, the default, keeps the INSERTs. IngestStream commits are not
+	// affected.
+	OptionIngestStagingLocation = "databricks.ingest.staging_location"
+	// OptionIngestUploadFileSize is a statement option setting the size in
+	// bytes after which data staged through OptionIngestStagingLocation
+	// continues in a new Parquet file, so that files are uploaded while
+	// the rest of the data is still being written. Zero stages a single
+	// file.
+	OptionIngestUploadFileSize = "databricks.ingest.upload.file_size"
+	// OptionIngestUploadMaxParallel is a statement option setting how many
+	// staged files are uploaded at once. Each file waiting for its upload
+	// stays on disk, so this also bounds the temporary space ingestion
+	// takes, to about this many files more than one.
+	OptionIngestUploadMaxParallel = "databricks.ingest.upload.max_parallel"
+	// OptionIngestManifest is a statement option naming a local file in
+	// which bulk ingestion through OptionIngestStagingLocation records its
+	// progress: the target, the mode and the files staged and uploaded. An
+	// ingestion that is interrupted, e.g. by a crash, leaves the file and
+	// its staged files behind; binding the same data again with the same
+	// options resumes it, uploading only the files that are missing, and
+	// loads each file exactly once. The file is deleted once the data is
+	// loaded. Not supported with OptionValueIngestModeUpsert.
+	OptionIngestManifest = "databricks.ingest.manifest"
+	// OptionIngestValidationRules is a statement option holding rules that
+	// bulk ingestion checks each bound row against before the row is sent,
+	// as a JSON array of objects with the fields column and any of
+	// not_null (true), min and max (numbers, for numeric columns) and
+	// pattern (a Go regular expression, for string columns, matched
+	// anywhere in the value unless anchored). Only not_null applies to
+	// nulls. Rows that break a rule are left out and listed in
+	// OptionIngestRejectedRows.
+	OptionIngestValidationRules = "databricks.ingest.validation.rules"
+	// OptionIngestValidationMaxRejected is a statement option setting how
+	// many rows OptionIngestValidationRules may reject before the
+	// ingestion fails; 0, the default, fails on the first. Staged
+	// ingestion fails before loading anything; row-by-row INSERTs have
+	// inserted the rows before the failing one.
+	OptionIngestValidationMaxRejected = "databricks.ingest.validation.max_rejected"
+	// OptionIngestRejectedRows is a read-only statement option listing the
+	// rows the last bulk ingestion rejected, as a JSON array of objects
+	// with the fields row (the row's index in the bound data), column,
+	// rule (not_null, min, max or pattern) and value.
+	OptionIngestRejectedRows = "databricks.ingest.rejected_rows"
+	// OptionIngestMergeKeys is a statement option naming the key columns,
+	// comma-separated, on which bulk ingestion in
+	// OptionValueIngestModeUpsert matches bound rows to rows of the table.
+	OptionIngestMergeKeys = "databricks.ingest.merge_keys"
+
+	// Streaming ingest options
+	//
+	// OptionIngestStreamMaxRows is a statement option setting how many rows
+	// an IngestStream buffers before committing them to the table. Zero
+	// disables the row threshold.
+	OptionIngestStreamMaxRows = "databricks.ingest.stream.max_rows"
+	// OptionIngestStreamMaxBytes is a statement option setting how many
+	// bytes of Arrow data an IngestStream buffers before committing them.
+	// Zero disables the byte threshold.
+	OptionIngestStreamMaxBytes = "databricks.ingest.stream.max_bytes"
+	// OptionIngestStreamInterval is a statement option setting how long
+	// appended rows may wait in an IngestStream before they are committed,
+	// as a Go duration or a number of seconds. Zero commits only when a
+	// size threshold is reached or the stream is flushed.
+	OptionIngestStreamInterval = "databricks.ingest.stream.interval"
+
+	// Diagnostics options
+	//
+	// OptionErrorHistorySize sets how many recent errors each connection
+	// remembers. Zero disables the history.
+	OptionErrorHistorySize = "databricks.error_history.size"
+	// OptionErrorHistory is a read-only connection option holding the
+	// connection's recent errors as a JSON array, oldest first. Each entry
+	// has the fields time, operation, query_id (when known), status and
+	// message.
+	OptionErrorHistory = "databricks.error_history"
+	// OptionSelfTest is a read-only connection option that, when read,
+	// runs a series of checks against the workspace and returns a JSON
+	// report for validating a deployment: an object with the fields passed
+	// and checks, each check having the fields name, status (passed,
+	// failed or skipped), duration_ms and detail. The checks are auth,
+	// query, arrow_fetch, metadata, cloudfetch (downloading a result from
+	// cloud storage, on SQL warehouses) and write (creating, filling and
+	// dropping a table in OptionSelfTestSchema, if set). Failed checks are
+	// reported rather than returned as errors.
+	OptionSelfTest = "databricks.selftest"
+	// OptionSelfTestSchema is the schema, as catalog.schema, where
+	// OptionSelfTest checks that tables can be written.
+	OptionSelfTestSchema = "databricks.selftest.schema"
+	// OptionErrorMaxMessageLength caps the length in bytes of the messages
+	// of errors returned by connections and statements, which the server
+	// can fill with megabytes of query plan. Longer messages keep their
+	// start and end with a marker in between saying how much was cut, and
+	// still name the error class and query ID. Zero disables truncation.
+	// Changes apply to open connections too.
+	OptionErrorMaxMessageLength = "databricks.error.max_message_length"
+	// OptionDebugHTTP logs the method, path, status, duration and request
+	// ID headers of every request to the SQL endpoint to the database's
+	// logger at INFO level. Bodies, query strings and credentials are never
+	// logged. CloudFetch downloads from cloud storage are not included.
+	OptionDebugHTTP = "databricks.debug.http"
+	// OptionAffinityCookies replays cookies set by the SQL endpoint on
+	// later requests, so that load balancers and gateways that pin clients
+	// with a cookie keep the database's sessions on one backend.
+	OptionAffinityCookies = "databricks.affinity.cookies"
+	// OptionAffinityHeaders is a comma-separated list of response headers
+	// whose values are sent back on later requests to the SQL endpoint,
+	// for gateways that pin clients with a header instead of a cookie.
+	OptionAffinityHeaders = "databricks.affinity.headers"
 
 	// TLS/SSL options
 	OptionSSLMode     = "databricks.ssl_mode"
 	OptionSSLRootCert = "databricks.ssl_root_cert"
 
-	// OAuth options (for future expansion)
+	// OAuth options
+	//
+	// OptionOAuthClientID and OptionOAuthClientSecret authenticate as a
+	// service principal with OAuth machine-to-machine (client credentials)
+	// authentication, instead of OptionAccessToken. The driver obtains
+	// access tokens from the workspace's token endpoint and requests a new
+	// one shortly before the current one expires; every request, including
+	// the polls of a long-running statement and result downloads through
+	// the Statement Execution API, carries a current token. The token is
+	// shared by the database's connections to a workspace.
 	OptionOAuthClientID     = "databricks.oauth.client_id"
 	OptionOAuthClientSecret = "databricks.oauth.client_secret"
+	// OptionOAuthRefreshToken is a refresh token to start OptionOAuthU2M
+	// with, instead of one from the token cache or signing in.
 	OptionOAuthRefreshToken = "databricks.oauth.refresh_token"
+	// OptionOAuthU2M authenticates as a user who signs in through the
+	// browser (OAuth user-to-machine, with the authorization code flow and
+	// PKCE), for desktop applications that shouldn't need a personal
+	// access token. When a token is first needed the driver opens the
+	// workspace's sign-in page in the default browser (and logs its URL)
+	// and waits for the redirect on a local listener. The refresh token is
+	// kept in OptionOAuthU2MTokenCache, so later connections sign in
+	// without the browser until it expires. The client is the
+	// "databricks-cli" public client unless OptionOAuthClientID names
+	// another OAuth app, whose redirect URL must be
+	// http://localhost:<OptionOAuthU2MRedirectPort>.
+	OptionOAuthU2M = "databricks.oauth.u2m"
+	// OptionOAuthU2MRedirectPort is the port of the local listener the
+	// browser is redirected to after signing in.
+	OptionOAuthU2MRedirectPort = "databricks.oauth.u2m.redirect_port"
+	// OptionOAuthU2MTokenCache is the file where OptionOAuthU2M keeps
+	// refresh tokens, by workspace and client, readable only by the user.
+	// Empty, the default, uses oauth-tokens.json in an adbc-drivers
+	// directory under the user's cache directory;
+	// OptionValueTokenCacheNone keeps them in memory only.
+	OptionOAuthU2MTokenCache  = "databricks.oauth.u2m.token_cache"
+	OptionValueTokenCacheNone = "none"
+
+	// Workload identity federation options
+	//
+	// OptionOAuthFederationTokenSource enables workload identity
+	// federation: the driver exchanges an OIDC token issued to its
+	// environment for a Databricks access token, so that CI jobs and
+	// Kubernetes workloads need no long-lived secret. One of
+	// OptionValueTokenSourceGitHubActions, OptionValueTokenSourceEnv or
+	// OptionValueTokenSourceFile. Set OptionOAuthClientID as well to
+	// authenticate as a service principal with a federation policy.
+	OptionOAuthFederationTokenSource = "databricks.oauth.federation.token_source"
+	// OptionOAuthFederationTokenEnv names the environment variable holding
+	// the OIDC token with OptionValueTokenSourceEnv, e.g. one set up with
+	// id_tokens in GitLab CI.
+	OptionOAuthFederationTokenEnv = "databricks.oauth.federation.token_env"
+	// OptionOAuthFederationTokenFile is the file holding the OIDC token
+	// with OptionValueTokenSourceFile, e.g. a projected Kubernetes service
+	// account token. It is read again whenever a new access token is
+	// needed.
+	OptionOAuthFederationTokenFile = "databricks.oauth.federation.token_file"
+	// OptionOAuthFederationAudience is the audience of the OIDC token
+	// requested from GitHub Actions, which must match the federation
+	// policy. GitHub's default audience is used if unset.
+	OptionOAuthFederationAudience = "databricks.oauth.federation.audience"
+
+	// Microsoft Entra ID options
+	//
+	// OptionOAuthAzureTenantID authenticates on Azure Databricks as a
+	// Microsoft Entra ID (Azure AD) service principal of the tenant, with
+	// OptionOAuthClientID and OptionOAuthClientSecret as its application
+	// (client) ID and client secret. The driver obtains tokens from
+	// Microsoft Entra ID when the session is opened and requests a new one
+	// shortly before the current one expires. The service principal must
+	// have been added to the workspace.
+	OptionOAuthAzureTenantID = "databricks.oauth.azure.tenant_id"
+	// OptionOAuthAzureLoginEndpoint is the Microsoft Entra ID authority
+	// for OptionOAuthAzureTenantID, e.g.
+	// https://login.microsoftonline.us for Azure Government.
+	OptionOAuthAzureLoginEndpoint = "databricks.oauth.azure.login_endpoint"
 
 	// Default values
 	DefaultPort    = 443
-	DefaultSSLMode = "require"
+	DefaultSSLMode = OptionValueSSLModeRequire
+	// DefaultCloudFetchMaxParallel is the default for
+	// OptionCloudFetchMaxParallel, databricks-sql-go's own.
+	DefaultCloudFetchMaxParallel = 10
+	// DefaultOAuthU2MClientID is the OAuth client OptionOAuthU2M signs in
+	// with unless OptionOAuthClientID is set.
+	DefaultOAuthU2MClientID = "databricks-cli"
+	// DefaultOAuthU2MRedirectPort is the default for
+	// OptionOAuthU2MRedirectPort.
+	DefaultOAuthU2MRedirectPort = 8020
+	// DefaultFederationTokenEnv is the default for
+	// OptionOAuthFederationTokenEnv.
+	DefaultFederationTokenEnv = "DATABRICKS_OIDC_TOKEN"
+	// DefaultFederationTokenFile is the default for
+	// OptionOAuthFederationTokenFile.
+	DefaultFederationTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// DefaultAzureLoginEndpoint is the default for
+	// OptionOAuthAzureLoginEndpoint.
+	DefaultAzureLoginEndpoint = "https://login.microsoftonline.com"
+	// DefaultErrorHistorySize is the default for OptionErrorHistorySize.
+	DefaultErrorHistorySize = 32
+	// DefaultErrorMaxMessageLength is the default for
+	// OptionErrorMaxMessageLength.
+	DefaultErrorMaxMessageLength = 64 * 1024
+	DefaultSchemaCache           = true
+	// DefaultNamespaceCache is the default for OptionNamespaceCacheEnabled.
+	DefaultNamespaceCache = true
+	// DefaultInformationSchema is the default for OptionInformationSchema.
+	DefaultInformationSchema = OptionValueInformationSchemaCatalog
+	// DefaultMetadataFilters is the default for OptionMetadataFilters.
+	DefaultMetadataFilters = OptionValueMetadataFiltersADBC
+	// DefaultMetadataFilterMatch is the default for
+	// OptionMetadataFilterMatch.
+	DefaultMetadataFilterMatch = OptionValueFilterMatchPattern
+	// DefaultMetadataRetryCount is the default for
+	// OptionMetadataRetryCount.
+	DefaultMetadataRetryCount = 6
+	// DefaultAffinityCookies is the default for OptionAffinityCookies.
+	DefaultAffinityCookies = true
+	// DefaultCapabilitiesProbe is the default for OptionCapabilitiesProbe.
+	DefaultCapabilitiesProbe = true
+	// DefaultTempMaxBytes is the default for OptionTempMaxBytes.
+	DefaultTempMaxBytes = 10 << 30
+	// DefaultClusterStartTimeout is the default for
+	// OptionClusterStartTimeout.
+	DefaultClusterStartTimeout = 20 * time.Minute
+	// DefaultConcatResultMaxBytes is the default for
+	// OptionFetchConcatResultMaxBytes.
+	DefaultConcatResultMaxBytes = 256 << 20
+	DefaultLargeTypes           = OptionValueLargeTypesAuto
+	DefaultDecimals             = OptionValueDecimalsString
+	DefaultDecimalMaxPrecision  = 38
+	DefaultDecimalOverflow      = OptionValueDecimalOverflowError
+	DefaultResultMode           = OptionValueResultModeStrict
+	DefaultFetchLane            = OptionValueFetchLaneAuto
+	DefaultSchemaDrift          = OptionValueSchemaDriftFail
+	DefaultFetchProtocols       = OptionValueProtocolArrow + "," + OptionValueProtocolRows
+	// DefaultIngestStreamMaxRows, DefaultIngestStreamMaxBytes and
+	// DefaultIngestStreamInterval are the defaults for the streaming ingest
+	// options.
+	DefaultIngestStreamMaxRows  = 10_000
+	DefaultIngestStreamMaxBytes = 16 << 20
+	DefaultIngestStreamInterval = 5 * time.Second
+	// DefaultIngestUploadFileSize and DefaultIngestUploadMaxParallel are
+	// the defaults for the staged ingest upload options.
+	DefaultIngestUploadFileSize    = 128 << 20
+	DefaultIngestUploadMaxParallel = 4
+
+	// MaxFetchRowsPerRequest is the largest value accepted for
+	// OptionFetchMaxRowsPerRequest.
+	MaxFetchRowsPerRequest = 2_000_000
+)
+
+const (
+	// OptionValueTokenSourceGitHubActions requests the OIDC token of the
+	// running GitHub Actions job.
+	OptionValueTokenSourceGitHubActions = "github_actions"
+	// OptionValueTokenSourceEnv reads the OIDC token from the environment
+	// variable named by OptionOAuthFederationTokenEnv.
+	OptionValueTokenSourceEnv = "env"
+	// OptionValueTokenSourceFile reads the OIDC token from
+	// OptionOAuthFederationTokenFile.
+	OptionValueTokenSourceFile = "file"
+
+	// OptionValueSSLModeRequire verifies the server certificate.
+	OptionValueSSLModeRequire = "require"
+	// OptionValueSSLModeInsecure skips server certificate verification.
+	OptionValueSSLModeInsecure = "insecure"
+)
+
+const (
+	// OptionValueIngestModeUpsert is a value of adbc.OptionKeyIngestMode
+	// that merges the bound rows into the table, creating it if needed:
+	// rows whose OptionIngestMergeKeys match a row of the table replace
+	// it, the others are inserted. The keys of the bound rows must be
+	// unique. The rows go through MERGE INTO, reading them from
+	// OptionIngestStagingLocation if set and from parameters otherwise.
+	OptionValueIngestModeUpsert = "databricks.ingest.mode.upsert"
+)
+
+const (
+	// OptionValueTimestampModeAuto binds timezone-aware timestamps as
+	// instants and timezone-naive timestamps as wall clock readings.
+	OptionValueTimestampModeAuto = "auto"
+	// OptionValueTimestampModeInstant binds every timestamp as an instant,
+	// treating timezone-naive values as UTC.
+	OptionValueTimestampModeInstant = "instant"
+	// OptionValueTimestampModeWallClock binds every timestamp as the wall
+	// clock reading in its own timezone, ignoring the session timezone.
+	OptionValueTimestampModeWallClock = "wall_clock"
+)
+
+const (
+	// OptionValueLargeTypesAuto up-casts a column only when its values would
+	// not fit in 32-bit offsets, which can happen when
+	// OptionFetchConcatResult joins many batches.
+	OptionValueLargeTypesAuto = "auto"
+	// OptionValueLargeTypesAlways up-casts every String and Binary column,
+	// so the result schema does not depend on the data.
+	OptionValueLargeTypesAlways = "always"
+	// OptionValueLargeTypesNever keeps the types sent by the server; results
+	// that would overflow 32-bit offsets fail instead.
+	OptionValueLargeTypesNever = "never"
+)
+
+const (
+	// OptionValueDecimalsString returns DECIMAL columns as strings.
+	OptionValueDecimalsString = "string"
+	// OptionValueDecimalsNative returns DECIMAL columns as Decimal128.
+	OptionValueDecimalsNative = "native"
+)
+
+const (
+	// OptionValueDecimalOverflowError fails the fetch on a value that
+	// doesn't fit.
+	OptionValueDecimalOverflowError = "error"
+	// OptionValueDecimalOverflowNull returns null for values that don't
+	// fit.
+	OptionValueDecimalOverflowNull = "null"
+	// OptionValueDecimalOverflowString returns columns that may not fit as
+	// strings.
+	OptionValueDecimalOverflowString = "string"
+)
+
+const (
+	// OptionValueResultModeStrict fails a fetch on the first result chunk
+	// that can't be downloaded.
+	OptionValueResultModeStrict = "strict"
+	// OptionValueResultModeLenient skips result chunks that still can't be
+	// downloaded after retrying, and reports them in OptionFetchSkippedRows.
+	OptionValueResultModeLenient = "lenient"
+)
+
+const (
+	// OptionValueFetchLaneAuto picks the lane by the result's size.
+	OptionValueFetchLaneAuto = "auto"
+	// OptionValueFetchLaneInteractive downloads results through the
+	// interactive lane.
+	OptionValueFetchLaneInteractive = "interactive"
+	// OptionValueFetchLaneBulk downloads results through the bulk lane.
+	OptionValueFetchLaneBulk = "bulk"
+)
+
+const (
+	// OptionValueSchemaDriftFail fails the fetch on the first batch whose
+	// schema differs from the result's.
+	OptionValueSchemaDriftFail = "fail"
+	// OptionValueSchemaDriftUnify matches the fields of such batches to
+	// the result's by name, filling missing fields with nulls and dropping
+	// added ones. Batches where a field's type changed still fail.
+	OptionValueSchemaDriftUnify = "unify"
+)
+
+const (
+	// OptionValueInformationSchemaCatalog reads a catalog's metadata from
+	// <catalog>.information_schema.
+	OptionValueInformationSchemaCatalog = "catalog"
+	// OptionValueInformationSchemaSystem reads it from
+	// system.information_schema.
+	OptionValueInformationSchemaSystem = "system"
+)
+
+const (
+	// OptionValueMetadataFiltersADBC gives empty filters their ADBC
+	// meaning.
+	OptionValueMetadataFiltersADBC = "adbc"
+	// OptionValueMetadataFiltersJDBC ignores empty filters.
+	OptionValueMetadataFiltersJDBC = "jdbc"
+)
+
+const (
+	// OptionValueFilterMatchPattern treats GetObjects filters as LIKE
+	// patterns.
+	OptionValueFilterMatchPattern = "pattern"
+	// OptionValueFilterMatchLiteral treats them as names.
+	OptionValueFilterMatchLiteral = "literal"
+)
+
+const (
+	// OptionValueProtocolArrow reads results as Arrow IPC streams over
+	// Thrift, with CloudFetch for large results.
+	OptionValueProtocolArrow = "arrow"
+	// OptionValueProtocolREST runs the query through the Statement
+	// Execution API and downloads its Arrow result chunks.
+	OptionValueProtocolREST = "rest"
+	// OptionValueProtocolRows reads results over Thrift row by row and
+	// converts them to Arrow. It is the slowest path, but reads any result.
+	OptionValueProtocolRows = "rows"
+)
+
+const (
+	// OptionValueProfileETL suits long-running jobs reading large results:
+	// big fetches, no query timeout, many retries and many parallel
+	// downloads.
+	OptionValueProfileETL = "etl"
+	// OptionValueProfileInteractive suits ad hoc queries with small
+	// results: small fetches, a short timeout and few retries, so that
+	// failures surface quickly.
+	OptionValueProfileInteractive = "interactive"
+	// OptionValueProfileBI suits dashboards and reports: medium fetches and
+	// a timeout long enough for heavy aggregations.
+	OptionValueProfileBI = "bi"
+)
+
+const (
+	// OptionValueComputeTypeWarehouse is a SQL warehouse.
+	OptionValueComputeTypeWarehouse = "warehouse"
+	// OptionValueComputeTypeCluster is an all-purpose (interactive)
+	// cluster. Clusters don't serve the Statement Execution API, so
+	// partitioned and lenient results are unavailable on them.
+	OptionValueComputeTypeCluster = "cluster"
 )
 
 func init() {
@@ -77,6 +928,49 @@ func init() {
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
 
+// GetInfo codes describing how Databricks treats identifiers, for SQL
+// generators that quote identifiers and escape search patterns. The codes
+// and values are those of the corresponding Flight SQL SqlInfo entries.
+const (
+	// InfoIdentifierCase is how unquoted identifiers are compared, as an
+	// int64 InfoCaseSensitivity value.
+	InfoIdentifierCase adbc.InfoCode = 503
+	// InfoIdentifierQuoteChar is the character that quotes identifiers.
+	InfoIdentifierQuoteChar adbc.InfoCode = 504
+	// InfoQuotedIdentifierCase is how quoted identifiers are compared, as an
+	// int64 InfoCaseSensitivity value.
+	InfoQuotedIdentifierCase adbc.InfoCode = 505
+	// InfoSearchStringEscape is the character that escapes _ and % in LIKE
+	// patterns.
+	InfoSearchStringEscape adbc.InfoCode = 513
+)
+
+// Driver-specific GetInfo codes describing the session. Reading them opens
+// the session if it isn't open yet.
+const (
+	// InfoSessionCollation is the collation of the session's string
+	// literals (see OptionSessionCollation), e.g. "UTF8_BINARY".
+	InfoSessionCollation adbc.InfoCode = 10000
+	// InfoSessionTimezone is the session's timezone (see
+	// OptionSessionTimezone), e.g. "Etc/UTC".
+	InfoSessionTimezone adbc.InfoCode = 10001
+)
+
+// InfoDriverFeatures is a driver-specific GetInfo code listing the
+// driver's optional features (FeatureTransactions, etc.) and whether each
+// is available on the connection, as a JSON object of booleans, so that
+// tools can check for a feature instead of trying it. Some features depend
+// on how the database is configured.
+const InfoDriverFeatures adbc.InfoCode = 10002
+
+// Values of InfoIdentifierCase and InfoQuotedIdentifierCase.
+const (
+	InfoCaseSensitivityUnknown         int64 = 0
+	InfoCaseSensitivityCaseInsensitive int64 = 1
+	InfoCaseSensitivityUppercase       int64 = 2
+	InfoCaseSensitivityLowercase       int64 = 3
+)
+
 type driverImpl struct {
 	driverbase.DriverImplBase
 }
@@ -88,6 +982,15 @@ func NewDriver(alloc memory.Allocator) adbc.Driver {
 	if err := info.RegisterInfoCode(adbc.InfoDriverName, "ADBC Driver Foundry Driver for Databricks"); err != nil {
 		panic(err)
 	}
+	// Identifiers are compared without regard to case whether or not they
+	// are quoted with backticks (column names keep their case, table names
+	// are stored in lower case)
+	info.MustRegister(map[adbc.InfoCode]any{
+		InfoIdentifierCase:       InfoCaseSensitivityCaseInsensitive,
+		InfoIdentifierQuoteChar:  "`",
+		InfoQuotedIdentifierCase: InfoCaseSensitivityCaseInsensitive,
+		InfoSearchStringEscape:   `\`,
+	})
 
 	return driverbase.NewDriver(&driverImpl{
 		DriverImplBase: driverbase.NewDriverImplBase(info, alloc),
@@ -105,14 +1008,32 @@ func (d *driverImpl) NewDatabaseWithContext(ctx context.Context, opts map[string
 	}
 
 	db := &databaseImpl{
-		DatabaseImplBase: dbBase,
-		port:             DefaultPort,
-		sslMode:          DefaultSSLMode,
+		DatabaseImplBase:    dbBase,
+		port:                DefaultPort,
+		sslMode:             DefaultSSLMode,
+		errorHistorySize:    DefaultErrorHistorySize,
+		federationTokenEnv:  DefaultFederationTokenEnv,
+		oauthRedirectPort:   DefaultOAuthU2MRedirectPort,
+		federationTokenFile: DefaultFederationTokenFile,
+		azureLoginEndpoint:  DefaultAzureLoginEndpoint,
+		errorMaxLength:      DefaultErrorMaxMessageLength,
+		schemaCacheEnabled:  DefaultSchemaCache,
+		namespaceCache:      DefaultNamespaceCache,
+		goroutines:          newGoroutineGroup(),
+		informationSchema:   DefaultInformationSchema,
+		metadataFilters:     DefaultMetadataFilters,
+		metadataFilterMatch: DefaultMetadataFilterMatch,
+		metadataRetryCount:  DefaultMetadataRetryCount,
+		schemaCache:         newSchemaCache(),
+		capabilitiesProbe:   DefaultCapabilitiesProbe,
+		clusterStartTimeout: DefaultClusterStartTimeout,
+		tempMaxBytes:        DefaultTempMaxBytes,
+		affinityCookies:     DefaultAffinityCookies,
 	}
 
 	if err := db.SetOptions(opts); err != nil {
 		return nil, err
 	}
 
-	return driverbase.NewDatabase(db), nil
+	return &clockedDatabase{Database: driverbase.NewDatabase(db), impl: db}, nil
 }
@@ -24,10 +24,17 @@ package databricks_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/adbc-drivers/databricks/go"
 	"github.com/adbc-drivers/driverbase-go/validation"
@@ -50,6 +57,8 @@ type DatabricksQuirks struct {
 	oauthClientSecret string
 	port              string
 	uri               string // The URI to use for the test if set
+	// Options added to DatabaseOptions, for the variants of validationMatrix
+	extraOptions map[string]string
 }
 
 func (d *DatabricksQuirks) SetupDriver(t *testing.T) adbc.Driver {
@@ -67,9 +76,11 @@ func (d *DatabricksQuirks) QuoteTableName(tableName string) string {
 
 func (d *DatabricksQuirks) DatabaseOptions() map[string]string {
 	if d.uri != "" {
-		return map[string]string{
+		opts := map[string]string{
 			adbc.OptionKeyURI: d.uri,
 		}
+		maps.Copy(opts, d.extraOptions)
+		return opts
 	}
 
 	opts := map[string]string{
@@ -88,6 +99,7 @@ func (d *DatabricksQuirks) DatabaseOptions() map[string]string {
 	if d.schemaName != "" {
 		opts[databricks.OptionSchema] = d.schemaName
 	}
+	maps.Copy(opts, d.extraOptions)
 
 	return opts
 }
@@ -276,6 +288,12 @@ func (d *DatabricksQuirks) GetMetadata(code adbc.InfoCode) any {
 		return adbc.AdbcVersion1_1_0
 	case adbc.InfoVendorName:
 		return "Databricks"
+	case databricks.InfoIdentifierCase, databricks.InfoQuotedIdentifierCase:
+		return databricks.InfoCaseSensitivityCaseInsensitive
+	case databricks.InfoIdentifierQuoteChar:
+		return "`"
+	case databricks.InfoSearchStringEscape:
+		return `\`
 	}
 	return nil
 }
@@ -392,17 +410,85 @@ func (suite *StatementTests) TestSqlIngestErrors() {
 	suite.T().Skip("sqlstate not supported")
 }
 
+// validationVariant is a configuration of the driver the validation suites
+// run against.
+type validationVariant struct {
+	name string
+	// Adjusts the quirks; returns a reason to skip the variant, if any
+	configure func(q *DatabricksQuirks) string
+}
+
+// validationMatrix lists the configurations that take different paths
+// through the driver: how results are fetched, how metadata is read, how
+// the session is opened and how the database is configured.
+var validationMatrix = []validationVariant{
+	{name: "default", configure: func(*DatabricksQuirks) string { return "" }},
+	{name: "system_information_schema", configure: func(q *DatabricksQuirks) string {
+		q.extraOptions = map[string]string{databricks.OptionInformationSchema: databricks.OptionValueInformationSchemaSystem}
+		return ""
+	}},
+	{name: "lazy_connect", configure: func(q *DatabricksQuirks) string {
+		q.extraOptions = map[string]string{databricks.OptionConnectLazy: adbc.OptionValueEnabled}
+		return ""
+	}},
+	{name: "access_token", configure: func(q *DatabricksQuirks) string {
+		token := os.Getenv("DATABRICKS_ACCESSTOKEN")
+		if token == "" {
+			return "DATABRICKS_ACCESSTOKEN not defined"
+		}
+		q.oauthClientId, q.oauthClientSecret = "", ""
+		q.extraOptions = map[string]string{databricks.OptionAccessToken: token}
+		return ""
+	}},
+	{name: "uri", configure: func(q *DatabricksQuirks) string {
+		q.uri = os.Getenv("DATABRICKS_URI")
+		if q.uri == "" {
+			return "DATABRICKS_URI not defined"
+		}
+		return ""
+	}},
+}
+
+// validationVariants returns the variants of validationMatrix selected by
+// DATABRICKS_VALIDATION_MATRIX: a comma-separated list of names, or "all".
+// Only the default configuration runs if it is unset, since each variant
+// runs the whole suite.
+func validationVariants(t *testing.T) []validationVariant {
+	selected := os.Getenv("DATABRICKS_VALIDATION_MATRIX")
+	if selected == "all" {
+		return validationMatrix
+	}
+	if selected == "" {
+		selected = "default"
+	}
+	var variants []validationVariant
+	for _, name := range strings.Split(selected, ",") {
+		i := slices.IndexFunc(validationMatrix, func(v validationVariant) bool { return v.name == strings.TrimSpace(name) })
+		require.GreaterOrEqual(t, i, 0, "unknown DATABRICKS_VALIDATION_MATRIX variant %q", name)
+		variants = append(variants, validationMatrix[i])
+	}
+	return variants
+}
+
 func TestValidation(t *testing.T) {
-	withQuirks(t, func(q *DatabricksQuirks) {
-		t.Run("Database", func(t *testing.T) {
-			suite.Run(t, &validation.DatabaseTests{Quirks: q})
-		})
-		t.Run("Connection", func(t *testing.T) {
-			suite.Run(t, &ConnectionTests{validation.ConnectionTests{Quirks: q}})
-		})
-		t.Run("Statement", func(t *testing.T) {
-			suite.Run(t, &StatementTests{validation.StatementTests{Quirks: q}})
-		})
+	withQuirks(t, func(base *DatabricksQuirks) {
+		for _, variant := range validationVariants(t) {
+			t.Run(variant.name, func(t *testing.T) {
+				q := *base
+				if reason := variant.configure(&q); reason != "" {
+					t.Skip(reason)
+				}
+				t.Run("Database", func(t *testing.T) {
+					suite.Run(t, &validation.DatabaseTests{Quirks: &q})
+				})
+				t.Run("Connection", func(t *testing.T) {
+					suite.Run(t, &ConnectionTests{validation.ConnectionTests{Quirks: &q}})
+				})
+				t.Run("Statement", func(t *testing.T) {
+					suite.Run(t, &StatementTests{validation.StatementTests{Quirks: &q}})
+				})
+			})
+		}
 	})
 }
 
@@ -747,184 +833,1452 @@ func TestDatabaseCreationWithAllOptions(t *testing.T) {
 	defer validation.CheckedClose(t, db)
 }
 
-func (suite *DatabricksTests) TestConnectionManagement() {
-	cnxn2, err := suite.db.Open(suite.ctx)
-	suite.Require().NoError(err)
-	defer validation.CheckedClose(suite.T(), cnxn2)
+func TestFetchMaxRowsPerRequest(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
 
-	stmt1, err := suite.cnxn.NewStatement()
-	suite.Require().NoError(err)
-	defer validation.CheckedClose(suite.T(), stmt1)
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname:         "test-hostname",
+		databricks.OptionHTTPPath:               "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:            "test-token",
+		databricks.OptionFetchMaxRowsPerRequest: "50000",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-	stmt2, err := cnxn2.NewStatement()
-	suite.Require().NoError(err)
-	defer validation.CheckedClose(suite.T(), stmt2)
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
 
-	suite.Require().NoError(stmt1.SetSqlQuery("SELECT 'connection1' as source"))
-	suite.Require().NoError(stmt2.SetSqlQuery("SELECT 'connection2' as source"))
+	value, err := getSetDB.GetOption(databricks.OptionFetchMaxRowsPerRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "50000", value)
 
-	rdr1, _, err := stmt1.ExecuteQuery(suite.ctx)
-	suite.Require().NoError(err)
-	defer rdr1.Release()
+	// The deprecated key is an alias for the same setting
+	value, err = getSetDB.GetOption(databricks.OptionMaxRows)
+	require.NoError(t, err)
+	assert.Equal(t, "50000", value)
 
-	rdr2, _, err := stmt2.ExecuteQuery(suite.ctx)
-	suite.Require().NoError(err)
-	defer rdr2.Release()
+	require.NoError(t, getSetDB.SetOptionInt(databricks.OptionFetchMaxRowsPerRequest, 1000))
+	n, err := getSetDB.GetOptionInt(databricks.OptionFetchMaxRowsPerRequest)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), n)
 
-	suite.True(rdr1.Next())
-	suite.True(rdr2.Next())
+	err = getSetDB.SetOption(databricks.OptionFetchMaxRowsPerRequest, "0")
+	assert.ErrorContains(t, err, databricks.OptionFetchMaxRowsPerRequest)
 }
 
-func (suite *DatabricksTests) TestDatabaseOptions() {
-	testCases := []struct {
-		name   string
-		option string
-		value  string
-	}{
-		{"Port", databricks.OptionPort, "443"},
-		{"SSLMode", databricks.OptionSSLMode, "require"},
-		{"QueryTimeout", databricks.OptionQueryTimeout, "1m0s"},
-		{"MaxRows", databricks.OptionMaxRows, "5000"},
-		{"QueryRetryCount", databricks.OptionQueryRetryCount, "5"},
-		{"DownloadThreadCount", databricks.OptionDownloadThreadCount, "8"},
-	}
+func TestLazyConnection(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
 
-	for _, tc := range testCases {
-		suite.Run(tc.name, func() {
-			dbOptions := suite.Quirks.DatabaseOptions()
-			dbOptions[tc.option] = tc.value
+	// Nothing listens on this host, so Open only succeeds if it makes no
+	// round trips.
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-			db, err := suite.driver.NewDatabase(dbOptions)
-			suite.NoError(err)
-			defer validation.CheckedClose(suite.T(), db)
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
 
-			getSetDB, ok := db.(adbc.GetSetOptions)
-			suite.True(ok)
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+	require.NoError(t, cnxn.Close())
 
-			value, err := getSetDB.GetOption(tc.option)
-			suite.NoError(err)
-			suite.Equal(tc.value, value)
-		})
-	}
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	value, err := getSetDB.GetOption(databricks.OptionConnectLazy)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueEnabled, value)
+
+	err = getSetDB.SetOption(databricks.OptionConnectValidate, "sometimes")
+	assert.ErrorContains(t, err, databricks.OptionConnectValidate)
 }
 
-// TestErrorHandling validates proper error handling for invalid SQL and missing tables.
-func (suite *DatabricksTests) TestErrorHandling() {
-	suite.Run("InvalidSQL", func() {
-		suite.Require().NoError(suite.stmt.SetSqlQuery("INVALID SQL SYNTAX"))
-		_, _, err := suite.stmt.ExecuteQuery(suite.ctx)
-		suite.Error(err, "Should return error for invalid SQL")
+func TestOAuthM2MOptions(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname:    "invalid.databricks.test",
+		databricks.OptionHTTPPath:          "/sql/1.0/warehouses/test",
+		databricks.OptionOAuthClientID:     "client-id",
+		databricks.OptionOAuthClientSecret: "client-secret",
+		databricks.OptionConnectLazy:       "true",
 	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-	suite.Run("NonExistentTable", func() {
-		suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT * FROM non_existent_table_12345"))
-		_, _, err := suite.stmt.ExecuteQuery(suite.ctx)
-		suite.Error(err, "Should return error for non-existent table")
+	// Tokens are obtained when the session is opened, not by Open
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, cnxn.Close())
+
+	// A client ID alone is not enough
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	require.NoError(t, getSetDB.SetOption(databricks.OptionOAuthClientSecret, ""))
+	_, err = db.Open(context.Background())
+	assert.ErrorContains(t, err, databricks.OptionOAuthClientSecret)
+}
+
+func TestErrorHistory(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname:   "invalid.databricks.test",
+		databricks.OptionHTTPPath:         "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:      "test-token",
+		databricks.OptionConnectLazy:      "true",
+		databricks.OptionErrorHistorySize: "2",
 	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	getSetCnxn, ok := cnxn.(adbc.GetSetOptions)
+	require.True(t, ok)
+
+	value, err := getSetCnxn.GetOption(databricks.OptionErrorHistory)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, stmt)
+
+	// None of these need a session, so they fail without a round trip
+	_, _, err = stmt.ExecuteQuery(context.Background())
+	require.Error(t, err)
+	_, err = stmt.ExecuteUpdate(context.Background())
+	require.Error(t, err)
+	require.Error(t, stmt.Prepare(context.Background()))
+
+	value, err = getSetCnxn.GetOption(databricks.OptionErrorHistory)
+	require.NoError(t, err)
 
+	var history []struct {
+		Time      string `json:"time"`
+		Operation string `json:"operation"`
+		Status    string `json:"status"`
+		Message   string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(value), &history))
+	// Only the last two errors are kept, oldest first
+	require.Len(t, history, 2)
+	assert.Equal(t, "ExecuteUpdate", history[0].Operation)
+	assert.Equal(t, "Prepare", history[1].Operation)
+	assert.Equal(t, adbc.StatusInvalidState.String(), history[1].Status)
+	assert.Contains(t, history[1].Message, "no query set")
+	assert.NotEmpty(t, history[1].Time)
+
+	err = db.(adbc.GetSetOptions).SetOption(databricks.OptionErrorHistorySize, "-1")
+	assert.ErrorContains(t, err, databricks.OptionErrorHistorySize)
 }
 
-func (suite *DatabricksTests) TestTimestampPrecision() {
-	query := "SELECT CAST('2023-01-01 12:00:00.123456' AS TIMESTAMP) as ts_col"
+func TestValidateOnlyOption(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
 
-	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
-	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
-	suite.Require().NoError(err)
-	defer rdr.Release()
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-	suite.checkRowCount(1, n)
-	suite.True(rdr.Next())
-	rec := rdr.RecordBatch()
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
 
-	suite.Equal(int64(1), rec.NumCols())
-	suite.Equal("ts_col", rec.ColumnName(0))
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, stmt)
 
-	field := rec.Schema().Field(0)
-	suite.Equal(arrow.TIMESTAMP, field.Type.ID())
+	getSetStmt, ok := stmt.(adbc.GetSetOptions)
+	require.True(t, ok)
 
-	suite.False(rdr.Next())
-	suite.Require().NoError(rdr.Err())
+	value, err := getSetStmt.GetOption(databricks.OptionValidateOnly)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueDisabled, value)
+
+	require.NoError(t, stmt.SetOption(databricks.OptionValidateOnly, "true"))
+	value, err = getSetStmt.GetOption(databricks.OptionValidateOnly)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueEnabled, value)
+
+	err = stmt.SetOption(databricks.OptionValidateOnly, "dry")
+	assert.ErrorContains(t, err, databricks.OptionValidateOnly)
 }
 
-func (suite *DatabricksTests) TestDecimalTypes() {
-	query := "SELECT CAST(123.45 AS DECIMAL(10,2)) as decimal_col, CAST(999.999 AS DECIMAL(6,3)) as decimal_col2"
+func TestLargeTypesOption(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
 
-	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
-	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
-	suite.Require().NoError(err)
-	defer rdr.Release()
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-	suite.checkRowCount(1, n)
-	suite.True(rdr.Next())
-	rec := rdr.RecordBatch()
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
 
-	// Verify we got decimal columns
-	suite.Equal(int64(2), rec.NumCols())
-	suite.Equal("decimal_col", rec.ColumnName(0))
-	suite.Equal("decimal_col2", rec.ColumnName(1))
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, stmt)
 
-	suite.False(rdr.Next())
-	suite.Require().NoError(rdr.Err())
+	getSetStmt, ok := stmt.(adbc.GetSetOptions)
+	require.True(t, ok)
+
+	value, err := getSetStmt.GetOption(databricks.OptionFetchLargeTypes)
+	require.NoError(t, err)
+	assert.Equal(t, databricks.OptionValueLargeTypesAuto, value)
+
+	require.NoError(t, stmt.SetOption(databricks.OptionFetchLargeTypes, "ALWAYS"))
+	value, err = getSetStmt.GetOption(databricks.OptionFetchLargeTypes)
+	require.NoError(t, err)
+	assert.Equal(t, databricks.OptionValueLargeTypesAlways, value)
+
+	err = stmt.SetOption(databricks.OptionFetchLargeTypes, "sometimes")
+	assert.ErrorContains(t, err, databricks.OptionFetchLargeTypes)
 }
 
-func (suite *DatabricksTests) TestMultiBatch() {
-	// Regression test for issue reported directly to us
-	query := fmt.Sprintf("CREATE OR REPLACE TABLE `%s`.`%s`.`test_multi_batch` (founder STRING, born STRING)", suite.Quirks.catalogName, suite.Quirks.schemaName)
-	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
-	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
-	suite.Require().NoError(err)
+func TestMemoryLimitOption(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
 
-	type row struct {
-		founder string
-		born    string
-	}
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
 
-	rows := []row{
-		{"Ali Ghodsi", "Iran"},
-		{"Ion Stoica", "Romania"},
-		{"Matei Zaharia", "Romania"},
-		{"Patrick Wendell", "USA"},
-		{"Reynold Xin", "China"},
-		{"Andy Konwinski", "USA"},
-		{"Arsalan Tavakoli-Shiraji", "Iran"},
-	}
-	for _, r := range rows {
-		query = fmt.Sprintf("INSERT INTO `%s`.`%s`.`test_multi_batch` VALUES ('%s', '%s')", suite.Quirks.catalogName, suite.Quirks.schemaName, r.founder, r.born)
-		suite.Require().NoError(suite.stmt.SetSqlQuery(query))
-		_, err = suite.stmt.ExecuteUpdate(suite.ctx)
-		suite.Require().NoError(err)
-	}
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
 
-	query = fmt.Sprintf("SELECT * FROM `%s`.`%s`.`test_multi_batch`", suite.Quirks.catalogName, suite.Quirks.schemaName)
-	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
-	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
-	suite.Require().NoError(err)
-	defer rdr.Release()
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, stmt)
 
-	// This used to only return one row.
-	rowCount := 0
-	seen := map[string]string{}
-	for rdr.Next() {
-		batch := rdr.RecordBatch()
-		rowCount += int(batch.NumRows())
+	getSetStmt, ok := stmt.(adbc.GetSetOptions)
+	require.True(t, ok)
 
-		founder := batch.Column(0).(*array.String)
-		born := batch.Column(1).(*array.String)
+	value, err := getSetStmt.GetOption(databricks.OptionFetchMemoryLimit)
+	require.NoError(t, err)
+	assert.Equal(t, "0", value)
+	value, err = getSetStmt.GetOption(databricks.OptionStatementMemoryInUse)
+	require.NoError(t, err)
+	assert.Equal(t, "0", value)
 
-		for i := range int(batch.NumRows()) {
-			seen[founder.Value(i)] = born.Value(i)
-		}
-	}
-	suite.NoError(rdr.Err())
-	suite.Equal(len(rows), rowCount)
+	require.NoError(t, stmt.SetOption(databricks.OptionFetchMemoryLimit, "1048576"))
+	value, err = getSetStmt.GetOption(databricks.OptionFetchMemoryLimit)
+	require.NoError(t, err)
+	assert.Equal(t, "1048576", value)
 
-	suite.EqualValues(map[string]string{
-		"Ali Ghodsi":               "Iran",
-		"Ion Stoica":               "Romania",
-		"Matei Zaharia":            "Romania",
-		"Patrick Wendell":          "USA",
-		"Reynold Xin":              "China",
-		"Andy Konwinski":           "USA",
-		"Arsalan Tavakoli-Shiraji": "Iran",
-	}, seen)
+	err = stmt.SetOption(databricks.OptionFetchMemoryLimit, "-1")
+	assert.ErrorContains(t, err, databricks.OptionFetchMemoryLimit)
+}
+
+func TestNewDatabaseFromConfig(t *testing.T) {
+	cfg := databricks.NewConfig(
+		databricks.WithHostname("invalid.databricks.test"),
+		databricks.WithHTTPPath("/sql/1.0/warehouses/test"),
+		databricks.WithOAuthM2M("client-id", "client-secret"),
+		databricks.WithNamespace("main", "default"),
+		databricks.WithTimeouts(databricks.Timeouts{Query: 90 * time.Second}),
+		databricks.WithOption(databricks.OptionConnectLazy, adbc.OptionValueEnabled),
+	)
+	assert.Equal(t, map[string]string{
+		databricks.OptionServerHostname:    "invalid.databricks.test",
+		databricks.OptionHTTPPath:          "/sql/1.0/warehouses/test",
+		databricks.OptionOAuthClientID:     "client-id",
+		databricks.OptionOAuthClientSecret: "client-secret",
+		databricks.OptionCatalog:           "main",
+		databricks.OptionSchema:            "default",
+		databricks.OptionQueryTimeout:      "1m30s",
+		databricks.OptionConnectLazy:       adbc.OptionValueEnabled,
+	}, cfg.StringOptions())
+
+	db, err := databricks.NewDatabaseFromConfig(context.Background(), databricks.NewDriver(memory.DefaultAllocator), cfg)
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	value, err := getSetDB.GetOption(databricks.OptionHTTPPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/sql/1.0/warehouses/test", value)
+	value, err = getSetDB.GetOption(databricks.OptionQueryTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, "1m30s", value)
+	_, ok = db.(databricks.ClockSetter)
+	assert.True(t, ok)
+
+	_, err = databricks.NewDatabaseFromConfig(context.Background(), databricks.NewDriver(memory.DefaultAllocator),
+		databricks.NewConfig(databricks.WithHostname("invalid.databricks.test"), databricks.WithPort(-1)))
+	assert.ErrorContains(t, err, databricks.OptionPort)
+}
+
+func TestIdentifierInfo(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	// No session is needed for these codes
+	rdr, err := cnxn.GetInfo(context.Background(), []adbc.InfoCode{
+		databricks.InfoIdentifierCase,
+		databricks.InfoIdentifierQuoteChar,
+		databricks.InfoQuotedIdentifierCase,
+		databricks.InfoSearchStringEscape,
+	})
+	require.NoError(t, err)
+	defer rdr.Release()
+
+	values := map[adbc.InfoCode]any{}
+	for rdr.Next() {
+		rec := rdr.RecordBatch()
+		codes := rec.Column(0).(*array.Uint32)
+		union := rec.Column(1).(*array.DenseUnion)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			child := union.Field(union.ChildID(i))
+			values[adbc.InfoCode(codes.Value(i))] = child.GetOneForMarshal(int(union.ValueOffset(i)))
+		}
+	}
+	require.NoError(t, rdr.Err())
+	assert.Equal(t, map[adbc.InfoCode]any{
+		databricks.InfoIdentifierCase:       databricks.InfoCaseSensitivityCaseInsensitive,
+		databricks.InfoIdentifierQuoteChar:  "`",
+		databricks.InfoQuotedIdentifierCase: databricks.InfoCaseSensitivityCaseInsensitive,
+		databricks.InfoSearchStringEscape:   `\`,
+	}, values)
+}
+
+func TestDriverFeaturesInfo(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	// No session is needed to list the features
+	rdr, err := cnxn.GetInfo(context.Background(), []adbc.InfoCode{databricks.InfoDriverFeatures})
+	require.NoError(t, err)
+	defer rdr.Release()
+	require.True(t, rdr.Next())
+	union := rdr.RecordBatch().Column(1).(*array.DenseUnion)
+	features := map[string]bool{}
+	require.NoError(t, json.Unmarshal([]byte(union.Field(union.ChildID(0)).ValueStr(int(union.ValueOffset(0)))), &features))
+	assert.False(t, features[databricks.FeatureTransactions])
+	// The warehouse's HTTP path enables the Statement Execution API
+	assert.True(t, features[databricks.FeaturePartitionedResults])
+}
+
+func TestAffinityOptions(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname:  "invalid.databricks.test",
+		databricks.OptionHTTPPath:        "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:     "test-token",
+		databricks.OptionConnectLazy:     "true",
+		databricks.OptionAffinityHeaders: "X-Route-Token, X-Backend",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	value, err := getSetDB.GetOption(databricks.OptionAffinityCookies)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueEnabled, value)
+	value, err = getSetDB.GetOption(databricks.OptionAffinityHeaders)
+	require.NoError(t, err)
+	assert.Equal(t, "X-Route-Token,X-Backend", value)
+
+	err = db.SetOptions(map[string]string{databricks.OptionAffinityCookies: "sometimes"})
+	assert.ErrorContains(t, err, databricks.OptionAffinityCookies)
+	require.NoError(t, db.SetOptions(map[string]string{databricks.OptionAffinityCookies: adbc.OptionValueDisabled}))
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, cnxn.Close())
+}
+
+func TestStatisticNames(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	_, ok := cnxn.(adbc.GetSetOptions)
+	require.True(t, ok)
+	gs, ok := cnxn.(adbc.ConnectionGetStatistics)
+	require.True(t, ok)
+
+	rdr, err := gs.GetStatisticNames(context.Background())
+	require.NoError(t, err)
+	defer rdr.Release()
+
+	names := map[string]int16{}
+	for rdr.Next() {
+		rec := rdr.RecordBatch()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			names[rec.Column(0).(*array.String).Value(i)] = rec.Column(1).(*array.Int16).Value(i)
+		}
+	}
+	require.NoError(t, rdr.Err())
+	assert.Equal(t, int16(databricks.StatisticSizeInBytesKey), names[databricks.StatisticSizeInBytesName])
+	assert.Equal(t, int16(databricks.StatisticPartitionKey), names[databricks.StatisticPartitionName])
+	assert.Len(t, names, 5)
+}
+
+func TestConnectionExtensions(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	assert.Implements(t, (*databricks.ResultAttacher)(nil), cnxn)
+	assert.Implements(t, (*databricks.CommentEditor)(nil), cnxn)
+	setter, ok := cnxn.(databricks.NamespaceSetter)
+	require.True(t, ok)
+
+	// Empty names are rejected before any round trip
+	err = setter.SetCurrentCatalogAndSchema("", "")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+}
+
+func TestCapabilitiesOption(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	value, err := getSetDB.GetOption(databricks.OptionCapabilitiesProbe)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueEnabled, value)
+	err = db.SetOptions(map[string]string{databricks.OptionCapabilitiesProbe: "sometimes"})
+	assert.ErrorContains(t, err, databricks.OptionCapabilitiesProbe)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	getSetCnxn, ok := cnxn.(adbc.GetSetOptions)
+	require.True(t, ok)
+
+	// Nothing is probed until the session is opened
+	value, err = getSetCnxn.GetOption(databricks.OptionCapabilities)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"probed": false, "native_parameters": true, "variant": true}`, value)
+}
+
+func (suite *DatabricksTests) TestCapabilities() {
+	value, err := suite.cnxn.(adbc.GetSetOptions).GetOption(databricks.OptionCapabilities)
+	suite.Require().NoError(err)
+
+	var caps map[string]bool
+	suite.Require().NoError(json.Unmarshal([]byte(value), &caps))
+	suite.Contains(caps, "native_parameters")
+	suite.Contains(caps, "variant")
+}
+
+func TestProfileOption(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	// Explicit options win whichever order the options are applied in
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionProfile:        databricks.OptionValueProfileInteractive,
+		databricks.OptionQueryTimeout:   "1m",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	getSetDB, ok := db.(adbc.GetSetOptions)
+	require.True(t, ok)
+	for key, expected := range map[string]string{
+		databricks.OptionProfile:                databricks.OptionValueProfileInteractive,
+		databricks.OptionFetchMaxRowsPerRequest: "10000",
+		databricks.OptionQueryRetryCount:        "2",
+		databricks.OptionDownloadThreadCount:    "4",
+		databricks.OptionQueryTimeout:           "1m0s",
+	} {
+		value, err := getSetDB.GetOption(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+
+	// Switching profiles keeps the explicit options too
+	require.NoError(t, getSetDB.SetOption(databricks.OptionProfile, databricks.OptionValueProfileETL))
+	value, err := getSetDB.GetOption(databricks.OptionDownloadThreadCount)
+	require.NoError(t, err)
+	assert.Equal(t, "16", value)
+	value, err = getSetDB.GetOption(databricks.OptionQueryTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, "1m0s", value)
+
+	err = getSetDB.SetOption(databricks.OptionProfile, "batch")
+	assert.ErrorContains(t, err, databricks.OptionProfile)
+}
+
+func TestIngestStreamOptions(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, stmt)
+
+	getSetStmt, ok := stmt.(adbc.GetSetOptions)
+	require.True(t, ok)
+
+	value, err := getSetStmt.GetOption(databricks.OptionIngestStreamMaxRows)
+	require.NoError(t, err)
+	assert.Equal(t, "10000", value)
+	value, err = getSetStmt.GetOption(databricks.OptionIngestStreamMaxBytes)
+	require.NoError(t, err)
+	assert.Equal(t, "16777216", value)
+	value, err = getSetStmt.GetOption(databricks.OptionIngestStreamInterval)
+	require.NoError(t, err)
+	assert.Equal(t, "5s", value)
+
+	require.NoError(t, stmt.SetOption(databricks.OptionIngestStreamMaxRows, "500"))
+	require.NoError(t, stmt.SetOption(databricks.OptionIngestStreamInterval, "250ms"))
+	value, err = getSetStmt.GetOption(databricks.OptionIngestStreamMaxRows)
+	require.NoError(t, err)
+	assert.Equal(t, "500", value)
+	value, err = getSetStmt.GetOption(databricks.OptionIngestStreamInterval)
+	require.NoError(t, err)
+	assert.Equal(t, "250ms", value)
+
+	err = stmt.SetOption(databricks.OptionIngestStreamMaxBytes, "-1")
+	assert.ErrorContains(t, err, databricks.OptionIngestStreamMaxBytes)
+
+	// A stream needs a target table
+	streamer, ok := stmt.(databricks.IngestStreamer)
+	require.True(t, ok)
+	_, err = streamer.ExecuteIngestStream(context.Background())
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+
+	require.NoError(t, stmt.SetOption(adbc.OptionKeyIngestTargetTable, "events"))
+	stream, err := streamer.ExecuteIngestStream(context.Background())
+	require.NoError(t, err)
+	_, err = streamer.ExecuteIngestStream(context.Background())
+	assert.Error(t, err)
+
+	// Closing an empty stream commits nothing
+	require.NoError(t, stream.Close())
+	assert.Equal(t, int64(0), stream.RowsIngested())
+	assert.Error(t, stream.Close())
+}
+
+func TestStatementClone(t *testing.T) {
+	drv := databricks.NewDriver(memory.DefaultAllocator)
+
+	db, err := drv.NewDatabase(map[string]string{
+		databricks.OptionServerHostname: "invalid.databricks.test",
+		databricks.OptionHTTPPath:       "/sql/1.0/warehouses/test",
+		databricks.OptionAccessToken:    "test-token",
+		databricks.OptionConnectLazy:    "true",
+	})
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, db)
+
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, cnxn)
+
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	require.NoError(t, stmt.SetSqlQuery("SELECT 1"))
+	require.NoError(t, stmt.SetOption(databricks.OptionFetchConcatResult, adbc.OptionValueEnabled))
+
+	cloner, ok := stmt.(databricks.StatementCloner)
+	require.True(t, ok)
+	clone, err := cloner.Clone()
+	require.NoError(t, err)
+	defer validation.CheckedClose(t, clone)
+
+	value, err := clone.(adbc.GetSetOptions).GetOption(databricks.OptionFetchConcatResult)
+	require.NoError(t, err)
+	assert.Equal(t, adbc.OptionValueEnabled, value)
+
+	// The clone outlives the original
+	require.NoError(t, stmt.Close())
+	_, err = cloner.Clone()
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+}
+
+func (suite *DatabricksTests) TestStatementReuse() {
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+
+	// Alternate prepared and unprepared queries on one statement
+	for i := range 4 {
+		suite.Require().NoError(stmt.SetSqlQuery(fmt.Sprintf("SELECT %d AS n", i)))
+		if i%2 == 1 {
+			suite.Require().NoError(stmt.Prepare(suite.ctx))
+		}
+		rdr, _, err := stmt.ExecuteQuery(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Require().True(rdr.Next())
+		suite.Equal(int32(i), rdr.RecordBatch().Column(0).(*array.Int32).Value(0))
+		rdr.Release()
+	}
+
+	suite.Require().NoError(stmt.(databricks.StatementResetter).Reset())
+	_, _, err = stmt.ExecuteQuery(suite.ctx)
+	var adbcErr adbc.Error
+	suite.Require().ErrorAs(err, &adbcErr)
+	suite.Equal(adbc.StatusInvalidState, adbcErr.Code)
+}
+
+func (suite *DatabricksTests) TestQueryParameters() {
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+	}, nil)
+	for _, query := range []string{
+		"SELECT ? + 1 AS n, upper(?) AS s",
+		"SELECT :a + 1 AS n, upper(:b) AS s",
+	} {
+		suite.Require().NoError(stmt.SetSqlQuery(query))
+		rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(`[{"a": 1, "b": "x"}, {"a": 2, "b": "y"}]`))
+		suite.Require().NoError(err)
+		err = stmt.Bind(suite.ctx, rec)
+		rec.Release()
+		suite.Require().NoError(err)
+
+		// The query runs once per row
+		rdr, _, err := stmt.ExecuteQuery(suite.ctx)
+		suite.Require().NoError(err, query)
+		var strs []string
+		for rdr.Next() {
+			batch := rdr.RecordBatch()
+			for i := range int(batch.NumRows()) {
+				strs = append(strs, batch.Column(1).(*array.String).Value(i))
+			}
+		}
+		suite.Require().NoError(rdr.Err())
+		rdr.Release()
+		suite.Equal([]string{"X", "Y"}, strs, query)
+	}
+}
+
+func (suite *DatabricksTests) TestBindStreamUpdate() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "bind_stream_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "bind_stream_test"))
+	}()
+	suite.Require().NoError(suite.stmt.SetSqlQuery("CREATE TABLE bind_stream_test (id INT, name STRING)"))
+	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	bindStream := func() {
+		var batches []arrow.RecordBatch
+		for _, rows := range []string{
+			`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`,
+			`[{"id": 3, "name": null}]`,
+			`[{"id": 4, "name": "d"}, {"id": 5, "name": "e"}]`,
+		} {
+			rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(rows))
+			suite.Require().NoError(err)
+			defer rec.Release()
+			batches = append(batches, rec)
+		}
+		rdr, err := array.NewRecordReader(schema, batches)
+		suite.Require().NoError(err)
+		defer rdr.Release()
+		suite.Require().NoError(suite.stmt.BindStream(suite.ctx, rdr))
+	}
+
+	// The insert takes several rows at once, the update one at a time
+	suite.Require().NoError(suite.stmt.SetSqlQuery("INSERT INTO bind_stream_test VALUES (?, ?)"))
+	bindStream()
+	n, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(int64(5), n)
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("UPDATE bind_stream_test SET id = id * 10 WHERE id = ? AND (name = ? OR name IS NULL)"))
+	bindStream()
+	n, err = suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(int64(5), n)
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT sum(id) FROM bind_stream_test"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Require().True(rdr.Next())
+	suite.Equal(int64(150), rdr.RecordBatch().Column(0).(*array.Int64).Value(0))
+}
+
+func (suite *DatabricksTests) TestStatementCloneConcurrent() {
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	suite.Require().NoError(stmt.SetSqlQuery("SELECT 42 AS answer"))
+	suite.Require().NoError(stmt.Prepare(suite.ctx))
+
+	const workers = 4
+	clones := make([]adbc.Statement, workers)
+	for i := range clones {
+		clones[i], err = stmt.(databricks.StatementCloner).Clone()
+		suite.Require().NoError(err)
+	}
+	// The prepared statement stays open for the clones
+	suite.Require().NoError(stmt.Close())
+
+	errs := make(chan error, workers)
+	for _, clone := range clones {
+		go func(clone adbc.Statement) {
+			rdr, _, err := clone.ExecuteQuery(suite.ctx)
+			if err == nil {
+				for rdr.Next() {
+				}
+				err = rdr.Err()
+				rdr.Release()
+			}
+			errs <- errors.Join(err, clone.Close())
+		}(clone)
+	}
+	for range workers {
+		suite.NoError(<-errs)
+	}
+}
+
+func (suite *DatabricksTests) TestConnectionManagement() {
+	cnxn2, err := suite.db.Open(suite.ctx)
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), cnxn2)
+
+	stmt1, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt1)
+
+	stmt2, err := cnxn2.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt2)
+
+	suite.Require().NoError(stmt1.SetSqlQuery("SELECT 'connection1' as source"))
+	suite.Require().NoError(stmt2.SetSqlQuery("SELECT 'connection2' as source"))
+
+	rdr1, _, err := stmt1.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr1.Release()
+
+	rdr2, _, err := stmt2.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr2.Release()
+
+	suite.True(rdr1.Next())
+	suite.True(rdr2.Next())
+}
+
+func (suite *DatabricksTests) TestSetCurrentCatalogAndSchema() {
+	getSetCnxn, ok := suite.cnxn.(adbc.GetSetOptions)
+	suite.Require().True(ok)
+	setter, ok := suite.cnxn.(databricks.NamespaceSetter)
+	suite.Require().True(ok)
+
+	suite.Require().NoError(setter.SetCurrentCatalogAndSchema(suite.Quirks.Catalog(), suite.Quirks.DBSchema()))
+
+	catalog, err := getSetCnxn.GetOption(adbc.OptionKeyCurrentCatalog)
+	suite.Require().NoError(err)
+	suite.Equal(suite.Quirks.Catalog(), catalog)
+	schema, err := getSetCnxn.GetOption(adbc.OptionKeyCurrentDbSchema)
+	suite.Require().NoError(err)
+	suite.Equal(suite.Quirks.DBSchema(), schema)
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT current_catalog(), current_schema()"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Require().True(rdr.Next())
+	rec := rdr.RecordBatch()
+	suite.Equal(suite.Quirks.Catalog(), rec.Column(0).ValueStr(0))
+	suite.Equal(suite.Quirks.DBSchema(), rec.Column(1).ValueStr(0))
+}
+
+func (suite *DatabricksTests) TestZeroRowResults() {
+	for _, query := range []string{
+		"SELECT CAST(1 AS INT) AS id, 'a' AS name WHERE 1 = 0",
+		"SELECT id, CAST(id AS STRING) AS name FROM range(10) WHERE id < 0",
+	} {
+		suite.Run(query, func() {
+			suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+			rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+			suite.Require().NoError(err)
+			defer rdr.Release()
+
+			suite.Require().Len(rdr.Schema().Fields(), 2)
+			suite.Equal("id", rdr.Schema().Field(0).Name)
+			suite.Equal("name", rdr.Schema().Field(1).Name)
+			suite.Equal(arrow.BinaryTypes.String, rdr.Schema().Field(1).Type)
+			for rdr.Next() {
+				suite.Equal(int64(0), rdr.RecordBatch().NumRows())
+			}
+			suite.NoError(rdr.Err())
+		})
+	}
+
+	// Statements without a result set
+	suite.Require().NoError(suite.stmt.SetSqlQuery("DROP TABLE IF EXISTS zero_row_results_does_not_exist"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	for rdr.Next() {
+		suite.Equal(int64(0), rdr.RecordBatch().NumRows())
+	}
+	suite.NoError(rdr.Err())
+}
+
+func (suite *DatabricksTests) TestConcatResult() {
+	suite.Require().NoError(suite.stmt.SetOption(databricks.OptionFetchConcatResult, adbc.OptionValueEnabled))
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT id FROM range(10000)"))
+	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Equal(int64(10000), n)
+
+	suite.Require().True(rdr.Next())
+	suite.Equal(int64(10000), rdr.RecordBatch().NumRows())
+	suite.False(rdr.Next())
+	suite.Require().NoError(rdr.Err())
+
+	suite.Require().NoError(suite.stmt.SetOption(databricks.OptionFetchConcatResultMaxBytes, "1024"))
+	_, _, err = suite.stmt.ExecuteQuery(suite.ctx)
+	var adbcErr adbc.Error
+	suite.Require().ErrorAs(err, &adbcErr)
+	suite.Equal(adbc.StatusInvalidState, adbcErr.Code)
+}
+
+func (suite *DatabricksTests) TestValidateOnly() {
+	suite.Require().NoError(suite.stmt.SetOption(databricks.OptionValidateOnly, adbc.OptionValueEnabled))
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT 1 AS id, 'a' AS name"))
+	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Equal(int64(0), n)
+	suite.Equal([]string{"id", "name"}, []string{rdr.Schema().Field(0).Name, rdr.Schema().Field(1).Name})
+	suite.Equal(arrow.PrimitiveTypes.Int32, rdr.Schema().Field(0).Type)
+	suite.False(rdr.Next())
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT * FROM table_that_does_not_exist_4c1e"))
+	_, _, err = suite.stmt.ExecuteQuery(suite.ctx)
+	var adbcErr adbc.Error
+	suite.Require().ErrorAs(err, &adbcErr)
+	suite.Equal(adbc.StatusInvalidArgument, adbcErr.Code)
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELEC 1"))
+	_, err = suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().ErrorAs(err, &adbcErr)
+	suite.Equal(adbc.StatusInvalidArgument, adbcErr.Code)
+}
+
+func (suite *DatabricksTests) TestMergeUpdateMetrics() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "merge_metrics_target"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "merge_metrics_target"))
+	}()
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("CREATE TABLE merge_metrics_target (id INT, name STRING)"))
+	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.stmt.SetSqlQuery("INSERT INTO merge_metrics_target VALUES (1, 'a'), (2, 'b')"))
+	_, err = suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery(`MERGE INTO merge_metrics_target t
+		USING (SELECT * FROM VALUES (2, 'B'), (3, 'c') AS s(id, name)) s ON t.id = s.id
+		WHEN MATCHED THEN UPDATE SET name = s.name
+		WHEN NOT MATCHED THEN INSERT *`))
+	n, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), n)
+
+	value, err := suite.stmt.(adbc.GetSetOptions).GetOption(databricks.OptionUpdateMetrics)
+	suite.Require().NoError(err)
+	var metrics map[string]int64
+	suite.Require().NoError(json.Unmarshal([]byte(value), &metrics))
+	suite.Equal(int64(1), metrics["num_updated_rows"])
+	suite.Equal(int64(1), metrics["num_inserted_rows"])
+	suite.Equal(int64(0), metrics["num_deleted_rows"])
+}
+
+func (suite *DatabricksTests) TestExecutePartitions() {
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT id FROM range(1000)"))
+	schema, partitions, n, err := suite.stmt.ExecutePartitions(suite.ctx)
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) && adbcErr.Code == adbc.StatusNotImplemented {
+		suite.T().Skip("partitioned execution needs a SQL warehouse HTTP path")
+	}
+	suite.Require().NoError(err)
+	suite.Equal(int64(1000), n)
+	suite.Equal("id", schema.Field(0).Name)
+	suite.Require().NotZero(partitions.NumPartitions)
+
+	// Each partition can be read any number of times
+	var rows int64
+	for _, id := range partitions.PartitionIDs {
+		for range 2 {
+			rdr, err := suite.cnxn.ReadPartition(suite.ctx, id)
+			suite.Require().NoError(err)
+			var partitionRows int64
+			for rdr.Next() {
+				partitionRows += rdr.RecordBatch().NumRows()
+			}
+			suite.Require().NoError(rdr.Err())
+			rdr.Release()
+			rows += partitionRows
+		}
+	}
+	suite.Equal(int64(2000), rows)
+}
+
+func (suite *DatabricksTests) TestTableStatistics() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "statistics_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "statistics_test"))
+	}()
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("CREATE TABLE statistics_test (id INT, region STRING) PARTITIONED BY (region)"))
+	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.stmt.SetSqlQuery("INSERT INTO statistics_test VALUES (1, 'eu'), (2, 'us'), (3, 'us')"))
+	_, err = suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+
+	gs, ok := suite.cnxn.(adbc.ConnectionGetStatistics)
+	suite.Require().True(ok)
+	catalog, err := suite.cnxn.(adbc.GetSetOptions).GetOption(adbc.OptionKeyCurrentCatalog)
+	suite.Require().NoError(err)
+	dbSchema, err := suite.cnxn.(adbc.GetSetOptions).GetOption(adbc.OptionKeyCurrentDbSchema)
+	suite.Require().NoError(err)
+	table := "statistics_test"
+	rdr, err := gs.GetStatistics(suite.ctx, &catalog, &dbSchema, &table, false)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+
+	values := map[int16][]any{}
+	for rdr.Next() {
+		schemas := rdr.RecordBatch().Column(1).(*array.List)
+		stats := schemas.ListValues().(*array.Struct).Field(1).(*array.List).ListValues().(*array.Struct)
+		keys := stats.Field(2).(*array.Int16)
+		union := stats.Field(3).(*array.DenseUnion)
+		for i := 0; i < stats.Len(); i++ {
+			child := union.Field(union.ChildID(i))
+			values[keys.Value(i)] = append(values[keys.Value(i)], child.GetOneForMarshal(int(union.ValueOffset(i))))
+		}
+	}
+	suite.Require().NoError(rdr.Err())
+
+	suite.Require().Len(values[databricks.StatisticSizeInBytesKey], 1)
+	suite.Positive(values[databricks.StatisticSizeInBytesKey][0])
+	suite.Require().Len(values[databricks.StatisticNumFilesKey], 1)
+	suite.Positive(values[databricks.StatisticNumFilesKey][0])
+	suite.Equal([]any{int64(2)}, values[databricks.StatisticNumPartitionsKey])
+	suite.ElementsMatch([]any{[]byte("region=eu"), []byte("region=us")}, values[databricks.StatisticPartitionKey])
+}
+
+func (suite *DatabricksTests) TestQueryTags() {
+	getSetStmt := suite.stmt.(adbc.GetSetOptions)
+	suite.Require().NoError(getSetStmt.SetOption(databricks.OptionQueryTags, "team=adbc,test=query_tags"))
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT 1"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	rdr.Release()
+
+	// A statement without tags restores the session's own
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+	suite.Require().NoError(stmt.SetSqlQuery("SELECT 1"))
+	rdr, _, err = stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	rdr.Release()
+}
+
+func (suite *DatabricksTests) TestIngestStream() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_stream_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_stream_test"))
+	}()
+
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestTargetTable, "ingest_stream_test"))
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestStreamMaxRows, "3"))
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestStreamInterval, "0"))
+
+	streamer, ok := stmt.(databricks.IngestStreamer)
+	suite.Require().True(ok)
+	stream, err := streamer.ExecuteIngestStream(suite.ctx)
+	suite.Require().NoError(err)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	for _, rows := range []string{`[{"id": 1}, {"id": 2}]`, `[{"id": 3}]`, `[{"id": 4}]`} {
+		rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(rows))
+		suite.Require().NoError(err)
+		err = stream.Append(suite.ctx, rec)
+		rec.Release()
+		suite.Require().NoError(err)
+	}
+	// The first two batches reached the row threshold
+	suite.Equal(int64(3), stream.RowsIngested())
+	suite.Require().NoError(stream.Close())
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT COUNT(*) FROM ingest_stream_test"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Require().True(rdr.Next())
+	suite.Equal(int64(4), rdr.RecordBatch().Column(0).(*array.Int64).Value(0))
+}
+
+func (suite *DatabricksTests) TestStagedIngest() {
+	location := os.Getenv("DATABRICKS_INGEST_STAGING_LOCATION")
+	if location == "" {
+		suite.T().Skip("DATABRICKS_INGEST_STAGING_LOCATION not set")
+	}
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "staged_ingest_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "staged_ingest_test"))
+	}()
+
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestStagingLocation, location))
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	for _, mode := range []string{
+		adbc.OptionValueIngestModeCreate,
+		adbc.OptionValueIngestModeAppend,
+		adbc.OptionValueIngestModeCreateAppend,
+		adbc.OptionValueIngestModeReplace,
+	} {
+		suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestTargetTable, "staged_ingest_test"))
+		suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestMode, mode))
+		rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(`[{"id": 1, "name": "a"}, {"id": 2, "name": null}]`))
+		suite.Require().NoError(err)
+		err = stmt.Bind(suite.ctx, rec)
+		rec.Release()
+		suite.Require().NoError(err)
+		n, err := stmt.ExecuteUpdate(suite.ctx)
+		suite.Require().NoError(err, mode)
+		suite.Equal(int64(2), n, mode)
+	}
+
+	// Create, append and create-append added rows, replace started over
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT COUNT(*) FROM staged_ingest_test"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	suite.Require().True(rdr.Next())
+	suite.Equal(int64(2), rdr.RecordBatch().Column(0).(*array.Int64).Value(0))
+
+	// Staged as one file per batch, uploaded two at a time
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestUploadFileSize, "1"))
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestUploadMaxParallel, "2"))
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestTargetTable, "staged_ingest_test"))
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeReplace))
+	var batches []arrow.RecordBatch
+	for i := range 5 {
+		rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(fmt.Sprintf(`[{"id": %d, "name": "a"}, {"id": %d, "name": "b"}]`, 2*i, 2*i+1)))
+		suite.Require().NoError(err)
+		defer rec.Release()
+		batches = append(batches, rec)
+	}
+	stream, err := array.NewRecordReader(schema, batches)
+	suite.Require().NoError(err)
+	defer stream.Release()
+	suite.Require().NoError(stmt.BindStream(suite.ctx, stream))
+	n, err := stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(int64(10), n)
+
+	// A manifest is deleted once its ingestion has loaded the data
+	manifest := filepath.Join(suite.T().TempDir(), "ingest.json")
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestManifest, manifest))
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeAppend))
+	suite.Require().NoError(stmt.Bind(suite.ctx, batches[0]))
+	n, err = stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), n)
+	suite.NoFileExists(manifest)
+}
+
+func (suite *DatabricksTests) TestIngestUpsert() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_upsert_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_upsert_test"))
+	}()
+
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+	suite.Require().NoError(stmt.SetOption(databricks.OptionIngestMergeKeys, "id"))
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	for _, rows := range []string{
+		`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`,
+		`[{"id": 2, "name": "B"}, {"id": 3, "name": "c"}]`,
+	} {
+		suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestTargetTable, "ingest_upsert_test"))
+		suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestMode, databricks.OptionValueIngestModeUpsert))
+		rec, _, err := array.RecordFromJSON(suite.Quirks.Alloc(), schema, strings.NewReader(rows))
+		suite.Require().NoError(err)
+		err = stmt.Bind(suite.ctx, rec)
+		rec.Release()
+		suite.Require().NoError(err)
+		n, err := stmt.ExecuteUpdate(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Equal(int64(2), n)
+	}
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT name FROM ingest_upsert_test ORDER BY id"))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+	var names []string
+	for rdr.Next() {
+		col := rdr.RecordBatch().Column(0).(*array.String)
+		for i := range col.Len() {
+			names = append(names, col.Value(i))
+		}
+	}
+	suite.Require().NoError(rdr.Err())
+	suite.Equal([]string{"a", "B", "c"}, names)
+}
+
+func (suite *DatabricksTests) TestCheckIngestSchema() {
+	suite.Require().NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_check_test"))
+	defer func() {
+		suite.NoError(suite.Quirks.DropTable(suite.cnxn, "ingest_check_test"))
+	}()
+	suite.Require().NoError(suite.stmt.SetSqlQuery("CREATE TABLE ingest_check_test (id INT NOT NULL, name STRING)"))
+	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+
+	stmt, err := suite.cnxn.NewStatement()
+	suite.Require().NoError(err)
+	defer validation.CheckedClose(suite.T(), stmt)
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestTargetTable, "ingest_check_test"))
+	suite.Require().NoError(stmt.SetOption(adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeAppend))
+
+	checker, ok := stmt.(databricks.IngestSchemaChecker)
+	suite.Require().True(ok)
+	report, err := checker.CheckIngestSchema(suite.ctx, arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil))
+	suite.Require().NoError(err)
+	suite.True(report.TableExists)
+	suite.True(report.Compatible(), "%+v", report.Issues)
+
+	report, err = checker.CheckIngestSchema(suite.ctx, arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil))
+	suite.Require().NoError(err)
+	suite.False(report.Compatible())
+	kinds := []databricks.IngestIssueKind{}
+	for _, issue := range report.Issues {
+		kinds = append(kinds, issue.Kind)
+	}
+	suite.Contains(kinds, databricks.IngestIssueTypeMismatch)
+}
+
+func (suite *DatabricksTests) TestDatabaseOptions() {
+	testCases := []struct {
+		name   string
+		option string
+		value  string
+	}{
+		{"Port", databricks.OptionPort, "443"},
+		{"SSLMode", databricks.OptionSSLMode, "require"},
+		{"QueryTimeout", databricks.OptionQueryTimeout, "1m0s"},
+		{"MaxRows", databricks.OptionMaxRows, "5000"},
+		{"QueryRetryCount", databricks.OptionQueryRetryCount, "5"},
+		{"DownloadThreadCount", databricks.OptionDownloadThreadCount, "8"},
+		{"CloudFetchMaxParallel", databricks.OptionCloudFetchMaxParallel, "8"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			dbOptions := suite.Quirks.DatabaseOptions()
+			dbOptions[tc.option] = tc.value
+
+			db, err := suite.driver.NewDatabase(dbOptions)
+			suite.NoError(err)
+			defer validation.CheckedClose(suite.T(), db)
+
+			getSetDB, ok := db.(adbc.GetSetOptions)
+			suite.True(ok)
+
+			value, err := getSetDB.GetOption(tc.option)
+			suite.NoError(err)
+			suite.Equal(tc.value, value)
+		})
+	}
+}
+
+// TestErrorHandling validates proper error handling for invalid SQL and missing tables.
+func (suite *DatabricksTests) TestErrorHandling() {
+	suite.Run("InvalidSQL", func() {
+		suite.Require().NoError(suite.stmt.SetSqlQuery("INVALID SQL SYNTAX"))
+		_, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+		suite.Error(err, "Should return error for invalid SQL")
+	})
+
+	suite.Run("NonExistentTable", func() {
+		suite.Require().NoError(suite.stmt.SetSqlQuery("SELECT * FROM non_existent_table_12345"))
+		_, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+		suite.Error(err, "Should return error for non-existent table")
+	})
+
+}
+
+func (suite *DatabricksTests) TestTimestampPrecision() {
+	query := "SELECT CAST('2023-01-01 12:00:00.123456' AS TIMESTAMP) as ts_col"
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+
+	suite.checkRowCount(1, n)
+	suite.True(rdr.Next())
+	rec := rdr.RecordBatch()
+
+	suite.Equal(int64(1), rec.NumCols())
+	suite.Equal("ts_col", rec.ColumnName(0))
+
+	field := rec.Schema().Field(0)
+	suite.Equal(arrow.TIMESTAMP, field.Type.ID())
+
+	suite.False(rdr.Next())
+	suite.Require().NoError(rdr.Err())
+}
+
+func (suite *DatabricksTests) TestDecimalTypes() {
+	query := "SELECT CAST(123.45 AS DECIMAL(10,2)) as decimal_col, CAST(999.999 AS DECIMAL(6,3)) as decimal_col2"
+
+	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+	rdr, n, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+
+	suite.checkRowCount(1, n)
+	suite.True(rdr.Next())
+	rec := rdr.RecordBatch()
+
+	// Verify we got decimal columns
+	suite.Equal(int64(2), rec.NumCols())
+	suite.Equal("decimal_col", rec.ColumnName(0))
+	suite.Equal("decimal_col2", rec.ColumnName(1))
+
+	suite.False(rdr.Next())
+	suite.Require().NoError(rdr.Err())
+}
+
+func (suite *DatabricksTests) TestMultiBatch() {
+	// Regression test for issue reported directly to us
+	query := fmt.Sprintf("CREATE OR REPLACE TABLE `%s`.`%s`.`test_multi_batch` (founder STRING, born STRING)", suite.Quirks.catalogName, suite.Quirks.schemaName)
+	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+	_, err := suite.stmt.ExecuteUpdate(suite.ctx)
+	suite.Require().NoError(err)
+
+	type row struct {
+		founder string
+		born    string
+	}
+
+	rows := []row{
+		{"Ali Ghodsi", "Iran"},
+		{"Ion Stoica", "Romania"},
+		{"Matei Zaharia", "Romania"},
+		{"Patrick Wendell", "USA"},
+		{"Reynold Xin", "China"},
+		{"Andy Konwinski", "USA"},
+		{"Arsalan Tavakoli-Shiraji", "Iran"},
+	}
+	for _, r := range rows {
+		query = fmt.Sprintf("INSERT INTO `%s`.`%s`.`test_multi_batch` VALUES ('%s', '%s')", suite.Quirks.catalogName, suite.Quirks.schemaName, r.founder, r.born)
+		suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+		_, err = suite.stmt.ExecuteUpdate(suite.ctx)
+		suite.Require().NoError(err)
+	}
+
+	query = fmt.Sprintf("SELECT * FROM `%s`.`%s`.`test_multi_batch`", suite.Quirks.catalogName, suite.Quirks.schemaName)
+	suite.Require().NoError(suite.stmt.SetSqlQuery(query))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+
+	// This used to only return one row.
+	rowCount := 0
+	seen := map[string]string{}
+	for rdr.Next() {
+		batch := rdr.RecordBatch()
+		rowCount += int(batch.NumRows())
+
+		founder := batch.Column(0).(*array.String)
+		born := batch.Column(1).(*array.String)
+
+		for i := range int(batch.NumRows()) {
+			seen[founder.Value(i)] = born.Value(i)
+		}
+	}
+	suite.NoError(rdr.Err())
+	suite.Equal(len(rows), rowCount)
+
+	suite.EqualValues(map[string]string{
+		"Ali Ghodsi":               "Iran",
+		"Ion Stoica":               "Romania",
+		"Matei Zaharia":            "Romania",
+		"Patrick Wendell":          "USA",
+		"Reynold Xin":              "China",
+		"Andy Konwinski":           "USA",
+		"Arsalan Tavakoli-Shiraji": "Iran",
+	}, seen)
+}
+
+// TestCloudFetchExpiredLinkProxy reads a CloudFetch result through the test
+// proxy (test-infrastructure/proxy-server) with its cloudfetch_expired_link
+// scenario enabled, which rejects a download as if its link had expired.
+// Run it with HTTPS_PROXY pointing at the proxy, its CA trusted, and
+// DATABRICKS_PROXY_CONTROL_URL set to its control API.
+func (suite *DatabricksTests) TestCloudFetchExpiredLinkProxy() {
+	control := os.Getenv("DATABRICKS_PROXY_CONTROL_URL")
+	if control == "" {
+		suite.T().Skip("DATABRICKS_PROXY_CONTROL_URL not defined, skipping proxy tests")
+	}
+	scenario := control + "/scenarios/cloudfetch_expired_link"
+	resp, err := http.Post(scenario+"/enable", "application/json", nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(resp.Body.Close())
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+	defer func() {
+		resp, err := http.Post(scenario+"/disable", "application/json", nil)
+		suite.Require().NoError(err)
+		suite.Require().NoError(resp.Body.Close())
+	}()
+
+	// Large enough for the result to be sent through CloudFetch
+	const total = 2_000_000
+	suite.Require().NoError(suite.stmt.SetSqlQuery(fmt.Sprintf("SELECT id FROM range(%d) ORDER BY id", total)))
+	rdr, _, err := suite.stmt.ExecuteQuery(suite.ctx)
+	suite.Require().NoError(err)
+	defer rdr.Release()
+
+	var next int64
+	for rdr.Next() {
+		ids := rdr.RecordBatch().Column(0).(*array.Int64).Int64Values()
+		for _, id := range ids {
+			suite.Require().Equal(next, id, "rows skipped or repeated when resuming")
+			next++
+		}
+	}
+	suite.Require().NoError(rdr.Err())
+	suite.Equal(int64(total), next)
 }
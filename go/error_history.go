@@ -0,0 +1,141 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/databricks/databricks-sql-go/driverctx"
+)
+
+// maxErrorHistorySize bounds OptionErrorHistorySize.
+const maxErrorHistorySize = 10_000
+
+// errorRecord is one entry of a connection's error history. It is what
+// OptionErrorHistory reports, so the JSON field names are part of the
+// driver's interface.
+type errorRecord struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	QueryID   string    `json:"query_id,omitempty"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+}
+
+// errorHistory is a fixed-size ring buffer of the most recent errors
+// returned by a connection and its statements.
+type errorHistory struct {
+	mu      sync.Mutex
+	size    int
+	records []errorRecord
+	// next is the slot to overwrite once the buffer is full; it is also
+	// where the oldest record lives.
+	next int
+}
+
+func newErrorHistory(size int) *errorHistory {
+	return &errorHistory{size: size}
+}
+
+func (h *errorHistory) add(rec errorRecord) {
+	if h == nil || h.size <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.records) < h.size {
+		h.records = append(h.records, rec)
+		return
+	}
+	h.records[h.next] = rec
+	h.next = (h.next + 1) % h.size
+}
+
+// snapshot returns a copy of the history, oldest first.
+func (h *errorHistory) snapshot() []errorRecord {
+	if h == nil {
+		return []errorRecord{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]errorRecord, 0, len(h.records))
+	out = append(out, h.records[h.next:]...)
+	return append(out, h.records[:h.next]...)
+}
+
+func (h *errorHistory) json() (string, error) {
+	data, err := json.Marshal(h.snapshot())
+	if err != nil {
+		return "", adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  "failed to encode error history: " + err.Error(),
+		}
+	}
+	return string(data), nil
+}
+
+// newErrorRecord describes err as an entry of the error history.
+func newErrorRecord(operation, queryID string, err error) errorRecord {
+	rec := errorRecord{
+		Time:      time.Now().UTC(),
+		Operation: operation,
+		QueryID:   queryID,
+		Status:    adbc.StatusUnknown.String(),
+		Message:   err.Error(),
+	}
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) {
+		rec.Status = adbcErr.Code.String()
+		rec.Message = adbcErr.Msg
+	}
+	return rec
+}
+
+// queryIDTracker captures the ID that Databricks assigns to a query so that
+// an error can be matched with the query history on the server.
+type queryIDTracker struct {
+	id atomic.Value
+}
+
+// attach returns a context that reports the query ID to the tracker. The
+// callback may fire from the goroutines that fetch results, hence the
+// atomic.
+func (t *queryIDTracker) attach(ctx context.Context) context.Context {
+	return driverctx.NewContextWithQueryIdCallback(ctx, func(id string) {
+		t.id.Store(id)
+	})
+}
+
+func (t *queryIDTracker) get() string {
+	if t == nil {
+		return ""
+	}
+	id, _ := t.id.Load().(string)
+	return id
+}
+
+// reportQueryID passes the ID of a query run outside databricks-sql-go
+// (e.g. through the Statement Execution API) to the callback in ctx.
+func reportQueryID(ctx context.Context, id string) {
+	if callback, ok := ctx.Value(driverctx.QueryIdCallbackKey).(driverctx.IdCallbackFunc); ok {
+		callback(id)
+	}
+}
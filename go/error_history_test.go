@@ -0,0 +1,87 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/databricks/databricks-sql-go/driverctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func operations(records []errorRecord) []string {
+	ops := make([]string, len(records))
+	for i, r := range records {
+		ops[i] = r.Operation
+	}
+	return ops
+}
+
+func TestErrorHistoryWrapsAround(t *testing.T) {
+	h := newErrorHistory(3)
+	for _, op := range []string{"a", "b"} {
+		h.add(errorRecord{Operation: op})
+	}
+	assert.Equal(t, []string{"a", "b"}, operations(h.snapshot()))
+
+	for _, op := range []string{"c", "d", "e"} {
+		h.add(errorRecord{Operation: op})
+	}
+	assert.Equal(t, []string{"c", "d", "e"}, operations(h.snapshot()))
+
+	h.add(errorRecord{Operation: "f"})
+	assert.Equal(t, []string{"d", "e", "f"}, operations(h.snapshot()))
+}
+
+func TestErrorHistoryDisabled(t *testing.T) {
+	h := newErrorHistory(0)
+	h.add(errorRecord{Operation: "a"})
+	assert.Empty(t, h.snapshot())
+
+	var nilHistory *errorHistory
+	nilHistory.add(errorRecord{Operation: "a"})
+	value, err := nilHistory.json()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+}
+
+func TestNewErrorRecord(t *testing.T) {
+	rec := newErrorRecord("ExecuteQuery", "01ef-query", adbc.Error{
+		Code: adbc.StatusIO,
+		Msg:  "connection reset",
+	})
+	assert.Equal(t, "ExecuteQuery", rec.Operation)
+	assert.Equal(t, "01ef-query", rec.QueryID)
+	assert.Equal(t, adbc.StatusIO.String(), rec.Status)
+	assert.Equal(t, "connection reset", rec.Message)
+	assert.False(t, rec.Time.IsZero())
+
+	rec = newErrorRecord("GetTables", "", errors.New("boom"))
+	assert.Equal(t, adbc.StatusUnknown.String(), rec.Status)
+	assert.Equal(t, "boom", rec.Message)
+}
+
+func TestQueryIDTracker(t *testing.T) {
+	var tracker queryIDTracker
+	assert.Empty(t, tracker.get())
+
+	ctx := tracker.attach(context.Background())
+	driverctx.NewContextWithQueryId(ctx, "01ef-query")
+	assert.Equal(t, "01ef-query", tracker.get())
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// classifiedError is an adbc.Error that also matches one of the sentinels
+// of the errors package. errors.As still finds the adbc.Error, so ADBC
+// status codes are reported as before.
+type classifiedError struct {
+	status adbc.Error
+	kind   error
+	cause  error
+}
+
+func (e *classifiedError) Error() string {
+	// Errors that weren't adbc.Errors keep their message, without a status
+	var adbcErr adbc.Error
+	if !errors.As(e.cause, &adbcErr) {
+		return e.status.Msg
+	}
+	return e.status.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
+
+func (e *classifiedError) As(target any) bool {
+	if t, ok := target.(*adbc.Error); ok {
+		*t = e.status
+		return true
+	}
+	return false
+}
+
+// rejectedDownload matches the errors databricks-sql-go reports when cloud
+// storage rejects a CloudFetch download of a Thrift result, which, like
+// errLinkExpired, usually means the presigned link has expired.
+// databricks-sql-go fetches and downloads the links itself, so it can't
+// refresh them; linkResumeReader reads the rest of the result over the
+// Statement Execution API instead.
+var rejectedDownload = regexp.MustCompile(`download.*http error (403|404|410)\b|request has expired|authorizationqueryparameterserror`)
+
+// classifyError returns err wrapped so that errors.Is matches the sentinel
+// describing it, or err itself if no sentinel does. Most of the driver's
+// errors carry their cause only as text, so they are recognized by the
+// messages the server and databricks-sql-go use.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	var kind error
+	var expired errLinkExpired
+	msg := strings.ToLower(err.Error())
+	switch {
+	case isMaintenance(msg):
+		kind = dbxerrors.ErrMaintenance
+	case errors.As(err, &expired) || strings.Contains(msg, "link expired") || rejectedDownload.MatchString(msg):
+		kind = dbxerrors.ErrLinkExpired
+	case errors.Is(err, driver.ErrBadConn) || strings.Contains(msg, "invalid sessionhandle"):
+		kind = dbxerrors.ErrSessionExpired
+	case strings.Contains(msg, "429 too many requests") || strings.Contains(msg, "request_limit_exceeded") ||
+		strings.Contains(msg, "too_many_requests"):
+		kind = dbxerrors.ErrRateLimited
+	case isClusterStarting(err):
+		kind = dbxerrors.ErrWarehouseStarting
+	default:
+		return err
+	}
+
+	var adbcErr adbc.Error
+	if !errors.As(err, &adbcErr) {
+		adbcErr = adbc.Error{Code: adbc.StatusIO, Msg: err.Error(), Details: retryDetails(err)}
+	}
+	if kind == dbxerrors.ErrMaintenance {
+		adbcErr = withMaintenanceDetail(adbcErr, retryAfter(msg))
+	}
+	return &classifiedError{status: adbcErr, kind: kind, cause: err}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		kind error
+	}{
+		{adbc.Error{Code: adbc.StatusIO, Msg: "failed to execute query: Invalid SessionHandle: 01ef-abcd"}, dbxerrors.ErrSessionExpired},
+		{fmt.Errorf("failed to open session: %w", driver.ErrBadConn), dbxerrors.ErrSessionExpired},
+		{adbc.Error{Code: adbc.StatusIO, Msg: "unexpected HTTP status 503 Service Unavailable: TEMPORARILY_UNAVAILABLE"}, dbxerrors.ErrWarehouseStarting},
+		{adbc.Error{Code: adbc.StatusIO, Msg: "POST /api/2.0/sql/statements failed: 429 Too Many Requests: slow down"}, dbxerrors.ErrRateLimited},
+		{adbc.Error{Code: adbc.StatusInternal, Msg: "failed to read result: link expired"}, dbxerrors.ErrLinkExpired},
+		{errors.New("databricks: driver error: cloud fetch batch loader failed to download results: HTTP error 403"), dbxerrors.ErrLinkExpired},
+		{errors.New("failed to fetch chunk: AuthorizationQueryParametersError: Query Parameters are not supported for this operation"), dbxerrors.ErrLinkExpired},
+	} {
+		t.Run(tc.kind.Error(), func(t *testing.T) {
+			err := classifyError(tc.err)
+			assert.ErrorIs(t, err, tc.kind)
+			assert.Equal(t, tc.err.Error(), err.Error())
+
+			var adbcErr adbc.Error
+			require.ErrorAs(t, err, &adbcErr)
+			var original adbc.Error
+			if errors.As(tc.err, &original) {
+				assert.Equal(t, original, adbcErr)
+			} else {
+				assert.Equal(t, adbc.StatusIO, adbcErr.Code)
+			}
+
+			// Classifying again changes nothing
+			assert.Same(t, err, classifyError(err))
+		})
+	}
+
+	other := adbc.Error{Code: adbc.StatusInvalidArgument, Msg: "syntax error"}
+	assert.Equal(t, error(other), classifyError(other))
+	other = adbc.Error{Code: adbc.StatusIO, Msg: "failed to download results: HTTP error 500"}
+	assert.Equal(t, error(other), classifyError(other))
+	assert.NoError(t, classifyError(nil))
+}
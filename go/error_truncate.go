@@ -0,0 +1,97 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// errorClassPattern matches the error class the server puts in brackets
+// in its messages, e.g. [TABLE_OR_VIEW_NOT_FOUND] or
+// [INVALID_PARAMETER_VALUE.LOCATION_OVERLAP].
+var errorClassPattern = regexp.MustCompile(`\[[A-Z][A-Z0-9_]*(\.[A-Z0-9_]+)*\]`)
+
+// truncatedError is an error whose message was shortened by
+// truncateMessage. It still matches its cause with errors.Is and errors.As.
+type truncatedError struct {
+	msg   string
+	cause error
+}
+
+func (e *truncatedError) Error() string {
+	return e.msg
+}
+
+func (e *truncatedError) Unwrap() error {
+	return e.cause
+}
+
+// truncateError returns err with its message shortened to about limit
+// bytes by truncateMessage, keeping its ADBC status and error kind. A
+// limit of zero leaves err as it is.
+func truncateError(err error, queryID string, limit int) error {
+	if limit <= 0 || len(err.Error()) <= limit {
+		return err
+	}
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		truncated := *classified
+		truncated.status.Msg = truncateMessage(classified.status.Msg, queryID, limit)
+		return &truncated
+	}
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) {
+		adbcErr.Msg = truncateMessage(adbcErr.Msg, queryID, limit)
+		return adbcErr
+	}
+	return &truncatedError{msg: truncateMessage(err.Error(), queryID, limit), cause: err}
+}
+
+// truncateMessage shortens msg to its first and last limit/2 bytes, with a
+// marker saying how many bytes were cut in between. The error class and
+// query ID are added back if they were cut.
+func truncateMessage(msg, queryID string, limit int) string {
+	if limit <= 0 || len(msg) <= limit {
+		return msg
+	}
+	// Cut on character boundaries
+	headEnd := limit / 2
+	for headEnd > 0 && !utf8.RuneStart(msg[headEnd]) {
+		headEnd--
+	}
+	tailStart := len(msg) - limit/2
+	for tailStart < len(msg) && !utf8.RuneStart(msg[tailStart]) {
+		tailStart++
+	}
+	head, tail := msg[:headEnd], msg[tailStart:]
+
+	var b strings.Builder
+	if class := errorClassPattern.FindString(msg); class != "" && !strings.Contains(head, class) {
+		b.WriteString(class + " ")
+	}
+	b.WriteString(head)
+	fmt.Fprintf(&b, "\n... [%d bytes truncated] ...\n", tailStart-headEnd)
+	b.WriteString(tail)
+	if queryID != "" && !strings.Contains(head, queryID) && !strings.Contains(tail, queryID) {
+		fmt.Fprintf(&b, " (query ID %s)", queryID)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateMessage(t *testing.T) {
+	assert.Equal(t, "short", truncateMessage("short", "", 10))
+	assert.Equal(t, strings.Repeat("x", 100), truncateMessage(strings.Repeat("x", 100), "", 0))
+
+	msg := "plan: " + strings.Repeat("a", 1000) + " [TABLE_OR_VIEW_NOT_FOUND] " + strings.Repeat("z", 1000) + " end"
+	truncated := truncateMessage(msg, "01ef-query", 100)
+	assert.True(t, strings.HasPrefix(truncated, "[TABLE_OR_VIEW_NOT_FOUND] plan: aaa"), truncated)
+	assert.Contains(t, truncated, "\n... [1937 bytes truncated] ...\nzzz")
+	assert.True(t, strings.HasSuffix(truncated, "zzz end (query ID 01ef-query)"), truncated)
+
+	// A class and query ID that survive aren't repeated
+	msg = "[DIVIDE_BY_ZERO] query 01ef-query " + strings.Repeat("a", 1000)
+	truncated = truncateMessage(msg, "01ef-query", 100)
+	assert.Equal(t, 1, strings.Count(truncated, "[DIVIDE_BY_ZERO]"))
+	assert.Equal(t, 1, strings.Count(truncated, "01ef-query"))
+
+	// Multi-byte characters aren't split
+	truncated = truncateMessage(strings.Repeat("é", 100), "", 51)
+	assert.True(t, strings.HasPrefix(truncated, strings.Repeat("é", 12)+"\n"), truncated)
+	assert.True(t, strings.HasSuffix(truncated, "\n"+strings.Repeat("é", 12)), truncated)
+}
+
+func TestTruncateError(t *testing.T) {
+	long := strings.Repeat("x", 1000)
+
+	err := truncateError(adbc.Error{Code: adbc.StatusNotFound, Msg: long, SqlState: [5]byte{'4', '2', 'P', '0', '1'}}, "", 100)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotFound, adbcErr.Code)
+	assert.Equal(t, [5]byte{'4', '2', 'P', '0', '1'}, adbcErr.SqlState)
+	assert.Less(t, len(adbcErr.Msg), 150)
+
+	// Classified errors keep their kind
+	err = truncateError(classifyError(errors.New("429 Too Many Requests "+long)), "", 100)
+	assert.ErrorIs(t, err, dbxerrors.ErrRateLimited)
+	assert.Less(t, len(err.Error()), 200)
+
+	cause := errors.New(long)
+	err = truncateError(cause, "", 100)
+	assert.ErrorIs(t, err, cause)
+	assert.Less(t, len(err.Error()), 150)
+
+	short := errors.New("short")
+	assert.Equal(t, short, truncateError(short, "", 100))
+	assert.Equal(t, cause, truncateError(cause, "", 0))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errors defines sentinel errors for conditions that callers of the
+// Databricks ADBC driver commonly handle, such as retrying once a warehouse
+// has started.
+//
+// The driver reports failures as adbc.Error values carrying an ADBC status
+// code. When it recognizes one of the conditions below, the error it
+// returns also matches the corresponding sentinel with errors.Is, while
+// errors.As still extracts the adbc.Error:
+//
+//	if errors.Is(err, dbxerrors.ErrRateLimited) {
+//		// back off and retry
+//	}
+package errors
+
+import "errors"
+
+var (
+	// ErrSessionExpired means the server no longer knows the connection's
+	// session, e.g. after it was idle for too long. The connection must be
+	// reopened.
+	ErrSessionExpired = errors.New("databricks: session expired")
+	// ErrWarehouseStarting means the SQL warehouse or cluster is not
+	// running yet. Retrying after a while usually succeeds.
+	ErrWarehouseStarting = errors.New("databricks: warehouse is starting")
+	// ErrLinkExpired means a presigned link to a result chunk expired
+	// before it was downloaded. Results read through the Statement
+	// Execution API ask the server for new links once first, and Thrift
+	// results read on SQL warehouses resume through that API. Re-running
+	// the query reads fresh links.
+	ErrLinkExpired = errors.New("databricks: result link expired")
+	// ErrRateLimited means the workspace rejected a request because too
+	// many were made. Retrying with a backoff usually succeeds.
+	ErrRateLimited = errors.New("databricks: rate limited")
+	// ErrMaintenance means the workspace or warehouse rejected a request
+	// because it is in a planned maintenance or upgrade window. Retrying
+	// once the window has passed succeeds; the driver's error suggests a
+	// backoff in its databricks.maintenance detail.
+	ErrMaintenance = errors.New("databricks: maintenance in progress")
+)
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sql-go/auth"
+)
+
+// ConnectionEventKind is the kind of a ConnectionEvent.
+type ConnectionEventKind string
+
+const (
+	// ConnectionEventOpened is sent once Open has returned the connection.
+	ConnectionEventOpened ConnectionEventKind = "opened"
+	// ConnectionEventClosed is sent when the connection is closed.
+	ConnectionEventClosed ConnectionEventKind = "closed"
+	// ConnectionEventSessionRecovered is sent when the connection opens a
+	// new session to replace one closed after OptionSessionIdleTimeout.
+	ConnectionEventSessionRecovered ConnectionEventKind = "session_recovered"
+	// ConnectionEventWarehouseStarted is sent when opening a session had to
+	// wait for the warehouse or cluster to start (see
+	// OptionClusterStartTimeout).
+	ConnectionEventWarehouseStarted ConnectionEventKind = "warehouse_started"
+	// ConnectionEventAuthTokenRefreshed is sent when the OAuth access token
+	// used by the connection has been replaced by a new one.
+	ConnectionEventAuthTokenRefreshed ConnectionEventKind = "auth_token_refreshed"
+)
+
+// ConnectionEvent is an event in the lifecycle of a connection.
+type ConnectionEvent struct {
+	Kind ConnectionEventKind
+	Time time.Time
+	// The workspace the connection was opened to (see WithWorkspace), or
+	// empty for the one of OptionServerHostname and OptionHTTPPath
+	Workspace string
+}
+
+// ConnectionListener is called with the events of a connection. It is
+// called synchronously from whichever goroutine caused the event, so it
+// should return quickly.
+type ConnectionListener func(ConnectionEvent)
+
+type connectionListenersKey struct{}
+
+// WithConnectionListener returns a copy of ctx that makes the Open of a
+// database register listener for the events of the connection it opens,
+// from ConnectionEventOpened until ConnectionEventClosed. Listeners added
+// to the same context are called in the order they were added.
+func WithConnectionListener(ctx context.Context, listener ConnectionListener) context.Context {
+	listeners, _ := ctx.Value(connectionListenersKey{}).([]ConnectionListener)
+	return context.WithValue(ctx, connectionListenersKey{}, append(listeners[:len(listeners):len(listeners)], listener))
+}
+
+// connectionEvents sends the events of a connection to its listeners. A
+// nil *connectionEvents has no listeners.
+type connectionEvents struct {
+	listeners []ConnectionListener
+	workspace string
+}
+
+// newConnectionEvents returns the events of a connection to workspace
+// opened with ctx, or nil if ctx has no listeners.
+func newConnectionEvents(ctx context.Context, workspace string) *connectionEvents {
+	listeners, _ := ctx.Value(connectionListenersKey{}).([]ConnectionListener)
+	if len(listeners) == 0 {
+		return nil
+	}
+	return &connectionEvents{listeners: listeners, workspace: workspace}
+}
+
+func (e *connectionEvents) emit(kind ConnectionEventKind) {
+	if e == nil {
+		return
+	}
+	event := ConnectionEvent{Kind: kind, Time: time.Now(), Workspace: e.workspace}
+	for _, listener := range e.listeners {
+		listener(event)
+	}
+}
+
+// eventAuthenticator is an OAuth authenticator that tells the connections
+// using it when it starts sending a new access token. Connections to the
+// same host share one, so that each refresh is reported once.
+type eventAuthenticator struct {
+	auth.Authenticator
+	mu sync.Mutex
+	// The Authorization header last sent
+	last        string
+	subscribers map[*connectionEvents]struct{}
+}
+
+func (a *eventAuthenticator) Authenticate(r *http.Request) error {
+	if err := a.Authenticator.Authenticate(r); err != nil {
+		return err
+	}
+	header := r.Header.Get("Authorization")
+	a.mu.Lock()
+	refreshed := a.last != "" && header != a.last
+	a.last = header
+	var subscribers []*connectionEvents
+	if refreshed {
+		for events := range a.subscribers {
+			subscribers = append(subscribers, events)
+		}
+	}
+	a.mu.Unlock()
+
+	for _, events := range subscribers {
+		events.emit(ConnectionEventAuthTokenRefreshed)
+	}
+	return nil
+}
+
+// subscribe reports token refreshes to events until the returned function
+// is called.
+func (a *eventAuthenticator) subscribe(events *connectionEvents) func() {
+	if a == nil || events == nil {
+		return func() {}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.subscribers == nil {
+		a.subscribers = make(map[*connectionEvents]struct{})
+	}
+	a.subscribers[events] = struct{}{}
+	return func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		delete(a.subscribers, events)
+	}
+}
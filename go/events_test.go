@@ -0,0 +1,135 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordListener returns a listener that appends the kinds of its events
+// to kinds.
+func recordListener(kinds *[]ConnectionEventKind) ConnectionListener {
+	return func(event ConnectionEvent) {
+		*kinds = append(*kinds, event.Kind)
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	assert.Nil(t, newConnectionEvents(context.Background(), ""))
+	// A nil *connectionEvents has no listeners
+	var none *connectionEvents
+	none.emit(ConnectionEventOpened)
+
+	var first, second []ConnectionEventKind
+	ctx := WithConnectionListener(context.Background(), recordListener(&first))
+	ctx = WithConnectionListener(ctx, recordListener(&second))
+	var workspace string
+	ctx = WithConnectionListener(ctx, func(event ConnectionEvent) {
+		workspace = event.Workspace
+	})
+
+	events := newConnectionEvents(ctx, "staging")
+	require.NotNil(t, events)
+	events.emit(ConnectionEventOpened)
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventOpened}, first)
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventOpened}, second)
+	assert.Equal(t, "staging", workspace)
+}
+
+func TestConnectionLifecycleEvents(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	var kinds []ConnectionEventKind
+	events := newConnectionEvents(WithConnectionListener(context.Background(), recordListener(&kinds)), "")
+	cnxn := &connectionImpl{db: db, idleTimeout: timeout, events: events}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, kinds)
+	require.Eventually(t, func() bool {
+		_, closed := connector.counts()
+		return closed == 1
+	}, time.Second, timeout/4)
+
+	// The session replacing the idle one is a recovery
+	_, err = cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventSessionRecovered}, kinds)
+
+	require.NoError(t, cnxn.Close())
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventSessionRecovered, ConnectionEventClosed}, kinds)
+
+	// Closing again fails, without another event
+	var adbcErr adbc.Error
+	require.ErrorAs(t, cnxn.Close(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+	assert.Len(t, kinds, 2)
+}
+
+// tokenAuth is an authenticator that sends token.
+type tokenAuth struct {
+	token string
+}
+
+func (a *tokenAuth) Authenticate(r *http.Request) error {
+	r.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func TestEventAuthenticator(t *testing.T) {
+	inner := &tokenAuth{token: "first"}
+	authenticator := &eventAuthenticator{Authenticator: inner}
+
+	var kinds, other []ConnectionEventKind
+	stop := authenticator.subscribe(newConnectionEvents(WithConnectionListener(context.Background(), recordListener(&kinds)), ""))
+	authenticator.subscribe(newConnectionEvents(WithConnectionListener(context.Background(), recordListener(&other)), ""))
+
+	authenticate := func() {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, err)
+		require.NoError(t, authenticator.Authenticate(req))
+	}
+
+	// The first token and its reuse aren't refreshes
+	authenticate()
+	authenticate()
+	assert.Empty(t, kinds)
+
+	inner.token = "second"
+	authenticate()
+	authenticate()
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventAuthTokenRefreshed}, kinds)
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventAuthTokenRefreshed}, other)
+
+	// Unsubscribed connections hear no more
+	stop()
+	inner.token = "third"
+	authenticate()
+	assert.Equal(t, []ConnectionEventKind{ConnectionEventAuthTokenRefreshed}, kinds)
+	assert.Len(t, other, 2)
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// linkResumeReader reads a Thrift Arrow result, and if one of its CloudFetch
+// links expires, reads the rest of the result through the Statement
+// Execution API instead. databricks-sql-go can't request the links of a
+// Thrift result again, but the server resolves the chunks of the same
+// query by its ID, so reading resumes at the chunk holding the first row
+// not yet returned.
+type linkResumeReader struct {
+	refCount int64
+	ctx      context.Context
+	stmt     *statementImpl
+	mem      memory.Allocator
+	queryID  string
+	schema   *arrow.Schema
+	declared []arrow.DataType
+	// The Thrift reader, released once reading resumes
+	thrift array.RecordReader
+	// Rows returned so far, and those of the resumed reader's first chunk
+	// that had already been returned over Thrift
+	offset int64
+	skip   int64
+
+	resumed *resultReader
+	current arrow.RecordBatch
+	err     error
+}
+
+func newLinkResumeReader(ctx context.Context, stmt *statementImpl, mem memory.Allocator, queryID string, thrift array.RecordReader) *linkResumeReader {
+	r := &linkResumeReader{
+		refCount: 1,
+		ctx:      ctx,
+		stmt:     stmt,
+		mem:      mem,
+		queryID:  queryID,
+		schema:   thrift.Schema(),
+		thrift:   thrift,
+	}
+	if declared, ok := thrift.(declaredTypesSource); ok {
+		r.declared = declared.declaredTypes()
+	}
+	return r
+}
+
+// resume opens the Statement Execution API reader at the chunk holding the
+// row at r.offset.
+func (r *linkResumeReader) resume() error {
+	if r.queryID == "" {
+		return adbc.Error{Code: adbc.StatusInvalidState, Msg: "the query ID is unknown"}
+	}
+	resp, err := r.stmt.conn.statementAPI.get(r.ctx, r.queryID)
+	if err != nil {
+		return err
+	}
+	if err := resp.finished(); err != nil {
+		return err
+	}
+	chunk, ok := chunkAtRow(resp.Manifest, r.offset)
+	if !ok {
+		return adbc.Error{
+			Code: adbc.StatusInvalidState,
+			Msg:  fmt.Sprintf("statement %s has no chunk holding row %d", r.queryID, r.offset),
+		}
+	}
+	resumed, err := r.stmt.conn.newResultReaderFrom(r.stmt.laneContext(r.ctx, resp), r.mem, resp, chunk.ChunkIndex)
+	if err != nil {
+		return err
+	}
+	if !sameColumnTypes(resumed.Schema(), r.schema) {
+		resumed.Release()
+		return adbc.Error{
+			Code: adbc.StatusInvalidState,
+			Msg:  fmt.Sprintf("statement %s returned a different schema over the Statement Execution API", r.queryID),
+		}
+	}
+	r.resumed = resumed
+	r.skip = r.offset - chunk.RowOffset
+	r.thrift.Release()
+	r.thrift = nil
+	return nil
+}
+
+// chunkAtRow returns the chunk of manifest that holds the row at offset.
+func chunkAtRow(manifest *resultManifest, offset int64) (chunkInfo, bool) {
+	for _, chunk := range manifest.Chunks {
+		if chunk.RowOffset <= offset && offset < chunk.RowOffset+chunk.RowCount {
+			return chunk, true
+		}
+	}
+	return chunkInfo{}, false
+}
+
+// sameColumnTypes reports whether a and b have the same column names and
+// types, ignoring metadata, which differs between the two protocols.
+func sameColumnTypes(a, b *arrow.Schema) bool {
+	if a.NumFields() != b.NumFields() {
+		return false
+	}
+	for i := range a.NumFields() {
+		if a.Field(i).Name != b.Field(i).Name || !arrow.TypeEqual(a.Field(i).Type, b.Field(i).Type) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *linkResumeReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *linkResumeReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.releaseCurrent()
+		if r.resumed != nil {
+			r.resumed.Release()
+			r.resumed = nil
+		}
+		if r.thrift != nil {
+			r.thrift.Release()
+			r.thrift = nil
+		}
+	}
+}
+
+func (r *linkResumeReader) releaseCurrent() {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+}
+
+func (r *linkResumeReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *linkResumeReader) Next() bool {
+	r.releaseCurrent()
+	if r.err != nil {
+		return false
+	}
+	if r.resumed == nil {
+		if r.thrift.Next() {
+			r.current = r.thrift.RecordBatch()
+			r.current.Retain()
+			r.offset += r.current.NumRows()
+			return true
+		}
+		err := r.thrift.Err()
+		if !errors.Is(err, dbxerrors.ErrLinkExpired) {
+			return false
+		}
+		r.stmt.conn.Logger.WarnContext(r.ctx, "CloudFetch link expired; resuming over the Statement Execution API",
+			"query_id", r.queryID, "row_offset", r.offset)
+		if resumeErr := r.resume(); resumeErr != nil {
+			r.err = &classifiedError{
+				status: adbc.Error{
+					Code: adbc.StatusIO,
+					Msg:  fmt.Sprintf("%v; failed to resume at row %d: %v", err, r.offset, resumeErr),
+				},
+				kind:  dbxerrors.ErrLinkExpired,
+				cause: resumeErr,
+			}
+			return false
+		}
+	}
+	for r.resumed.Next() {
+		rec := r.resumed.RecordBatch()
+		rows := rec.NumRows()
+		if r.skip >= rows {
+			r.skip -= rows
+			continue
+		}
+		if r.skip > 0 {
+			r.current = rec.NewSlice(r.skip, rows)
+			r.skip = 0
+		} else {
+			r.current = rec
+			r.current.Retain()
+		}
+		r.offset += r.current.NumRows()
+		return true
+	}
+	return false
+}
+
+func (r *linkResumeReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *linkResumeReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *linkResumeReader) declaredTypes() []arrow.DataType {
+	return r.declared
+}
+
+func (r *linkResumeReader) batchStats() batchStats {
+	if r.resumed != nil {
+		return r.resumed.batchStats()
+	}
+	if stats, ok := r.thrift.(batchStatsSource); ok {
+		return stats.batchStats()
+	}
+	return batchStats{}
+}
+
+func (r *linkResumeReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.resumed != nil {
+		return r.resumed.Err()
+	}
+	return r.thrift.Err()
+}
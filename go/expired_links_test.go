@@ -0,0 +1,181 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiredLinkError is how databricks-sql-go reports the download the
+// proxy's cloudfetch_expired_link scenario rejects.
+var expiredLinkError = errors.New("databricks: driver error: error fetching cloud fetch batch: HTTP error 403: " +
+	"AuthorizationQueryParametersError: Query Parameters are not supported for this operation")
+
+// newChunkedStatementAPI serves statement stmt-1 with one chunk per entry
+// of chunks, and records which chunks' links were resolved.
+func newChunkedStatementAPI(t *testing.T, chunks [][]int64) (*statementAPI, func() []int64) {
+	mem := memory.NewGoAllocator()
+	var (
+		mu       sync.Mutex
+		resolved []int64
+		infos    []chunkInfo
+		streams  [][]byte
+		offset   int64
+	)
+	for i, ids := range chunks {
+		names := make([]string, len(ids))
+		batch := makeConcatTestBatch(t, mem, ids, names)
+		streams = append(streams, writeIPCStream(t, concatTestSchema, batch))
+		batch.Release()
+		infos = append(infos, chunkInfo{ChunkIndex: int64(i), RowOffset: offset, RowCount: int64(len(ids))})
+		offset += int64(len(ids))
+	}
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1", func(w http.ResponseWriter, r *http.Request) {
+		resp := statementResponse{StatementID: "stmt-1", Manifest: &resultManifest{
+			TotalChunkCount: int64(len(chunks)),
+			TotalRowCount:   offset,
+			Chunks:          infos,
+		}}
+		resp.Status.State = statementStateSucceeded
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1/result/chunks/{index}", func(w http.ResponseWriter, r *http.Request) {
+		var index int64
+		_, _ = fmt.Sscan(r.PathValue("index"), &index)
+		mu.Lock()
+		resolved = append(resolved, index)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(resultData{ExternalLinks: []externalLink{{
+			ChunkIndex:   index,
+			ExternalLink: fmt.Sprintf("%s/storage/%d", server.URL, index),
+		}}})
+	})
+	mux.HandleFunc("GET /storage/{index}", func(w http.ResponseWriter, r *http.Request) {
+		var index int
+		_, _ = fmt.Sscan(r.PathValue("index"), &index)
+		_, _ = w.Write(streams[index])
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	api := &statementAPI{
+		client:      server.Client(),
+		storage:     server.Client(),
+		baseURL:     server.URL,
+		auth:        &pat.PATAuth{AccessToken: "token"},
+		warehouseID: "wh",
+	}
+	return api, func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64(nil), resolved...)
+	}
+}
+
+// TestLinkResumeReader reads a Thrift result whose CloudFetch download is
+// rejected as in the proxy's cloudfetch_expired_link scenario.
+func TestLinkResumeReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// The Thrift result has three streams of two rows; the Statement
+	// Execution API result has a chunk of four rows and one of two
+	var streams [][]byte
+	for i := range int64(3) {
+		batch := makeConcatTestBatch(t, mem, []int64{2 * i, 2*i + 1}, []string{"", ""})
+		streams = append(streams, writeIPCStream(t, concatTestSchema, batch))
+		batch.Release()
+	}
+
+	for _, tc := range []struct {
+		name     string
+		failAt   int
+		resolved []int64
+	}{
+		{"within a chunk", 1, []int64{0, 1}},
+		{"at a chunk boundary", 2, []int64{1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			api, resolved := newChunkedStatementAPI(t, [][]int64{{0, 1, 2, 3}, {4, 5}})
+			stmt := newLinkResumeTestStatement(t, api, mem)
+
+			rdr := newLinkResumeTestReader(t, stmt, mem, "stmt-1", streams, tc.failAt)
+			defer rdr.Release()
+
+			var ids []int64
+			for rdr.Next() {
+				ids = append(ids, rdr.RecordBatch().Column(0).(*array.Int64).Int64Values()...)
+			}
+			require.NoError(t, rdr.Err())
+			assert.Equal(t, []int64{0, 1, 2, 3, 4, 5}, ids)
+			assert.Equal(t, tc.resolved, resolved())
+		})
+	}
+
+	t.Run("resume fails", func(t *testing.T) {
+		api, _ := newChunkedStatementAPI(t, [][]int64{{0, 1, 2, 3}, {4, 5}})
+		stmt := newLinkResumeTestStatement(t, api, mem)
+
+		rdr := newLinkResumeTestReader(t, stmt, mem, "stmt-2", streams, 1)
+		defer rdr.Release()
+
+		require.True(t, rdr.Next())
+		assert.False(t, rdr.Next())
+		var adbcErr adbc.Error
+		require.ErrorAs(t, rdr.Err(), &adbcErr)
+		assert.Equal(t, adbc.StatusIO, adbcErr.Code)
+		assert.ErrorIs(t, rdr.Err(), dbxerrors.ErrLinkExpired)
+	})
+}
+
+func newLinkResumeTestStatement(t *testing.T, api *statementAPI, mem memory.Allocator) *statementImpl {
+	cnxn := &connectionImpl{statementAPI: api}
+	cnxn.Alloc = mem
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, stmt.Close()) })
+	return stmt.(*statementImpl)
+}
+
+func newLinkResumeTestReader(t *testing.T, stmt *statementImpl, mem memory.Allocator, queryID string, streams [][]byte, failAt int) *linkResumeReader {
+	rows := &mockRows{iterator: &failingIPCStreamIterator{
+		mockIPCStreamIterator: &mockIPCStreamIterator{streams: streams},
+		failAt:                failAt,
+		err:                   expiredLinkError,
+	}}
+	thrift, err := newIPCReaderAdapter(context.Background(), mem, rows)
+	require.NoError(t, err)
+	return newLinkResumeReader(context.Background(), stmt, mem, queryID, thrift)
+}
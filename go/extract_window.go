@@ -0,0 +1,193 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// windowBound is a bound of OptionExtractWindowStart or
+// OptionExtractWindowEnd: a date, or a time with an offset.
+type windowBound struct {
+	t        time.Time
+	dateOnly bool
+}
+
+// parseWindowBound parses a window bound; an empty value unsets it.
+func parseWindowBound(key, value string) (*windowBound, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return &windowBound{t: t, dateOnly: true}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, invalidOption(key, value, "a date (2006-01-02) or an RFC 3339 timestamp")
+	}
+	return &windowBound{t: t}, nil
+}
+
+func (b *windowBound) String() string {
+	if b == nil {
+		return ""
+	}
+	if b.dateOnly {
+		return b.t.Format(time.DateOnly)
+	}
+	return b.t.Format(time.RFC3339Nano)
+}
+
+// literal renders the bound as a literal comparable with columns of type
+// dt. Times are compared with TIMESTAMP_NTZ columns by their local time.
+func (b *windowBound) literal(dt arrow.DataType) string {
+	if b.dateOnly {
+		if dt.ID() == arrow.DATE32 {
+			return "DATE'" + b.String() + "'"
+		}
+		if isNaiveTimestamp(dt) {
+			return "TIMESTAMP_NTZ'" + b.String() + "'"
+		}
+		return "TIMESTAMP'" + b.String() + "'"
+	}
+	switch {
+	case dt.ID() == arrow.DATE32:
+		return "CAST(TIMESTAMP'" + b.String() + "' AS DATE)"
+	case isNaiveTimestamp(dt):
+		return "TIMESTAMP_NTZ'" + b.t.Format("2006-01-02T15:04:05.999999999") + "'"
+	default:
+		return "TIMESTAMP'" + b.String() + "'"
+	}
+}
+
+func isNaiveTimestamp(dt arrow.DataType) bool {
+	ts, ok := dt.(*arrow.TimestampType)
+	return ok && ts.TimeZone == ""
+}
+
+// isWindowColumn reports whether a column of type dt can carry an extract
+// window.
+func isWindowColumn(dt arrow.DataType) bool {
+	return dt.ID() == arrow.DATE32 || dt.ID() == arrow.TIMESTAMP
+}
+
+// windowPredicate renders the predicate selecting the rows of column
+// within [start, end). Either bound may be nil. A DATE column is compared
+// with the dates of time bounds, keeping the whole day of the end.
+func windowPredicate(column arrow.Field, start, end *windowBound) string {
+	name := quoteIdentifier(column.Name)
+	var preds []string
+	if start != nil {
+		preds = append(preds, name+" >= "+start.literal(column.Type))
+	}
+	if end != nil {
+		op := " < "
+		if column.Type.ID() == arrow.DATE32 && !end.dateOnly {
+			op = " <= "
+		}
+		preds = append(preds, name+op+end.literal(column.Type))
+	}
+	return strings.Join(preds, " AND ")
+}
+
+// windowColumn returns the column of the table named by
+// OptionExtractTable that carries the statement's window: the one named by
+// OptionExtractWindowColumn, or else the first date or timestamp column
+// among the table's partition columns and then its clustering columns.
+func (s *statementImpl) windowColumn(ctx context.Context) (arrow.Field, error) {
+	if s.extractColumn != nil {
+		return *s.extractColumn, nil
+	}
+	parts, err := splitQualifiedName(s.extractTable)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	if len(parts) > 3 {
+		return arrow.Field{}, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "invalid table name '%s'", s.extractTable)
+	}
+	name, err := s.conn.qualifyReference(parts)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	schema, err := s.conn.describeTable(ctx, name)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+
+	candidates := []string{s.extractWindowColumn}
+	if s.extractWindowColumn == "" {
+		detail, ok, err := s.conn.describeDetail(ctx, name)
+		if err != nil {
+			return arrow.Field{}, err
+		}
+		if ok {
+			candidates = append(detail.partitionColumns, detail.clusteringColumns...)
+		}
+	}
+	for _, candidate := range candidates {
+		for _, field := range schema.Fields() {
+			if !strings.EqualFold(field.Name, candidate) {
+				continue
+			}
+			if isWindowColumn(field.Type) {
+				s.extractColumn = &field
+				return field, nil
+			}
+			if s.extractWindowColumn != "" {
+				return arrow.Field{}, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+					"column %s of %s is not a date or timestamp", field.Name, name)
+			}
+		}
+	}
+	if s.extractWindowColumn != "" {
+		return arrow.Field{}, s.ErrorHelper.Errorf(adbc.StatusNotFound, "column %s not found in %s", s.extractWindowColumn, name)
+	}
+	return arrow.Field{}, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+		"%s has no date or timestamp partition or clustering column; set %s", name, OptionExtractWindowColumn)
+}
+
+// windowQuery restricts query to the statement's extract window, per
+// OptionExtractTable, OptionExtractWindowStart and OptionExtractWindowEnd,
+// recording the predicate in OptionExtractPredicate. Statements other
+// than queries are returned as they are. The query is closed on its own
+// line so that a trailing comment in it can't swallow the wrapper.
+func (s *statementImpl) windowQuery(ctx context.Context, query string) (string, error) {
+	s.extractPredicate = ""
+	if (s.windowStart == nil && s.windowEnd == nil) || !readsSnapshot(query) {
+		return query, nil
+	}
+	if s.extractTable == "" {
+		return "", s.ErrorHelper.Errorf(adbc.StatusInvalidState, "an extract window needs %s", OptionExtractTable)
+	}
+	if s.windowStart != nil && s.windowEnd != nil && !s.windowStart.t.Before(s.windowEnd.t) {
+		return "", s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "extract window ends at %s, before it starts at %s",
+			s.windowEnd, s.windowStart)
+	}
+	column, err := s.windowColumn(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.extractPredicate = windowPredicate(column, s.windowStart, s.windowEnd)
+	s.logExecution(ctx, "restricting query to extract window", "predicate", s.extractPredicate)
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) AS windowed WHERE %s",
+		trimTerminator(query), s.extractPredicate), nil
+}
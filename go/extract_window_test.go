@@ -0,0 +1,125 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowPredicate(t *testing.T) {
+	date := arrow.Field{Name: "event_date", Type: arrow.FixedWidthTypes.Date32}
+	ts := arrow.Field{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}}
+	ntz := arrow.Field{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond}}
+	bound := func(value string) *windowBound {
+		b, err := parseWindowBound("bound", value)
+		require.NoError(t, err)
+		return b
+	}
+	for _, tc := range []struct {
+		name       string
+		column     arrow.Field
+		start, end string
+		want       string
+	}{
+		{"dates on date", date, "2026-03-01", "2026-03-08",
+			"`event_date` >= DATE'2026-03-01' AND `event_date` < DATE'2026-03-08'"},
+		{"times on date", date, "2026-03-01T06:00:00Z", "2026-03-01T18:30:00+02:00",
+			"`event_date` >= CAST(TIMESTAMP'2026-03-01T06:00:00Z' AS DATE) AND `event_date` <= CAST(TIMESTAMP'2026-03-01T18:30:00+02:00' AS DATE)"},
+		{"times on timestamp", ts, "2026-03-01T06:00:00Z", "2026-03-01T18:30:00.5+02:00",
+			"`ts` >= TIMESTAMP'2026-03-01T06:00:00Z' AND `ts` < TIMESTAMP'2026-03-01T18:30:00.5+02:00'"},
+		{"date on timestamp", ts, "2026-03-01", "", "`ts` >= TIMESTAMP'2026-03-01'"},
+		{"time on timestamp_ntz", ntz, "", "2026-03-01T18:30:00+02:00", "`ts` < TIMESTAMP_NTZ'2026-03-01T18:30:00'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, windowPredicate(tc.column, bound(tc.start), bound(tc.end)))
+		})
+	}
+}
+
+func TestExtractWindowOptions(t *testing.T) {
+	s := &statementImpl{}
+	require.NoError(t, s.SetOption(OptionExtractTable, "main.sales.events"))
+	require.NoError(t, s.SetOption(OptionExtractWindowStart, "2026-03-01"))
+	require.NoError(t, s.SetOption(OptionExtractWindowEnd, " 2026-03-02T00:00:00+01:00 "))
+	require.NoError(t, s.SetOption(OptionExtractWindowColumn, "event_date"))
+	for key, want := range map[string]string{
+		OptionExtractTable:        "main.sales.events",
+		OptionExtractWindowStart:  "2026-03-01",
+		OptionExtractWindowEnd:    "2026-03-02T00:00:00+01:00",
+		OptionExtractWindowColumn: "event_date",
+		OptionExtractPredicate:    "",
+	} {
+		got, err := s.GetOption(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, key)
+	}
+
+	assert.ErrorContains(t, s.SetOption(OptionExtractWindowStart, "yesterday"), "RFC 3339")
+	assert.Error(t, s.SetOption(OptionExtractWindowEnd, "2026-03-02 00:00:00"))
+	require.NoError(t, s.SetOption(OptionExtractWindowEnd, ""))
+	assert.Nil(t, s.windowEnd)
+}
+
+func TestWindowQuery(t *testing.T) {
+	ctx := context.Background()
+	cnxn := &connectionImpl{}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	column := arrow.Field{Name: "event_date", Type: arrow.FixedWidthTypes.Date32}
+	s := &statementImpl{conn: cnxn, extractTable: "events", extractColumn: &column}
+
+	// Without a window queries run as they are
+	query, err := s.windowQuery(ctx, "SELECT * FROM events")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM events", query)
+
+	require.NoError(t, s.SetOption(OptionExtractWindowStart, "2026-03-01"))
+	require.NoError(t, s.SetOption(OptionExtractWindowEnd, "2026-03-08"))
+	query, err = s.windowQuery(ctx, "SELECT id, event_date FROM events -- all\n;")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM (\nSELECT id, event_date FROM events -- all\n) AS windowed WHERE `event_date` >= DATE'2026-03-01' AND `event_date` < DATE'2026-03-08'", query)
+	predicate, err := s.GetOption(OptionExtractPredicate)
+	require.NoError(t, err)
+	assert.Equal(t, "`event_date` >= DATE'2026-03-01' AND `event_date` < DATE'2026-03-08'", predicate)
+
+	// The window goes inside the sample
+	s.sampleRows = 10
+	query, err = s.rewriteQuery(ctx, "SELECT * FROM events")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM (\nSELECT * FROM (\nSELECT * FROM events\n) AS windowed WHERE `event_date` >= DATE'2026-03-01' AND `event_date` < DATE'2026-03-08'\n) AS sampled LIMIT 10", query)
+
+	// Only queries are filtered
+	query, err = s.windowQuery(ctx, "DELETE FROM events")
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM events", query)
+	assert.Empty(t, s.extractPredicate)
+
+	require.NoError(t, s.SetOption(OptionExtractWindowStart, "2026-03-08"))
+	_, err = s.windowQuery(ctx, "SELECT * FROM events")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+
+	require.NoError(t, s.SetOption(OptionExtractTable, ""))
+	_, err = s.windowQuery(ctx, "SELECT * FROM events")
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// Features reported by InfoDriverFeatures. Using a feature that is
+// unavailable fails with StatusNotImplemented and a message naming it.
+const (
+	// FeatureTransactions is Commit, Rollback and disabling autocommit.
+	FeatureTransactions = "transactions"
+	// FeatureSubstrait is SetSubstraitPlan.
+	FeatureSubstrait = "substrait"
+	// FeaturePartitionedResults is ExecutePartitions and ReadPartition.
+	FeaturePartitionedResults = "partitioned_results"
+	// FeatureAttachResults is attaching to the result of a query by its
+	// ID (see ResultAttacher).
+	FeatureAttachResults = "attach_results"
+	// FeatureLenientResults is OptionFetchResultMode set to
+	// OptionValueResultModeLenient.
+	FeatureLenientResults = "lenient_results"
+	// FeatureJobsHandoff is OptionJobsHandoffAfter.
+	FeatureJobsHandoff = "jobs_handoff"
+	// FeatureAsyncExecution is OptionExecutionAsync (see AsyncExecutor).
+	FeatureAsyncExecution = "async_execution"
+)
+
+// driverFeature is one entry of InfoDriverFeatures.
+type driverFeature struct {
+	name string
+	// What using the feature is, for error messages
+	summary string
+	// available is nil for features the driver never has
+	available func(c *connectionImpl) bool
+	// Why the feature is unavailable
+	reason string
+}
+
+// needsWarehouse is the reason features built on the Statement Execution
+// API are unavailable.
+const needsWarehouse = "requires a SQL warehouse configured with " + OptionServerHostname + " and " + OptionHTTPPath
+
+// driverFeatures lists the optional features.
+var driverFeatures = []driverFeature{
+	{
+		name:    FeatureTransactions,
+		summary: "explicit transactions are",
+		reason:  "Databricks SQL commits each statement as it runs",
+	},
+	{
+		name:    FeatureSubstrait,
+		summary: "Substrait plans are",
+		reason:  "Databricks SQL doesn't accept Substrait plans",
+	},
+	{
+		name:      FeaturePartitionedResults,
+		summary:   "partitioned result sets are",
+		available: hasStatementAPI,
+		reason:    needsWarehouse,
+	},
+	{
+		name:      FeatureAttachResults,
+		summary:   "attaching to results is",
+		available: hasStatementAPI,
+		reason:    needsWarehouse,
+	},
+	{
+		name:      FeatureLenientResults,
+		summary:   OptionFetchResultMode + "=" + OptionValueResultModeLenient + " is",
+		available: hasStatementAPI,
+		reason:    needsWarehouse,
+	},
+	{
+		name:      FeatureJobsHandoff,
+		summary:   OptionJobsHandoffAfter + " is",
+		available: hasStatementAPI,
+		reason:    needsWarehouse,
+	},
+	{
+		name:      FeatureAsyncExecution,
+		summary:   OptionExecutionAsync + " is",
+		available: hasStatementAPI,
+		reason:    needsWarehouse,
+	},
+}
+
+func hasStatementAPI(c *connectionImpl) bool {
+	return c.statementAPI != nil
+}
+
+// features returns the availability of each of driverFeatures on the
+// connection, the value of InfoDriverFeatures.
+func (c *connectionImpl) features() map[string]bool {
+	features := make(map[string]bool, len(driverFeatures))
+	for _, f := range driverFeatures {
+		features[f.name] = f.available != nil && f.available(c)
+	}
+	return features
+}
+
+func (c *connectionImpl) featuresJSON() (string, error) {
+	out, err := json.Marshal(c.features())
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// unsupported returns the error for using the named feature, which is
+// unavailable on the connection.
+func (c *connectionImpl) unsupported(name string) error {
+	for _, f := range driverFeatures {
+		if f.name == name {
+			return c.ErrorHelper.Errorf(adbc.StatusNotImplemented,
+				"%s not supported: %s (GetInfo code %d reports feature %q as unavailable)",
+				f.summary, f.reason, InfoDriverFeatures, f.name)
+		}
+	}
+	return c.ErrorHelper.Errorf(adbc.StatusNotImplemented, "%s is not supported", name)
+}
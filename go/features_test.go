@@ -0,0 +1,59 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatures(t *testing.T) {
+	cnxn := &connectionImpl{}
+	features, err := cnxn.featuresJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"transactions": false,
+		"substrait": false,
+		"partitioned_results": false,
+		"attach_results": false,
+		"lenient_results": false,
+		"jobs_handoff": false,
+		"async_execution": false
+	}`, features)
+
+	// Errors name the feature to check for
+	_, err = cnxn.AttachResult(context.Background(), "stmt-1")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "attaching to results is not supported: requires a SQL warehouse")
+	assert.Contains(t, adbcErr.Msg, fmt.Sprintf(`GetInfo code %d reports feature "attach_results"`, InfoDriverFeatures))
+
+	err = cnxn.Commit(context.Background())
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, `"transactions"`)
+
+	cnxn.statementAPI = &statementAPI{}
+	features, err = cnxn.featuresJSON()
+	require.NoError(t, err)
+	assert.Contains(t, features, `"partitioned_results":true`)
+	assert.Contains(t, features, `"transactions":false`)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// federationRefreshMargin is how long before it expires a federated
+// access token is exchanged for a new one.
+const federationRefreshMargin = time.Minute
+
+// federationAuth authenticates requests with a Databricks access token
+// obtained by exchanging an OIDC token issued to the environment (a CI job
+// or Kubernetes service account) at the workspace's token endpoint. The
+// OIDC token is read again for each exchange, since CI providers and
+// Kubernetes rotate it.
+type federationAuth struct {
+	client   *http.Client
+	tokenURL string
+	// Service principal to authenticate as, for federation policies on a
+	// service principal; empty for account-wide policies
+	clientID     string
+	subjectToken func(ctx context.Context) (string, error)
+	clock        driverClock
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (a *federationAuth) Authenticate(r *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token == "" || a.clock.Now().Add(federationRefreshMargin).After(a.expiry) {
+		if err := a.exchange(r.Context()); err != nil {
+			return err
+		}
+	}
+	r.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// exchange replaces the access token with one exchanged for the current
+// OIDC token.
+func (a *federationAuth) exchange(ctx context.Context) error {
+	subject, err := a.subjectToken(ctx)
+	if err != nil {
+		return err
+	}
+	form := url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {subject},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:jwt"},
+		"scope":              {"all-apis"},
+	}
+	if a.clientID != "" {
+		form.Set("client_id", a.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create token exchange request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := doTokenRequest(a.client, req, &out); err != nil {
+		return err
+	}
+	if out.AccessToken == "" {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: "token exchange returned no access token"}
+	}
+	a.token = out.AccessToken
+	a.expiry = a.clock.Now().Add(time.Duration(out.ExpiresIn) * time.Second)
+	return nil
+}
+
+// doTokenRequest sends req with client and decodes its JSON response into
+// out. Failures are StatusUnauthenticated, as they leave the driver
+// without credentials.
+func doTokenRequest(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("%s %s failed: %v", req.Method, req.URL.Path, err)}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return adbc.Error{
+			Code: adbc.StatusUnauthenticated,
+			Msg:  fmt.Sprintf("%s %s failed: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(detail))),
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("failed to decode %s response: %v", req.URL.Path, err)}
+	}
+	return nil
+}
+
+// federationSubjectToken returns the function reading the OIDC token of
+// OptionOAuthFederationTokenSource.
+func (d *databaseImpl) federationSubjectToken(client *http.Client) func(context.Context) (string, error) {
+	switch d.federationTokenSource {
+	case OptionValueTokenSourceGitHubActions:
+		audience := d.federationAudience
+		return func(ctx context.Context) (string, error) {
+			return gitHubActionsToken(ctx, client, audience)
+		}
+	case OptionValueTokenSourceFile:
+		path := d.federationTokenFile
+		return func(context.Context) (string, error) {
+			token, err := os.ReadFile(path)
+			if err != nil {
+				return "", adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("failed to read OIDC token: %v", err)}
+			}
+			return strings.TrimSpace(string(token)), nil
+		}
+	default:
+		name := d.federationTokenEnv
+		return func(context.Context) (string, error) {
+			token := strings.TrimSpace(os.Getenv(name))
+			if token == "" {
+				return "", adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("no OIDC token in environment variable %s", name)}
+			}
+			return token, nil
+		}
+	}
+}
+
+// gitHubActionsToken requests an OIDC token for the running GitHub Actions
+// job, which needs the id-token: write permission. An empty audience gets
+// GitHub's default one.
+func gitHubActionsToken(ctx context.Context, client *http.Client, audience string) (string, error) {
+	requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+	requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL == "" || requestToken == "" {
+		return "", adbc.Error{
+			Code: adbc.StatusUnauthenticated,
+			Msg:  "no GitHub Actions OIDC token available; the job needs the id-token: write permission",
+		}
+	}
+	if audience != "" {
+		u, err := url.Parse(requestURL)
+		if err != nil {
+			return "", adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("invalid ACTIONS_ID_TOKEN_REQUEST_URL: %v", err)}
+		}
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+		requestURL = u.String()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create OIDC token request: %v", err)}
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := doTokenRequest(client, req, &out); err != nil {
+		return "", err
+	}
+	return out.Value, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederationAuth(t *testing.T) {
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oidc/v1/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:token-exchange", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "urn:ietf:params:oauth:token-type:jwt", r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, "oidc-jwt", r.PostForm.Get("subject_token"))
+		assert.Equal(t, "sp-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "all-apis", r.PostForm.Get("scope"))
+		exchanges++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "dbx-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer server.Close()
+
+	authenticator := &federationAuth{
+		client:   server.Client(),
+		tokenURL: server.URL + "/oidc/v1/token",
+		clientID: "sp-id",
+		subjectToken: func(context.Context) (string, error) {
+			return "oidc-jwt", nil
+		},
+	}
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, authenticator.Authenticate(req))
+		assert.Equal(t, "Bearer dbx-token", req.Header.Get("Authorization"))
+	}
+	// The token is reused until it nears expiry
+	assert.Equal(t, 1, exchanges)
+}
+
+func TestFederationAuthRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	authenticator := &federationAuth{
+		client:   server.Client(),
+		tokenURL: server.URL + "/oidc/v1/token",
+		subjectToken: func(context.Context) (string, error) {
+			return "oidc-jwt", nil
+		},
+	}
+	var adbcErr adbc.Error
+	require.ErrorAs(t, authenticator.Authenticate(httptest.NewRequest(http.MethodGet, "https://example.com", nil)), &adbcErr)
+	assert.Equal(t, adbc.StatusUnauthenticated, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "invalid_request")
+}
+
+func TestFederationSubjectToken(t *testing.T) {
+	ctx := context.Background()
+
+	db := &databaseImpl{federationTokenSource: OptionValueTokenSourceEnv, federationTokenEnv: "TEST_OIDC_TOKEN"}
+	t.Setenv("TEST_OIDC_TOKEN", "from-env\n")
+	token, err := db.federationSubjectToken(http.DefaultClient)(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", token)
+	t.Setenv("TEST_OIDC_TOKEN", "")
+	_, err = db.federationSubjectToken(http.DefaultClient)(ctx)
+	assert.ErrorContains(t, err, "TEST_OIDC_TOKEN")
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("from-file"), 0o600))
+	db = &databaseImpl{federationTokenSource: OptionValueTokenSourceFile, federationTokenFile: path}
+	token, err = db.federationSubjectToken(http.DefaultClient)(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", token)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "databricks", r.URL.Query().Get("audience"))
+		assert.Equal(t, "1", r.URL.Query().Get("api-version"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": "from-github"})
+	}))
+	defer server.Close()
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", server.URL+"/token?api-version=1")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+	db = &databaseImpl{federationTokenSource: OptionValueTokenSourceGitHubActions, federationAudience: "databricks"}
+	token, err = db.federationSubjectToken(server.Client())(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-github", token)
+
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	_, err = db.federationSubjectToken(server.Client())(ctx)
+	assert.ErrorContains(t, err, "id-token: write")
+}
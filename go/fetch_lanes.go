@@ -0,0 +1,55 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import "context"
+
+// bulkLaneThreshold is the result size from which OptionValueFetchLaneAuto
+// downloads a result in the bulk lane.
+const bulkLaneThreshold = 64 << 20
+
+type fetchLaneKey struct{}
+
+// withFetchLane returns a context whose result downloads go through the
+// storage connections of lane.
+func withFetchLane(ctx context.Context, lane string) context.Context {
+	return context.WithValue(ctx, fetchLaneKey{}, lane)
+}
+
+// fetchLane returns the download lane of ctx, which is the interactive
+// lane unless withFetchLane says otherwise.
+func fetchLane(ctx context.Context) string {
+	if lane, ok := ctx.Value(fetchLaneKey{}).(string); ok {
+		return lane
+	}
+	return OptionValueFetchLaneInteractive
+}
+
+// laneContext returns ctx with the download lane of resp's result, per
+// OptionFetchLane. Each lane has its own connections to every storage
+// host, so that large extracts don't hold up the downloads of interactive
+// queries on the same database. Partitions are always read in the bulk
+// lane. The CloudFetch downloads of the Thrift path are made by
+// databricks-sql-go through http.DefaultClient, outside the lanes.
+func (s *statementImpl) laneContext(ctx context.Context, resp *statementResponse) context.Context {
+	lane := s.fetchLane
+	if lane == OptionValueFetchLaneAuto {
+		lane = OptionValueFetchLaneInteractive
+		if resp.Manifest != nil && resp.Manifest.TotalByteCount >= bulkLaneThreshold {
+			lane = OptionValueFetchLaneBulk
+		}
+	}
+	return withFetchLane(ctx, lane)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaneContext(t *testing.T) {
+	small := &statementResponse{Manifest: &resultManifest{TotalByteCount: 1 << 20}}
+	large := &statementResponse{Manifest: &resultManifest{TotalByteCount: 1 << 30}}
+	ctx := context.Background()
+	assert.Equal(t, OptionValueFetchLaneInteractive, fetchLane(ctx))
+
+	for _, tc := range []struct {
+		lane string
+		resp *statementResponse
+		want string
+	}{
+		{OptionValueFetchLaneAuto, small, OptionValueFetchLaneInteractive},
+		{OptionValueFetchLaneAuto, large, OptionValueFetchLaneBulk},
+		{OptionValueFetchLaneAuto, &statementResponse{}, OptionValueFetchLaneInteractive},
+		{OptionValueFetchLaneInteractive, large, OptionValueFetchLaneInteractive},
+		{OptionValueFetchLaneBulk, small, OptionValueFetchLaneBulk},
+	} {
+		s := &statementImpl{fetchLane: tc.lane}
+		assert.Equal(t, tc.want, fetchLane(s.laneContext(ctx, tc.resp)), tc.lane)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// fetchManifest is the record of one query's fetch written to
+// OptionFetchTraceFile.
+type fetchManifest struct {
+	QueryID  string          `json:"query_id,omitempty"`
+	Protocol string          `json:"protocol"`
+	Started  time.Time       `json:"started"`
+	Finished time.Time       `json:"finished"`
+	Rows     int64           `json:"rows"`
+	Error    string          `json:"error,omitempty"`
+	Chunks   []chunkManifest `json:"chunks"`
+}
+
+// chunkManifest describes how one chunk of a result was fetched. The
+// timings are in nanoseconds.
+type chunkManifest struct {
+	ChunkIndex int64  `json:"chunk_index"`
+	URLHash    string `json:"url_hash,omitempty"`
+	Bytes      int64  `json:"bytes"`
+	Rows       int64  `json:"rows"`
+	Batches    int    `json:"batches"`
+	DownloadNs int64  `json:"download_ns"`
+	DecodeNs   int64  `json:"decode_ns"`
+	Retries    int    `json:"retries"`
+}
+
+// linkHash identifies a download link without recording it: the links are
+// presigned, so the manifest only holds a hash of the object they point to.
+func linkHash(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		link = u.Scheme + "://" + u.Host + u.Path
+	}
+	sum := sha256.Sum256([]byte(link))
+	return hex.EncodeToString(sum[:8])
+}
+
+// countingReader counts the bytes read through it into n.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// fetchTraceReader records the chunks that the batches of rdr come from,
+// and appends the result's manifest to a file as a line of JSON once the
+// reader is released.
+type fetchTraceReader struct {
+	refCount int64
+	rdr      array.RecordReader
+	stats    batchStatsSource
+	path     string
+	logger   *slog.Logger
+	queryID  *queryIDTracker
+	manifest fetchManifest
+}
+
+// newFetchTraceReader wraps rdr, taking ownership of it. stats may be nil,
+// in which case the whole result is recorded as one chunk.
+func newFetchTraceReader(rdr array.RecordReader, stats batchStatsSource, path, protocol string, queryID *queryIDTracker, logger *slog.Logger) *fetchTraceReader {
+	return &fetchTraceReader{
+		refCount: 1,
+		rdr:      rdr,
+		stats:    stats,
+		path:     path,
+		logger:   logger,
+		queryID:  queryID,
+		manifest: fetchManifest{Protocol: protocol, Started: time.Now().UTC(), Chunks: []chunkManifest{}},
+	}
+}
+
+func (r *fetchTraceReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *fetchTraceReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if err := r.rdr.Err(); err != nil {
+			r.manifest.Error = err.Error()
+		}
+		r.rdr.Release()
+		r.write()
+	}
+}
+
+// write appends the manifest to the trace file. Failing to write it
+// doesn't fail the fetch; it is logged instead.
+func (r *fetchTraceReader) write() {
+	r.manifest.QueryID = r.queryID.get()
+	r.manifest.Finished = time.Now().UTC()
+	line, err := json.Marshal(r.manifest)
+	if err == nil {
+		var f *os.File
+		if f, err = os.OpenFile(r.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600); err == nil {
+			_, err = f.Write(append(line, '\n'))
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err != nil {
+		r.logger.Warn("failed to write fetch trace", slog.String("path", r.path), slog.Any("error", err))
+	}
+}
+
+func (r *fetchTraceReader) Schema() *arrow.Schema {
+	return r.rdr.Schema()
+}
+
+func (r *fetchTraceReader) Next() bool {
+	if !r.rdr.Next() {
+		return false
+	}
+	var stats batchStats
+	if r.stats != nil {
+		stats = r.stats.batchStats()
+	}
+	chunks := r.manifest.Chunks
+	if len(chunks) == 0 || chunks[len(chunks)-1].ChunkIndex != stats.chunkIndex {
+		chunks = append(chunks, chunkManifest{ChunkIndex: stats.chunkIndex})
+	}
+	chunk := &chunks[len(chunks)-1]
+	rows := r.rdr.RecordBatch().NumRows()
+	chunk.URLHash = stats.urlHash
+	chunk.Bytes = stats.bytes
+	chunk.Rows += rows
+	chunk.Batches++
+	chunk.DownloadNs = stats.download.Nanoseconds()
+	chunk.DecodeNs += stats.decode.Nanoseconds()
+	chunk.Retries = stats.retries
+	r.manifest.Chunks = chunks
+	r.manifest.Rows += rows
+	return true
+}
+
+func (r *fetchTraceReader) Record() arrow.RecordBatch {
+	return r.rdr.RecordBatch()
+}
+
+func (r *fetchTraceReader) RecordBatch() arrow.RecordBatch {
+	return r.rdr.RecordBatch()
+}
+
+func (r *fetchTraceReader) Err() error {
+	return r.rdr.Err()
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statsSequence returns its stats one batch at a time.
+type statsSequence struct {
+	stats []batchStats
+}
+
+func (s *statsSequence) batchStats() batchStats {
+	stats := s.stats[0]
+	s.stats = s.stats[1:]
+	return stats
+}
+
+func TestFetchTraceReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	queryID := &queryIDTracker{}
+	queryID.id.Store("01ef-query")
+
+	for range 2 {
+		stats := &statsSequence{stats: []batchStats{
+			{chunkIndex: 0, download: time.Millisecond, decode: time.Microsecond, bytes: 100, urlHash: "aa"},
+			{chunkIndex: 0, download: time.Millisecond, decode: time.Microsecond, bytes: 150, urlHash: "aa"},
+			{chunkIndex: 1, download: 2 * time.Millisecond, decode: time.Microsecond, bytes: 80, urlHash: "bb", retries: 1},
+		}}
+		rdr := newFetchTraceReader(makeConcatTestReader(t, mem,
+			makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"}),
+			makeConcatTestBatch(t, mem, []int64{3}, []string{"c"}),
+			makeConcatTestBatch(t, mem, []int64{4}, []string{"d"}),
+		), stats, path, OptionValueProtocolREST, queryID, slog.New(slog.DiscardHandler))
+		for rdr.Next() {
+		}
+		require.NoError(t, rdr.Err())
+		rdr.Release()
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var manifest fetchManifest
+	require.NoError(t, json.Unmarshal(lines[1], &manifest))
+	assert.Equal(t, "01ef-query", manifest.QueryID)
+	assert.Equal(t, OptionValueProtocolREST, manifest.Protocol)
+	assert.Equal(t, int64(4), manifest.Rows)
+	assert.Empty(t, manifest.Error)
+	assert.Equal(t, []chunkManifest{
+		{ChunkIndex: 0, URLHash: "aa", Bytes: 150, Rows: 3, Batches: 2, DownloadNs: 1e6, DecodeNs: 2e3},
+		{ChunkIndex: 1, URLHash: "bb", Bytes: 80, Rows: 1, Batches: 1, DownloadNs: 2e6, DecodeNs: 1e3, Retries: 1},
+	}, manifest.Chunks)
+}
+
+func TestLinkHash(t *testing.T) {
+	// Presigned query parameters don't change the hash
+	a := linkHash("https://storage.example.com/results/chunk0?sig=abc&expires=1")
+	b := linkHash("https://storage.example.com/results/chunk0?sig=def&expires=2")
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 16)
+	assert.NotEqual(t, a, linkHash("https://storage.example.com/results/chunk1?sig=abc"))
+}
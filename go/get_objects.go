@@ -0,0 +1,196 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// objectsSchemaWindow is how many schemas' tables GetObjects looks up at
+// once. Only their tables are held in memory at a time.
+const objectsSchemaWindow = 8
+
+// getObjects implements GetObjects as a stream with one record batch per
+// catalog (or a single batch of catalog names at ObjectDepthCatalogs).
+// Catalogs are only listed up front; schemas and tables are looked up as
+// the reader reaches their catalog, and tables are appended to the batch
+// a few schemas at a time, so workspaces with many tables needn't be held
+// in memory at once. Like driverbase's GetObjects, it doesn't filter by
+// table type.
+func (c *connectionImpl) getObjects(ctx context.Context, depth adbc.ObjectDepth, catalog, dbSchema, tableName, columnName *string) (array.RecordReader, error) {
+	return newObjectsReader(ctx, c.Alloc, c, depth, catalog, dbSchema, tableName, columnName)
+}
+
+func newObjectsReader(ctx context.Context, mem memory.Allocator, enum driverbase.DbObjectsEnumerator, depth adbc.ObjectDepth, catalog, dbSchema, tableName, columnName *string) (array.RecordReader, error) {
+	catalogs, err := enum.GetCatalogs(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	if len(catalogs) == 0 {
+		// Like driverbase's, an empty result still has one (empty) batch
+		in := make(chan driverbase.GetObjectsInfo)
+		close(in)
+		return driverbase.BuildGetObjectsRecordReader(mem, in, nil)
+	}
+	return &objectsReader{
+		refCount:   1,
+		ctx:        ctx,
+		enum:       enum,
+		mem:        mem,
+		depth:      depth,
+		dbSchema:   dbSchema,
+		tableName:  tableName,
+		columnName: columnName,
+		catalogs:   catalogs,
+	}, nil
+}
+
+// objectsReader builds the batches of a GetObjects result as it is read.
+type objectsReader struct {
+	refCount   int64
+	ctx        context.Context
+	enum       driverbase.DbObjectsEnumerator
+	mem        memory.Allocator
+	depth      adbc.ObjectDepth
+	dbSchema   *string
+	tableName  *string
+	columnName *string
+	catalogs   []string
+
+	next    int
+	current arrow.RecordBatch
+	err     error
+}
+
+func (r *objectsReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *objectsReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 && r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+}
+
+func (r *objectsReader) Schema() *arrow.Schema {
+	return adbc.GetObjectsSchema
+}
+
+func (r *objectsReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if r.err != nil || r.next >= len(r.catalogs) {
+		return false
+	}
+
+	bldr := array.NewRecordBuilder(r.mem, adbc.GetObjectsSchema)
+	defer bldr.Release()
+	for r.next < len(r.catalogs) {
+		catalog := r.catalogs[r.next]
+		r.next++
+		if r.err = r.appendCatalog(bldr, catalog); r.err != nil {
+			return false
+		}
+		if r.depth != adbc.ObjectDepthCatalogs {
+			break
+		}
+	}
+	r.current = bldr.NewRecordBatch()
+	return true
+}
+
+// appendCatalog appends the row for one catalog to bldr.
+func (r *objectsReader) appendCatalog(bldr *array.RecordBuilder, catalog string) error {
+	bldr.Field(0).(*array.StringBuilder).Append(catalog)
+	schemasBldr := bldr.Field(1).(*array.ListBuilder)
+	if r.depth == adbc.ObjectDepthCatalogs {
+		schemasBldr.AppendNull()
+		return nil
+	}
+
+	schemas, err := r.enum.GetDBSchemasForCatalog(r.ctx, catalog, r.dbSchema)
+	if err != nil {
+		return err
+	}
+	schemasBldr.Append(true)
+	for start := 0; start < len(schemas); start += objectsSchemaWindow {
+		window := schemas[start:min(start+objectsSchemaWindow, len(schemas))]
+		infos, err := r.lookupTables(catalog, window)
+		if err != nil {
+			return err
+		}
+		// The nested builders decode the same JSON form as driverbase's
+		// GetObjects
+		encoded, err := json.Marshal(infos)
+		if err != nil {
+			return adbc.Error{Code: adbc.StatusInternal, Msg: err.Error()}
+		}
+		if err := schemasBldr.ValueBuilder().UnmarshalJSON(encoded); err != nil {
+			return adbc.Error{Code: adbc.StatusInternal, Msg: err.Error()}
+		}
+	}
+	return nil
+}
+
+// lookupTables returns the schemas with their tables, looked up
+// concurrently, unless the depth stops at schemas.
+func (r *objectsReader) lookupTables(catalog string, schemas []string) ([]driverbase.DBSchemaInfo, error) {
+	infos := make([]driverbase.DBSchemaInfo, len(schemas))
+	for i, schema := range schemas {
+		infos[i].DbSchemaName = driverbase.Nullable(schema)
+	}
+	if r.depth == adbc.ObjectDepthDBSchemas {
+		return infos, nil
+	}
+
+	errs := make([]error, len(schemas))
+	var wg sync.WaitGroup
+	for i, schema := range schemas {
+		wg.Go(func() {
+			infos[i].DbSchemaTables, errs[i] = r.enum.GetTablesForDBSchema(r.ctx, catalog, schema, r.tableName, r.columnName, r.depth == adbc.ObjectDepthColumns)
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
+
+func (r *objectsReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *objectsReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *objectsReader) Err() error {
+	return r.err
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEnumerator serves schemas s0..sN-1 in each catalog, each with one
+// table t with one column id.
+type fakeEnumerator struct {
+	catalogs   []string
+	schemas    int
+	tableCalls atomic.Int64
+	failSchema string
+}
+
+func (e *fakeEnumerator) GetCatalogs(ctx context.Context, catalogFilter *string) ([]string, error) {
+	return e.catalogs, nil
+}
+
+func (e *fakeEnumerator) GetDBSchemasForCatalog(ctx context.Context, catalog string, schemaFilter *string) ([]string, error) {
+	schemas := make([]string, e.schemas)
+	for i := range schemas {
+		schemas[i] = fmt.Sprintf("s%d", i)
+	}
+	return schemas, nil
+}
+
+func (e *fakeEnumerator) GetTablesForDBSchema(ctx context.Context, catalog string, schema string, tableFilter *string, columnFilter *string, includeColumns bool) ([]driverbase.TableInfo, error) {
+	e.tableCalls.Add(1)
+	if schema == e.failSchema {
+		return nil, adbc.Error{Code: adbc.StatusIO, Msg: "lookup failed"}
+	}
+	table := driverbase.TableInfo{TableName: "t", TableType: "TABLE"}
+	if includeColumns {
+		table.TableColumns = []driverbase.ColumnInfo{{ColumnName: "id", OrdinalPosition: driverbase.Nullable(int32(1))}}
+	}
+	return []driverbase.TableInfo{table}, nil
+}
+
+func TestObjectsReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	ctx := context.Background()
+
+	t.Run("one batch per catalog", func(t *testing.T) {
+		enum := &fakeEnumerator{catalogs: []string{"main", "dev"}, schemas: objectsSchemaWindow + 2}
+		rdr, err := newObjectsReader(ctx, mem, enum, adbc.ObjectDepthColumns, nil, nil, nil, nil)
+		require.NoError(t, err)
+		defer rdr.Release()
+		assert.True(t, adbc.GetObjectsSchema.Equal(rdr.Schema()))
+
+		for _, catalog := range enum.catalogs {
+			require.True(t, rdr.Next())
+			rec := rdr.RecordBatch()
+			require.Equal(t, int64(1), rec.NumRows())
+			assert.Equal(t, catalog, rec.Column(0).(*array.String).Value(0))
+
+			schemasList := rec.Column(1).(*array.List)
+			schemas := schemasList.ListValues().(*array.Struct)
+			require.Equal(t, enum.schemas, schemas.Len())
+			assert.Equal(t, "s0", schemas.Field(0).(*array.String).Value(0))
+			assert.Equal(t, fmt.Sprintf("s%d", enum.schemas-1), schemas.Field(0).(*array.String).Value(enum.schemas-1))
+
+			tables := schemas.Field(1).(*array.List).ListValues().(*array.Struct)
+			require.Equal(t, enum.schemas, tables.Len())
+			assert.Equal(t, "t", tables.Field(0).(*array.String).Value(0))
+			columns := tables.Field(2).(*array.List).ListValues().(*array.Struct)
+			require.Equal(t, enum.schemas, columns.Len())
+			assert.Equal(t, "id", columns.Field(0).(*array.String).Value(0))
+		}
+		assert.False(t, rdr.Next())
+		require.NoError(t, rdr.Err())
+		assert.Equal(t, int64(2*enum.schemas), enum.tableCalls.Load())
+	})
+
+	t.Run("schemas only", func(t *testing.T) {
+		enum := &fakeEnumerator{catalogs: []string{"main"}, schemas: 2}
+		rdr, err := newObjectsReader(ctx, mem, enum, adbc.ObjectDepthDBSchemas, nil, nil, nil, nil)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		require.True(t, rdr.Next())
+		schemas := rdr.RecordBatch().Column(1).(*array.List).ListValues().(*array.Struct)
+		assert.Equal(t, 2, schemas.Len())
+		assert.True(t, schemas.Field(1).IsNull(0))
+		assert.False(t, rdr.Next())
+		assert.Zero(t, enum.tableCalls.Load())
+	})
+
+	t.Run("catalogs in one batch", func(t *testing.T) {
+		enum := &fakeEnumerator{catalogs: []string{"main", "dev", "system"}}
+		rdr, err := newObjectsReader(ctx, mem, enum, adbc.ObjectDepthCatalogs, nil, nil, nil, nil)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		require.True(t, rdr.Next())
+		rec := rdr.RecordBatch()
+		assert.Equal(t, int64(3), rec.NumRows())
+		assert.Equal(t, 3, rec.Column(1).NullN())
+		assert.False(t, rdr.Next())
+	})
+
+	t.Run("no catalogs", func(t *testing.T) {
+		rdr, err := newObjectsReader(ctx, mem, &fakeEnumerator{}, adbc.ObjectDepthAll, nil, nil, nil, nil)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		require.True(t, rdr.Next())
+		assert.Zero(t, rdr.RecordBatch().NumRows())
+		assert.False(t, rdr.Next())
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		enum := &fakeEnumerator{catalogs: []string{"main"}, schemas: 3, failSchema: "s1"}
+		rdr, err := newObjectsReader(ctx, mem, enum, adbc.ObjectDepthTables, nil, nil, nil, nil)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		assert.False(t, rdr.Next())
+		var adbcErr adbc.Error
+		require.ErrorAs(t, rdr.Err(), &adbcErr)
+		assert.Equal(t, adbc.StatusIO, adbcErr.Code)
+	})
+}
+
+func TestObjectFilter(t *testing.T) {
+	pattern := "ma%"
+	empty := ""
+	tests := []struct {
+		semantics string
+		filter    *string
+		expected  *string
+		none      bool
+	}{
+		{OptionValueMetadataFiltersADBC, nil, nil, false},
+		{OptionValueMetadataFiltersADBC, &empty, nil, true},
+		{OptionValueMetadataFiltersADBC, &pattern, &pattern, false},
+		{OptionValueMetadataFiltersJDBC, nil, nil, false},
+		{OptionValueMetadataFiltersJDBC, &empty, nil, false},
+		{OptionValueMetadataFiltersJDBC, &pattern, &pattern, false},
+	}
+	for _, tc := range tests {
+		c := &connectionImpl{metadataFilters: tc.semantics}
+		filter, none := c.objectFilter(tc.filter)
+		assert.Equal(t, tc.expected, filter, "%s %v", tc.semantics, tc.filter)
+		assert.Equal(t, tc.none, none, "%s %v", tc.semantics, tc.filter)
+	}
+
+	// Empty filters match nothing without a query being run
+	c := &connectionImpl{metadataFilters: OptionValueMetadataFiltersADBC}
+	catalogs, err := c.GetCatalogs(context.Background(), &empty)
+	require.NoError(t, err)
+	assert.Empty(t, catalogs)
+	schemas, err := c.GetDBSchemasForCatalog(context.Background(), "main", &empty)
+	require.NoError(t, err)
+	assert.Empty(t, schemas)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// goroutineGroup owns the background goroutines of a database and of the
+// connections, statements and readers opened from it: batch prefetching,
+// query timeouts, staged uploads, Statement Execution API submissions and
+// the OAuth redirect server. Each goroutine is given a context that close
+// cancels, and must then return promptly; close waits for all of them, so
+// that none outlives Database.Close.
+//
+// A nil group runs goroutines without an owner.
+type goroutineGroup struct {
+	group   errgroup.Group
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running atomic.Int64
+}
+
+func newGoroutineGroup() *goroutineGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &goroutineGroup{ctx: ctx, cancel: cancel}
+}
+
+// Go runs f in a new goroutine, passing it the context that close cancels.
+func (g *goroutineGroup) Go(f func(ctx context.Context)) {
+	if g == nil {
+		go f(context.Background())
+		return
+	}
+	g.running.Add(1)
+	g.group.Go(func() error {
+		defer g.running.Add(-1)
+		f(g.ctx)
+		return nil
+	})
+}
+
+// count returns the number of goroutines running, for tests checking that
+// none are leaked.
+func (g *goroutineGroup) count() int64 {
+	if g == nil {
+		return 0
+	}
+	return g.running.Load()
+}
+
+// close cancels the goroutines' context and waits for them to return.
+func (g *goroutineGroup) close() {
+	if g == nil {
+		return
+	}
+	g.cancel()
+	_ = g.group.Wait()
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkGoroutines returns a goroutine group for the test, failing it if
+// any of the group's goroutines are still running once it has finished.
+func checkGoroutines(t *testing.T) *goroutineGroup {
+	g := newGoroutineGroup()
+	t.Cleanup(func() {
+		assert.Eventually(t, func() bool { return g.count() == 0 }, time.Second, time.Millisecond,
+			"%d goroutines leaked", g.count())
+		g.close()
+	})
+	return g
+}
+
+func TestGoroutineGroup(t *testing.T) {
+	g := newGoroutineGroup()
+	var stopped atomic.Int32
+	for range 3 {
+		g.Go(func(ctx context.Context) {
+			<-ctx.Done()
+			stopped.Add(1)
+		})
+	}
+	assert.EqualValues(t, 3, g.count())
+	g.close()
+	assert.EqualValues(t, 3, stopped.Load())
+	assert.Zero(t, g.count())
+
+	// A nil group runs goroutines too
+	var nilGroup *goroutineGroup
+	done := make(chan struct{})
+	nilGroup.Go(func(context.Context) { close(done) })
+	<-done
+	nilGroup.close()
+}
+
+func TestGoroutineGroupStopsPrefetcher(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// The decoder blocks, as on a download, until it is aborted
+	aborted := make(chan struct{})
+	var calls atomic.Int64
+	decode := func() (arrow.RecordBatch, batchStats, error) {
+		if calls.Add(1) == 1 {
+			return makeConcatTestBatch(t, mem, []int64{0}, []string{"a"}), batchStats{}, nil
+		}
+		<-aborted
+		return nil, batchStats{}, context.Canceled
+	}
+	g := newGoroutineGroup()
+	p := newBatchPrefetcher(g, 1<<20, 0, decode, func() { close(aborted) })
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Closing the database stops the prefetcher; the batch already decoded
+	// can still be taken
+	g.close()
+	assert.Zero(t, g.count())
+	batch, err := p.next()
+	require.NoError(t, err)
+	batch.rec.Release()
+	_, err = p.next()
+	assert.ErrorIs(t, err, context.Canceled)
+	p.stop()
+}
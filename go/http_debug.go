@@ -0,0 +1,88 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// debugHTTPHeaders are the only headers debugTransport logs. They identify
+// a request to Databricks support or to a gateway operator and carry no
+// credentials.
+var debugHTTPHeaders = []string{
+	"X-Request-Id",
+	"X-Databricks-Org-Id",
+	"X-Databricks-Reason-Phrase",
+	"X-Thriftserver-Error-Message",
+	"Retry-After",
+	"Via",
+}
+
+// debugTransport logs the metadata of each HTTP request sent to the SQL
+// endpoint: method, host, path, status, duration and debugHTTPHeaders.
+// Bodies, query strings and all other headers (including Authorization) are
+// never logged.
+type debugTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("host", req.URL.Host),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", time.Since(start)),
+	}
+	attrs = appendHeaderAttrs(attrs, "request_headers", req.Header)
+	if err != nil {
+		// url.Error repeats the full URL; log only the cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			attrs = append(attrs, slog.String("error", urlErr.Err.Error()))
+		} else {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		t.logger.InfoContext(req.Context(), "http request failed", attrs...)
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	attrs = appendHeaderAttrs(attrs, "response_headers", resp.Header)
+	t.logger.InfoContext(req.Context(), "http request", attrs...)
+	return resp, nil
+}
+
+// appendHeaderAttrs adds the debugHTTPHeaders present in header to attrs as
+// a group.
+func appendHeaderAttrs(attrs []any, group string, header http.Header) []any {
+	var values []any
+	for _, name := range debugHTTPHeaders {
+		if v := header.Get(name); v != "" {
+			values = append(values, slog.String(name, v))
+		}
+	}
+	if len(values) == 0 {
+		return attrs
+	}
+	return append(attrs, slog.Group(group, values...))
+}
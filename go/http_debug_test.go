@@ -0,0 +1,84 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("secret-body"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := &http.Client{Transport: &debugTransport{
+		base:   http.DefaultTransport,
+		logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+	}}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/sql/1.0/warehouses/abc?token=secret-query", strings.NewReader("secret-request"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, resp.Body.Close())
+
+	logged := buf.String()
+	assert.NotContains(t, logged, "secret")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "http request", entry["msg"])
+	assert.Equal(t, "POST", entry["method"])
+	assert.Equal(t, "/sql/1.0/warehouses/abc", entry["path"])
+	assert.EqualValues(t, http.StatusServiceUnavailable, entry["status"])
+	assert.Equal(t, map[string]any{"X-Request-Id": "req-123"}, entry["response_headers"])
+	assert.Contains(t, entry, "duration")
+}
+
+func TestDebugTransportError(t *testing.T) {
+	var buf bytes.Buffer
+	client := &http.Client{Transport: &debugTransport{
+		base:   http.DefaultTransport,
+		logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+	}}
+
+	// Nothing listens on port 1
+	_, err := client.Get("http://127.0.0.1:1/path?token=secret-query")
+	require.Error(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "http request failed", entry["msg"])
+	assert.Equal(t, "/path", entry["path"])
+	assert.NotEmpty(t, entry["error"])
+	assert.NotContains(t, buf.String(), "secret")
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// idleState tracks a connection's use of its session, for closing the
+// session once it has gone unused for OptionSessionIdleTimeout.
+type idleState struct {
+	// Stops the pending check for the idle timeout; nil when none is
+	// pending
+	stopTimer func()
+	lastUsed  time.Time
+	// Operations running on the session and results still being read
+	busy int
+	// Whether the session was closed while idle, so that the next one
+	// must be given the current catalog and schema
+	closed bool
+}
+
+func (st *idleState) stop() {
+	if st.stopTimer != nil {
+		st.stopTimer()
+		st.stopTimer = nil
+	}
+}
+
+// touchLocked records a use of the session and starts the check for the
+// idle timeout, unless one is pending. connMu must be held.
+func (c *connectionImpl) touchLocked() {
+	if c.idleTimeout <= 0 {
+		return
+	}
+	c.idle.lastUsed = c.clock.Now()
+	if c.idle.stopTimer == nil {
+		c.idle.stopTimer = c.clock.AfterFunc(c.idleTimeout, c.closeIfIdle)
+	}
+}
+
+// closeIfIdle closes the session if nothing has used it for the idle
+// timeout, and otherwise checks again once the timeout may have passed.
+// sqlConn opens a new session when it is next needed.
+func (c *connectionImpl) closeIfIdle() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.idle.stopTimer = nil
+	if c.conn == nil || c.idle.busy > 0 {
+		// The end of the last use starts the next check
+		return
+	}
+	if wait := c.idleTimeout - c.clock.Since(c.idle.lastUsed); wait > 0 {
+		c.idle.stopTimer = c.clock.AfterFunc(wait, c.closeIfIdle)
+		return
+	}
+	c.captureNamespaceLocked(context.Background())
+	c.Logger.Info("closing idle session", "idle_timeout", c.idleTimeout)
+	discardSession(c.conn)
+	c.conn = nil
+	c.queryTags = ""
+	c.statementTimeout = 0
+	c.idle.closed = true
+}
+
+// busy keeps the session open until the returned function is called, so
+// that it isn't closed under a long-running operation.
+func (c *connectionImpl) busy() func() {
+	if c.idleTimeout <= 0 {
+		return func() {}
+	}
+	c.connMu.Lock()
+	c.idle.busy++
+	c.connMu.Unlock()
+	return func() {
+		c.connMu.Lock()
+		defer c.connMu.Unlock()
+		c.idle.busy--
+		c.touchLocked()
+	}
+}
+
+// trackResult keeps the session open until rdr has been released.
+func (c *connectionImpl) trackResult(rdr array.RecordReader) array.RecordReader {
+	if c.idleTimeout <= 0 {
+		return rdr
+	}
+	return &sessionResultReader{RecordReader: rdr, refCount: 1, done: c.busy()}
+}
+
+// sessionResultReader is a result reader that holds its connection's
+// session open.
+type sessionResultReader struct {
+	array.RecordReader
+	refCount int64
+	done     func()
+}
+
+func (r *sessionResultReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+	r.RecordReader.Retain()
+}
+
+func (r *sessionResultReader) Release() {
+	r.RecordReader.Release()
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.done()
+	}
+}
+
+// discardSession closes conn along with its server-side session. Closing a
+// sql.Conn would only return the session to the pool.
+func discardSession(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+}
+
+// restoreNamespace makes the connection's current catalog and schema
+// current in a new session.
+func (c *connectionImpl) restoreNamespace(ctx context.Context, conn *sql.Conn) error {
+	if c.catalog != "" {
+		if _, err := conn.ExecContext(ctx, useCatalogQuery(c.catalog)); err != nil {
+			return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to restore catalog after idle timeout: %v", err)}
+		}
+	}
+	if c.dbSchema != "" {
+		if _, err := conn.ExecContext(ctx, useSchemaQuery("", c.dbSchema)); err != nil {
+			return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to restore schema after idle timeout: %v", err)}
+		}
+	}
+	return nil
+}
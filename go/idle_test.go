@@ -0,0 +1,161 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingConnector opens sessions that only record the statements run on
+// them.
+type countingConnector struct {
+	mu     sync.Mutex
+	opened int
+	closed int
+	execs  []string
+}
+
+func (c *countingConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opened++
+	return &countingConn{connector: c}, nil
+}
+
+func (c *countingConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c *countingConnector) counts() (opened, closed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened, c.closed
+}
+
+type countingConn struct {
+	connector *countingConnector
+}
+
+func (c *countingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.execs = append(c.connector.execs, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *countingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *countingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *countingConn) Close() error {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.closed++
+	return nil
+}
+
+func TestSessionIdleTimeout(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db, idleTimeout: timeout, catalog: "main", dbSchema: "sales"}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	closedAfter := func(n int) func() bool {
+		return func() bool {
+			_, closed := connector.counts()
+			return closed == n
+		}
+	}
+
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, closedAfter(1), time.Second, timeout/4)
+	assert.Empty(t, connector.execs)
+
+	// A new session gets the connection's catalog and schema
+	_, err = cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	opened, _ := connector.counts()
+	assert.Equal(t, 2, opened)
+	assert.Equal(t, []string{
+		"USE CATALOG " + quoteIdentifier("main"),
+		"USE SCHEMA " + quoteIdentifier("sales"),
+	}, connector.execs)
+
+	// The session stays open while in use
+	done := cnxn.busy()
+	time.Sleep(3 * timeout)
+	assert.Condition(t, closedAfter(1))
+	done()
+	require.Eventually(t, closedAfter(2), time.Second, timeout/4)
+
+	_, err = cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, cnxn.Close())
+	cnxn.connMu.Lock()
+	assert.Nil(t, cnxn.idle.stopTimer)
+	cnxn.connMu.Unlock()
+}
+
+func TestSessionIdleTimeoutClock(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	// The idle timeout passes on the connection's clock, which the fake
+	// moves forward at once
+	clock := newFakeClock()
+	cnxn := &connectionImpl{db: db, idleTimeout: time.Hour, clock: driverClock{clock}}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	start := clock.Now()
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, closed := connector.counts()
+		return closed == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, time.Hour, clock.Now().Sub(start))
+	require.NoError(t, cnxn.Close())
+}
+
+func TestSessionIdleTimeoutDisabled(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db}
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	done := cnxn.busy()
+	done()
+	assert.Nil(t, cnxn.idle.stopTimer)
+	require.NoError(t, cnxn.Close())
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// inList is a run of positional markers making up a whole IN list, such
+// as the markers of "id IN (?, ?, ?)".
+type inList struct {
+	// Position of the list in the query, parentheses included
+	start, end int
+	// Index of its first marker among those of the query, and how many
+	// markers it has
+	first, count int
+}
+
+// findInLists returns the IN lists of query with more than threshold
+// markers. Queries with named markers have none.
+func findInLists(query string, threshold int) []inList {
+	type marker struct {
+		start, end int
+		named      bool
+	}
+	var markers []marker
+	scanMarkers(query, func(start, end int, name string) {
+		markers = append(markers, marker{start, end, name != ""})
+	})
+	if slices.ContainsFunc(markers, func(m marker) bool { return m.named }) {
+		return nil
+	}
+
+	var lists []inList
+	for i := 0; i < len(markers); {
+		open := strings.TrimRightFunc(query[:markers[i].start], unicode.IsSpace)
+		keyword := strings.TrimRightFunc(strings.TrimSuffix(open, "("), unicode.IsSpace)
+		if !strings.HasSuffix(open, "(") || len(keyword) < 2 || !strings.EqualFold(keyword[len(keyword)-2:], "IN") ||
+			(len(keyword) > 2 && isIdentPart(rune(keyword[len(keyword)-3]))) {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(markers) && strings.TrimSpace(query[markers[j-1].end:markers[j].start]) == "," {
+			j++
+		}
+		rest := strings.TrimLeftFunc(query[markers[j-1].end:], unicode.IsSpace)
+		if strings.HasPrefix(rest, ")") && j-i > threshold {
+			lists = append(lists, inList{start: len(open) - 1, end: len(query) - len(rest) + 1, first: i, count: j - i})
+		}
+		i = j
+	}
+	return lists
+}
+
+// stagedInList is an IN list whose values are staged in a temporary table
+// of the session, which the query reads in its place.
+type stagedInList struct {
+	table string
+	// Databricks type of the table's value column
+	typ string
+	// Binding of the list's markers
+	binding *parameterBinding
+}
+
+// boundInList is a staged IN list with the values of one execution.
+type boundInList struct {
+	*stagedInList
+	values []driver.NamedValue
+}
+
+// stageInLists rewrites the IN lists of query with more than
+// OptionInListStageThreshold markers into semi-joins against temporary
+// tables, "id IN (SELECT value FROM <table>)". It returns the rewritten
+// query and the binding of its markers, whose inLists stage the values of
+// the lists.
+func (s *statementImpl) stageInLists(query string, b *parameterBinding, schema *arrow.Schema) (string, *parameterBinding, error) {
+	if s.inListThreshold == 0 {
+		return query, b, nil
+	}
+	lists := findInLists(query, s.inListThreshold)
+	if len(lists) == 0 {
+		return query, b, nil
+	}
+	if s.inListPrefix == "" {
+		s.inListPrefix = "adbc_in_list_" + strings.ToLower(rand.Text())
+	}
+
+	staged := &parameterBinding{}
+	var rewritten strings.Builder
+	// Position in query and index of the marker reached
+	pos, next := 0, 0
+	for i, list := range lists {
+		columns := b.columns[list.first : list.first+list.count]
+		dt := schema.Field(columns[0]).Type
+		for _, col := range columns[1:] {
+			if !arrow.TypeEqual(schema.Field(col).Type, dt) {
+				return "", nil, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+					"the %d parameters of an IN list must be bound to columns of one type to be staged, found %s and %s",
+					list.count, dt, schema.Field(col).Type)
+			}
+		}
+		table := fmt.Sprintf("%s_%d", s.inListPrefix, i)
+		staged.names = append(staged.names, b.names[next:list.first]...)
+		staged.columns = append(staged.columns, b.columns[next:list.first]...)
+		staged.inLists = append(staged.inLists, stagedInList{
+			table:   table,
+			typ:     arrowTypeToDatabricksType(dt),
+			binding: &parameterBinding{names: make([]string, list.count), columns: columns},
+		})
+		rewritten.WriteString(query[pos:list.start])
+		rewritten.WriteString("(SELECT value FROM " + table + ")")
+		pos, next = list.end, list.first+list.count
+	}
+	staged.names = append(staged.names, b.names[next:]...)
+	staged.columns = append(staged.columns, b.columns[next:]...)
+	rewritten.WriteString(query[pos:])
+	return rewritten.String(), staged, nil
+}
+
+// writeInLists fills the temporary tables of lists with their values,
+// replacing those of earlier executions, maxBatchParameters values per
+// INSERT.
+func (s *statementImpl) writeInLists(ctx context.Context, conn *sql.Conn, lists []boundInList) error {
+	inline := !s.conn.sessionCapabilities().NativeParameters
+	for _, list := range lists {
+		for _, query := range []string{
+			"DROP TEMPORARY TABLE IF EXISTS " + list.table,
+			fmt.Sprintf("CREATE TEMPORARY TABLE %s (value %s)", list.table, list.typ),
+		} {
+			if _, err := conn.ExecContext(ctx, annotateQuery(ctx, query)); err != nil {
+				return s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to stage IN list: %v", err)
+			}
+		}
+		if !slices.Contains(s.inListTables, list.table) {
+			s.inListTables = append(s.inListTables, list.table)
+		}
+
+		for start := 0; start < len(list.values); start += maxBatchParameters {
+			values := list.values[start:min(start+maxBatchParameters, len(list.values))]
+			bound := boundQuery{
+				query: "INSERT INTO " + list.table + " VALUES " + strings.Repeat("(?), ", len(values)-1) + "(?)",
+				args:  values,
+			}
+			if inline {
+				inlined, err := inlineParameters(bound.query, valuesToInterfaces(values))
+				if err != nil {
+					return s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "failed to inline parameters: %v", err)
+				}
+				bound = boundQuery{query: inlined}
+			}
+			if _, err := s.execBound(ctx, conn, bound); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropInLists drops the temporary tables of the statement's staged IN
+// lists, if the session they were created in is still open. Failures are
+// only logged, as the tables go away with the session anyway.
+func (s *statementImpl) dropInLists() {
+	if len(s.inListTables) == 0 {
+		return
+	}
+	s.conn.connMu.Lock()
+	conn := s.conn.conn
+	s.conn.connMu.Unlock()
+	for _, table := range s.inListTables {
+		if conn == nil {
+			break
+		}
+		if _, err := conn.ExecContext(context.Background(), "DROP TEMPORARY TABLE IF EXISTS "+table); err != nil {
+			s.conn.Logger.Warn("failed to drop staged IN list", "table", table, "error", err)
+		}
+	}
+	s.inListTables = nil
+}
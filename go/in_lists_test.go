@@ -0,0 +1,140 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInLists(t *testing.T) {
+	query := "SELECT * FROM t WHERE a IN (?, ?,?) AND b = ? AND c NOT IN ( ? , ? ) AND d IN (?)"
+	lists := findInLists(query, 1)
+	require.Len(t, lists, 2)
+	assert.Equal(t, "(?, ?,?)", query[lists[0].start:lists[0].end])
+	assert.Equal(t, []int{0, 3}, []int{lists[0].first, lists[0].count})
+	assert.Equal(t, "( ? , ? )", query[lists[1].start:lists[1].end])
+	assert.Equal(t, []int{4, 2}, []int{lists[1].first, lists[1].count})
+	assert.Len(t, findInLists(query, 2), 1)
+	assert.Empty(t, findInLists(query, 3))
+
+	for _, query := range []string{
+		"SELECT * FROM t WHERE a IN (?, ?, 3)",
+		"SELECT * FROM t WHERE a IN (upper(?), ?, ?)",
+		"SELECT * FROM t WHERE a IN (:a, :b, :c)",
+		"SELECT * FROM t WHERE a = ? OR pin (?, ?, ?)",
+		"SELECT 'IN (?, ?, ?)', ? FROM t",
+	} {
+		assert.Empty(t, findInLists(query, 1), query)
+	}
+}
+
+func TestStageInLists(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "kind", Type: arrow.BinaryTypes.String},
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "c", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "limit", Type: arrow.PrimitiveTypes.Int32},
+	}, nil)
+	bind := func(s *statementImpl) {
+		rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(
+			`[{"kind": "x", "a": 1, "b": null, "c": 3, "limit": 10}, {"kind": "y", "a": 4, "b": 5, "c": 6, "limit": 20}]`))
+		require.NoError(t, err)
+		defer rec.Release()
+		require.NoError(t, s.Bind(t.Context(), rec))
+	}
+
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+	cnxn := &connectionImpl{db: db, capabilities: assumedCapabilities()}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+
+	s := &statementImpl{conn: cnxn}
+	require.NoError(t, s.SetOption(OptionInListStageThreshold, "2"))
+	val, err := s.GetOption(OptionInListStageThreshold)
+	require.NoError(t, err)
+	assert.Equal(t, "2", val)
+
+	// The list is read from a temporary table; the other markers keep
+	// their columns
+	query := "SELECT * FROM t WHERE kind = ? AND id IN (?, ?, ?) LIMIT ?"
+	bind(s)
+	bound, err := s.boundQueries(query)
+	require.NoError(t, err)
+	require.Len(t, bound, 2)
+	table := s.inListPrefix + "_0"
+	assert.Equal(t, "SELECT * FROM t WHERE kind = ? AND id IN (SELECT value FROM "+table+") LIMIT ?", bound[0].query)
+	assert.Equal(t, []driver.NamedValue{{Ordinal: 1, Value: "x"}, {Ordinal: 2, Value: int64(10)}}, bound[0].args)
+	require.Len(t, bound[0].inLists, 1)
+	assert.Equal(t, "INT", bound[0].inLists[0].typ)
+	assert.Equal(t, []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: nil}, {Ordinal: 3, Value: int64(3)}}, bound[0].inLists[0].values)
+	assert.Equal(t, []driver.NamedValue{{Ordinal: 1, Value: int64(4)}, {Ordinal: 2, Value: int64(5)}, {Ordinal: 3, Value: int64(6)}}, bound[1].inLists[0].values)
+
+	// Each execution replaces the table's values, at most
+	// maxBatchParameters per INSERT; they are inlined without native
+	// parameters
+	cnxn.capabilities.NativeParameters = false
+	s.query = "DELETE FROM t WHERE kind = ? AND id IN (?, ?, ?) AND ? > 0"
+	bind(s)
+	rows, err := s.executeBoundUpdate(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, rows)
+	staging := func(values string) []string {
+		return []string{
+			"DROP TEMPORARY TABLE IF EXISTS " + table,
+			"CREATE TEMPORARY TABLE " + table + " (value INT)",
+			"INSERT INTO " + table + " VALUES " + values,
+		}
+	}
+	deleteRows := func(kind string, limit int) string {
+		return fmt.Sprintf("DELETE FROM t WHERE kind = '%s' AND id IN (SELECT value FROM %s) AND %d > 0", kind, table, limit)
+	}
+	connector.mu.Lock()
+	assert.Equal(t, append(append(append(staging("(1), (NULL), (3)"), deleteRows("x", 10)),
+		staging("(4), (5), (6)")...), deleteRows("y", 20)), connector.execs)
+	connector.execs = nil
+	connector.mu.Unlock()
+
+	// Closing the statement drops its tables
+	require.NoError(t, s.Close())
+	connector.mu.Lock()
+	assert.Equal(t, []string{"DROP TEMPORARY TABLE IF EXISTS " + table}, connector.execs)
+	connector.mu.Unlock()
+
+	// A list's columns must share a type
+	s = &statementImpl{conn: cnxn, inListThreshold: 1}
+	bind(s)
+	_, err = s.boundQueries("SELECT * FROM t WHERE kind IN (?, ?) AND ? < ? AND ? > 0")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+}
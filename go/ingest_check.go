@@ -0,0 +1,275 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// IngestSchemaChecker is implemented by the statements of this driver.
+//
+// CheckIngestSchema compares schema, the schema of data about to be
+// ingested, with the table named by the statement's ADBC ingest options,
+// taking the ingest mode into account. It changes nothing and returns the
+// differences that would make the ingestion fail, so that callers can
+// report them before any row is sent.
+type IngestSchemaChecker interface {
+	CheckIngestSchema(ctx context.Context, schema *arrow.Schema) (*IngestSchemaReport, error)
+}
+
+// IngestSchemaReport is the result of CheckIngestSchema.
+type IngestSchemaReport struct {
+	// TableExists is whether the target table exists.
+	TableExists bool
+	// NullabilityChecked is false if the table's NOT NULL constraints
+	// couldn't be read (e.g. in hive_metastore, which has no
+	// information_schema), in which case no IngestIssueNullability or
+	// IngestIssueMissingColumn issues are reported.
+	NullabilityChecked bool
+	// Issues lists the problems found, in column order.
+	Issues []IngestSchemaIssue
+}
+
+// Compatible reports whether the ingestion is expected to succeed.
+func (r *IngestSchemaReport) Compatible() bool {
+	return len(r.Issues) == 0
+}
+
+// IngestSchemaIssue is one difference between the ingested data and the
+// target table.
+type IngestSchemaIssue struct {
+	Kind IngestIssueKind
+	// Column is the column concerned, if any.
+	Column string
+	// SourceType is the Databricks type the column's data is ingested as,
+	// and TargetType the column's type in the table.
+	SourceType string
+	TargetType string
+	Message    string
+}
+
+// IngestIssueKind classifies an IngestSchemaIssue.
+type IngestIssueKind string
+
+const (
+	// IngestIssueTableExists means the table exists but the ingest mode
+	// is create.
+	IngestIssueTableExists IngestIssueKind = "table_exists"
+	// IngestIssueTableMissing means the table doesn't exist but the ingest
+	// mode is append.
+	IngestIssueTableMissing IngestIssueKind = "table_missing"
+	// IngestIssueUnknownColumn means the data has a column the table
+	// doesn't.
+	IngestIssueUnknownColumn IngestIssueKind = "unknown_column"
+	// IngestIssueMissingColumn means the table has a NOT NULL column the
+	// data doesn't.
+	IngestIssueMissingColumn IngestIssueKind = "missing_column"
+	// IngestIssueTypeMismatch means a column's data can't be stored in the
+	// table's column without a narrowing or lossy cast.
+	IngestIssueTypeMismatch IngestIssueKind = "type_mismatch"
+	// IngestIssueNullability means a nullable column of the data goes into
+	// a NOT NULL column of the table.
+	IngestIssueNullability IngestIssueKind = "nullability"
+)
+
+func (s *statementImpl) CheckIngestSchema(ctx context.Context, schema *arrow.Schema) (*IngestSchemaReport, error) {
+	if s.conn == nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement already closed")
+	}
+	opts := &s.bulkIngestOptions
+	if !opts.IsSet() {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no ingest target set")
+	}
+
+	var catalog, dbSchema *string
+	if opts.CatalogName != "" {
+		catalog = &opts.CatalogName
+	}
+	if opts.SchemaName != "" {
+		dbSchema = &opts.SchemaName
+	}
+	target, err := s.conn.GetTableSchema(ctx, catalog, dbSchema, opts.TableName)
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) && adbcErr.Code == adbc.StatusNotFound {
+		report := &IngestSchemaReport{NullabilityChecked: true}
+		if opts.Mode == adbc.OptionValueIngestModeAppend {
+			report.Issues = append(report.Issues, IngestSchemaIssue{
+				Kind:    IngestIssueTableMissing,
+				Message: fmt.Sprintf("table %s does not exist and the ingest mode is %s", opts.TableName, opts.Mode),
+			})
+		}
+		return report, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	report := &IngestSchemaReport{TableExists: true}
+	switch opts.Mode {
+	case adbc.OptionValueIngestModeCreate:
+		report.NullabilityChecked = true
+		report.Issues = append(report.Issues, IngestSchemaIssue{
+			Kind:    IngestIssueTableExists,
+			Message: fmt.Sprintf("table %s already exists and the ingest mode is %s", opts.TableName, opts.Mode),
+		})
+		return report, nil
+	case adbc.OptionValueIngestModeReplace:
+		// The table is recreated from the data
+		report.NullabilityChecked = true
+		return report, nil
+	}
+
+	notNull, checked := s.conn.notNullColumns(ctx, catalog, dbSchema, opts.TableName)
+	report.NullabilityChecked = checked
+	report.Issues = diffIngestSchema(schema, target, notNull)
+	return report, nil
+}
+
+// diffIngestSchema compares the fields of source with the columns of
+// target, matching names case-insensitively as Databricks does. notNull
+// holds the lower-cased names of target's NOT NULL columns.
+func diffIngestSchema(source, target *arrow.Schema, notNull map[string]bool) []IngestSchemaIssue {
+	targetFields := make(map[string]arrow.Field, target.NumFields())
+	for _, field := range target.Fields() {
+		targetFields[strings.ToLower(field.Name)] = field
+	}
+
+	var issues []IngestSchemaIssue
+	seen := make(map[string]bool, source.NumFields())
+	for _, field := range source.Fields() {
+		name := strings.ToLower(field.Name)
+		seen[name] = true
+		sourceType := arrowTypeToDatabricksType(field.Type)
+		column, ok := targetFields[name]
+		if !ok {
+			issues = append(issues, IngestSchemaIssue{
+				Kind:       IngestIssueUnknownColumn,
+				Column:     field.Name,
+				SourceType: sourceType,
+				Message:    fmt.Sprintf("column %s does not exist in the table", field.Name),
+			})
+			continue
+		}
+		targetType := arrowTypeToDatabricksType(column.Type)
+		if !ingestTypeCompatible(field.Type, column.Type) {
+			issues = append(issues, IngestSchemaIssue{
+				Kind:       IngestIssueTypeMismatch,
+				Column:     field.Name,
+				SourceType: sourceType,
+				TargetType: targetType,
+				Message:    fmt.Sprintf("column %s is %s but the table's column is %s", field.Name, sourceType, targetType),
+			})
+		}
+		if field.Nullable && notNull[name] {
+			issues = append(issues, IngestSchemaIssue{
+				Kind:       IngestIssueNullability,
+				Column:     field.Name,
+				SourceType: sourceType,
+				TargetType: targetType,
+				Message:    fmt.Sprintf("column %s is nullable but the table's column is NOT NULL", field.Name),
+			})
+		}
+	}
+	for _, column := range target.Fields() {
+		name := strings.ToLower(column.Name)
+		if !seen[name] && notNull[name] {
+			issues = append(issues, IngestSchemaIssue{
+				Kind:       IngestIssueMissingColumn,
+				Column:     column.Name,
+				TargetType: arrowTypeToDatabricksType(column.Type),
+				Message:    fmt.Sprintf("NOT NULL column %s is missing from the data", column.Name),
+			})
+		}
+	}
+	return issues
+}
+
+// integerRanks orders the integer types by width.
+var integerRanks = map[string]int{"TINYINT": 1, "SMALLINT": 2, "INT": 3, "BIGINT": 4}
+
+// ingestTypeCompatible reports whether data of type source, as the driver
+// ingests it, fits a column of type target without loss.
+func ingestTypeCompatible(source, target arrow.DataType) bool {
+	sourceType := arrowTypeToDatabricksType(source)
+	targetType := arrowTypeToDatabricksType(target)
+	if sourceType == targetType {
+		return true
+	}
+	if sourceDec, ok := source.(*arrow.Decimal128Type); ok {
+		targetDec, ok := target.(*arrow.Decimal128Type)
+		return ok && targetDec.Scale >= sourceDec.Scale &&
+			targetDec.Precision-targetDec.Scale >= sourceDec.Precision-sourceDec.Scale
+	}
+	if rank, ok := integerRanks[sourceType]; ok {
+		return integerRanks[targetType] > rank || targetType == "DOUBLE" && rank < integerRanks["BIGINT"]
+	}
+	switch sourceType {
+	case "FLOAT":
+		return targetType == "DOUBLE"
+	case "DATE":
+		return targetType == "TIMESTAMP" || targetType == "TIMESTAMP_NTZ"
+	}
+	return false
+}
+
+// notNullColumns returns the lower-cased names of a table's NOT NULL
+// columns from information_schema, and whether they could be read.
+func (c *connectionImpl) notNullColumns(ctx context.Context, catalog, dbSchema *string, tableName string) (map[string]bool, bool) {
+	if catalog == nil {
+		current, err := c.GetCurrentCatalog()
+		if err != nil {
+			return nil, false
+		}
+		catalog = &current
+	}
+	if dbSchema == nil {
+		current, err := c.GetCurrentDbSchema()
+		if err != nil {
+			return nil, false
+		}
+		dbSchema = &current
+	}
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return nil, false
+	}
+
+	query := fmt.Sprintf("SELECT column_name FROM %s.information_schema.columns WHERE table_schema = %s AND table_name = %s AND is_nullable = 'NO'",
+		quoteIdentifier(*catalog), stringLiteral(strings.ToLower(*dbSchema)), stringLiteral(strings.ToLower(tableName)))
+	if c.useSystemInformationSchema(*catalog) {
+		query = fmt.Sprintf("SELECT column_name FROM system.information_schema.columns WHERE table_catalog = %s AND table_schema = %s AND table_name = %s AND is_nullable = 'NO'",
+			stringLiteral(strings.ToLower(*catalog)), stringLiteral(strings.ToLower(*dbSchema)), stringLiteral(strings.ToLower(tableName)))
+	}
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = rows.Close() }()
+
+	notNull := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, false
+		}
+		notNull[strings.ToLower(name)] = true
+	}
+	return notNull, rows.Err() == nil
+}
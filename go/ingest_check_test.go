@@ -0,0 +1,80 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestTypeCompatible(t *testing.T) {
+	for _, tc := range []struct {
+		source, target arrow.DataType
+		compatible     bool
+	}{
+		{arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int32, true},
+		{arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64, true},
+		{arrow.PrimitiveTypes.Int64, arrow.PrimitiveTypes.Int32, false},
+		{arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Float64, true},
+		{arrow.PrimitiveTypes.Int64, arrow.PrimitiveTypes.Float64, false},
+		{arrow.PrimitiveTypes.Float32, arrow.PrimitiveTypes.Float64, true},
+		{arrow.PrimitiveTypes.Float64, arrow.PrimitiveTypes.Float32, false},
+		{arrow.BinaryTypes.LargeString, arrow.BinaryTypes.String, true},
+		{arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64, false},
+		{arrow.FixedWidthTypes.Date32, &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, true},
+		{&arrow.Decimal128Type{Precision: 10, Scale: 2}, &arrow.Decimal128Type{Precision: 12, Scale: 4}, true},
+		{&arrow.Decimal128Type{Precision: 10, Scale: 2}, &arrow.Decimal128Type{Precision: 10, Scale: 4}, false},
+	} {
+		assert.Equal(t, tc.compatible, ingestTypeCompatible(tc.source, tc.target), "%s -> %s", tc.source, tc.target)
+	}
+}
+
+func TestDiffIngestSchema(t *testing.T) {
+	target := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "amount", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+		{Name: "created", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+		{Name: "region", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	source := arrow.NewSchema([]arrow.Field{
+		{Name: "ID", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "amount", Type: arrow.PrimitiveTypes.Float64, Nullable: false},
+		{Name: "note", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	issues := diffIngestSchema(source, target, map[string]bool{"id": true, "created": true})
+	assert.Equal(t, []IngestSchemaIssue{
+		{
+			Kind: IngestIssueNullability, Column: "ID", SourceType: "INT", TargetType: "BIGINT",
+			Message: "column ID is nullable but the table's column is NOT NULL",
+		},
+		{
+			Kind: IngestIssueTypeMismatch, Column: "amount", SourceType: "DOUBLE", TargetType: "DECIMAL(10, 2)",
+			Message: "column amount is DOUBLE but the table's column is DECIMAL(10, 2)",
+		},
+		{
+			Kind: IngestIssueUnknownColumn, Column: "note", SourceType: "STRING",
+			Message: "column note does not exist in the table",
+		},
+		{
+			Kind: IngestIssueMissingColumn, Column: "created", TargetType: "TIMESTAMP",
+			Message: "NOT NULL column created is missing from the data",
+		},
+	}, issues)
+
+	assert.Empty(t, diffIngestSchema(target, target, nil))
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// manifestVersion is the version of the ingest manifest format.
+const manifestVersion = 1
+
+// maxCopyIntoFiles is the most files one COPY INTO may list.
+const maxCopyIntoFiles = 1000
+
+// ingestManifest records the progress of a staged ingestion in the file
+// named by OptionIngestManifest, so that an ingestion interrupted by a
+// crash can resume where it stopped.
+type ingestManifest struct {
+	Version int `json:"v"`
+	// What is ingested: the target table, the ingest mode, the staging
+	// location and the size at which files are split. Resuming needs all
+	// of them unchanged
+	Table    string `json:"table"`
+	Mode     string `json:"mode"`
+	Location string `json:"location"`
+	FileSize int64  `json:"file_size"`
+	// Directory of the staged files
+	Dir string `json:"dir"`
+	// Whether the table was created (or dropped and created) for the mode
+	TableReady bool           `json:"table_ready"`
+	Files      []manifestFile `json:"files"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// manifestFile is a staged file of an ingestManifest.
+type manifestFile struct {
+	Name     string `json:"name"`
+	SHA256   string `json:"sha256"`
+	Uploaded bool   `json:"uploaded"`
+}
+
+// loadIngestManifest reads the manifest at path, or returns nil if there is
+// none.
+func loadIngestManifest(path string) (*ingestManifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to read ingest manifest: %v", err)}
+	}
+	m := &ingestManifest{path: path}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, adbc.Error{Code: adbc.StatusInvalidData, Msg: fmt.Sprintf("invalid ingest manifest %s: %v", path, err)}
+	}
+	if m.Version != manifestVersion || m.Dir == "" {
+		return nil, adbc.Error{Code: adbc.StatusInvalidData, Msg: fmt.Sprintf("invalid ingest manifest %s: unsupported version %d or missing directory", path, m.Version)}
+	}
+	return m, nil
+}
+
+// save writes the manifest to its file. The file is replaced at once, so a
+// crash leaves either the previous manifest or this one.
+func (m *ingestManifest) save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := json.Marshal(m)
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to encode ingest manifest: %v", err)}
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to write ingest manifest: %v", err)}
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to write ingest manifest: %v", err)}
+	}
+	return nil
+}
+
+// file returns the index-th file of the manifest, if it has that many.
+func (m *ingestManifest) file(index int) (manifestFile, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if index >= len(m.Files) {
+		return manifestFile{}, false
+	}
+	return m.Files[index], true
+}
+
+// markUploaded records that the named file has been uploaded.
+func (m *ingestManifest) markUploaded(name string) error {
+	m.mu.Lock()
+	for i := range m.Files {
+		if m.Files[i].Name == name {
+			m.Files[i].Uploaded = true
+		}
+	}
+	m.mu.Unlock()
+	return m.save()
+}
+
+// copyIntoFilesQueries returns the COPY INTOs loading the named Parquet
+// files of the directory at source into table, as few as the limit on
+// listed files allows. Loading isn't forced, so a file COPY INTO has loaded
+// before is skipped rather than loaded twice.
+func copyIntoFilesQueries(table, source string, names []string) []string {
+	var queries []string
+	for start := 0; start < len(names); start += maxCopyIntoFiles {
+		batch := names[start:min(start+maxCopyIntoFiles, len(names))]
+		files := make([]string, len(batch))
+		for i, name := range batch {
+			files[i] = stringLiteral(name)
+		}
+		queries = append(queries, "COPY INTO "+table+" FROM "+stringLiteral(source)+
+			" FILEFORMAT = PARQUET FILES = ("+strings.Join(files, ", ")+")")
+	}
+	return queries
+}
+
+// resumableIngest loads the bound data into table through
+// OptionIngestStagingLocation, recording its progress in
+// OptionIngestManifest. If the manifest is left by an interrupted
+// ingestion of the same data, the table is not created again, files
+// already uploaded are not uploaded again, and COPY INTO skips files it
+// loaded before, so the data is loaded exactly once. Once the data is
+// loaded the staged files and the manifest are deleted; if the ingestion
+// fails they are kept for the next attempt.
+func (s *statementImpl) resumableIngest(ctx context.Context, table string) (int64, error) {
+	opts := &s.bulkIngestOptions
+	if s.ingestStaging == "" {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "%s requires %s", OptionIngestManifest, OptionIngestStagingLocation)
+	}
+	if opts.Mode == OptionValueIngestModeUpsert {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "%s does not support %s", OptionIngestManifest, OptionValueIngestModeUpsert)
+	}
+	location, err := parseStagingLocation(OptionIngestStagingLocation, s.ingestStaging)
+	if err != nil {
+		return -1, err
+	}
+	api := s.conn.workspaceAPI
+	if api == nil || s.conn.tempStore == nil {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "%s requires %s", OptionIngestStagingLocation, OptionServerHostname)
+	}
+
+	m, err := loadIngestManifest(s.ingestManifest)
+	if err != nil {
+		return -1, err
+	}
+	if m == nil {
+		m = &ingestManifest{
+			Version:  manifestVersion,
+			Table:    table,
+			Mode:     opts.Mode,
+			Location: s.ingestStaging,
+			FileSize: s.ingestFileSize,
+			Dir:      location.file("adbc_ingest_" + strings.ToLower(rand.Text())),
+			path:     s.ingestManifest,
+		}
+	} else if m.Table != table || m.Mode != opts.Mode || m.Location != s.ingestStaging || m.FileSize != s.ingestFileSize {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState,
+			"ingest manifest %s is for another ingestion (%s into %s, staged in %s in files of %d bytes)",
+			s.ingestManifest, m.Mode, m.Table, m.Location, m.FileSize)
+	} else {
+		s.logExecution(ctx, "resuming ingestion", "manifest", s.ingestManifest, "files", len(m.Files))
+	}
+	if err := m.save(); err != nil {
+		return -1, err
+	}
+
+	if !m.TableReady {
+		if err := s.createTableIfNeeded(ctx, table, s.boundStream.Schema(), opts); err != nil {
+			return -1, err
+		}
+		m.TableReady = true
+		if err := m.save(); err != nil {
+			return -1, err
+		}
+	}
+	conn, err := s.session(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := s.stageManifestFiles(ctx, m, location, api); err != nil {
+		return -1, err
+	}
+	rows, err := s.copyManifestFiles(ctx, conn, table, location.uri(m.Dir), m)
+	if err != nil {
+		return -1, err
+	}
+
+	removeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	files := make([]string, len(m.Files))
+	for i, file := range m.Files {
+		files[i] = m.Dir + "/" + file.Name
+	}
+	if err := location.removeDir(removeCtx, api, m.Dir, files); err != nil {
+		s.conn.Logger.WarnContext(ctx, "failed to delete staged ingest data",
+			slog.String("path", m.Dir), slog.Any("error", err))
+	}
+	if err := os.Remove(s.ingestManifest); err != nil {
+		s.conn.Logger.WarnContext(ctx, "failed to delete ingest manifest",
+			slog.String("path", s.ingestManifest), slog.Any("error", err))
+	}
+	return rows, nil
+}
+
+// stageManifestFiles writes the bound data to files and uploads those the
+// manifest doesn't record as uploaded, recording each upload. A file that
+// differs from the one the manifest records means the data isn't what the
+// interrupted ingestion was loading.
+func (s *statementImpl) stageManifestFiles(ctx context.Context, m *ingestManifest, location stagingLocation, api *statementAPI) error {
+	uploads := newStagedUploads(ctx, api, location, s.ingestUploadParallel)
+	defer uploads.cancel()
+
+	written := 0
+	err := s.writeStagedFiles(ctx, m.Dir, func(file *tempFile, filePath string, sum []byte) error {
+		name := path.Base(filePath)
+		digest := hex.EncodeToString(sum)
+		recorded, known := m.file(written)
+		written++
+		if known {
+			if recorded.Name != name || recorded.SHA256 != digest {
+				_ = file.Close()
+				return s.ErrorHelper.Errorf(adbc.StatusInvalidState,
+					"bound data differs from the data staged for ingest manifest %s (file %s)", s.ingestManifest, name)
+			}
+			if recorded.Uploaded {
+				return file.Close()
+			}
+		} else {
+			m.mu.Lock()
+			m.Files = append(m.Files, manifestFile{Name: name, SHA256: digest})
+			m.mu.Unlock()
+			if err := m.save(); err != nil {
+				_ = file.Close()
+				return err
+			}
+		}
+		return uploads.start(file, filePath, func() {
+			if err := m.markUploaded(name); err != nil {
+				s.conn.Logger.WarnContext(ctx, "failed to record staged upload",
+					slog.String("path", filePath), slog.Any("error", err))
+			}
+		})
+	})
+	if err == nil && written != len(m.Files) {
+		err = s.ErrorHelper.Errorf(adbc.StatusInvalidState,
+			"bound data differs from the data staged for ingest manifest %s (%d files, not %d)", s.ingestManifest, written, len(m.Files))
+	}
+	if err != nil {
+		uploads.cancel()
+	}
+	if uploadErr := uploads.wait(); err == nil {
+		err = uploadErr
+	}
+	return err
+}
+
+// copyManifestFiles copies the files of the manifest from source into
+// table and returns the number of rows loaded, summing the counts COPY INTO
+// reports into the statement's update metrics. Rows loaded before an
+// interruption aren't counted again.
+func (s *statementImpl) copyManifestFiles(ctx context.Context, conn *sql.Conn, table, source string, m *ingestManifest) (int64, error) {
+	names := make([]string, len(m.Files))
+	for i, file := range m.Files {
+		names[i] = file.Name
+	}
+	totals := map[string]int64{metricAffectedRows: 0}
+	for _, query := range copyIntoFilesQueries(table, source, names) {
+		rows, err := conn.QueryContext(ctx, annotateQuery(ctx, query))
+		if err != nil {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to copy staged data into %s: %v", table, err)
+		}
+		metrics, err := readUpdateMetrics(rows)
+		if err != nil {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to read update metrics: %v", err)
+		}
+		for name, n := range metrics {
+			totals[name] += n
+		}
+	}
+	s.updateMetrics = totals
+	return metricsRowsAffected(totals), nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ingest.json")
+	m, err := loadIngestManifest(path)
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	m = &ingestManifest{
+		Version:  manifestVersion,
+		Table:    "`main`.`default`.`t`",
+		Mode:     adbc.OptionValueIngestModeAppend,
+		Location: "/Volumes/main/default/staging",
+		FileSize: 1 << 20,
+		Dir:      "/Volumes/main/default/staging/adbc_ingest_x",
+		Files:    []manifestFile{{Name: "part-00000.parquet", SHA256: "00"}, {Name: "part-00001.parquet", SHA256: "01"}},
+		path:     path,
+	}
+	require.NoError(t, m.save())
+	require.NoError(t, m.markUploaded("part-00001.parquet"))
+
+	loaded, err := loadIngestManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, m.Table, loaded.Table)
+	assert.Equal(t, m.Dir, loaded.Dir)
+	assert.Equal(t, []manifestFile{
+		{Name: "part-00000.parquet", SHA256: "00"},
+		{Name: "part-00001.parquet", SHA256: "01", Uploaded: true},
+	}, loaded.Files)
+	file, ok := loaded.file(1)
+	assert.True(t, ok)
+	assert.True(t, file.Uploaded)
+	_, ok = loaded.file(2)
+	assert.False(t, ok)
+
+	for _, content := range []string{"not json", `{"v": 2, "dir": "/tmp"}`, `{"v": 1}`} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := loadIngestManifest(path)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestCopyIntoFilesQueries(t *testing.T) {
+	assert.Equal(t, []string{
+		"COPY INTO `t` FROM 'dbfs:/tmp/adbc_ingest_x' FILEFORMAT = PARQUET FILES = ('part-00000.parquet', 'part-00001.parquet')",
+	}, copyIntoFilesQueries("`t`", "dbfs:/tmp/adbc_ingest_x", []string{"part-00000.parquet", "part-00001.parquet"}))
+
+	var names []string
+	for i := range maxCopyIntoFiles + 1 {
+		names = append(names, fmt.Sprintf("part-%05d.parquet", i))
+	}
+	queries := copyIntoFilesQueries("`t`", "/Volumes/c/s/v/d", names)
+	require.Len(t, queries, 2)
+	assert.Equal(t, maxCopyIntoFiles, strings.Count(queries[0], ".parquet'"))
+	assert.True(t, strings.HasSuffix(queries[1], "FILES = ('part-01000.parquet')"))
+}
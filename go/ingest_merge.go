@@ -0,0 +1,141 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// parseMergeKeys parses the value of OptionIngestMergeKeys.
+func parseMergeKeys(key, value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var keys []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(keys, name) {
+			return nil, invalidOption(key, value, "a comma-separated list of distinct column names")
+		}
+		keys = append(keys, name)
+	}
+	return keys, nil
+}
+
+// mergeQuery returns the MERGE INTO upserting the rows of source, a table
+// reference with the columns of table, into table by keys.
+func mergeQuery(table, source string, keys []string) string {
+	on := make([]string, len(keys))
+	for i, key := range keys {
+		on[i] = "t." + quoteIdentifier(key) + " = s." + quoteIdentifier(key)
+	}
+	return "MERGE INTO " + table + " AS t USING " + source + " AS s ON " + strings.Join(on, " AND ") +
+		" WHEN MATCHED THEN UPDATE SET * WHEN NOT MATCHED THEN INSERT *"
+}
+
+// valuesSource returns a table reference to n rows of parameter markers,
+// with the columns of schema.
+func valuesSource(schema *arrow.Schema, n int) string {
+	row := rowMarkers(schema)
+	names := make([]string, schema.NumFields())
+	for i, field := range schema.Fields() {
+		names[i] = quoteIdentifier(field.Name)
+	}
+	return "(SELECT * FROM VALUES " + strings.Repeat(row+", ", n-1) + row + " AS v(" + strings.Join(names, ", ") + "))"
+}
+
+// mergeIntoTable upserts the bound data into table, for
+// OptionValueIngestModeUpsert. Staged through OptionIngestStagingLocation,
+// one MERGE reads all of it; otherwise each MERGE takes up to
+// maxBatchParameters values as parameters. The counts MERGE reports, summed,
+// become the statement's update metrics.
+func (s *statementImpl) mergeIntoTable(ctx context.Context, conn *sql.Conn, table string) (int64, error) {
+	schema := s.boundStream.Schema()
+	if len(s.ingestMergeKeys) == 0 {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "%s requires %s", OptionValueIngestModeUpsert, OptionIngestMergeKeys)
+	}
+	for _, key := range s.ingestMergeKeys {
+		if len(schema.FieldIndices(key)) != 1 {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "merge key %s needs exactly one bound column of that name", key)
+		}
+	}
+
+	if s.ingestStaging != "" {
+		uri, remove, err := s.stageBoundData(ctx)
+		if err != nil {
+			return -1, err
+		}
+		defer remove()
+		source := "(SELECT * FROM read_files(" + stringLiteral(uri) + ", format => 'parquet'))"
+		if s.updateMetrics, err = s.runMerge(ctx, conn, mergeQuery(table, source, s.ingestMergeKeys), nil); err != nil {
+			return -1, err
+		}
+		return metricsRowsAffected(s.updateMetrics), nil
+	}
+
+	// The values take the columns in order
+	binding := &parameterBinding{names: make([]string, schema.NumFields()), columns: make([]int, schema.NumFields())}
+	for i := range binding.columns {
+		binding.columns[i] = i
+	}
+	inline := !s.conn.sessionCapabilities().NativeParameters
+	rowsPerStatement := max(1, maxBatchParameters/schema.NumFields())
+
+	totals := map[string]int64{metricAffectedRows: 0}
+	for s.boundStream.Next() {
+		rec := s.boundStream.RecordBatch()
+		numRows := int(rec.NumRows())
+		for start := 0; start < numRows; start += rowsPerStatement {
+			end := min(start+rowsPerStatement, numRows)
+			query := mergeQuery(table, valuesSource(schema, end-start), s.ingestMergeKeys)
+			bound, err := s.bindRows(binding, query, rec, start, end, inline)
+			if err != nil {
+				return -1, err
+			}
+			metrics, err := s.runMerge(ctx, conn, bound.query, bound.args)
+			if err != nil {
+				return -1, err
+			}
+			for name, n := range metrics {
+				totals[name] += n
+			}
+		}
+	}
+	if err := s.boundStream.Err(); err != nil {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusIO, "failed to read bound data: %v", err)
+	}
+	s.updateMetrics = totals
+	return metricsRowsAffected(totals), nil
+}
+
+// runMerge runs a MERGE and returns the counts it reports.
+func (s *statementImpl) runMerge(ctx context.Context, conn *sql.Conn, query string, args []driver.NamedValue) (map[string]int64, error) {
+	rows, err := conn.QueryContext(ctx, annotateQuery(ctx, query), valuesToInterfaces(args)...)
+	if err != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to merge into the table: %v", err)
+	}
+	metrics, err := readUpdateMetrics(rows)
+	if err != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to read update metrics: %v", err)
+	}
+	return metrics, nil
+}
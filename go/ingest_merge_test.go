@@ -0,0 +1,67 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMergeKeys(t *testing.T) {
+	keys, err := parseMergeKeys(OptionIngestMergeKeys, "id, region")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "region"}, keys)
+
+	keys, err = parseMergeKeys(OptionIngestMergeKeys, "")
+	require.NoError(t, err)
+	assert.Nil(t, keys)
+
+	for _, value := range []string{"id,", "id,,region", "id,id"} {
+		_, err := parseMergeKeys(OptionIngestMergeKeys, value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestMergeQuery(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 4}},
+	}, nil)
+	assert.Equal(t, "(SELECT * FROM VALUES (?, UNHEX(?)), (?, UNHEX(?)) AS v(`id`, `hash`))", valuesSource(schema, 2))
+	assert.Equal(t,
+		"MERGE INTO `t` AS t USING (SELECT * FROM VALUES (?, UNHEX(?)) AS v(`id`, `hash`)) AS s ON t.`id` = s.`id` AND t.`hash` = s.`hash`"+
+			" WHEN MATCHED THEN UPDATE SET * WHEN NOT MATCHED THEN INSERT *",
+		mergeQuery("`t`", valuesSource(schema, 1), []string{"id", "hash"}))
+}
+
+func TestUpsertModeOption(t *testing.T) {
+	s := &statementImpl{bulkIngestOptions: driverbase.NewBulkIngestOptions()}
+	require.NoError(t, s.SetOption(adbc.OptionKeyIngestMode, OptionValueIngestModeUpsert))
+	assert.Equal(t, OptionValueIngestModeUpsert, s.bulkIngestOptions.Mode)
+	require.NoError(t, s.SetOption(OptionIngestMergeKeys, "id"))
+	value, err := s.GetOption(OptionIngestMergeKeys)
+	require.NoError(t, err)
+	assert.Equal(t, "id", value)
+
+	// The standard modes still go through driverbase
+	require.NoError(t, s.SetOption(adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeAppend))
+	assert.Equal(t, adbc.OptionValueIngestModeAppend, s.bulkIngestOptions.Mode)
+	assert.Error(t, s.SetOption(adbc.OptionKeyIngestMode, "upsert"))
+}
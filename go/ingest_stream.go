@@ -0,0 +1,247 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/util"
+)
+
+// IngestStreamer is implemented by the statements of this driver.
+//
+// ExecuteIngestStream starts ingesting into the table named by the
+// statement's ADBC ingest options, for loaders that receive rows a few at a
+// time. Record batches appended to the returned stream are buffered and
+// committed to the table in micro-batches, once OptionIngestStreamMaxRows
+// rows or OptionIngestStreamMaxBytes bytes are buffered or the oldest
+// buffered rows have waited OptionIngestStreamInterval. The table is
+// created or replaced, as the ingest mode asks, when the first batch is
+// appended; later micro-batches always append to it.
+//
+// Commits made in the background and by Close use ctx. A statement has at
+// most one open stream; closing the statement closes it.
+type IngestStreamer interface {
+	ExecuteIngestStream(ctx context.Context) (IngestStream, error)
+}
+
+// IngestStream appends record batches to a table over time. Its methods
+// may be called from different goroutines. Once a commit fails every
+// method but Close returns the error.
+type IngestStream interface {
+	// Append buffers a batch, committing the buffered rows if a threshold
+	// is reached. Every batch must have the schema of the first one. The
+	// stream retains the batch until it is committed.
+	Append(ctx context.Context, batch arrow.RecordBatch) error
+	// Flush commits the buffered rows.
+	Flush(ctx context.Context) error
+	// RowsIngested returns the number of rows committed so far.
+	RowsIngested() int64
+	// Close commits the buffered rows and ends the stream.
+	Close() error
+}
+
+type ingestStream struct {
+	stmt      *statementImpl
+	ctx       context.Context
+	tableName string
+	maxRows   int64
+	maxBytes  int64
+	interval  time.Duration
+
+	mu        sync.Mutex
+	schema    *arrow.Schema
+	insertSQL string
+	pending   []arrow.RecordBatch
+	rows      int64
+	bytes     int64
+	ingested  int64
+	stopTimer func()
+	err       error
+	closed    bool
+}
+
+func (s *statementImpl) ExecuteIngestStream(ctx context.Context) (IngestStream, error) {
+	if s.conn == nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement already closed")
+	}
+	if !s.bulkIngestOptions.IsSet() {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no ingest target set")
+	}
+	if s.boundStream != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "bound data can't be ingested with an ingest stream")
+	}
+	if s.ingestStream != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "an ingest stream is already open on this statement")
+	}
+	if s.bulkIngestOptions.Mode == OptionValueIngestModeUpsert {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "ingest streams can't upsert; use ExecuteUpdate")
+	}
+
+	opts := &s.bulkIngestOptions
+	s.ingestStream = &ingestStream{
+		stmt:      s,
+		ctx:       ctx,
+		tableName: buildTableName(opts.CatalogName, opts.SchemaName, opts.TableName),
+		maxRows:   s.ingestStreamMaxRows,
+		maxBytes:  s.ingestStreamMaxBytes,
+		interval:  s.ingestStreamInterval,
+	}
+	return s.ingestStream, nil
+}
+
+func (st *ingestStream) Append(ctx context.Context, batch arrow.RecordBatch) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err := st.checkLocked(); err != nil {
+		return err
+	}
+
+	if st.schema == nil {
+		if err := st.startLocked(ctx, batch.Schema()); err != nil {
+			st.err = err
+			return err
+		}
+	} else if !batch.Schema().Equal(st.schema) {
+		return st.stmt.ErrorHelper.Errorf(adbc.StatusInvalidArgument,
+			"batch schema %s does not match the ingest stream's schema %s", batch.Schema(), st.schema)
+	}
+	if batch.NumRows() == 0 {
+		return nil
+	}
+
+	batch.Retain()
+	st.pending = append(st.pending, batch)
+	st.rows += batch.NumRows()
+	st.bytes += util.TotalRecordSize(batch)
+
+	if (st.maxRows > 0 && st.rows >= st.maxRows) || (st.maxBytes > 0 && st.bytes >= st.maxBytes) {
+		return st.flushLocked(ctx)
+	}
+	if st.stopTimer == nil && st.interval > 0 {
+		st.stopTimer = st.stmt.conn.clock.AfterFunc(st.interval, st.flushOnTimer)
+	}
+	return nil
+}
+
+func (st *ingestStream) Flush(ctx context.Context) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err := st.checkLocked(); err != nil {
+		return err
+	}
+	return st.flushLocked(ctx)
+}
+
+func (st *ingestStream) RowsIngested() int64 {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.ingested
+}
+
+func (st *ingestStream) Close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.closed {
+		return st.stmt.ErrorHelper.Errorf(adbc.StatusInvalidState, "ingest stream already closed")
+	}
+	var err error
+	if st.err == nil {
+		err = st.flushLocked(st.ctx)
+	}
+	st.releaseLocked()
+	st.closed = true
+	st.stmt.ingestStream = nil
+	return err
+}
+
+// checkLocked returns the error that keeps the stream from being used.
+func (st *ingestStream) checkLocked() error {
+	if st.closed {
+		return st.stmt.ErrorHelper.Errorf(adbc.StatusInvalidState, "ingest stream already closed")
+	}
+	return st.err
+}
+
+// startLocked prepares the target table for batches with the given schema.
+func (st *ingestStream) startLocked(ctx context.Context, schema *arrow.Schema) error {
+	if err := st.stmt.createTableIfNeeded(ctx, st.tableName, schema, &st.stmt.bulkIngestOptions); err != nil {
+		return err
+	}
+	insertSQL, err := buildInsertSQL(st.tableName, schema)
+	if err != nil {
+		return err
+	}
+	st.schema = schema
+	st.insertSQL = insertSQL
+	return nil
+}
+
+// flushLocked commits the buffered batches. A failure is remembered so
+// that the stream isn't used past rows that may be partly committed.
+func (st *ingestStream) flushLocked(ctx context.Context) error {
+	if st.stopTimer != nil {
+		st.stopTimer()
+		st.stopTimer = nil
+	}
+	if len(st.pending) == 0 {
+		return nil
+	}
+	defer st.releaseLocked()
+	defer st.stmt.conn.busy()()
+
+	conn, err := st.stmt.session(ctx)
+	if err != nil {
+		st.err = err
+		return err
+	}
+	for _, batch := range st.pending {
+		rows, err := st.stmt.insertBatch(ctx, conn, st.insertSQL, batch)
+		st.ingested += rows
+		if err != nil {
+			st.err = err
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *ingestStream) flushOnTimer() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.closed || st.err != nil {
+		return
+	}
+	// The error is returned by the next call on the stream
+	_ = st.flushLocked(st.ctx)
+}
+
+// releaseLocked drops the buffered batches.
+func (st *ingestStream) releaseLocked() {
+	for _, batch := range st.pending {
+		batch.Release()
+	}
+	st.pending = nil
+	st.rows = 0
+	st.bytes = 0
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// validationRule is one rule of OptionIngestValidationRules.
+type validationRule struct {
+	Column  string   `json:"column"`
+	NotNull bool     `json:"not_null,omitempty"`
+	Min     *float64 `json:"min,omitempty"`
+	Max     *float64 `json:"max,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// parseValidationRules parses the value of OptionIngestValidationRules.
+func parseValidationRules(key, value string) ([]validationRule, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var rules []validationRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, invalidOption(key, value, "a JSON array of rules")
+	}
+	for i := range rules {
+		rule := &rules[i]
+		switch {
+		case rule.Column == "":
+			return nil, invalidOption(key, value, "rules naming their column")
+		case !rule.NotNull && rule.Min == nil && rule.Max == nil && rule.Pattern == "":
+			return nil, invalidOption(key, value, "rules with not_null, min, max or pattern")
+		case rule.Min != nil && rule.Max != nil && *rule.Min > *rule.Max:
+			return nil, invalidOption(key, value, "rules whose min is at most their max")
+		}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, invalidOption(key, value, "rules with valid regular expressions")
+			}
+			rule.pattern = pattern
+		}
+	}
+	return rules, nil
+}
+
+// rejectedRow is a row of bound data left out of an ingestion because it
+// broke a rule of OptionIngestValidationRules.
+type rejectedRow struct {
+	// Index of the row in the bound data
+	Row    int64  `json:"row"`
+	Column string `json:"column"`
+	// not_null, min, max or pattern
+	Rule  string `json:"rule"`
+	Value string `json:"value,omitempty"`
+}
+
+// rejectedRows collects the rows rejected by an ingestion.
+type rejectedRows struct {
+	mu   sync.Mutex
+	rows []rejectedRow
+}
+
+func (r *rejectedRows) add(row rejectedRow) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows = append(r.rows, row)
+	return len(r.rows)
+}
+
+// json formats the rows as a JSON array; a nil rejectedRows has none.
+func (r *rejectedRows) json() (string, error) {
+	rows := []rejectedRow{}
+	if r != nil {
+		r.mu.Lock()
+		rows = append(rows, r.rows...)
+		r.mu.Unlock()
+	}
+	out, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// columnRule is a validationRule bound to a column of the data.
+type columnRule struct {
+	validationRule
+	index int
+}
+
+// bindValidationRules matches rules to the fields of schema, checking that
+// each rule suits its column's type.
+func bindValidationRules(rules []validationRule, schema *arrow.Schema) ([]columnRule, error) {
+	bound := make([]columnRule, len(rules))
+	for i, rule := range rules {
+		indices := schema.FieldIndices(rule.Column)
+		if len(indices) != 1 {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidArgument,
+				Msg:  fmt.Sprintf("validation rule for column %s needs exactly one bound column of that name, found %d", rule.Column, len(indices)),
+			}
+		}
+		typ := schema.Field(indices[0]).Type
+		if (rule.Min != nil || rule.Max != nil) && !arrow.IsInteger(typ.ID()) && !arrow.IsFloating(typ.ID()) && !arrow.IsDecimal(typ.ID()) {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidArgument,
+				Msg:  fmt.Sprintf("validation rule for column %s: min and max need a numeric column, not %s", rule.Column, typ),
+			}
+		}
+		if rule.pattern != nil && !isStringType(typ) {
+			return nil, adbc.Error{
+				Code: adbc.StatusInvalidArgument,
+				Msg:  fmt.Sprintf("validation rule for column %s: pattern needs a string column, not %s", rule.Column, typ),
+			}
+		}
+		bound[i] = columnRule{validationRule: rule, index: indices[0]}
+	}
+	return bound, nil
+}
+
+func isStringType(typ arrow.DataType) bool {
+	switch typ.ID() {
+	case arrow.STRING, arrow.LARGE_STRING, arrow.STRING_VIEW:
+		return true
+	}
+	return false
+}
+
+// broken returns the name of the rule the value at idx of arr breaks, or ""
+// if it keeps all of them. Nulls only break not_null.
+func (r columnRule) broken(arr arrow.Array, idx int) string {
+	if arr.IsNull(idx) {
+		if r.NotNull {
+			return "not_null"
+		}
+		return ""
+	}
+	if r.Min != nil || r.Max != nil {
+		value := numericValue(arr, idx)
+		if r.Min != nil && value < *r.Min {
+			return "min"
+		}
+		if r.Max != nil && value > *r.Max {
+			return "max"
+		}
+	}
+	if r.pattern != nil && !r.pattern.MatchString(arr.ValueStr(idx)) {
+		return "pattern"
+	}
+	return ""
+}
+
+// numericValue returns the value at idx of a numeric array as a float64.
+func numericValue(arr arrow.Array, idx int) float64 {
+	switch a := arr.(type) {
+	case *array.Int8:
+		return float64(a.Value(idx))
+	case *array.Int16:
+		return float64(a.Value(idx))
+	case *array.Int32:
+		return float64(a.Value(idx))
+	case *array.Int64:
+		return float64(a.Value(idx))
+	case *array.Uint8:
+		return float64(a.Value(idx))
+	case *array.Uint16:
+		return float64(a.Value(idx))
+	case *array.Uint32:
+		return float64(a.Value(idx))
+	case *array.Uint64:
+		return float64(a.Value(idx))
+	case *array.Float16:
+		return float64(a.Value(idx).Float32())
+	case *array.Float32:
+		return float64(a.Value(idx))
+	case *array.Float64:
+		return a.Value(idx)
+	}
+	// Decimals
+	value, _ := strconv.ParseFloat(arr.ValueStr(idx), 64)
+	return value
+}
+
+// validatingReader leaves out the rows of rdr that break a rule, recording
+// them in rejected, and fails once more than maxRejected rows have been
+// rejected.
+type validatingReader struct {
+	refCount    int64
+	mem         memory.Allocator
+	rdr         array.RecordReader
+	rules       []columnRule
+	maxRejected int64
+	rejected    *rejectedRows
+	// Index in the data of the first row of the next batch
+	offset  int64
+	current arrow.RecordBatch
+	err     error
+}
+
+// newValidatingReader wraps rdr, taking ownership of it.
+func newValidatingReader(mem memory.Allocator, rdr array.RecordReader, rules []validationRule, maxRejected int64, rejected *rejectedRows) (*validatingReader, error) {
+	bound, err := bindValidationRules(rules, rdr.Schema())
+	if err != nil {
+		return nil, err
+	}
+	return &validatingReader{
+		refCount:    1,
+		mem:         mem,
+		rdr:         rdr,
+		rules:       bound,
+		maxRejected: maxRejected,
+		rejected:    rejected,
+	}, nil
+}
+
+func (r *validatingReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *validatingReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.rdr.Release()
+	}
+}
+
+func (r *validatingReader) Schema() *arrow.Schema {
+	return r.rdr.Schema()
+}
+
+func (r *validatingReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	for r.err == nil && r.rdr.Next() {
+		batch := r.rdr.RecordBatch()
+		offset := r.offset
+		r.offset += batch.NumRows()
+
+		// Runs of rows that keep every rule, as [start, end) pairs
+		var kept [][2]int
+		start := 0
+		numRows := int(batch.NumRows())
+		for idx := range numRows {
+			rule, broken := r.check(batch, idx)
+			if broken == "" {
+				continue
+			}
+			if idx > start {
+				kept = append(kept, [2]int{start, idx})
+			}
+			start = idx + 1
+			row := rejectedRow{Row: offset + int64(idx), Column: rule.Column, Rule: broken}
+			if col := batch.Column(rule.index); col.IsValid(idx) {
+				row.Value = col.ValueStr(idx)
+			}
+			if n := r.rejected.add(row); int64(n) > r.maxRejected {
+				r.err = adbc.Error{
+					Code: adbc.StatusInvalidData,
+					Msg: fmt.Sprintf("row %d of the bound data breaks the %s rule on column %s; %d rows rejected, more than %s=%d allows",
+						row.Row, broken, rule.Column, n, OptionIngestValidationMaxRejected, r.maxRejected),
+				}
+				return false
+			}
+		}
+		if start == 0 {
+			batch.Retain()
+			r.current = batch
+			return true
+		}
+		if start < numRows {
+			kept = append(kept, [2]int{start, numRows})
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		if r.current, r.err = r.filter(batch, kept); r.err != nil {
+			return false
+		}
+		return true
+	}
+	if r.err == nil {
+		r.err = r.rdr.Err()
+	}
+	return false
+}
+
+// check returns the first rule row idx of batch breaks, and how.
+func (r *validatingReader) check(batch arrow.RecordBatch, idx int) (columnRule, string) {
+	for _, rule := range r.rules {
+		if broken := rule.broken(batch.Column(rule.index), idx); broken != "" {
+			return rule, broken
+		}
+	}
+	return columnRule{}, ""
+}
+
+// filter returns the rows of batch in the kept runs.
+func (r *validatingReader) filter(batch arrow.RecordBatch, kept [][2]int) (arrow.RecordBatch, error) {
+	numRows := int64(0)
+	for _, run := range kept {
+		numRows += int64(run[1] - run[0])
+	}
+	cols := make([]arrow.Array, batch.NumCols())
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+	for i, col := range batch.Columns() {
+		slices := make([]arrow.Array, len(kept))
+		for j, run := range kept {
+			slices[j] = array.NewSlice(col, int64(run[0]), int64(run[1]))
+		}
+		var err error
+		cols[i], err = array.Concatenate(slices, r.mem)
+		for _, slice := range slices {
+			slice.Release()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return array.NewRecordBatch(batch.Schema(), cols, numRows), nil
+}
+
+func (r *validatingReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *validatingReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *validatingReader) Err() error {
+	return r.err
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var validationTestSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "email", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// makeValidationTestReader returns a reader over one batch for each of
+// batches, given as JSON rows of validationTestSchema.
+func makeValidationTestReader(t *testing.T, mem memory.Allocator, batches ...string) array.RecordReader {
+	t.Helper()
+	var recs []arrow.RecordBatch
+	for _, batch := range batches {
+		rec, _, err := array.RecordFromJSON(mem, validationTestSchema, strings.NewReader(batch))
+		require.NoError(t, err)
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+	rdr, err := array.NewRecordReader(validationTestSchema, recs)
+	require.NoError(t, err)
+	return rdr
+}
+
+func TestParseValidationRules(t *testing.T) {
+	rules, err := parseValidationRules(OptionIngestValidationRules, "")
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	rules, err = parseValidationRules(OptionIngestValidationRules,
+		`[{"column": "id", "not_null": true, "min": 1}, {"column": "email", "pattern": "@"}]`)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.True(t, rules[0].NotNull)
+	assert.Equal(t, 1.0, *rules[0].Min)
+	assert.Nil(t, rules[0].Max)
+	assert.True(t, rules[1].pattern.MatchString("a@b"))
+
+	for _, value := range []string{
+		`{"column": "id"}`,
+		`[{"not_null": true}]`,
+		`[{"column": "id"}]`,
+		`[{"column": "id", "min": 2, "max": 1}]`,
+		`[{"column": "email", "pattern": "("}]`,
+	} {
+		_, err := parseValidationRules(OptionIngestValidationRules, value)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, value)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code, value)
+	}
+}
+
+func TestBindValidationRules(t *testing.T) {
+	for _, value := range []string{
+		`[{"column": "missing", "not_null": true}]`,
+		`[{"column": "email", "min": 0}]`,
+		`[{"column": "id", "pattern": "^1"}]`,
+	} {
+		rules, err := parseValidationRules(OptionIngestValidationRules, value)
+		require.NoError(t, err)
+		_, err = bindValidationRules(rules, validationTestSchema)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, value)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code, value)
+	}
+}
+
+func TestValidatingReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rules, err := parseValidationRules(OptionIngestValidationRules,
+		`[{"column": "id", "not_null": true, "max": 100}, {"column": "email", "pattern": "^[^@]+@[^@]+$"}]`)
+	require.NoError(t, err)
+	rejected := &rejectedRows{}
+	rdr, err := newValidatingReader(mem, makeValidationTestReader(t, mem,
+		`[{"id": 1, "email": "a@x"}, {"id": null, "email": "b@x"}, {"id": 3, "email": null}, {"id": 4, "email": "d"}]`,
+		`[{"id": 500, "email": "e@x"}]`,
+		`[{"id": 6, "email": "f@x"}, {"id": 7, "email": "g@x"}]`,
+	), rules, 3, rejected)
+	require.NoError(t, err)
+	defer rdr.Release()
+
+	var ids []int64
+	for rdr.Next() {
+		col := rdr.RecordBatch().Column(0).(*array.Int64)
+		ids = append(ids, col.Int64Values()...)
+	}
+	require.NoError(t, rdr.Err())
+	// Nulls only break not_null
+	assert.Equal(t, []int64{1, 3, 6, 7}, ids)
+
+	out, err := rejected.json()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"row": 1, "column": "id", "rule": "not_null"},
+		{"row": 3, "column": "email", "rule": "pattern", "value": "d"},
+		{"row": 4, "column": "id", "rule": "max", "value": "500"}
+	]`, out)
+}
+
+func TestValidatingReaderMaxRejected(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rules, err := parseValidationRules(OptionIngestValidationRules, `[{"column": "id", "min": 0}]`)
+	require.NoError(t, err)
+	rejected := &rejectedRows{}
+	rdr, err := newValidatingReader(mem, makeValidationTestReader(t, mem,
+		`[{"id": 1, "email": "a"}, {"id": -2, "email": "b"}]`,
+		`[{"id": -3, "email": "c"}]`,
+	), rules, 1, rejected)
+	require.NoError(t, err)
+	defer rdr.Release()
+
+	require.True(t, rdr.Next())
+	assert.EqualValues(t, 1, rdr.RecordBatch().NumRows())
+	assert.False(t, rdr.Next())
+	var adbcErr adbc.Error
+	require.ErrorAs(t, rdr.Err(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidData, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "row 2 of the bound data breaks the min rule on column id")
+
+	out, err := rejected.json()
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(out, `"rule":"min"`))
+
+	out, err = (*rejectedRows)(nil).json()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", out)
+}
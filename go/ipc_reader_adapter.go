@@ -29,17 +29,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/apache/arrow-adbc/go/adbc"
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	dbsqlrows "github.com/databricks/databricks-sql-go/rows"
 )
 
 // ipcReaderAdapter uses the new IPC stream interface for Arrow access
 type ipcReaderAdapter struct {
+	mem           memory.Allocator
 	rows          driver.Rows
 	ipcIterator   dbsqlrows.ArrowIPCStreamIterator
 	currentReader *ipc.Reader
@@ -48,21 +52,54 @@ type ipcReaderAdapter struct {
 	closed        bool
 	refCount      int64
 	err           error
+	// Cancels the downloads running ahead of the reader
+	cancel context.CancelFunc
+	// Decodes batches ahead of the reader, with OptionFetchPrefetchMaxBytes
+	batches *batchPrefetcher
+	// Stats of the current batch, and of the batch being decoded: the
+	// index of its IPC stream and its timings
+	stats    batchStats
+	decoding batchStats
+	// Declared types of the DECIMAL columns
+	declared []arrow.DataType
+	// OptionFetchRejectOverBytes, and the bytes of the IPC streams read
+	// before the one being decoded
+	rejectOverBytes int64
+	readBytes       int64
 }
 
-// newIPCReaderAdapter creates a RecordReader using direct IPC stream access
-func newIPCReaderAdapter(ctx context.Context, rows driver.Rows) (array.RecordReader, error) {
+// errArrowUnavailable is returned by newIPCReaderAdapter for results that
+// can't be read as Arrow IPC streams. The rows are left open, so the result
+// can still be read through the row-based path.
+type errArrowUnavailable struct {
+	msg string
+}
+
+func (e errArrowUnavailable) Error() string {
+	return e.msg
+}
+
+// newIPCReaderAdapter creates a RecordReader using direct IPC stream access.
+// Record batches are allocated from mem. CloudFetch downloads run ahead of
+// the reader under a context that Release cancels, so that releasing the
+// reader early stops them rather than letting them finish. How many are
+// downloaded at once is databricks-sql-go's download thread count (see
+// OptionCloudFetchMaxParallel).
+func newIPCReaderAdapter(ctx context.Context, mem memory.Allocator, rows driver.Rows) (array.RecordReader, error) {
 	ipcRows, ok := rows.(dbsqlrows.Rows)
 	if !ok {
-		return nil, adbc.Error{
-			Code: adbc.StatusInternal,
-			Msg:  "[db] rows do not support Arrow IPC streams",
-		}
+		return nil, errArrowUnavailable{"[db] rows do not support Arrow IPC streams"}
 	}
 
 	// Get IPC stream iterator
+	ctx, cancel := context.WithCancel(ctx)
 	ipcIterator, err := ipcRows.GetArrowIPCStreams(ctx)
 	if err != nil {
+		cancel()
+		if strings.Contains(err.Error(), "not in arrow format") {
+			// A column-based result, which can still be read row by row
+			return nil, errArrowUnavailable{err.Error()}
+		}
 		return nil, adbc.Error{
 			Code: adbc.StatusInternal,
 			Msg:  fmt.Sprintf("failed to get IPC streams: %v", err),
@@ -70,9 +107,13 @@ func newIPCReaderAdapter(ctx context.Context, rows driver.Rows) (array.RecordRea
 	}
 
 	adapter := &ipcReaderAdapter{
+		mem:         mem,
 		rows:        rows,
 		refCount:    1,
 		ipcIterator: ipcIterator,
+		cancel:      cancel,
+		decoding:    batchStats{chunkIndex: -1},
+		declared:    declaredRowsTypes(rows),
 	}
 
 	// Load the first IPC stream to get the schema.
@@ -82,6 +123,7 @@ func newIPCReaderAdapter(ctx context.Context, rows driver.Rows) (array.RecordRea
 	// first reader, we ensure the schema is available.
 	err = adapter.loadNextReader()
 	if err != nil && err != io.EOF {
+		adapter.closeReaders()
 		return nil, adbc.Error{
 			Code: adbc.StatusInternal,
 			Msg:  fmt.Sprintf("failed to initialize IPC reader: %v", err),
@@ -89,45 +131,73 @@ func newIPCReaderAdapter(ctx context.Context, rows driver.Rows) (array.RecordRea
 	}
 
 	// Get schema from the first reader, or fall back to SchemaBytes() if
-	// the result set is empty (no readers available)
+	// the result set is empty (no readers available). Results without
+	// either (e.g. DDL, or zero rows without direct results) get their
+	// schema from the result set metadata.
 	if adapter.currentReader != nil {
 		adapter.schema = adapter.currentReader.Schema()
 	} else {
-		// Empty result set - try to get schema from SchemaBytes()
-		schema_bytes, err := ipcIterator.SchemaBytes()
+		adapter.schema, err = emptyResultSchema(ipcIterator, rows)
 		if err != nil {
-			return nil, adbc.Error{
-				Code: adbc.StatusInternal,
-				Msg:  fmt.Sprintf("failed to get schema bytes: %v", err),
-			}
+			adapter.closeReaders()
+			return nil, err
 		}
+	}
 
-		if len(schema_bytes) == 0 {
-			return nil, adbc.Error{
-				Code: adbc.StatusInternal,
-				Msg:  "schema bytes are empty and no data available",
-			}
+	if adapter.schema == nil {
+		adapter.closeReaders()
+		return nil, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  "schema is nil",
 		}
+	}
 
-		reader, err := ipc.NewReader(bytes.NewReader(schema_bytes))
-		if err != nil {
-			return nil, adbc.Error{
-				Code: adbc.StatusInternal,
-				Msg:  fmt.Sprintf("failed to read schema: %v", err),
-			}
+	return adapter, nil
+}
+
+// emptyResultSchema returns the schema of a result without IPC streams,
+// from the schema bytes if the server sent them, or else from the column
+// metadata of rows.
+func emptyResultSchema(ipcIterator dbsqlrows.ArrowIPCStreamIterator, rows driver.Rows) (*arrow.Schema, error) {
+	schemaBytes, err := ipcIterator.SchemaBytes()
+	if err != nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to get schema bytes: %v", err),
 		}
-		adapter.schema = reader.Schema()
-		reader.Release()
 	}
 
-	if adapter.schema == nil {
+	if len(schemaBytes) == 0 {
+		return schemaFromColumns(rows), nil
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(schemaBytes), ipc.WithEnsureNativeEndian(true))
+	if err != nil {
 		return nil, adbc.Error{
 			Code: adbc.StatusInternal,
-			Msg:  "schema is nil",
+			Msg:  fmt.Sprintf("failed to read schema: %v", err),
 		}
 	}
+	defer reader.Release()
+	return reader.Schema(), nil
+}
 
-	return adapter, nil
+// schemaFromColumns builds a schema from the column names and Thrift type
+// names reported by rows. Statements without a result set have no columns
+// and get an empty schema.
+func schemaFromColumns(rows driver.Rows) *arrow.Schema {
+	columns := rows.Columns()
+	typed, _ := rows.(driver.RowsColumnTypeDatabaseTypeName)
+
+	fields := make([]arrow.Field, len(columns))
+	for i, name := range columns {
+		var typeName string
+		if typed != nil {
+			typeName = typed.ColumnTypeDatabaseTypeName(i)
+		}
+		fields[i] = arrow.Field{Name: name, Type: thriftTypeToArrow(typeName), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil)
 }
 
 func (r *ipcReaderAdapter) loadNextReader() error {
@@ -136,18 +206,17 @@ func (r *ipcReaderAdapter) loadNextReader() error {
 		r.currentReader = nil
 	}
 
-	// Get next IPC stream
-	if !r.ipcIterator.HasNext() {
-		return io.EOF
-	}
-
-	ipcStream, err := r.ipcIterator.Next()
+	ipcStream, download, err := r.nextStream()
 	if err != nil {
 		return err
 	}
 
-	// Create IPC reader from stream
-	reader, err := ipc.NewReader(ipcStream)
+	// Create IPC reader from stream, byte-swapping batches written by a
+	// server with the other endianness
+	r.readBytes += r.decoding.bytes
+	r.decoding.bytes = 0
+	counted := &countingReader{r: ipcStream, n: &r.decoding.bytes}
+	reader, err := ipc.NewReader(counted, ipc.WithAllocator(r.mem), ipc.WithEnsureNativeEndian(true))
 	if err != nil {
 		return adbc.Error{
 			Code: adbc.StatusInternal,
@@ -156,10 +225,26 @@ func (r *ipcReaderAdapter) loadNextReader() error {
 	}
 
 	r.currentReader = reader
+	r.decoding.chunkIndex++
+	r.decoding.download = download
 
 	return nil
 }
 
+// nextStream returns the next IPC stream and how long it took to download,
+// or io.EOF after the last one.
+func (r *ipcReaderAdapter) nextStream() (io.Reader, time.Duration, error) {
+	if !r.ipcIterator.HasNext() {
+		return nil, 0, io.EOF
+	}
+	start := time.Now()
+	ipcStream, err := r.ipcIterator.Next()
+	if err != nil {
+		return nil, 0, err
+	}
+	return ipcStream, time.Since(start), nil
+}
+
 // Implement array.RecordReader interface
 func (r *ipcReaderAdapter) Schema() *arrow.Schema {
 	return r.schema
@@ -176,30 +261,82 @@ func (r *ipcReaderAdapter) Next() bool {
 		r.currentRecord = nil
 	}
 
-	// Try to get next record from current reader
-	if r.currentReader != nil && r.currentReader.Next() {
-		r.currentRecord = r.currentReader.RecordBatch()
-		r.currentRecord.Retain()
-		return true
+	var batch prefetchedBatch
+	var err error
+	if r.batches != nil {
+		batch, err = r.batches.next()
+	} else {
+		batch.rec, batch.stats, err = r.decode()
 	}
-
-	// Need to load next IPC stream
-	err := r.loadNextReader()
-	if err == io.EOF {
-		return false
-	} else if err != nil {
+	if err != nil {
 		r.err = err
 		return false
 	}
+	if batch.rec == nil {
+		return false
+	}
+	r.currentRecord, r.stats = batch.rec, batch.stats
+	return true
+}
+
+// decode reads the next record batch of the IPC streams, retained, and the
+// stats it was read with. After the last batch the record is nil.
+func (r *ipcReaderAdapter) decode() (arrow.RecordBatch, batchStats, error) {
+	for {
+		// Try to get next record from current reader
+		start := time.Now()
+		if r.currentReader != nil && r.currentReader.Next() {
+			r.decoding.decode = time.Since(start)
+			if err := r.checkReadSize(); err != nil {
+				return nil, batchStats{}, err
+			}
+			rec := r.currentReader.RecordBatch()
+			rec.Retain()
+			return rec, r.decoding, nil
+		}
+		if r.currentReader != nil {
+			if err := r.currentReader.Err(); err != nil && err != io.EOF {
+				return nil, batchStats{}, err
+			}
+		}
 
-	// Try again with new reader
-	if r.currentReader != nil && r.currentReader.Next() {
-		r.currentRecord = r.currentReader.RecordBatch()
-		r.currentRecord.Retain()
-		return true
+		// Need to load next IPC stream; streams may hold no batches, in
+		// which case we move on to the one after
+		err := r.loadNextReader()
+		if err == io.EOF {
+			return nil, batchStats{}, nil
+		} else if err != nil {
+			return nil, batchStats{}, err
+		}
 	}
+}
+
+// checkReadSize fails with StatusInvalidState once the IPC streams read so
+// far exceed rejectOverBytes. Thrift doesn't report the size of a result up
+// front as the Statement Execution API does (see checkResultSize), so the
+// result is rejected part way through, at the batch that crosses the limit.
+func (r *ipcReaderAdapter) checkReadSize() error {
+	if r.rejectOverBytes <= 0 {
+		return nil
+	}
+	read := r.readBytes + r.decoding.bytes
+	if read <= r.rejectOverBytes {
+		return nil
+	}
+	return adbc.Error{
+		Code: adbc.StatusInvalidState,
+		Msg: fmt.Sprintf("result is over the %s allowed by %s (%s read by chunk %d); narrow the query or raise the limit",
+			formatByteSize(r.rejectOverBytes), OptionFetchRejectOverBytes, formatByteSize(read), r.decoding.chunkIndex),
+	}
+}
 
-	return false
+// prefetchBatches starts decoding batches ahead of Next, holding up to
+// maxBytes of them, so that a slow consumer doesn't wait on the network
+// for each batch; once full, decoding resumes after drainPercent of them
+// have been taken. The decoding goroutine, owned by g, then owns the IPC
+// streams and their reader.
+func (r *ipcReaderAdapter) prefetchBatches(g *goroutineGroup, maxBytes int64, drainPercent int) {
+	r.batches = newBatchPrefetcher(g, maxBytes, drainPercent, r.decode, r.cancel)
 }
 
 func (r *ipcReaderAdapter) Record() arrow.RecordBatch {
@@ -210,6 +347,14 @@ func (r *ipcReaderAdapter) RecordBatch() arrow.RecordBatch {
 	return r.currentRecord
 }
 
+func (r *ipcReaderAdapter) batchStats() batchStats {
+	return r.stats
+}
+
+func (r *ipcReaderAdapter) declaredTypes() []arrow.DataType {
+	return r.declared
+}
+
 func (r *ipcReaderAdapter) Release() {
 	if atomic.AddInt64(&r.refCount, -1) <= 0 {
 		if r.closed {
@@ -217,21 +362,7 @@ func (r *ipcReaderAdapter) Release() {
 		}
 		r.closed = true
 
-		if r.currentRecord != nil {
-			r.currentRecord.Release()
-			r.currentRecord = nil
-		}
-
-		if r.currentReader != nil {
-			r.currentReader.Release()
-			r.currentReader = nil
-		}
-
-		if r.schema != nil {
-			r.schema = nil
-		}
-
-		r.ipcIterator.Close()
+		r.closeReaders()
 
 		if r.rows != nil {
 			r.err = errors.Join(r.err, r.rows.Close())
@@ -240,10 +371,34 @@ func (r *ipcReaderAdapter) Release() {
 	}
 }
 
+// closeReaders cancels pending downloads, stops decoding ahead, releases
+// the current record and reader and closes the IPC stream iterator.
+func (r *ipcReaderAdapter) closeReaders() {
+	r.cancel()
+
+	if r.batches != nil {
+		r.batches.stop()
+	}
+
+	if r.currentRecord != nil {
+		r.currentRecord.Release()
+		r.currentRecord = nil
+	}
+
+	if r.currentReader != nil {
+		r.currentReader.Release()
+		r.currentReader = nil
+	}
+
+	r.schema = nil
+
+	r.ipcIterator.Close()
+}
+
 func (r *ipcReaderAdapter) Retain() {
 	atomic.AddInt64(&r.refCount, 1)
 }
 
 func (r *ipcReaderAdapter) Err() error {
-	return r.err
+	return classifyError(r.err)
 }
@@ -30,6 +30,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql/driver"
+	"errors"
 	"io"
 	"testing"
 
@@ -73,9 +74,16 @@ func (m *mockIPCStreamIterator) SchemaBytes() ([]byte, error) {
 // mockRows implements the subset of dbsqlrows.Rows needed for testing
 type mockRows struct {
 	iterator dbsqlrows.ArrowIPCStreamIterator
+	// Result set metadata, used when there are no schema bytes
+	columns     []string
+	columnTypes []string
+	// The context the streams were requested with
+	ctx    context.Context
+	closed bool
 }
 
 func (m *mockRows) GetArrowIPCStreams(ctx context.Context) (dbsqlrows.ArrowIPCStreamIterator, error) {
+	m.ctx = ctx
 	return m.iterator, nil
 }
 
@@ -84,7 +92,11 @@ func (m *mockRows) GetArrowBatches(ctx context.Context) (dbsqlrows.ArrowBatchIte
 }
 
 func (m *mockRows) Columns() []string {
-	panic("not implemented")
+	return m.columns
+}
+
+func (m *mockRows) ColumnTypeDatabaseTypeName(index int) string {
+	return m.columnTypes[index]
 }
 
 func (m *mockRows) Next(dest []driver.Value) error {
@@ -92,6 +104,7 @@ func (m *mockRows) Next(dest []driver.Value) error {
 }
 
 func (m *mockRows) Close() error {
+	m.closed = true
 	return nil
 }
 
@@ -148,7 +161,7 @@ func TestIPCReaderAdapter(t *testing.T) {
 
 	// Test the IPC reader adapter
 	ctx := context.Background()
-	reader, err := newIPCReaderAdapter(ctx, mockRows)
+	reader, err := newIPCReaderAdapter(ctx, memory.DefaultAllocator, mockRows)
 	require.NoError(t, err)
 	defer reader.Release()
 
@@ -245,7 +258,7 @@ func TestIPCReaderAdapterMultipleStreams(t *testing.T) {
 
 	// Test the adapter
 	ctx := context.Background()
-	reader, err := newIPCReaderAdapter(ctx, mockRows)
+	reader, err := newIPCReaderAdapter(ctx, memory.DefaultAllocator, mockRows)
 	require.NoError(t, err)
 	defer reader.Release()
 
@@ -267,3 +280,205 @@ func TestIPCReaderAdapterMultipleStreams(t *testing.T) {
 	assert.Equal(t, 3, batchCount)
 	assert.Equal(t, 300, rowCount)
 }
+
+// writeIPCStream serializes batches as an Arrow IPC stream; without batches
+// the stream holds only the schema.
+func writeIPCStream(t *testing.T, schema *arrow.Schema, batches ...arrow.RecordBatch) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+	for _, batch := range batches {
+		require.NoError(t, writer.Write(batch))
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// TestIPCReaderAdapterZeroRows checks that results without any rows produce
+// a reader with the correct schema and no batches, whichever way the server
+// describes them.
+func TestIPCReaderAdapterZeroRows(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	schemaOnly := writeIPCStream(t, schema)
+
+	tests := []struct {
+		name     string
+		rows     *mockRows
+		expected *arrow.Schema
+	}{
+		{
+			name:     "schema bytes without streams",
+			rows:     &mockRows{iterator: &mockIPCStreamIterator{schema: schemaOnly}},
+			expected: schema,
+		},
+		{
+			name: "streams without batches",
+			rows: &mockRows{iterator: &mockIPCStreamIterator{
+				streams: [][]byte{schemaOnly, schemaOnly},
+			}},
+			expected: schema,
+		},
+		{
+			name: "result set metadata only",
+			rows: &mockRows{
+				iterator:    &mockIPCStreamIterator{},
+				columns:     []string{"id", "amount", "ts"},
+				columnTypes: []string{"BIGINT", "DECIMAL", "TIMESTAMP"},
+			},
+			expected: arrow.NewSchema([]arrow.Field{
+				{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+				{Name: "amount", Type: arrow.BinaryTypes.String, Nullable: true},
+				{Name: "ts", Type: arrow.FixedWidthTypes.Timestamp_us, Nullable: true},
+			}, nil),
+		},
+		{
+			name:     "no result set",
+			rows:     &mockRows{iterator: &mockIPCStreamIterator{}},
+			expected: arrow.NewSchema([]arrow.Field{}, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := newIPCReaderAdapter(context.Background(), memory.DefaultAllocator, tt.rows)
+			require.NoError(t, err)
+			defer reader.Release()
+
+			assert.True(t, tt.expected.Equal(reader.Schema()), "got schema %s", reader.Schema())
+			assert.False(t, reader.Next())
+			assert.NoError(t, reader.Err())
+		})
+	}
+}
+
+// TestIPCReaderAdapterSkipsEmptyStreams checks that streams without batches
+// in the middle of a result don't end it early.
+func TestIPCReaderAdapterSkipsEmptyStreams(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "value", Type: arrow.PrimitiveTypes.Int32},
+	}, nil)
+
+	builder := array.NewRecordBuilder(mem, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	record := builder.NewRecordBatch()
+	defer record.Release()
+
+	mockRows := &mockRows{iterator: &mockIPCStreamIterator{
+		streams: [][]byte{
+			writeIPCStream(t, schema),
+			writeIPCStream(t, schema, record),
+			writeIPCStream(t, schema),
+			writeIPCStream(t, schema, record),
+		},
+	}}
+
+	reader, err := newIPCReaderAdapter(context.Background(), memory.DefaultAllocator, mockRows)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	rowCount := 0
+	for reader.Next() {
+		rowCount += int(reader.RecordBatch().NumRows())
+	}
+	require.NoError(t, reader.Err())
+	assert.Equal(t, 4, rowCount)
+}
+
+// TestIPCReaderAdapterEarlyRelease checks that releasing the reader before
+// the end of the result cancels the downloads and closes the operation.
+func TestIPCReaderAdapterEarlyRelease(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	first := makeConcatTestBatch(t, mem, []int64{1}, []string{"a"})
+	defer first.Release()
+	second := makeConcatTestBatch(t, mem, []int64{2}, []string{"b"})
+	defer second.Release()
+	rows := &mockRows{iterator: &mockIPCStreamIterator{streams: [][]byte{
+		writeIPCStream(t, concatTestSchema, first),
+		writeIPCStream(t, concatTestSchema, second),
+	}}}
+
+	reader, err := newIPCReaderAdapter(context.Background(), mem, rows)
+	require.NoError(t, err)
+	require.True(t, reader.Next())
+	require.NoError(t, rows.ctx.Err())
+
+	reader.Release()
+	assert.ErrorIs(t, rows.ctx.Err(), context.Canceled)
+	assert.True(t, rows.closed)
+}
+
+// failingIPCStreamIterator fails to fetch the stream at index failAt, with
+// err if set.
+type failingIPCStreamIterator struct {
+	*mockIPCStreamIterator
+	failAt int
+	err    error
+}
+
+func (m *failingIPCStreamIterator) Next() (io.Reader, error) {
+	if m.index == m.failAt {
+		m.index++
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, errors.New("link expired")
+	}
+	return m.mockIPCStreamIterator.Next()
+}
+
+func TestIPCReaderAdapterPrefetchBatches(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	var streams [][]byte
+	for i := range int64(4) {
+		first := makeConcatTestBatch(t, mem, []int64{4 * i, 4*i + 1}, []string{"a", "b"})
+		second := makeConcatTestBatch(t, mem, []int64{4*i + 2, 4*i + 3}, []string{"c", "d"})
+		streams = append(streams, writeIPCStream(t, concatTestSchema, first, second))
+		first.Release()
+		second.Release()
+	}
+
+	rows := &mockRows{iterator: &mockIPCStreamIterator{streams: streams}}
+	reader, err := newIPCReaderAdapter(context.Background(), mem, rows)
+	require.NoError(t, err)
+	reader.(*ipcReaderAdapter).prefetchBatches(checkGoroutines(t), 1, 0)
+	var ids []int64
+	for reader.Next() {
+		ids = append(ids, reader.RecordBatch().Column(0).(*array.Int64).Int64Values()...)
+		assert.Equal(t, int64((len(ids)-1)/4), reader.(*ipcReaderAdapter).batchStats().chunkIndex)
+	}
+	require.NoError(t, reader.Err())
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, ids)
+	reader.Release()
+
+	rows = &mockRows{iterator: &failingIPCStreamIterator{mockIPCStreamIterator: &mockIPCStreamIterator{streams: streams}, failAt: 2}}
+	reader, err = newIPCReaderAdapter(context.Background(), mem, rows)
+	require.NoError(t, err)
+	reader.(*ipcReaderAdapter).prefetchBatches(checkGoroutines(t), 1<<20, 0)
+	batches := 0
+	for reader.Next() {
+		batches++
+	}
+	assert.Equal(t, 4, batches)
+	assert.ErrorContains(t, reader.Err(), "link expired")
+	reader.Release()
+
+	// Releasing the reader early releases the batches decoded ahead
+	rows = &mockRows{iterator: &mockIPCStreamIterator{streams: streams}}
+	reader, err = newIPCReaderAdapter(context.Background(), mem, rows)
+	require.NoError(t, err)
+	reader.(*ipcReaderAdapter).prefetchBatches(checkGoroutines(t), 1<<20, 0)
+	require.True(t, reader.Next())
+	reader.Release()
+	assert.True(t, rows.closed)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// jobPollInterval is how often a job run is polled.
+var jobPollInterval = 5 * time.Second
+
+// Job run life cycle states after which a run won't change.
+var jobRunFinalStates = []string{"TERMINATED", "SKIPPED", "INTERNAL_ERROR"}
+
+type jobRun struct {
+	RunID int64 `json:"run_id"`
+	State struct {
+		LifeCycleState string `json:"life_cycle_state"`
+		ResultState    string `json:"result_state"`
+		StateMessage   string `json:"state_message"`
+	} `json:"state"`
+}
+
+// trimTerminator returns query without surrounding whitespace and its
+// terminating semicolons, for embedding it in another statement.
+func trimTerminator(query string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(query), ";"))
+}
+
+// executeHandoff runs query through the Statement Execution API, handing
+// it off to a job run if it takes longer than OptionJobsHandoffAfter.
+func (s *statementImpl) executeHandoff(ctx context.Context, query string) (array.RecordReader, error) {
+	api := s.conn.statementAPI
+	if api == nil {
+		return nil, s.conn.unsupported(FeatureJobsHandoff)
+	}
+	if s.conn.jobsOutputSchema == "" {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "%s requires %s", OptionJobsHandoffAfter, OptionJobsOutputSchema)
+	}
+
+	// The query is embedded in a CREATE TABLE if it is handed off
+	query = trimTerminator(query)
+	resp, err := api.executeWithin(ctx, query, s.conn.catalog, s.conn.dbSchema, s.conn.jobsHandoffAfter)
+	var running errStillRunning
+	if !errors.As(err, &running) {
+		if err != nil {
+			return nil, err
+		}
+		reportQueryID(ctx, resp.StatementID)
+		if err := s.checkResultSize(resp); err != nil {
+			return nil, err
+		}
+		return s.conn.newResultReader(s.laneContext(ctx, resp), s.alloc, resp)
+	}
+
+	table, err := jobOutputTable(s.conn.jobsOutputSchema)
+	if err != nil {
+		return nil, err
+	}
+	s.conn.Logger.InfoContext(ctx, "handing long-running query off to a job run",
+		"statement_id", running.statementID, "output_table", table)
+	create := "CREATE TABLE " + table + " AS\n" + query
+	if err := api.runQueryJob(ctx, create, s.conn.catalog, s.conn.dbSchema); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// The result read below is kept apart from the table, so it
+		// outlives it
+		dropCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := api.execute(dropCtx, "DROP TABLE IF EXISTS "+table, "", ""); err != nil {
+			s.conn.Logger.WarnContext(ctx, "failed to drop job output table", "output_table", table, "error", err)
+		}
+	}()
+
+	if resp, err = api.execute(ctx, "SELECT * FROM "+table, "", ""); err != nil {
+		return nil, err
+	}
+	reportQueryID(ctx, resp.StatementID)
+	if err := s.checkResultSize(resp); err != nil {
+		return nil, err
+	}
+	return s.conn.newResultReader(s.laneContext(ctx, resp), s.alloc, resp)
+}
+
+// jobOutputTable returns a new, quoted table name in schema (as
+// catalog.schema) for a job run's result.
+func jobOutputTable(schema string) (string, error) {
+	parts, err := splitQualifiedName(schema)
+	if err != nil || len(parts) != 2 {
+		return "", invalidOption(OptionJobsOutputSchema, schema, "a schema name of the form catalog.schema")
+	}
+	return qualifiedTableName(parts[0], parts[1], "adbc_job_"+strings.ToLower(rand.Text())), nil
+}
+
+// runQueryJob runs query as the SQL task of a one-time job run on the
+// warehouse and waits for the run to finish. The task's query is saved for
+// the run and deleted afterwards.
+func (a *statementAPI) runQueryJob(ctx context.Context, query, catalog, schema string) error {
+	var saved struct {
+		ID string `json:"id"`
+	}
+	saveQuery := map[string]string{
+		"display_name": "ADBC job " + rand.Text(),
+		"warehouse_id": a.warehouseID,
+		"query_text":   query,
+	}
+	if catalog != "" {
+		saveQuery["catalog"] = catalog
+	}
+	if schema != "" {
+		saveQuery["schema"] = schema
+	}
+	req := map[string]any{"query": saveQuery}
+	if err := a.do(ctx, http.MethodPost, "/api/2.0/sql/queries", req, &saved); err != nil {
+		return err
+	}
+	defer func() {
+		deleteCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = a.do(deleteCtx, http.MethodDelete, "/api/2.0/sql/queries/"+url.PathEscape(saved.ID), nil, nil)
+	}()
+
+	var run jobRun
+	submit := map[string]any{
+		"run_name": "ADBC long-running query",
+		"tasks": []any{map[string]any{
+			"task_key": "query",
+			"sql_task": map[string]any{
+				"query":        map[string]string{"query_id": saved.ID},
+				"warehouse_id": a.warehouseID,
+			},
+		}},
+	}
+	if err := a.do(ctx, http.MethodPost, "/api/2.2/jobs/runs/submit", submit, &run); err != nil {
+		return err
+	}
+	runID := run.RunID
+
+	cancelRun := func() error {
+		cancelCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_ = a.do(cancelCtx, http.MethodPost, "/api/2.2/jobs/runs/cancel", map[string]int64{"run_id": runID}, nil)
+		cancel()
+		return adbc.Error{Code: adbc.StatusCancelled, Msg: fmt.Sprintf("job run %d cancelled: %v", runID, ctx.Err())}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return cancelRun()
+		case <-a.clock.After(jobPollInterval):
+		}
+		if err := a.do(ctx, http.MethodGet, "/api/2.2/jobs/runs/get?run_id="+strconv.FormatInt(runID, 10), nil, &run); err != nil {
+			if ctx.Err() != nil {
+				return cancelRun()
+			}
+			return err
+		}
+		if !slices.Contains(jobRunFinalStates, run.State.LifeCycleState) {
+			continue
+		}
+		if run.State.ResultState != "SUCCESS" {
+			return adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg: fmt.Sprintf("job run %d %s: %s", runID,
+					strings.ToLower(cmp.Or(run.State.ResultState, run.State.LifeCycleState)), run.State.StateMessage),
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteHandoff(t *testing.T) {
+	defer func(interval time.Duration) { jobPollInterval = interval }(jobPollInterval)
+	jobPollInterval = time.Millisecond
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/2.0/sql/statements", func(w http.ResponseWriter, r *http.Request) {
+		var req statementRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case strings.HasPrefix(req.Statement, "SELECT * FROM `main`.`jobs`.`adbc_job_"):
+			record("select")
+			_, _ = fmt.Fprint(w, `{"statement_id": "stmt-2", "status": {"state": "SUCCEEDED"},
+				"manifest": {"total_chunk_count": 0, "schema": {"columns": [{"name": "n", "type_text": "BIGINT"}]}}}`)
+		case strings.HasPrefix(req.Statement, "DROP TABLE IF EXISTS `main`.`jobs`.`adbc_job_"):
+			record("drop")
+			_, _ = fmt.Fprint(w, `{"statement_id": "stmt-3", "status": {"state": "SUCCEEDED"}, "manifest": {}}`)
+		default:
+			assert.Equal(t, "SELECT count(*) AS n FROM big", req.Statement)
+			assert.Equal(t, "0s", req.WaitTimeout)
+			record("execute")
+			_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "RUNNING"}}`)
+		}
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "RUNNING"}}`)
+	})
+	mux.HandleFunc("POST /api/2.0/sql/statements/stmt-1/cancel", func(w http.ResponseWriter, r *http.Request) {
+		record("cancel")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("POST /api/2.0/sql/queries", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query map[string]string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Contains(t, req.Query["query_text"], "CREATE TABLE `main`.`jobs`.`adbc_job_")
+		assert.True(t, strings.HasSuffix(req.Query["query_text"], " AS\nSELECT count(*) AS n FROM big"))
+		assert.Equal(t, "wh", req.Query["warehouse_id"])
+		record("save query")
+		_, _ = fmt.Fprint(w, `{"id": "q-1"}`)
+	})
+	mux.HandleFunc("DELETE /api/2.0/sql/queries/q-1", func(w http.ResponseWriter, r *http.Request) {
+		record("delete query")
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("POST /api/2.2/jobs/runs/submit", func(w http.ResponseWriter, r *http.Request) {
+		record("submit")
+		_, _ = fmt.Fprint(w, `{"run_id": 42}`)
+	})
+	polls := 0
+	mux.HandleFunc("GET /api/2.2/jobs/runs/get", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "42", r.URL.Query().Get("run_id"))
+		polls++
+		if polls < 3 {
+			_, _ = fmt.Fprint(w, `{"run_id": 42, "state": {"life_cycle_state": "RUNNING"}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"run_id": 42, "state": {"life_cycle_state": "TERMINATED", "result_state": "SUCCESS"}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cnxn := &connectionImpl{
+		statementAPI: &statementAPI{
+			client:      server.Client(),
+			baseURL:     server.URL,
+			auth:        &pat.PATAuth{AccessToken: "token"},
+			warehouseID: "wh",
+		},
+		jobsHandoffAfter: 50 * time.Millisecond,
+		jobsOutputSchema: "main.jobs",
+	}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	stmt := &statementImpl{conn: cnxn, alloc: newTrackingAllocator(memory.DefaultAllocator)}
+
+	rdr, err := stmt.executeHandoff(context.Background(), "SELECT count(*) AS n FROM big;")
+	require.NoError(t, err)
+	defer rdr.Release()
+	assert.Equal(t, "n", rdr.Schema().Field(0).Name)
+	assert.False(t, rdr.Next())
+	assert.Equal(t, []string{"execute", "cancel", "save query", "submit", "delete query", "select", "drop"}, calls)
+	assert.Equal(t, 3, polls)
+}
+
+func TestJobOutputTable(t *testing.T) {
+	table, err := jobOutputTable("main.`my schema`")
+	require.NoError(t, err)
+	assert.Regexp(t, "^`main`.`my schema`.`adbc_job_[a-z0-9]+`$", table)
+
+	_, err = jobOutputTable("main")
+	assert.Error(t, err)
+}
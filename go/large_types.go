@@ -0,0 +1,190 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// maxSmallOffset is the largest value offset a String or Binary array can
+// hold.
+const maxSmallOffset = math.MaxInt32
+
+// largeType returns the 64-bit offset equivalent of dt, or nil if dt is
+// not a String or Binary type.
+func largeType(dt arrow.DataType) arrow.DataType {
+	switch dt.ID() {
+	case arrow.STRING:
+		return arrow.BinaryTypes.LargeString
+	case arrow.BINARY:
+		return arrow.BinaryTypes.LargeBinary
+	}
+	return nil
+}
+
+// largeTypesSchema returns schema with every top-level String and Binary
+// field replaced by LargeString and LargeBinary.
+func largeTypesSchema(schema *arrow.Schema) *arrow.Schema {
+	fields := schema.Fields()
+	for i, field := range fields {
+		if dt := largeType(field.Type); dt != nil {
+			fields[i].Type = dt
+		}
+	}
+	md := schema.Metadata()
+	return arrow.NewSchema(fields, &md)
+}
+
+// valueBytes returns the total length of the values in String and Binary
+// chunks, which is the last offset the concatenated array would need.
+func valueBytes(chunks []arrow.Array) int64 {
+	var total int64
+	for _, chunk := range chunks {
+		offsetter, ok := chunk.(interface{ ValueOffsets() []int32 })
+		if !ok || chunk.Len() == 0 {
+			continue
+		}
+		offsets := offsetter.ValueOffsets()
+		total += int64(offsets[len(offsets)-1] - offsets[0])
+	}
+	return total
+}
+
+// widenArray returns arr converted to LargeString or LargeBinary if it is
+// a String or Binary array, and arr itself (retained) otherwise. The
+// validity and value buffers are shared with arr; only the offsets are
+// copied.
+func widenArray(mem memory.Allocator, arr arrow.Array) arrow.Array {
+	dt := largeType(arr.DataType())
+	if dt == nil {
+		arr.Retain()
+		return arr
+	}
+
+	data := arr.Data()
+	var offsets []int32
+	if data.Len() > 0 {
+		offsets = arr.(interface{ ValueOffsets() []int32 }).ValueOffsets()
+	}
+
+	// Keep the array's offset so the validity bitmap still lines up; the
+	// slots before it are never read and just repeat the first offset.
+	wide := memory.NewResizableBuffer(mem)
+	defer wide.Release()
+	wide.Resize((data.Offset() + data.Len() + 1) * arrow.Int64SizeBytes)
+	wideOffsets := arrow.Int64Traits.CastFromBytes(wide.Bytes())
+	if len(offsets) > 0 {
+		for i := range wideOffsets[:data.Offset()] {
+			wideOffsets[i] = int64(offsets[0])
+		}
+		for i, off := range offsets {
+			wideOffsets[data.Offset()+i] = int64(off)
+		}
+	} else {
+		clear(wideOffsets)
+	}
+
+	buffers := data.Buffers()
+	var values *memory.Buffer
+	if len(buffers) > 2 {
+		values = buffers[2]
+	}
+	wideData := array.NewData(dt, data.Len(),
+		[]*memory.Buffer{buffers[0], wide, values}, nil, data.NullN(), data.Offset())
+	defer wideData.Release()
+	return array.MakeFromData(wideData)
+}
+
+// widenRecordBatch returns batch with its String and Binary columns
+// converted by widenArray, matching largeTypesSchema(batch.Schema()).
+func widenRecordBatch(mem memory.Allocator, schema *arrow.Schema, batch arrow.RecordBatch) arrow.RecordBatch {
+	cols := make([]arrow.Array, batch.NumCols())
+	for i, col := range batch.Columns() {
+		cols[i] = widenArray(mem, col)
+	}
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	return array.NewRecordBatch(schema, cols, batch.NumRows())
+}
+
+// largeTypesReader converts the String and Binary columns of every batch
+// read from rdr to LargeString and LargeBinary.
+type largeTypesReader struct {
+	refCount int64
+	mem      memory.Allocator
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	current  arrow.RecordBatch
+}
+
+// newLargeTypesReader wraps rdr, taking ownership of it.
+func newLargeTypesReader(mem memory.Allocator, rdr array.RecordReader) *largeTypesReader {
+	return &largeTypesReader{
+		refCount: 1,
+		mem:      mem,
+		rdr:      rdr,
+		schema:   largeTypesSchema(rdr.Schema()),
+	}
+}
+
+func (r *largeTypesReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *largeTypesReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.rdr.Release()
+	}
+}
+
+func (r *largeTypesReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *largeTypesReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if !r.rdr.Next() {
+		return false
+	}
+	r.current = widenRecordBatch(r.mem, r.schema, r.rdr.RecordBatch())
+	return true
+}
+
+func (r *largeTypesReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *largeTypesReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *largeTypesReader) Err() error {
+	return r.rdr.Err()
+}
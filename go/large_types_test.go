@@ -0,0 +1,146 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWidenArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewStringBuilder(mem)
+	defer bldr.Release()
+	bldr.AppendValues([]string{"a", "bb", "", "dddd"}, []bool{true, true, false, true})
+	arr := bldr.NewArray()
+	defer arr.Release()
+
+	t.Run("whole", func(t *testing.T) {
+		wide := widenArray(mem, arr)
+		defer wide.Release()
+		require.Equal(t, arrow.BinaryTypes.LargeString, wide.DataType())
+		assert.Equal(t, "bb", wide.(*array.LargeString).Value(1))
+		assert.True(t, wide.IsNull(2))
+		assert.Equal(t, "dddd", wide.(*array.LargeString).Value(3))
+	})
+
+	t.Run("sliced", func(t *testing.T) {
+		sliced := array.NewSlice(arr, 1, 4)
+		defer sliced.Release()
+		wide := widenArray(mem, sliced)
+		defer wide.Release()
+		require.Equal(t, 3, wide.Len())
+		assert.Equal(t, "bb", wide.(*array.LargeString).Value(0))
+		assert.True(t, wide.IsNull(1))
+		assert.Equal(t, "dddd", wide.(*array.LargeString).Value(2))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		empty := array.MakeArrayOfNull(mem, arrow.BinaryTypes.Binary, 0)
+		defer empty.Release()
+		wide := widenArray(mem, empty)
+		defer wide.Release()
+		assert.Equal(t, arrow.BinaryTypes.LargeBinary, wide.DataType())
+		assert.Equal(t, 0, wide.Len())
+	})
+
+	t.Run("other types", func(t *testing.T) {
+		ints := array.MakeArrayOfNull(mem, arrow.PrimitiveTypes.Int64, 2)
+		defer ints.Release()
+		same := widenArray(mem, ints)
+		defer same.Release()
+		assert.Same(t, ints, same)
+	})
+}
+
+func TestValueBytes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewStringBuilder(mem)
+	defer bldr.Release()
+	bldr.AppendValues([]string{"abc", "de"}, nil)
+	first := bldr.NewArray()
+	defer first.Release()
+	bldr.AppendValues([]string{"fghij"}, nil)
+	second := bldr.NewArray()
+	defer second.Release()
+	sliced := array.NewSlice(first, 1, 2)
+	defer sliced.Release()
+
+	assert.Equal(t, int64(10), valueBytes([]arrow.Array{first, second}))
+	assert.Equal(t, int64(2), valueBytes([]arrow.Array{sliced}))
+}
+
+func TestLargeTypesReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rdr := newLargeTypesReader(mem, makeConcatTestReader(t, mem,
+		makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"}),
+		makeConcatTestBatch(t, mem, []int64{3}, []string{"c"}),
+	))
+	defer rdr.Release()
+
+	expected := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.LargeString, Nullable: true},
+	}, nil)
+	assert.True(t, expected.Equal(rdr.Schema()))
+
+	var names []string
+	for rdr.Next() {
+		batch := rdr.RecordBatch()
+		assert.True(t, expected.Equal(batch.Schema()))
+		col := batch.Column(1).(*array.LargeString)
+		for i := 0; i < col.Len(); i++ {
+			names = append(names, col.Value(i))
+		}
+	}
+	require.NoError(t, rdr.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestConcatRecordReaderLargeTypes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// Already-large columns concatenate unchanged
+	rdr := newLargeTypesReader(mem, makeConcatTestReader(t, mem,
+		makeConcatTestBatch(t, mem, []int64{1}, []string{"a"}),
+		makeConcatTestBatch(t, mem, []int64{2}, []string{"b"}),
+	))
+	rec, err := concatRecordReader(mem, rdr, 0, true)
+	require.NoError(t, err)
+	defer rec.Release()
+
+	assert.Equal(t, arrow.BinaryTypes.LargeString, rec.Schema().Field(1).Type)
+	assert.Equal(t, "b", rec.Column(1).(*array.LargeString).Value(1))
+
+	// Small columns are not up-cast unless they would overflow
+	rec2, err := concatRecordReader(mem, makeConcatTestReader(t, mem,
+		makeConcatTestBatch(t, mem, []int64{1}, []string{"a"}),
+	), 0, true)
+	require.NoError(t, err)
+	defer rec2.Release()
+	assert.True(t, concatTestSchema.Equal(rec2.Schema()))
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// chunkAttempts is how many times a chunk is opened before lenient result
+// assembly gives up on it.
+const chunkAttempts = 3
+
+// chunkRetryWait is how long to wait before the second attempt to open a
+// chunk; later attempts wait proportionally longer. A variable for tests.
+var chunkRetryWait = time.Second
+
+// skippedRange is a run of result rows left out by lenient result assembly.
+type skippedRange struct {
+	ChunkIndex int64  `json:"chunk_index"`
+	RowOffset  int64  `json:"row_offset"`
+	RowCount   int64  `json:"row_count"`
+	Error      string `json:"error"`
+}
+
+// skippedRows collects the rows skipped while a result is read, which may
+// be after ExecuteQuery has returned.
+type skippedRows struct {
+	mu     sync.Mutex
+	ranges []skippedRange
+}
+
+func (s *skippedRows) add(r skippedRange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ranges = append(s.ranges, r)
+}
+
+// json formats the ranges as a JSON array; a nil skippedRows has none.
+func (s *skippedRows) json() (string, error) {
+	ranges := []skippedRange{}
+	if s != nil {
+		s.mu.Lock()
+		ranges = append(ranges, s.ranges...)
+		s.mu.Unlock()
+	}
+	out, err := json.Marshal(ranges)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// executeLenient runs the statement's query through the Statement Execution
+// API and reads the result chunk by chunk, skipping the chunks that can't
+// be read and recording them in skipped.
+func (s *statementImpl) executeLenient(ctx context.Context, query string, skipped *skippedRows) (array.RecordReader, error) {
+	api := s.conn.statementAPI
+	if api == nil {
+		return nil, s.conn.unsupported(FeatureLenientResults)
+	}
+	resp, err := api.execute(ctx, query, s.conn.catalog, s.conn.dbSchema)
+	if err != nil {
+		return nil, err
+	}
+	reportQueryID(ctx, resp.StatementID)
+	if err := s.checkResultSize(resp); err != nil {
+		return nil, err
+	}
+
+	chunks := resp.Manifest.Chunks
+	if int64(len(chunks)) != resp.Manifest.TotalChunkCount {
+		// Without the row ranges, skipped chunks can only be reported by
+		// index
+		chunks = make([]chunkInfo, resp.Manifest.TotalChunkCount)
+		for i := range chunks {
+			chunks[i] = chunkInfo{ChunkIndex: int64(i), RowOffset: -1, RowCount: -1}
+		}
+	}
+	ctx, cancel := context.WithCancel(s.laneContext(ctx, resp))
+	rdr := &lenientReader{
+		refCount:    1,
+		ctx:         ctx,
+		cancel:      cancel,
+		conn:        s.conn,
+		mem:         s.alloc,
+		statementID: resp.StatementID,
+		chunks:      chunks,
+		skipped:     skipped,
+		warnings:    s.warnings,
+		declared:    declaredManifestTypes(resp.Manifest),
+	}
+
+	// The schema comes from the first chunk that can be read, or else from
+	// the manifest
+	for rdr.current == nil && rdr.next < len(rdr.chunks) {
+		rdr.openNext()
+	}
+	if rdr.current != nil {
+		rdr.schema = rdr.current.Schema()
+	} else if rdr.schema, err = manifestSchema(resp.Manifest); err != nil {
+		rdr.Release()
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "%v", err)
+	}
+	return rdr, nil
+}
+
+// lenientReader reads the chunks of a result in order. A chunk that still
+// fails after chunkAttempts is skipped, as is the rest of a chunk that
+// fails part way through.
+type lenientReader struct {
+	refCount    int64
+	ctx         context.Context
+	cancel      context.CancelFunc
+	conn        *connectionImpl
+	mem         memory.Allocator
+	statementID string
+	chunks      []chunkInfo
+	skipped     *skippedRows
+	warnings    *statementWarnings
+	schema      *arrow.Schema
+	declared    []arrow.DataType
+
+	next     int
+	current  *chunkReader
+	chunk    chunkInfo
+	rowsRead int64
+	err      error
+}
+
+// openNext opens the next chunk, recording it as skipped if it can't be
+// opened. Cancellation is returned through err instead.
+func (r *lenientReader) openNext() {
+	r.chunk = r.chunks[r.next]
+	r.next++
+	r.rowsRead = 0
+
+	var err error
+	for attempt := 1; attempt <= chunkAttempts; attempt++ {
+		var rdr *chunkReader
+		if rdr, err = r.conn.readChunk(r.ctx, r.mem, r.statementID, r.chunk.ChunkIndex); err == nil {
+			r.current = rdr
+			return
+		}
+		if r.ctx.Err() != nil {
+			break
+		}
+		if attempt < chunkAttempts {
+			select {
+			case <-r.ctx.Done():
+			case <-r.conn.clock.After(time.Duration(attempt) * chunkRetryWait):
+			}
+		}
+	}
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		r.err = adbc.Error{Code: adbc.StatusCancelled, Msg: ctxErr.Error()}
+		return
+	}
+	r.skip(err)
+}
+
+// skip records the unread rows of the current chunk as skipped.
+func (r *lenientReader) skip(err error) {
+	skipped := skippedRange{
+		ChunkIndex: r.chunk.ChunkIndex,
+		RowOffset:  r.chunk.RowOffset,
+		RowCount:   r.chunk.RowCount,
+		Error:      err.Error(),
+	}
+	if skipped.RowOffset >= 0 {
+		skipped.RowOffset += r.rowsRead
+		skipped.RowCount -= r.rowsRead
+	}
+	r.skipped.add(skipped)
+	r.warnings.add(WarningRowsSkipped, "skipped unreadable chunk %d of the result: %s", skipped.ChunkIndex, skipped.Error)
+	r.conn.Logger.WarnContext(r.ctx, "skipped unreadable result chunk",
+		"statement_id", r.statementID, "chunk_index", skipped.ChunkIndex,
+		"row_offset", skipped.RowOffset, "row_count", skipped.RowCount, "error", skipped.Error)
+}
+
+func (r *lenientReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *lenientReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		// Stop a download in progress rather than waiting for it
+		r.cancel()
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+	}
+}
+
+func (r *lenientReader) declaredTypes() []arrow.DataType {
+	return r.declared
+}
+
+func (r *lenientReader) batchStats() batchStats {
+	if r.current == nil {
+		return batchStats{}
+	}
+	return r.current.batchStats()
+}
+
+func (r *lenientReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *lenientReader) Next() bool {
+	for r.err == nil {
+		if r.current != nil {
+			if r.current.Next() {
+				r.rowsRead += r.current.RecordBatch().NumRows()
+				return true
+			}
+			if err := r.current.Err(); err != nil {
+				var adbcErr adbc.Error
+				if errors.As(err, &adbcErr) && adbcErr.Code == adbc.StatusCancelled || r.ctx.Err() != nil {
+					r.err = err
+					return false
+				}
+				r.skip(err)
+			}
+			r.current.Release()
+			r.current = nil
+		}
+		if r.next >= len(r.chunks) {
+			return false
+		}
+		r.openNext()
+	}
+	return false
+}
+
+func (r *lenientReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *lenientReader) RecordBatch() arrow.RecordBatch {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.RecordBatch()
+}
+
+func (r *lenientReader) Err() error {
+	return r.err
+}
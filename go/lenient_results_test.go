@@ -0,0 +1,171 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLenientTestConnection serves a finished statement whose result has one
+// chunk per element of chunks; nil chunks can't be downloaded.
+func newLenientTestConnection(t *testing.T, mem memory.Allocator, chunks [][]byte) *connectionImpl {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("POST /api/2.0/sql/statements", func(w http.ResponseWriter, r *http.Request) {
+		var chunkInfos []chunkInfo
+		for i := range chunks {
+			chunkInfos = append(chunkInfos, chunkInfo{ChunkIndex: int64(i), RowOffset: int64(2 * i), RowCount: 2})
+		}
+		resp := map[string]any{
+			"statement_id": "stmt-1",
+			"status":       map[string]any{"state": "SUCCEEDED"},
+			"manifest": map[string]any{
+				"total_chunk_count": len(chunks),
+				"chunks":            chunkInfos,
+				"schema": map[string]any{"columns": []map[string]string{
+					{"name": "id", "type_text": "BIGINT"},
+					{"name": "name", "type_text": "STRING"},
+				}},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	for i := range chunks {
+		mux.HandleFunc(fmt.Sprintf("GET /api/2.0/sql/statements/stmt-1/result/chunks/%d", i), func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(resultData{ExternalLinks: []externalLink{{
+				ChunkIndex:   int64(i),
+				ExternalLink: fmt.Sprintf("%s/storage/chunk-%d", server.URL, i),
+			}}})
+		})
+		mux.HandleFunc(fmt.Sprintf("GET /storage/chunk-%d", i), func(w http.ResponseWriter, r *http.Request) {
+			if chunks[i] == nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write(chunks[i])
+		})
+	}
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cnxn := &connectionImpl{statementAPI: &statementAPI{
+		client:      server.Client(),
+		storage:     &http.Client{Transport: newStoragePool(nil)},
+		baseURL:     server.URL,
+		auth:        &pat.PATAuth{AccessToken: "token"},
+		warehouseID: "wh",
+	}}
+	cnxn.Alloc = mem
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	return cnxn
+}
+
+func TestLenientResults(t *testing.T) {
+	retryWait := chunkRetryWait
+	chunkRetryWait = 0
+	defer func() { chunkRetryWait = retryWait }()
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	chunk := func(ids []int64, names []string) []byte {
+		batch := makeConcatTestBatch(t, mem, ids, names)
+		defer batch.Release()
+		return writeIPCStream(t, concatTestSchema, batch)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		chunks  [][]byte
+		ids     []int64
+		skipped []skippedRange
+	}{
+		{"all readable", [][]byte{chunk([]int64{1, 2}, []string{"a", "b"}), chunk([]int64{3, 4}, []string{"c", "d"})}, []int64{1, 2, 3, 4}, nil},
+		{"middle chunk lost", [][]byte{chunk([]int64{1, 2}, []string{"a", "b"}), nil, chunk([]int64{5, 6}, []string{"e", "f"})}, []int64{1, 2, 5, 6},
+			[]skippedRange{{ChunkIndex: 1, RowOffset: 2, RowCount: 2}}},
+		{"first chunk lost", [][]byte{nil, chunk([]int64{3, 4}, []string{"c", "d"})}, []int64{3, 4},
+			[]skippedRange{{ChunkIndex: 0, RowOffset: 0, RowCount: 2}}},
+		{"every chunk lost", [][]byte{nil}, nil,
+			[]skippedRange{{ChunkIndex: 0, RowOffset: 0, RowCount: 2}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cnxn := newLenientTestConnection(t, mem, tc.chunks)
+			stmt, err := cnxn.NewStatement()
+			require.NoError(t, err)
+			s := stmt.(*statementImpl)
+			require.NoError(t, s.SetOption(OptionFetchResultMode, OptionValueResultModeLenient))
+
+			skipped := &skippedRows{}
+			rdr, err := s.executeLenient(context.Background(), "SELECT", skipped)
+			require.NoError(t, err)
+			defer rdr.Release()
+
+			assert.Equal(t, []string{"id", "name"}, []string{rdr.Schema().Field(0).Name, rdr.Schema().Field(1).Name})
+			var ids []int64
+			for rdr.Next() {
+				ids = append(ids, rdr.RecordBatch().Column(0).(*array.Int64).Int64Values()...)
+			}
+			require.NoError(t, rdr.Err())
+			assert.Equal(t, tc.ids, ids)
+
+			require.Len(t, skipped.ranges, len(tc.skipped))
+			for i, r := range skipped.ranges {
+				assert.NotEmpty(t, r.Error)
+				r.Error = ""
+				assert.Equal(t, tc.skipped[i], r)
+			}
+		})
+	}
+}
+
+func TestSkippedRowsJSON(t *testing.T) {
+	var none *skippedRows
+	value, err := none.json()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	skipped := &skippedRows{}
+	skipped.add(skippedRange{ChunkIndex: 3, RowOffset: 100, RowCount: 50, Error: "download failed"})
+	value, err = skipped.json()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"chunk_index": 3, "row_offset": 100, "row_count": 50, "error": "download failed"}]`, value)
+}
+
+func TestResultModeOption(t *testing.T) {
+	stmt, err := (&connectionImpl{}).NewStatement()
+	require.NoError(t, err)
+	s := stmt.(*statementImpl)
+
+	value, err := s.GetOption(OptionFetchResultMode)
+	require.NoError(t, err)
+	assert.Equal(t, OptionValueResultModeStrict, value)
+	assert.ErrorContains(t, s.SetOption(OptionFetchResultMode, "sloppy"), OptionFetchResultMode)
+
+	require.NoError(t, s.SetOption(OptionFetchResultMode, OptionValueResultModeLenient))
+	_, err = s.executeLenient(context.Background(), "SELECT 1", &skippedRows{})
+	assert.ErrorContains(t, err, OptionHTTPPath)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import "sync"
+
+// liveOptions are the database options whose new values reach connections
+// that are already open, by the function applying each to a connection.
+// The connection settings they change are atomic, since the connection may
+// be in use on another goroutine. Other options take effect for
+// connections opened afterwards.
+var liveOptions = map[string]func(d *databaseImpl, c *connectionImpl){
+	OptionMetadataRetryCount: func(d *databaseImpl, c *connectionImpl) {
+		c.metadataRetries.Store(int64(d.metadataRetryCount))
+	},
+	OptionErrorMaxMessageLength: func(d *databaseImpl, c *connectionImpl) {
+		c.errorMaxLength.Store(int64(d.errorMaxLength))
+	},
+	OptionSchemaCacheTTL: func(d *databaseImpl, c *connectionImpl) {
+		c.schemaCacheTTL.Store(int64(d.schemaCacheTTL))
+	},
+}
+
+// openConnections is the set of a database's open connections.
+type openConnections struct {
+	mu    sync.Mutex
+	conns map[*connectionImpl]struct{}
+}
+
+// add tracks c until the returned function is called.
+func (o *openConnections) add(c *connectionImpl) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.conns == nil {
+		o.conns = map[*connectionImpl]struct{}{}
+	}
+	o.conns[c] = struct{}{}
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.conns, c)
+	}
+}
+
+// applyLiveOption passes the new value of key, if it is one of
+// liveOptions, on to the database's open connections.
+func (d *databaseImpl) applyLiveOption(key string) {
+	apply, ok := liveOptions[key]
+	if !ok {
+		return
+	}
+	d.openConns.mu.Lock()
+	defer d.openConns.mu.Unlock()
+	for c := range d.openConns.conns {
+		apply(d, c)
+	}
+}
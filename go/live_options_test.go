@@ -0,0 +1,49 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveOptions(t *testing.T) {
+	d := &databaseImpl{}
+	first, second := &connectionImpl{}, &connectionImpl{}
+	d.openConns.add(first)
+	stop := d.openConns.add(second)
+
+	require.NoError(t, d.SetOption(OptionMetadataRetryCount, "9"))
+	require.NoError(t, d.SetOption(OptionErrorMaxMessageLength, "2048"))
+	require.NoError(t, d.SetOption(OptionSchemaCacheTTL, "5m"))
+	for _, c := range []*connectionImpl{first, second} {
+		assert.EqualValues(t, 9, c.metadataRetries.Load())
+		assert.EqualValues(t, 2048, c.errorMaxLength.Load())
+		assert.EqualValues(t, 5*time.Minute, c.schemaCacheTTL.Load())
+	}
+
+	// Closed connections are left alone
+	stop()
+	require.NoError(t, d.SetOption(OptionMetadataRetryCount, "1"))
+	assert.EqualValues(t, 1, first.metadataRetries.Load())
+	assert.EqualValues(t, 9, second.metadataRetries.Load())
+
+	// Other options only apply to new connections
+	require.NoError(t, d.SetOption(OptionQueryRetryCount, "3"))
+	assert.Equal(t, 3, d.queryRetryCount)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// ErrorDetailMaintenance is the key of the error detail attached to the
+// errors of requests rejected because the workspace or warehouse is in a
+// maintenance or upgrade window; these errors also match
+// errors.ErrMaintenance. The detail is a JSON object with the fields
+// retryable (always true) and retry_after_ms, the backoff the server
+// suggested with Retry-After, or maintenanceRetryAfter if it didn't.
+// Orchestrators can use it to pause jobs until the window ends rather
+// than fail them.
+const ErrorDetailMaintenance = "databricks.maintenance"
+
+// maintenanceRetryAfter is the backoff suggested for maintenance errors
+// that don't come with a Retry-After.
+var maintenanceRetryAfter = 5 * time.Minute
+
+// maintenancePattern matches the messages of the responses the workspace
+// and warehouses send during planned maintenance and upgrades.
+var maintenancePattern = regexp.MustCompile(`under(going)? maintenance|maintenance (window|mode|in progress)|scheduled maintenance|upgrade in progress|being upgraded|(workspace|service)_under_maintenance`)
+
+// retryAfterPattern matches the Retry-After that send adds to the messages
+// of 429 and 503 responses, in seconds.
+var retryAfterPattern = regexp.MustCompile(`\(retry-after: (\d+)\)`)
+
+// isMaintenance reports whether msg, a lowercased error message, is the
+// response of a workspace or warehouse in a maintenance window.
+func isMaintenance(msg string) bool {
+	return maintenancePattern.MatchString(msg)
+}
+
+// retryAfter returns the backoff a lowercased error message suggests, or
+// maintenanceRetryAfter.
+func retryAfter(msg string) time.Duration {
+	if m := retryAfterPattern.FindStringSubmatch(msg); m != nil {
+		if seconds, err := strconv.ParseInt(m[1], 10, 64); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return maintenanceRetryAfter
+}
+
+// withMaintenanceDetail returns adbcErr with ErrorDetailMaintenance added.
+func withMaintenanceDetail(adbcErr adbc.Error, backoff time.Duration) adbc.Error {
+	detail, err := json.Marshal(struct {
+		Retryable    bool  `json:"retryable"`
+		RetryAfterMs int64 `json:"retry_after_ms"`
+	}{true, backoff.Milliseconds()})
+	if err != nil {
+		return adbcErr
+	}
+	adbcErr.Details = append(slices.Clip(adbcErr.Details),
+		&adbc.TextErrorDetail{Name: ErrorDetailMaintenance, Detail: string(detail)})
+	return adbcErr
+}
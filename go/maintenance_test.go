@@ -0,0 +1,76 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func maintenanceDetail(t *testing.T, err error) string {
+	t.Helper()
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	for _, detail := range adbcErr.Details {
+		if detail.Key() == ErrorDetailMaintenance {
+			return detail.(*adbc.TextErrorDetail).Detail
+		}
+	}
+	t.Fatalf("no %s detail in %v", ErrorDetailMaintenance, adbcErr.Details)
+	return ""
+}
+
+func TestClassifyMaintenance(t *testing.T) {
+	err := classifyError(errors.New("failed to open session: 503 Service Unavailable: TEMPORARILY_UNAVAILABLE: The workspace is undergoing maintenance"))
+	assert.ErrorIs(t, err, dbxerrors.ErrMaintenance)
+	assert.NotErrorIs(t, err, dbxerrors.ErrWarehouseStarting)
+	assert.True(t, isTransient(err))
+	assert.JSONEq(t, `{"retryable": true, "retry_after_ms": 300000}`, maintenanceDetail(t, err))
+
+	// Classifying again doesn't add the detail twice
+	var adbcErr adbc.Error
+	require.ErrorAs(t, classifyError(err), &adbcErr)
+	assert.Len(t, adbcErr.Details, 1)
+
+	assert.NotErrorIs(t, classifyError(errors.New("syntax error near 'maintenance'")), dbxerrors.ErrMaintenance)
+}
+
+func TestStatementAPIMaintenance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		http.Error(w, `{"error_code": "TEMPORARILY_UNAVAILABLE", "message": "Warehouse upgrade in progress"}`, http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	api := &statementAPI{
+		client:      server.Client(),
+		baseURL:     server.URL,
+		auth:        &pat.PATAuth{AccessToken: "token"},
+		warehouseID: "wh",
+	}
+
+	_, err := api.execute(context.Background(), "SELECT 1", "", "")
+	err = classifyError(err)
+	assert.ErrorIs(t, err, dbxerrors.ErrMaintenance)
+	assert.JSONEq(t, `{"retryable": true, "retry_after_ms": 120000}`, maintenanceDetail(t, err))
+}
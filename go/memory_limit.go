@@ -0,0 +1,136 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// trackingAllocator counts the bytes currently allocated through it and
+// not yet freed. Each statement allocates its results from its own
+// trackingAllocator wrapping the driver's allocator.
+type trackingAllocator struct {
+	mem  memory.Allocator
+	live atomic.Int64
+}
+
+func newTrackingAllocator(mem memory.Allocator) *trackingAllocator {
+	return &trackingAllocator{mem: mem}
+}
+
+func (a *trackingAllocator) Allocate(size int) []byte {
+	b := a.mem.Allocate(size)
+	a.live.Add(int64(len(b)))
+	return b
+}
+
+func (a *trackingAllocator) Reallocate(size int, b []byte) []byte {
+	old := len(b)
+	b = a.mem.Reallocate(size, b)
+	a.live.Add(int64(len(b) - old))
+	return b
+}
+
+func (a *trackingAllocator) Free(b []byte) {
+	a.live.Add(-int64(len(b)))
+	a.mem.Free(b)
+}
+
+// Live returns the number of bytes allocated and not yet freed.
+func (a *trackingAllocator) Live() int64 {
+	return a.live.Load()
+}
+
+// memoryLimitReader stops reading from rdr once the allocator's live bytes
+// exceed limit. rdr is released at that point, which closes the
+// server-side operation instead of fetching the rest of the result.
+type memoryLimitReader struct {
+	refCount int64
+	alloc    *trackingAllocator
+	limit    int64
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	err      error
+}
+
+// newMemoryLimitReader wraps rdr, taking ownership of it.
+func newMemoryLimitReader(alloc *trackingAllocator, limit int64, rdr array.RecordReader) *memoryLimitReader {
+	return &memoryLimitReader{
+		refCount: 1,
+		alloc:    alloc,
+		limit:    limit,
+		rdr:      rdr,
+		schema:   rdr.Schema(),
+	}
+}
+
+func (r *memoryLimitReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *memoryLimitReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 && r.rdr != nil {
+		r.rdr.Release()
+		r.rdr = nil
+	}
+}
+
+func (r *memoryLimitReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *memoryLimitReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	if !r.rdr.Next() {
+		return false
+	}
+	if live := r.alloc.Live(); live > r.limit {
+		r.err = adbc.Error{
+			Code: adbc.StatusCancelled,
+			Msg: fmt.Sprintf("fetch cancelled: statement holds %d bytes of Arrow memory, over the %s limit of %d; release earlier batches or raise the limit",
+				live, OptionFetchMemoryLimit, r.limit),
+		}
+		r.rdr.Release()
+		r.rdr = nil
+		return false
+	}
+	return true
+}
+
+func (r *memoryLimitReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *memoryLimitReader) RecordBatch() arrow.RecordBatch {
+	if r.rdr == nil {
+		return nil
+	}
+	return r.rdr.RecordBatch()
+}
+
+func (r *memoryLimitReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.rdr.Err()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackingAllocator(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	alloc := newTrackingAllocator(mem)
+	b := alloc.Allocate(64)
+	assert.Equal(t, int64(64), alloc.Live())
+	b = alloc.Reallocate(128, b)
+	assert.Equal(t, int64(128), alloc.Live())
+	alloc.Free(b)
+	assert.Equal(t, int64(0), alloc.Live())
+}
+
+func TestMemoryLimitReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	alloc := newTrackingAllocator(mem)
+
+	t.Run("under limit", func(t *testing.T) {
+		rdr := newMemoryLimitReader(alloc, 1<<20, makeConcatTestReader(t, alloc,
+			makeConcatTestBatch(t, alloc, []int64{1, 2}, []string{"a", "b"}),
+			makeConcatTestBatch(t, alloc, []int64{3}, []string{"c"}),
+		))
+		defer rdr.Release()
+
+		var rows int64
+		for rdr.Next() {
+			rows += rdr.RecordBatch().NumRows()
+		}
+		require.NoError(t, rdr.Err())
+		assert.Equal(t, int64(3), rows)
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		rdr := newMemoryLimitReader(alloc, 1, makeConcatTestReader(t, alloc,
+			makeConcatTestBatch(t, alloc, []int64{1, 2}, []string{"a", "b"}),
+			makeConcatTestBatch(t, alloc, []int64{3}, []string{"c"}),
+		))
+		defer rdr.Release()
+
+		assert.False(t, rdr.Next())
+		assert.Nil(t, rdr.RecordBatch())
+		assert.True(t, concatTestSchema.Equal(rdr.Schema()))
+		var adbcErr adbc.Error
+		require.ErrorAs(t, rdr.Err(), &adbcErr)
+		assert.Equal(t, adbc.StatusCancelled, adbcErr.Code)
+		assert.Contains(t, adbcErr.Msg, OptionFetchMemoryLimit)
+		// Cancelling released the underlying reader and its batches
+		assert.Equal(t, int64(0), alloc.Live())
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// The backoff between attempts of a metadata query doubles from
+// metadataRetryWaitMin up to metadataRetryWaitMax, with full jitter so
+// that the clients of a scaling warehouse don't retry in step.
+var (
+	metadataRetryWaitMin = 500 * time.Millisecond
+	metadataRetryWaitMax = 10 * time.Second
+)
+
+// isTransient reports whether err is a failure the gateway or warehouse is
+// expected to recover from by itself, such as a 502 or 503 while a
+// warehouse scales, or a maintenance window.
+func isTransient(err error) bool {
+	err = classifyError(err)
+	if errors.Is(err, dbxerrors.ErrWarehouseStarting) || errors.Is(err, dbxerrors.ErrRateLimited) ||
+		errors.Is(err, dbxerrors.ErrMaintenance) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "502 bad gateway") || strings.Contains(msg, "504 gateway timeout")
+}
+
+// retryMetadata calls op, calling it again after a backoff while it fails
+// with a transient error, up to OptionMetadataRetryCount more times. Only
+// idempotent operations (metadata queries) are retried this way. If op
+// fails after being retried, the error carries ErrorDetailRetryHistory.
+func (c *connectionImpl) retryMetadata(ctx context.Context, operation string, op func() error) error {
+	history := newRetryHistory(operation, c.clock)
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+		if int64(attempt) >= c.metadataRetries.Load() || !isTransient(err) {
+			history.record(err, 0)
+			return history.fail(err)
+		}
+		wait := rand.N(min(metadataRetryWaitMax, metadataRetryWaitMin<<attempt) + 1)
+		history.record(err, wait)
+		c.Logger.WarnContext(ctx, "metadata query failed; retrying",
+			slog.String("operation", operation), slog.Int("attempt", attempt+1),
+			slog.Duration("retry_in", wait), slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return history.fail(errors.Join(err, ctx.Err()))
+		case <-c.clock.After(wait):
+		}
+	}
+}
+
+// queryMetadata runs a metadata query, retrying it with retryMetadata.
+// what names the objects queried, for the error message.
+func (c *connectionImpl) queryMetadata(ctx context.Context, what, query string) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := c.retryMetadata(ctx, "query "+what, func() error {
+		conn, err := c.sqlConn(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err = conn.QueryContext(ctx, query)
+		if err != nil {
+			return adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to query %s: %v", what, err),
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
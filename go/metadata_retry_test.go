@@ -0,0 +1,99 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(errors.New("unexpected HTTP status 503 Service Unavailable")))
+	assert.True(t, isTransient(errors.New("unexpected HTTP status 502 Bad Gateway")))
+	assert.True(t, isTransient(errors.New("unexpected HTTP status 504 Gateway Timeout")))
+	assert.True(t, isTransient(errors.New("unexpected HTTP status 429 Too Many Requests")))
+	assert.False(t, isTransient(errors.New("[TABLE_OR_VIEW_NOT_FOUND] The table cannot be found")))
+}
+
+func TestRetryMetadata(t *testing.T) {
+	defer func(lo, hi time.Duration) {
+		metadataRetryWaitMin, metadataRetryWaitMax = lo, hi
+	}(metadataRetryWaitMin, metadataRetryWaitMax)
+	metadataRetryWaitMin, metadataRetryWaitMax = time.Millisecond, 2*time.Millisecond
+
+	cnxn := &connectionImpl{}
+	cnxn.metadataRetries.Store(2)
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	unavailable := errors.New("unexpected HTTP status 503 Service Unavailable")
+
+	calls := 0
+	err := cnxn.retryMetadata(context.Background(), "test", func() error {
+		calls++
+		if calls < 3 {
+			return unavailable
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// The budget runs out
+	calls = 0
+	err = cnxn.retryMetadata(context.Background(), "test", func() error {
+		calls++
+		return unavailable
+	})
+	assert.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 3, calls)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, classifyError(err), &adbcErr)
+	require.Len(t, adbcErr.Details, 1)
+	assert.Equal(t, ErrorDetailRetryHistory, adbcErr.Details[0].Key())
+	detail, _ := adbcErr.Details[0].Serialize()
+	var history retryHistory
+	require.NoError(t, json.Unmarshal(detail, &history))
+	assert.Equal(t, "test", history.Operation)
+	require.Len(t, history.Attempts, 3)
+	assert.Equal(t, "warehouse is starting", history.Attempts[0].Kind)
+	assert.Zero(t, history.Attempts[2].BackoffMs)
+
+	// Other errors aren't retried
+	calls = 0
+	notFound := errors.New("[SCHEMA_NOT_FOUND] The schema cannot be found")
+	err = cnxn.retryMetadata(context.Background(), "test", func() error {
+		calls++
+		return notFound
+	})
+	assert.ErrorIs(t, err, notFound)
+	assert.Equal(t, 1, calls)
+
+	// Retries are disabled with a zero count
+	calls = 0
+	cnxn.metadataRetries.Store(0)
+	err = cnxn.retryMetadata(context.Background(), "test", func() error {
+		calls++
+		return unavailable
+	})
+	assert.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 1, calls)
+}
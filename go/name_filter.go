@@ -0,0 +1,86 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// likeEscape is the character that escapes % and _ in GetObjects filters,
+// as reported by InfoSearchStringEscape.
+const likeEscape = '\\'
+
+// escapeLike returns filter as a LIKE pattern: filter itself in pattern
+// mode, or with its wildcards and escapes escaped in literal mode.
+func (c *connectionImpl) escapeLike(filter string) string {
+	if c.metadataFilterMatch != OptionValueFilterMatchLiteral {
+		return filter
+	}
+	var b strings.Builder
+	for _, r := range filter {
+		if r == '%' || r == '_' || r == likeEscape {
+			b.WriteRune(likeEscape)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// likeCondition returns a SQL condition matching column against a
+// GetObjects filter. Identifiers are case-insensitive, so the comparison
+// is too.
+func (c *connectionImpl) likeCondition(column, filter string) string {
+	return column + " ILIKE " + stringLiteral(c.escapeLike(filter)) + " ESCAPE " + stringLiteral(string(likeEscape))
+}
+
+// nameMatcher returns a function matching names against a GetObjects
+// filter on the client, as SHOW commands take their own pattern syntax
+// rather than LIKE's. It returns nil if filter is nil.
+func (c *connectionImpl) nameMatcher(filter *string) func(string) bool {
+	if filter == nil {
+		return nil
+	}
+	re := likeRegexp(c.escapeLike(*filter))
+	return re.MatchString
+}
+
+// likeRegexp compiles a LIKE pattern into an equivalent case-insensitive
+// regular expression. A trailing escape character matches itself.
+func likeRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`(?is)^`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == likeEscape:
+			escaped = true
+		case r == '%':
+			b.WriteString(`.*`)
+		case r == '_':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		b.WriteString(regexp.QuoteMeta(string(likeEscape)))
+	}
+	b.WriteString(`$`)
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameMatcher(t *testing.T) {
+	tests := []struct {
+		match   string
+		filter  string
+		name    string
+		matches bool
+	}{
+		{OptionValueFilterMatchPattern, "ma%", "main", true},
+		{OptionValueFilterMatchPattern, "ma%", "MAIN", true},
+		{OptionValueFilterMatchPattern, "m_in", "main", true},
+		{OptionValueFilterMatchPattern, "m_in", "mxxin", false},
+		{OptionValueFilterMatchPattern, `my\_table`, "my_table", true},
+		{OptionValueFilterMatchPattern, `my\_table`, "myxtable", false},
+		{OptionValueFilterMatchPattern, `100\%`, "100%", true},
+		{OptionValueFilterMatchPattern, "a.b", "axb", false},
+		{OptionValueFilterMatchPattern, "a*", "abc", false},
+		{OptionValueFilterMatchPattern, `trailing\`, `trailing\`, true},
+		{OptionValueFilterMatchLiteral, "my_table", "my_table", true},
+		{OptionValueFilterMatchLiteral, "my_table", "myxtable", false},
+		{OptionValueFilterMatchLiteral, "ma%", "main", false},
+		{OptionValueFilterMatchLiteral, `a\b`, `A\B`, true},
+	}
+	for _, tc := range tests {
+		c := &connectionImpl{metadataFilterMatch: tc.match}
+		assert.Equal(t, tc.matches, c.nameMatcher(&tc.filter)(tc.name), "%s %q %q", tc.match, tc.filter, tc.name)
+	}
+	assert.Nil(t, (&connectionImpl{}).nameMatcher(nil))
+}
+
+func TestLikeCondition(t *testing.T) {
+	c := &connectionImpl{metadataFilterMatch: OptionValueFilterMatchPattern}
+	assert.Equal(t, `c.TABLE_NAME ILIKE 'it\'s%' ESCAPE '\\'`, c.likeCondition("c.TABLE_NAME", "it's%"))
+
+	c.metadataFilterMatch = OptionValueFilterMatchLiteral
+	assert.Equal(t, `c.TABLE_NAME ILIKE 'a\\_b\\%\\\\' ESCAPE '\\'`, c.likeCondition("c.TABLE_NAME", `a_b%\`))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// changesNamespace reports whether query may switch the session's current
+// catalog or schema: USE, and SET (SET CATALOG is an alias of USE CATALOG).
+// Other SET statements are caught too, which only costs a lookup.
+func changesNamespace(query string) bool {
+	switch leadingKeyword(query) {
+	case "USE", "SET":
+		return true
+	}
+	return false
+}
+
+// invalidateNamespace forgets the current catalog and schema after a
+// statement may have switched them, so that GetCurrentCatalog and
+// GetCurrentDbSchema ask the server again.
+func (c *connectionImpl) invalidateNamespace() {
+	c.catalog = ""
+	c.dbSchema = ""
+}
+
+// captureNamespaceLocked looks up the current catalog and schema of the
+// session if they are unknown, so that restoreNamespace can bring them
+// back when the session is replaced. Failures are only logged. connMu
+// must be held.
+func (c *connectionImpl) captureNamespaceLocked(ctx context.Context) {
+	if c.catalog != "" && c.dbSchema != "" {
+		return
+	}
+	var catalog, schema string
+	err := c.conn.QueryRowContext(ctx, "SELECT current_catalog(), current_schema()").Scan(&catalog, &schema)
+	if err != nil {
+		c.Logger.Warn("failed to look up current catalog and schema", "error", err)
+		return
+	}
+	c.catalog, c.dbSchema = catalog, schema
+}
+
+// NamespaceSetter is implemented by the connections of this driver.
+//
+// SetCurrentCatalogAndSchema switches the current catalog and schema with
+// a single USE statement, where SetCurrentCatalog followed by
+// SetCurrentDbSchema takes two round trips.
+type NamespaceSetter interface {
+	SetCurrentCatalogAndSchema(catalog, schema string) error
+}
+
+func (c *connectionImpl) SetCurrentCatalogAndSchema(catalog, schema string) (err error) {
+	defer func() { err = c.recordError("SetCurrentCatalogAndSchema", "", err) }()
+
+	if catalog == "" || schema == "" {
+		return adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  "catalog and schema cannot be empty",
+		}
+	}
+	return c.useSchema(catalog, schema)
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namespaceConnector opens sessions whose current catalog and schema are
+// main.sales, counting the queries run on them. DESCRIBE HISTORY reports
+// the versions of the tables in versions, fails with the errors in
+// failures, and reports other names as having no Delta history.
+type namespaceConnector struct {
+	countingConnector
+	queries  []string
+	versions map[string]int64
+	failures map[string]error
+}
+
+func (c *namespaceConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.countingConnector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &namespaceConn{countingConn: conn.(*countingConn), connector: c}, nil
+}
+
+type namespaceConn struct {
+	*countingConn
+	connector *namespaceConnector
+}
+
+func (c *namespaceConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.queries = append(c.connector.queries, query)
+	rows := &valueRows{columnTypes: []string{"STRING", "STRING"}}
+	switch query {
+	case "SELECT current_catalog()":
+		rows.columns = []string{"current_catalog()"}
+		rows.values = [][]driver.Value{{"main"}}
+	case "SELECT current_schema()":
+		rows.columns = []string{"current_schema()"}
+		rows.values = [][]driver.Value{{"sales"}}
+	case "SELECT current_catalog(), current_schema()":
+		rows.columns = []string{"current_catalog()", "current_schema()"}
+		rows.values = [][]driver.Value{{"main", "sales"}}
+	case "DESCRIBE TABLE EXTENDED `main`.`sales`.`orders`":
+		rows.columnTypes = []string{"STRING", "STRING", "STRING"}
+		rows.columns = []string{"col_name", "data_type", "comment"}
+		rows.values = [][]driver.Value{{"id", "bigint", nil}}
+	default:
+		name, ok := strings.CutPrefix(query, "DESCRIBE HISTORY ")
+		if !ok {
+			return nil, fmt.Errorf("unexpected query %q", query)
+		}
+		name = strings.TrimSuffix(name, " LIMIT 1")
+		if err, ok := c.connector.failures[name]; ok {
+			return nil, err
+		}
+		version, ok := c.connector.versions[name]
+		if !ok {
+			return nil, fmt.Errorf("[DELTA_TABLE_ONLY_OPERATION] %s is not a Delta table", name)
+		}
+		rows.columns = []string{"version", "timestamp"}
+		rows.values = [][]driver.Value{{version, "2026-01-01"}}
+	}
+	return rows, nil
+}
+
+func (c *namespaceConnector) queryCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queries)
+}
+
+func TestChangesNamespace(t *testing.T) {
+	for _, query := range []string{"USE main", "use catalog main", "  -- switch\nUSE SCHEMA main.sales", "SET CATALOG main"} {
+		assert.True(t, changesNamespace(query), query)
+	}
+	for _, query := range []string{"SELECT 1", "INSERT INTO used VALUES (1)", "-- USE main\nSELECT 1", ""} {
+		assert.False(t, changesNamespace(query), query)
+	}
+}
+
+func TestNamespaceCache(t *testing.T) {
+	connector := &namespaceConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db, namespaceCache: true}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+
+	for range 2 {
+		catalog, err := cnxn.GetCurrentCatalog()
+		require.NoError(t, err)
+		assert.Equal(t, "main", catalog)
+		schema, err := cnxn.GetCurrentDbSchema()
+		require.NoError(t, err)
+		assert.Equal(t, "sales", schema)
+	}
+	assert.Equal(t, 2, connector.queryCount())
+
+	// A USE statement makes the next call ask again
+	cnxn.invalidateNamespace()
+	_, err := cnxn.GetCurrentCatalog()
+	require.NoError(t, err)
+	assert.Equal(t, 3, connector.queryCount())
+
+	// Without the cache every call asks
+	cnxn.namespaceCache = false
+	_, err = cnxn.GetCurrentCatalog()
+	require.NoError(t, err)
+	_, err = cnxn.GetCurrentCatalog()
+	require.NoError(t, err)
+	assert.Equal(t, 5, connector.queryCount())
+}
+
+func TestCaptureNamespace(t *testing.T) {
+	connector := &namespaceConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+
+	// A session closed while idle after a USE statement hands its
+	// namespace on to the next one
+	cnxn.connMu.Lock()
+	cnxn.captureNamespaceLocked(context.Background())
+	cnxn.connMu.Unlock()
+	assert.Equal(t, "main", cnxn.catalog)
+	assert.Equal(t, "sales", cnxn.dbSchema)
+	assert.Equal(t, []string{"SELECT current_catalog(), current_schema()"}, connector.queries)
+
+	// Nothing is looked up when the namespace is known
+	cnxn.connMu.Lock()
+	cnxn.captureNamespaceLocked(context.Background())
+	cnxn.connMu.Unlock()
+	assert.Equal(t, 1, connector.queryCount())
+}
+
+func TestSchemaCacheUnversionedTable(t *testing.T) {
+	connector := &namespaceConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	clock := newFakeClock()
+	cnxn := &connectionImpl{db: db, schemaCache: newSchemaCache(), clock: driverClock{clock}}
+	cnxn.schemaCacheTTL.Store(int64(time.Minute))
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+
+	// A view has no history, which is only looked up again once the TTL
+	// has passed
+	catalog, schema := "main", "sales"
+	getSchema := func() {
+		got, err := cnxn.GetTableSchema(context.Background(), &catalog, &schema, "orders")
+		require.NoError(t, err)
+		assert.Equal(t, "id", got.Field(0).Name)
+	}
+	getSchema()
+	getSchema()
+	clock.Advance(time.Minute)
+	getSchema()
+	assert.Equal(t, []string{
+		"DESCRIBE HISTORY `main`.`sales`.`orders` LIMIT 1",
+		"DESCRIBE TABLE EXTENDED `main`.`sales`.`orders`",
+		"DESCRIBE TABLE EXTENDED `main`.`sales`.`orders`",
+		"DESCRIBE HISTORY `main`.`sales`.`orders` LIMIT 1",
+		"DESCRIBE TABLE EXTENDED `main`.`sales`.`orders`",
+	}, connector.queries)
+
+	// A table whose history can't be read is not marked unversioned: the
+	// call fails, and the next one caches the schema under its version
+	clock.Advance(time.Minute)
+	errTimeout := errors.New("i/o timeout")
+	connector.failures = map[string]error{"`main`.`sales`.`orders`": errTimeout}
+	_, err := cnxn.GetTableSchema(context.Background(), &catalog, &schema, "orders")
+	require.ErrorIs(t, err, errTimeout)
+	connector.failures = nil
+	connector.versions = map[string]int64{"`main`.`sales`.`orders`": 4}
+	getSchema()
+	getSchema()
+	entry, ok := cnxn.schemaCache.get("`main`.`sales`.`orders`")
+	require.True(t, ok)
+	assert.False(t, entry.unversioned)
+	assert.EqualValues(t, 4, entry.version)
+	assert.Equal(t, 8, connector.queryCount())
+}
+
+func TestSetCurrentCatalogAndSchema(t *testing.T) {
+	connector := &namespaceConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db, namespaceCache: true}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+
+	require.NoError(t, cnxn.SetCurrentCatalogAndSchema("dev", "my.schema"))
+	// A dotted schema name is never split
+	require.NoError(t, cnxn.SetCurrentDbSchema("other.schema"))
+	connector.mu.Lock()
+	assert.Equal(t, []string{"USE SCHEMA `dev`.`my.schema`", "USE SCHEMA `other.schema`"}, connector.execs)
+	connector.mu.Unlock()
+
+	catalog, err := cnxn.GetCurrentCatalog()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", catalog)
+	schema, err := cnxn.GetCurrentDbSchema()
+	require.NoError(t, err)
+	assert.Equal(t, "other.schema", schema)
+	assert.Equal(t, 0, connector.queryCount())
+
+	assert.Error(t, cnxn.SetCurrentCatalogAndSchema("", "sales"))
+
+	// USE CATALOG resets the schema, which is looked up again
+	require.NoError(t, cnxn.SetCurrentCatalog("main"))
+	schema, err = cnxn.GetCurrentDbSchema()
+	require.NoError(t, err)
+	assert.Equal(t, "sales", schema)
+	assert.Equal(t, []string{"SELECT current_schema()"}, connector.queries)
+	catalog, err = cnxn.GetCurrentCatalog()
+	require.NoError(t, err)
+	assert.Equal(t, "main", catalog)
+	assert.Equal(t, 1, connector.queryCount())
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// invalidOption builds the error returned for a malformed option value. The
+// message always names the option and the expected form so that users can
+// fix their configuration without reading the source.
+func invalidOption(key, value, expected string) error {
+	return adbc.Error{
+		Code: adbc.StatusInvalidArgument,
+		Msg:  fmt.Sprintf("invalid value '%s' for option %s: expected %s", value, key, expected),
+	}
+}
+
+// parseBoolOption parses a boolean option. Besides the ADBC "true"/"false"
+// values it accepts the usual spellings (1/0, yes/no, on/off).
+func parseBoolOption(key, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case adbc.OptionValueEnabled, "1", "yes", "on":
+		return true, nil
+	case adbc.OptionValueDisabled, "0", "no", "off":
+		return false, nil
+	default:
+		return false, invalidOption(key, value, "a boolean ('true' or 'false')")
+	}
+}
+
+// parseIntOption parses an integer option and checks that it lies within
+// [minValue, maxValue].
+func parseIntOption(key, value string, minValue, maxValue int) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < minValue || n > maxValue {
+		return 0, invalidOption(key, value, describeIntRange(minValue, maxValue))
+	}
+	return n, nil
+}
+
+func describeIntRange(minValue, maxValue int) string {
+	// math.MaxInt32 only keeps options passed on as 32-bit values in range
+	unbounded := maxValue == math.MaxInt || maxValue == math.MaxInt32
+	switch {
+	case unbounded && minValue == 0:
+		return "a non-negative integer"
+	case unbounded && minValue == 1:
+		return "a positive integer"
+	case unbounded:
+		return fmt.Sprintf("an integer greater than or equal to %d", minValue)
+	default:
+		return fmt.Sprintf("an integer between %d and %d", minValue, maxValue)
+	}
+}
+
+// parseDurationOption parses a duration option. Values may be Go duration
+// strings ("30s", "1m30s") or a bare number of seconds. Negative durations
+// are rejected.
+func parseDurationOption(key, value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 || seconds > math.MaxInt64/float64(time.Second) {
+			return 0, invalidOption(key, value, "a non-negative duration")
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, invalidOption(key, value, "a non-negative duration such as '30s' or '5m'")
+	}
+	return d, nil
+}
+
+// parseEnumOption checks that value is one of allowed (case-insensitively)
+// and returns it lower-cased.
+func parseEnumOption(key, value string, allowed ...string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	for _, v := range allowed {
+		if lower == v {
+			return lower, nil
+		}
+	}
+	quoted := make([]string, len(allowed))
+	for i, v := range allowed {
+		quoted[i] = "'" + v + "'"
+	}
+	return "", invalidOption(key, value, "one of "+strings.Join(quoted, ", "))
+}
+
+// formatBoolOption renders a boolean the way GetOption reports it.
+func formatBoolOption(v bool) string {
+	if v {
+		return adbc.OptionValueEnabled
+	}
+	return adbc.OptionValueDisabled
+}
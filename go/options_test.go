@@ -0,0 +1,120 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBoolOption(t *testing.T) {
+	for _, v := range []string{"true", "TRUE", "1", "yes", "on", " true "} {
+		b, err := parseBoolOption("k", v)
+		require.NoError(t, err, v)
+		assert.True(t, b, v)
+	}
+	for _, v := range []string{"false", "False", "0", "no", "off"} {
+		b, err := parseBoolOption("k", v)
+		require.NoError(t, err, v)
+		assert.False(t, b, v)
+	}
+
+	_, err := parseBoolOption("databricks.some_flag", "maybe")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "databricks.some_flag")
+	assert.Contains(t, adbcErr.Msg, "'maybe'")
+}
+
+func TestParseIntOption(t *testing.T) {
+	n, err := parseIntOption("k", "443", 1, 65535)
+	require.NoError(t, err)
+	assert.Equal(t, 443, n)
+
+	_, err = parseIntOption("databricks.port", "0", 1, 65535)
+	assert.ErrorContains(t, err, "between 1 and 65535")
+
+	_, err = parseIntOption("k", "-1", 0, math.MaxInt)
+	assert.ErrorContains(t, err, "non-negative integer")
+
+	_, err = parseIntOption("k", "abc", 1, math.MaxInt)
+	assert.ErrorContains(t, err, "positive integer")
+	_, err = parseIntOption("k", "0", 1, math.MaxInt32)
+	assert.ErrorContains(t, err, "positive integer")
+}
+
+func TestParseDurationOption(t *testing.T) {
+	d, err := parseDurationOption("k", "1m30s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	d, err = parseDurationOption("k", "45")
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, d)
+
+	d, err = parseDurationOption("k", "0.5")
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, d)
+
+	_, err = parseDurationOption("k", "-5s")
+	assert.Error(t, err)
+	_, err = parseDurationOption("k", "soon")
+	assert.Error(t, err)
+}
+
+func TestParseEnumOption(t *testing.T) {
+	v, err := parseEnumOption("k", "Insecure", OptionValueSSLModeRequire, OptionValueSSLModeInsecure)
+	require.NoError(t, err)
+	assert.Equal(t, OptionValueSSLModeInsecure, v)
+
+	_, err = parseEnumOption("databricks.ssl_mode", "verify-full", OptionValueSSLModeRequire, OptionValueSSLModeInsecure)
+	assert.ErrorContains(t, err, "one of 'require', 'insecure'")
+}
+
+func TestMaxRowsPerRequestRange(t *testing.T) {
+	d := &databaseImpl{}
+	assert.Error(t, d.SetOption(OptionFetchMaxRowsPerRequest, "0"))
+	assert.Error(t, d.SetOption(OptionFetchMaxRowsPerRequest, "5000000"))
+
+	// The deprecated key accepts what it always has
+	require.NoError(t, d.SetOption(OptionMaxRows, "0"))
+	require.NoError(t, d.SetOption(OptionMaxRows, "5000000"))
+	assert.Equal(t, 5_000_000, d.maxRows)
+	assert.Error(t, d.SetOption(OptionMaxRows, "many"))
+}
+
+func TestCloudFetchMaxParallel(t *testing.T) {
+	d := &databaseImpl{}
+	value, err := d.GetOption(OptionCloudFetchMaxParallel)
+	require.NoError(t, err)
+	assert.Equal(t, "10", value)
+
+	// Both keys set databricks-sql-go's download thread count
+	require.NoError(t, d.SetOption(OptionCloudFetchMaxParallel, "3"))
+	value, err = d.GetOption(OptionDownloadThreadCount)
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+	require.NoError(t, d.SetOption(OptionDownloadThreadCount, "6"))
+	value, err = d.GetOption(OptionCloudFetchMaxParallel)
+	require.NoError(t, err)
+	assert.Equal(t, "6", value)
+	assert.Error(t, d.SetOption(OptionCloudFetchMaxParallel, "0"))
+}
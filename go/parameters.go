@@ -0,0 +1,165 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// queryParameter is a parameter marker found in a query: named (:name) or
+// positional (?, with an empty name). Its type is the one it is cast to
+// in the query, or Null if the query doesn't say.
+type queryParameter struct {
+	name string
+	typ  arrow.DataType
+}
+
+// queryParameters finds the parameter markers of query (see scanMarkers).
+// A named parameter used more than once is reported once.
+func queryParameters(query string) []queryParameter {
+	var params []queryParameter
+	seen := map[string]bool{}
+	scanMarkers(query, func(start, end int, name string) {
+		if name == "" {
+			params = append(params, queryParameter{typ: parameterType(query, start, end)})
+		} else if !seen[name] {
+			seen[name] = true
+			params = append(params, queryParameter{name: name, typ: parameterType(query, start, end)})
+		}
+	})
+	return params
+}
+
+// scanMarkers calls marker with the position of each parameter marker of
+// query, in order, and its name (empty for ?), skipping string literals,
+// quoted identifiers and comments. A colon that follows an identifier is a
+// JSON path (col:field) rather than a marker.
+func scanMarkers(query string, marker func(start, end int, name string)) {
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(query, i)
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return
+			}
+			i += end + 4
+		case c == '?':
+			marker(i, i+1, "")
+			i++
+		case c == ':' && i+1 < len(query) && isIdentStart(rune(query[i+1])) &&
+			(i == 0 || (!isIdentPart(rune(query[i-1])) && query[i-1] != ':')):
+			end := i + 1
+			for end < len(query) && isIdentPart(rune(query[end])) {
+				end++
+			}
+			marker(i, end, query[i+1:end])
+			i = end
+		default:
+			i++
+		}
+	}
+}
+
+// skipQuoted returns the position after the literal or quoted identifier
+// starting at query[start]. Backslashes escape characters in string
+// literals; a doubled quote stands for itself.
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+// parameterType returns the type the marker at query[start:end] is cast
+// to, with CAST(marker AS type) or marker::type, or Null.
+func parameterType(query string, start, end int) arrow.DataType {
+	var typeText string
+	rest := strings.TrimLeftFunc(query[end:], unicode.IsSpace)
+	if strings.HasPrefix(rest, "::") {
+		typeText = readTypeText(strings.TrimLeftFunc(rest[2:], unicode.IsSpace))
+	} else if before := strings.TrimRightFunc(query[:start], unicode.IsSpace); strings.HasSuffix(before, "(") {
+		before = strings.TrimRightFunc(before[:len(before)-1], unicode.IsSpace)
+		keyword := before[strings.LastIndexFunc(before, func(r rune) bool { return !isIdentPart(r) })+1:]
+		if strings.EqualFold(keyword, "CAST") && len(rest) > 2 && strings.EqualFold(rest[:2], "AS") && !isIdentPart(rune(rest[2])) {
+			typeText = readTypeText(strings.TrimLeftFunc(rest[2:], unicode.IsSpace))
+		}
+	}
+	if typeText == "" {
+		return arrow.Null
+	}
+	dt, err := parseDatabricksType(typeText)
+	if err != nil {
+		return arrow.Null
+	}
+	return dt
+}
+
+// readTypeText returns the type name at the start of s, with its
+// arguments in parentheses or angle brackets.
+func readTypeText(s string) string {
+	depth := 0
+	for i, r := range s {
+		switch {
+		case r == '(' || r == '<':
+			depth++
+		case r == ')' || r == '>':
+			if depth == 0 {
+				return strings.TrimSpace(s[:i])
+			}
+			depth--
+		case depth == 0 && !isIdentPart(r) && !unicode.IsSpace(r):
+			return strings.TrimSpace(s[:i])
+		case depth == 0 && unicode.IsSpace(r):
+			// Types such as TIMESTAMP_NTZ are one word; a space at the top
+			// level ends the type unless arguments follow
+			next := strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+			if !strings.HasPrefix(next, "(") && !strings.HasPrefix(next, "<") {
+				return strings.TrimSpace(s[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParameters(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []queryParameter
+	}{
+		{"SELECT 1", nil},
+		{"SELECT ?, ?", []queryParameter{{typ: arrow.Null}, {typ: arrow.Null}}},
+		{"SELECT * FROM t WHERE id = :id AND name = :name OR id > :id", []queryParameter{
+			{name: "id", typ: arrow.Null},
+			{name: "name", typ: arrow.Null},
+		}},
+		{"SELECT CAST(? AS BIGINT), cast(:d as decimal(10, 2))", []queryParameter{
+			{typ: arrow.PrimitiveTypes.Int64},
+			{name: "d", typ: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		}},
+		{"SELECT ?::date + 1, :ts :: TIMESTAMP_NTZ", []queryParameter{
+			{typ: arrow.FixedWidthTypes.Date32},
+			{name: "ts", typ: &arrow.TimestampType{Unit: arrow.Microsecond}},
+		}},
+		// Unknown types are reported as Null
+		{"SELECT ?::nonsense", []queryParameter{{typ: arrow.Null}}},
+		// Markers in literals, identifiers and comments are not parameters
+		{"SELECT '?', \"it's :x\", `:y`, 'a\\'?' -- ?\n/* :z */ FROM t WHERE a = ?", []queryParameter{{typ: arrow.Null}}},
+		// Casts and JSON paths are not named parameters
+		{"SELECT col::int, raw:field.nested FROM t", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.expected, queryParameters(tc.query))
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// partitionVersion is the version of the partition descriptor format.
+const partitionVersion = 1
+
+// partitionDescriptor identifies one chunk of a statement's result. It
+// holds no download links, which expire, so the same descriptor can be
+// read (and retried) for as long as the server keeps the result.
+type partitionDescriptor struct {
+	Version     int    `json:"v"`
+	StatementID string `json:"statement_id"`
+	ChunkIndex  int64  `json:"chunk_index"`
+}
+
+func encodePartition(statementID string, chunkIndex int64) ([]byte, error) {
+	return json.Marshal(partitionDescriptor{
+		Version:     partitionVersion,
+		StatementID: statementID,
+		ChunkIndex:  chunkIndex,
+	})
+}
+
+func decodePartition(serialized []byte) (partitionDescriptor, error) {
+	var desc partitionDescriptor
+	if err := json.Unmarshal(serialized, &desc); err != nil {
+		return desc, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("invalid partition: %v", err),
+		}
+	}
+	if desc.Version != partitionVersion || desc.StatementID == "" || desc.ChunkIndex < 0 {
+		return desc, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("invalid partition: unsupported version %d or missing statement", desc.Version),
+		}
+	}
+	return desc, nil
+}
+
+// ExecutePartitions runs the query through the Statement Execution API and
+// returns one partition per result chunk. Partitions are identified by
+// statement ID and chunk index, so they are the same on every call and can
+// be read with ReadPartition on any connection to the workspace.
+func (s *statementImpl) ExecutePartitions(ctx context.Context) (schema *arrow.Schema, partitions adbc.Partitions, rowsAffected int64, err error) {
+	ctx, finish := s.startExecution(ctx)
+	queryID := &queryIDTracker{}
+	s.queryID = queryID
+	ctx = queryID.attach(ctx)
+	defer func() { err = s.recordExecution(ctx, "ExecutePartitions", queryID.get(), finish(queryID.get(), err)) }()
+
+	if s.boundStream != nil {
+		return nil, adbc.Partitions{}, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "bound parameters can't be used with partitioned result sets")
+	}
+	if s.query == "" {
+		return nil, adbc.Partitions{}, -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
+	}
+	api := s.conn.statementAPI
+	if api == nil {
+		return nil, adbc.Partitions{}, -1, s.conn.unsupported(FeaturePartitionedResults)
+	}
+
+	query := s.query
+	if s.conn.snapshot != nil {
+		if query, err = s.conn.pinSnapshot(ctx, query); err != nil {
+			return nil, adbc.Partitions{}, -1, err
+		}
+	}
+	if query, err = s.windowQuery(ctx, query); err != nil {
+		return nil, adbc.Partitions{}, -1, err
+	}
+	query = s.sampleQuery(query)
+	s.logExecution(ctx, "executing partitioned query")
+	resp, err := api.execute(ctx, annotateQuery(ctx, query), s.conn.catalog, s.conn.dbSchema)
+	if err != nil {
+		return nil, adbc.Partitions{}, -1, err
+	}
+	reportQueryID(ctx, resp.StatementID)
+	if err := s.checkResultSize(resp); err != nil {
+		return nil, adbc.Partitions{}, -1, err
+	}
+	manifest := resp.Manifest
+
+	if manifest.TotalChunkCount > 0 {
+		// The Arrow schema comes from the data itself; reading the first
+		// chunk's stream header is enough
+		rdr, err := s.conn.readChunk(withFetchLane(ctx, OptionValueFetchLaneBulk), s.alloc, resp.StatementID, 0)
+		if err != nil {
+			return nil, adbc.Partitions{}, -1, err
+		}
+		schema = rdr.Schema()
+		rdr.Release()
+	} else if schema, err = manifestSchema(manifest); err != nil {
+		return nil, adbc.Partitions{}, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "%v", err)
+	}
+
+	ids := make([][]byte, manifest.TotalChunkCount)
+	for i := range ids {
+		if ids[i], err = encodePartition(resp.StatementID, int64(i)); err != nil {
+			return nil, adbc.Partitions{}, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to encode partition: %v", err)
+		}
+	}
+	return schema, adbc.Partitions{NumPartitions: uint64(len(ids)), PartitionIDs: ids}, manifest.TotalRowCount, nil
+}
+
+// manifestSchema converts the column types in a result manifest to an
+// Arrow schema, for results whose data can't be read.
+func manifestSchema(manifest *resultManifest) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(manifest.Schema.Columns))
+	for i, col := range manifest.Schema.Columns {
+		dt, err := parseDatabricksType(col.TypeText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert type of column %s: %v", col.Name, err)
+		}
+		fields[i] = arrow.Field{Name: col.Name, Type: dt, Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// ReadPartition reads a partition returned by ExecutePartitions. Download
+// links are requested afresh, so partitions can be read long after the
+// query ran, until the server discards the result.
+func (c *connectionImpl) ReadPartition(ctx context.Context, serializedPartition []byte) (rdr array.RecordReader, err error) {
+	defer func() { err = c.recordError("ReadPartition", "", err) }()
+
+	desc, err := decodePartition(serializedPartition)
+	if err != nil {
+		return nil, err
+	}
+	if c.statementAPI == nil {
+		return nil, c.unsupported(FeaturePartitionedResults)
+	}
+	// Partitions are pieces of a distributed extract
+	return c.readChunk(withFetchLane(ctx, OptionValueFetchLaneBulk), c.Alloc, desc.StatementID, desc.ChunkIndex)
+}
+
+// readChunk returns a reader over one chunk of a statement's result, with
+// record batches allocated from mem.
+func (c *connectionImpl) readChunk(ctx context.Context, mem memory.Allocator, statementID string, chunkIndex int64) (*chunkReader, error) {
+	links, err := c.statementAPI.chunkLinks(ctx, statementID, chunkIndex)
+	if err != nil {
+		return nil, err
+	}
+	rdr := &chunkReader{
+		refCount:    1,
+		ctx:         ctx,
+		api:         c.statementAPI,
+		mem:         mem,
+		statementID: statementID,
+		chunkIndex:  chunkIndex,
+		links:       links,
+	}
+	if err := rdr.openNext(); err != nil {
+		rdr.Release()
+		return nil, err
+	}
+	rdr.schema = rdr.reader.Schema()
+	return rdr, nil
+}
+
+// chunkReader reads the Arrow streams behind a chunk's download links in
+// order. If a link has expired by the time it is downloaded, the chunk's
+// links are resolved again once.
+type chunkReader struct {
+	refCount    int64
+	ctx         context.Context
+	api         *statementAPI
+	mem         memory.Allocator
+	statementID string
+	chunkIndex  int64
+	links       []externalLink
+	next        int
+	refreshed   bool
+
+	body   io.ReadCloser
+	reader *ipc.Reader
+	schema *arrow.Schema
+	err    error
+	// Time spent downloading the chunk so far, and decoding the current
+	// batch
+	downloadTime time.Duration
+	decodeTime   time.Duration
+	// Bytes downloaded for the chunk so far, and the hash of the link
+	// being read
+	bytesRead int64
+	urlHash   string
+}
+
+// openNext opens the stream behind the next link.
+func (r *chunkReader) openNext() error {
+	r.closeCurrent()
+	start := time.Now()
+	defer func() { r.downloadTime += time.Since(start) }()
+
+	body, err := r.download(r.links[r.next])
+	var expired errLinkExpired
+	if errors.As(err, &expired) && !r.refreshed {
+		r.refreshed = true
+		if r.links, err = r.api.chunkLinks(r.ctx, r.statementID, r.chunkIndex); err != nil {
+			return err
+		}
+		if r.next >= len(r.links) {
+			return adbc.Error{
+				Code: adbc.StatusInvalidState,
+				Msg:  fmt.Sprintf("chunk %d of statement %s changed while being read", r.chunkIndex, r.statementID),
+			}
+		}
+		body, err = r.download(r.links[r.next])
+	}
+	if errors.As(err, &expired) {
+		return &classifiedError{
+			status: adbc.Error{
+				Code: adbc.StatusIO,
+				Msg:  fmt.Sprintf("failed to download chunk %d of statement %s: %v", r.chunkIndex, r.statementID, err),
+			},
+			kind:  dbxerrors.ErrLinkExpired,
+			cause: err,
+		}
+	} else if err != nil {
+		return err
+	}
+	r.urlHash = linkHash(r.links[r.next].ExternalLink)
+	r.next++
+
+	counted := &countingReader{r: body, n: &r.bytesRead}
+	reader, err := ipc.NewReader(counted, ipc.WithAllocator(r.mem), ipc.WithEnsureNativeEndian(true))
+	if err != nil {
+		_ = body.Close()
+		return adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to read chunk %d of statement %s: %v", r.chunkIndex, r.statementID, err),
+		}
+	}
+	r.body = body
+	r.reader = reader
+	return nil
+}
+
+// errLinkExpired is returned by download when the storage service rejects
+// a link, which usually means it has expired.
+type errLinkExpired struct {
+	status string
+}
+
+func (e errLinkExpired) Error() string {
+	return "download link rejected: " + e.status
+}
+
+func (r *chunkReader) download(link externalLink) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, link.ExternalLink, nil)
+	if err != nil {
+		return nil, adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create download request: %v", err)}
+	}
+	// Links are presigned; the workspace credentials must not be sent
+	for key, value := range link.HTTPHeaders {
+		req.Header.Set(key, value)
+	}
+	resp, err := r.api.storage.Do(req)
+	if err != nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusIO,
+			Msg:  fmt.Sprintf("failed to download chunk %d of statement %s: %v", r.chunkIndex, r.statementID, err),
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		_ = resp.Body.Close()
+		return nil, errLinkExpired{status: resp.Status}
+	default:
+		_ = resp.Body.Close()
+		return nil, adbc.Error{
+			Code: adbc.StatusIO,
+			Msg:  fmt.Sprintf("failed to download chunk %d of statement %s: %s", r.chunkIndex, r.statementID, resp.Status),
+		}
+	}
+}
+
+func (r *chunkReader) closeCurrent() {
+	if r.reader != nil {
+		r.reader.Release()
+		r.reader = nil
+	}
+	if r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+}
+
+func (r *chunkReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *chunkReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.closeCurrent()
+	}
+}
+
+func (r *chunkReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *chunkReader) Next() bool {
+	for r.err == nil && r.reader != nil {
+		start := time.Now()
+		if r.reader.Next() {
+			r.decodeTime = time.Since(start)
+			return true
+		}
+		if err := r.reader.Err(); err != nil && err != io.EOF {
+			r.err = err
+			return false
+		}
+		if r.next >= len(r.links) {
+			return false
+		}
+		if err := r.openNext(); err != nil {
+			r.err = err
+		}
+	}
+	return false
+}
+
+func (r *chunkReader) batchStats() batchStats {
+	stats := batchStats{
+		chunkIndex: r.chunkIndex,
+		download:   r.downloadTime,
+		decode:     r.decodeTime,
+		bytes:      r.bytesRead,
+		urlHash:    r.urlHash,
+	}
+	if r.refreshed {
+		stats.retries = 1
+	}
+	return stats
+}
+
+func (r *chunkReader) Record() arrow.RecordBatch {
+	return r.RecordBatch()
+}
+
+func (r *chunkReader) RecordBatch() arrow.RecordBatch {
+	if r.reader == nil {
+		return nil
+	}
+	return r.reader.RecordBatch()
+}
+
+func (r *chunkReader) Err() error {
+	return r.err
+}
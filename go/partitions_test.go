@@ -0,0 +1,171 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	dbxerrors "github.com/adbc-drivers/databricks/go/errors"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionDescriptor(t *testing.T) {
+	first, err := encodePartition("01ef-abcd", 3)
+	require.NoError(t, err)
+	second, err := encodePartition("01ef-abcd", 3)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	desc, err := decodePartition(first)
+	require.NoError(t, err)
+	assert.Equal(t, "01ef-abcd", desc.StatementID)
+	assert.Equal(t, int64(3), desc.ChunkIndex)
+
+	for _, invalid := range []string{"", "not json", `{"v":2,"statement_id":"x","chunk_index":0}`, `{"v":1,"chunk_index":0}`} {
+		_, err := decodePartition([]byte(invalid))
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, invalid)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+	}
+}
+
+func TestWarehouseIDFromHTTPPath(t *testing.T) {
+	assert.Equal(t, "abc123", warehouseIDFromHTTPPath("/sql/1.0/warehouses/abc123"))
+	assert.Equal(t, "abc123", warehouseIDFromHTTPPath("sql/1.0/endpoints/abc123/"))
+	assert.Equal(t, "", warehouseIDFromHTTPPath("/sql/protocolv1/o/123/0123-456789-abcdef"))
+}
+
+// fakeStatementAPI serves one finished statement with a single chunk. The
+// first expireFirst downloads are rejected as if the link had expired.
+type fakeStatementAPI struct {
+	server      *httptest.Server
+	chunk       []byte
+	expireFirst int64
+	downloads   atomic.Int64
+	resolutions atomic.Int64
+}
+
+func newFakeStatementAPI(t *testing.T, chunk []byte, expireFirst int64) *fakeStatementAPI {
+	f := &fakeStatementAPI{chunk: chunk, expireFirst: expireFirst}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/2.0/sql/statements", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "RUNNING"}}`)
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"statement_id": "stmt-1", "status": {"state": "SUCCEEDED"},
+			"manifest": {"total_chunk_count": 1, "total_row_count": 2,
+				"schema": {"columns": [{"name": "id", "type_text": "BIGINT"}]}}}`)
+	})
+	mux.HandleFunc("GET /api/2.0/sql/statements/stmt-1/result/chunks/0", func(w http.ResponseWriter, r *http.Request) {
+		n := f.resolutions.Add(1)
+		_ = json.NewEncoder(w).Encode(resultData{ExternalLinks: []externalLink{{
+			ChunkIndex:   0,
+			ExternalLink: fmt.Sprintf("%s/storage/chunk-0?sig=%d", f.server.URL, n),
+			HTTPHeaders:  map[string]string{"x-test": "1"},
+		}}})
+	})
+	mux.HandleFunc("GET /storage/chunk-0", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		assert.Equal(t, "1", r.Header.Get("x-test"))
+		if f.downloads.Add(1) <= f.expireFirst {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(f.chunk)
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeStatementAPI) client() *statementAPI {
+	return &statementAPI{
+		client:      f.server.Client(),
+		storage:     &http.Client{Transport: newStoragePool(nil)},
+		baseURL:     f.server.URL,
+		auth:        &pat.PATAuth{AccessToken: "token"},
+		warehouseID: "wh",
+	}
+}
+
+func TestStatementAPIExecute(t *testing.T) {
+	f := newFakeStatementAPI(t, nil, 0)
+	resp, err := f.client().execute(context.Background(), "SELECT 1", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "stmt-1", resp.StatementID)
+	assert.Equal(t, int64(1), resp.Manifest.TotalChunkCount)
+	assert.Equal(t, int64(2), resp.Manifest.TotalRowCount)
+}
+
+func TestReadPartition(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	batch := makeConcatTestBatch(t, mem, []int64{1, 2}, []string{"a", "b"})
+	chunk := writeIPCStream(t, concatTestSchema, batch)
+	batch.Release()
+	partition, err := encodePartition("stmt-1", 0)
+	require.NoError(t, err)
+
+	for _, expireFirst := range []int64{0, 1} {
+		t.Run(fmt.Sprintf("expired links %d", expireFirst), func(t *testing.T) {
+			f := newFakeStatementAPI(t, chunk, expireFirst)
+			cnxn := &connectionImpl{statementAPI: f.client()}
+			cnxn.Alloc = mem
+
+			rdr, err := cnxn.ReadPartition(context.Background(), partition)
+			require.NoError(t, err)
+			defer rdr.Release()
+
+			assert.True(t, concatTestSchema.Equal(rdr.Schema()))
+			require.True(t, rdr.Next())
+			assert.Equal(t, []int64{1, 2}, rdr.RecordBatch().Column(0).(*array.Int64).Int64Values())
+			assert.False(t, rdr.Next())
+			require.NoError(t, rdr.Err())
+			assert.Equal(t, 1+expireFirst, f.resolutions.Load())
+		})
+	}
+
+	t.Run("links keep expiring", func(t *testing.T) {
+		f := newFakeStatementAPI(t, chunk, 2)
+		cnxn := &connectionImpl{statementAPI: f.client()}
+		cnxn.Alloc = mem
+
+		_, err := cnxn.ReadPartition(context.Background(), partition)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr)
+		assert.Equal(t, adbc.StatusIO, adbcErr.Code)
+		assert.ErrorIs(t, err, dbxerrors.ErrLinkExpired)
+	})
+
+	t.Run("no warehouse", func(t *testing.T) {
+		_, err := (&connectionImpl{}).ReadPartition(context.Background(), partition)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr)
+		assert.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+	})
+}
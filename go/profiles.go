@@ -0,0 +1,71 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+// profiles holds the options set by each OptionProfile value.
+var profiles = map[string]map[string]string{
+	OptionValueProfileETL: {
+		OptionFetchMaxRowsPerRequest: "1000000",
+		OptionQueryTimeout:           "0",
+		OptionQueryRetryCount:        "8",
+		OptionDownloadThreadCount:    "16",
+	},
+	OptionValueProfileInteractive: {
+		OptionFetchMaxRowsPerRequest: "10000",
+		OptionQueryTimeout:           "5m",
+		OptionQueryRetryCount:        "2",
+		OptionDownloadThreadCount:    "4",
+	},
+	OptionValueProfileBI: {
+		OptionFetchMaxRowsPerRequest: "200000",
+		OptionQueryTimeout:           "30m",
+		OptionQueryRetryCount:        "4",
+		OptionDownloadThreadCount:    "10",
+	},
+}
+
+// applyProfile sets the options of the named profile, except those set
+// explicitly.
+func (d *databaseImpl) applyProfile(value string) error {
+	name, err := parseEnumOption(OptionProfile, value,
+		OptionValueProfileETL, OptionValueProfileInteractive, OptionValueProfileBI)
+	if err != nil {
+		return err
+	}
+	for key, val := range profiles[name] {
+		if _, ok := d.explicitOptions[key]; ok {
+			continue
+		}
+		if err := d.setOption(key, val); err != nil {
+			return err
+		}
+		d.applyLiveOption(key)
+	}
+	d.profile = name
+	return nil
+}
+
+// markExplicit records that key was set by the user, so that profiles
+// leave it alone.
+func (d *databaseImpl) markExplicit(key string) {
+	if d.explicitOptions == nil {
+		d.explicitOptions = map[string]struct{}{}
+	}
+	d.explicitOptions[key] = struct{}{}
+	// The deprecated name sets the same value
+	if key == OptionMaxRows {
+		d.explicitOptions[OptionFetchMaxRowsPerRequest] = struct{}{}
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// rowsBatchSize is how many rows the row-based path puts in each record
+// batch.
+const rowsBatchSize = 4096
+
+// parseProtocols parses a comma-separated list of result paths for
+// OptionFetchProtocols.
+func parseProtocols(key, val string) ([]string, error) {
+	var protocols []string
+	for _, part := range strings.Split(val, ",") {
+		protocol, err := parseEnumOption(key, part, OptionValueProtocolArrow, OptionValueProtocolREST, OptionValueProtocolRows)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(protocols, protocol) {
+			return nil, invalidOption(key, val, "a list without repeated protocols")
+		}
+		protocols = append(protocols, protocol)
+	}
+	return protocols, nil
+}
+
+// executeProtocols runs b and reads its result through the first
+// available path in the statement's protocols, returning the path used.
+// The Arrow and row-based paths read the same Thrift result, so the query
+// runs only once however many paths are tried. b's IN lists must already
+// be staged.
+func (s *statementImpl) executeProtocols(ctx context.Context, conn *sql.Conn, b boundQuery) (reader array.RecordReader, protocol string, err error) {
+	var rows driver.Rows
+	defer func() {
+		if rows != nil {
+			if closeErr := rows.Close(); closeErr != nil {
+				err = errors.Join(err, closeErr)
+			}
+		}
+	}()
+
+	var unavailable []string
+	for _, protocol = range s.protocols {
+		switch protocol {
+		case OptionValueProtocolREST:
+			if s.conn.statementAPI == nil {
+				unavailable = append(unavailable, protocol+": "+needsWarehouse)
+				continue
+			}
+			if len(b.args) > 0 {
+				unavailable = append(unavailable, protocol+": bound parameters are only sent over Thrift")
+				continue
+			}
+			if len(b.inLists) > 0 {
+				unavailable = append(unavailable, protocol+": staged IN lists are only readable in the session")
+				continue
+			}
+			if rows != nil {
+				unavailable = append(unavailable, protocol+": the query has already run over Thrift")
+				continue
+			}
+			reader, err = s.executeREST(ctx, b.query)
+		case OptionValueProtocolArrow, OptionValueProtocolRows:
+			if rows == nil {
+				if rows, err = s.queryRows(ctx, conn, b.query, b.args); err != nil {
+					return nil, "", err
+				}
+			}
+			if protocol == OptionValueProtocolRows {
+				reader = newRowsReader(s.alloc, rows)
+				rows = nil
+				break
+			}
+			reader, err = newIPCReaderAdapter(ctx, s.alloc, rows)
+			var arrowErr errArrowUnavailable
+			if errors.As(err, &arrowErr) {
+				unavailable = append(unavailable, protocol+": "+arrowErr.Error())
+				continue
+			}
+			if err != nil {
+				return nil, "", s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create IPC reader adapter: %v", err)
+			}
+			rows = nil // Now owned by the reader
+			reader.(*ipcReaderAdapter).rejectOverBytes = s.rejectOverBytes
+			if s.prefetchMaxBytes > 0 {
+				reader.(*ipcReaderAdapter).prefetchBatches(s.conn.goroutines, s.prefetchMaxBytes, s.prefetchDrainPercent)
+			}
+			if s.conn.statementAPI != nil {
+				// The query has run, so the tracker holds its ID
+				reader = newLinkResumeReader(ctx, s, s.alloc, s.queryID.get(), reader)
+			}
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if len(unavailable) > 0 {
+			s.conn.Logger.WarnContext(ctx, "fell back to another result protocol",
+				"protocol", protocol, "unavailable", unavailable)
+			s.warnings.add(WarningProtocolFallback, "result read through %s; unavailable: %s", protocol, strings.Join(unavailable, "; "))
+		}
+		return reader, protocol, nil
+	}
+	return nil, "", s.ErrorHelper.Errorf(adbc.StatusNotImplemented,
+		"no result protocol available (%s=%s): %s",
+		OptionFetchProtocols, strings.Join(s.protocols, ","), strings.Join(unavailable, "; "))
+}
+
+// queryRows runs query on conn through the raw driver interface, which
+// gives direct access to the Arrow result.
+func (s *statementImpl) queryRows(ctx context.Context, conn *sql.Conn, query string, args []driver.NamedValue) (driver.Rows, error) {
+	var rows driver.Rows
+	err := conn.Raw(func(driverConn interface{}) (err error) {
+		queryerCtx := driverConn.(driver.QueryerContext)
+		rows, err = queryerCtx.QueryContext(ctx, query, args)
+		return err
+	})
+	if err != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute query: %v", err)
+	}
+	return rows, nil
+}
+
+// executeREST runs query through the Statement Execution API and reads its
+// result chunks in order.
+func (s *statementImpl) executeREST(ctx context.Context, query string) (array.RecordReader, error) {
+	resp, err := s.conn.statementAPI.execute(ctx, query, s.conn.catalog, s.conn.dbSchema)
+	if err != nil {
+		return nil, err
+	}
+	reportQueryID(ctx, resp.StatementID)
+	if err := s.checkResultSize(resp); err != nil {
+		return nil, err
+	}
+	return s.conn.newResultReader(s.laneContext(ctx, resp), s.alloc, resp)
+}
+
+// rowsReader converts a result read row by row into record batches of up
+// to rowsBatchSize rows, for results that aren't available as Arrow.
+// Columns get the types schemaFromColumns gives them.
+type rowsReader struct {
+	refCount int64
+	mem      memory.Allocator
+	rows     driver.Rows
+	schema   *arrow.Schema
+	values   []driver.Value
+	declared []arrow.DataType
+	// The builder of the batches, reused from one batch to the next, and
+	// the collector of each of its columns
+	builder *array.RecordBuilder
+	columns []rowColumn
+	current arrow.RecordBatch
+	done    bool
+	err     error
+}
+
+func newRowsReader(mem memory.Allocator, rows driver.Rows) *rowsReader {
+	schema := schemaFromColumns(rows)
+	builder := array.NewRecordBuilder(mem, schema)
+	columns := make([]rowColumn, schema.NumFields())
+	for i := range columns {
+		columns[i] = newRowColumn(builder.Field(i))
+	}
+	return &rowsReader{
+		refCount: 1,
+		mem:      mem,
+		rows:     rows,
+		schema:   schema,
+		values:   make([]driver.Value, schema.NumFields()),
+		declared: declaredRowsTypes(rows),
+		builder:  builder,
+		columns:  columns,
+	}
+}
+
+func (r *rowsReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *rowsReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.builder.Release()
+		_ = r.rows.Close()
+	}
+}
+
+func (r *rowsReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *rowsReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if r.done || r.err != nil {
+		return false
+	}
+
+	n := 0
+	for ; n < rowsBatchSize; n++ {
+		if err := r.rows.Next(r.values); err != nil {
+			if err != io.EOF {
+				r.err = adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to read row: %v", err)}
+				return false
+			}
+			r.done = true
+			break
+		}
+		for i, v := range r.values {
+			if err := r.columns[i].add(v); err != nil {
+				r.err = adbc.Error{
+					Code: adbc.StatusInternal,
+					Msg:  fmt.Sprintf("failed to convert value of column %s: %v", r.schema.Field(i).Name, err),
+				}
+				return false
+			}
+		}
+	}
+	if n == 0 {
+		return false
+	}
+	for _, c := range r.columns {
+		c.flush()
+	}
+	r.current = r.builder.NewRecordBatch()
+	return true
+}
+
+func (r *rowsReader) declaredTypes() []arrow.DataType {
+	return r.declared
+}
+
+func (r *rowsReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *rowsReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *rowsReader) Err() error {
+	return classifyError(r.err)
+}
+
+// rowColumn collects the values of a column of a batch, scanned by
+// databricks-sql-go, and appends them to the column's builder at once
+// when the batch is complete.
+type rowColumn interface {
+	add(v driver.Value) error
+	flush()
+}
+
+// newRowColumn returns the collector of b's values, which takes the Go
+// type that databricks-sql-go scans values of b's column type (see
+// thriftTypeToArrow) to. Values of any other type fail, as they would only
+// be appended by guessing at their string form.
+func newRowColumn(b array.Builder) rowColumn {
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		return &typedRowColumn[int8]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Int16Builder:
+		return &typedRowColumn[int16]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Int32Builder:
+		return &typedRowColumn[int32]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Int64Builder:
+		return &typedRowColumn[int64]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Float32Builder:
+		return &typedRowColumn[float32]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Float64Builder:
+		return &typedRowColumn[float64]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.BooleanBuilder:
+		return &typedRowColumn[bool]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.BinaryBuilder:
+		return &typedRowColumn[[]byte]{dt: b.Type(), appendValues: b.AppendValues}
+	case *array.Date32Builder:
+		return &typedRowColumn[arrow.Date32]{
+			dt:           b.Type(),
+			appendValues: b.AppendValues,
+			convert: func(v driver.Value) (arrow.Date32, error) {
+				x, ok := v.(time.Time)
+				if !ok {
+					return 0, unexpectedRowValue(v, b.Type())
+				}
+				return arrow.Date32FromTime(x), nil
+			},
+		}
+	case *array.TimestampBuilder:
+		unit := b.Type().(*arrow.TimestampType).Unit
+		return &typedRowColumn[arrow.Timestamp]{
+			dt:           b.Type(),
+			appendValues: b.AppendValues,
+			convert: func(v driver.Value) (arrow.Timestamp, error) {
+				x, ok := v.(time.Time)
+				if !ok {
+					return 0, unexpectedRowValue(v, b.Type())
+				}
+				return arrow.TimestampFromTime(x, unit)
+			},
+		}
+	case *array.StringBuilder:
+		// Strings are the fallback type of columns, which may also
+		// arrive as bytes or, for date and time types the driver doesn't
+		// map, as times
+		return &typedRowColumn[string]{
+			dt:           b.Type(),
+			appendValues: b.AppendValues,
+			convert: func(v driver.Value) (string, error) {
+				switch x := v.(type) {
+				case string:
+					return x, nil
+				case []byte:
+					return string(x), nil
+				case time.Time:
+					return x.Format(time.RFC3339Nano), nil
+				default:
+					return "", unexpectedRowValue(v, b.Type())
+				}
+			},
+		}
+	default:
+		return &nullRowColumn{b: b}
+	}
+}
+
+// typedRowColumn collects the values of a column as a slice of T, which
+// is handed to the builder's AppendValues.
+type typedRowColumn[T any] struct {
+	dt arrow.DataType
+	// convert turns a non-nil value into a T. If nil, values must be Ts.
+	convert      func(v driver.Value) (T, error)
+	appendValues func(values []T, valid []bool)
+	values       []T
+	valid        []bool
+	nulls        int
+}
+
+func (c *typedRowColumn[T]) add(v driver.Value) error {
+	var x T
+	switch {
+	case v == nil:
+		c.nulls++
+		c.values = append(c.values, x)
+		c.valid = append(c.valid, false)
+		return nil
+	case c.convert != nil:
+		var err error
+		if x, err = c.convert(v); err != nil {
+			return err
+		}
+	default:
+		var ok bool
+		if x, ok = v.(T); !ok {
+			return unexpectedRowValue(v, c.dt)
+		}
+	}
+	c.values = append(c.values, x)
+	c.valid = append(c.valid, true)
+	return nil
+}
+
+func (c *typedRowColumn[T]) flush() {
+	valid := c.valid
+	if c.nulls == 0 {
+		valid = nil
+	}
+	c.appendValues(c.values, valid)
+	// Keep the slices for the next batch, without holding on to the
+	// strings and bytes of this one
+	clear(c.values)
+	c.values, c.valid, c.nulls = c.values[:0], c.valid[:0], 0
+}
+
+// nullRowColumn is the collector of columns of types with no Go type to
+// collect, which can only hold nulls.
+type nullRowColumn struct {
+	b     array.Builder
+	nulls int
+}
+
+func (c *nullRowColumn) add(v driver.Value) error {
+	if v != nil {
+		return unexpectedRowValue(v, c.b.Type())
+	}
+	c.nulls++
+	return nil
+}
+
+func (c *nullRowColumn) flush() {
+	c.b.AppendNulls(c.nulls)
+	c.nulls = 0
+}
+
+// unexpectedRowValue is the error for a value of a type a column of type
+// dt can't hold.
+func unexpectedRowValue(v driver.Value, dt arrow.DataType) error {
+	return fmt.Errorf("unexpected %T value for a %s column", v, dt)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// queryTagsUnknown stands for the session's tags after failing to change
+// them, so that they are reset before the next statement.
+const queryTagsUnknown = "\x00"
+
+// queryTag is one key-value pair of OptionQueryTags.
+type queryTag struct {
+	key, value string
+}
+
+// parseQueryTags parses a list of the form "k1=v1,k2=v2". A repeated key
+// keeps its last value, in the position of its first.
+func parseQueryTags(key, value string) ([]queryTag, error) {
+	var tags []queryTag
+	index := map[string]int{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" {
+			return nil, invalidOption(key, value, "a list of key=value pairs separated by commas")
+		}
+		if i, seen := index[k]; seen {
+			tags[i].value = v
+			continue
+		}
+		index[k] = len(tags)
+		tags = append(tags, queryTag{key: k, value: v})
+	}
+	return tags, nil
+}
+
+// formatQueryTags renders tags the way OptionQueryTags is set.
+func formatQueryTags(tags []queryTag) string {
+	pairs := make([]string, len(tags))
+	for i, tag := range tags {
+		pairs[i] = tag.key + "=" + tag.value
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Query tags carrying OptionLineageTool and OptionLineageRunID.
+const (
+	lineageToolTag  = "adbc_tool"
+	lineageRunIDTag = "adbc_run_id"
+)
+
+// lineageTags returns the query tags for the lineage options that are set.
+func lineageTags(tool, runID string) []queryTag {
+	var tags []queryTag
+	if tool != "" {
+		tags = append(tags, queryTag{key: lineageToolTag, value: tool})
+	}
+	if runID != "" {
+		tags = append(tags, queryTag{key: lineageRunIDTag, value: runID})
+	}
+	return tags
+}
+
+// mergeQueryTags returns base with the tags of override added, replacing
+// those with the same key.
+func mergeQueryTags(base, override []queryTag) []queryTag {
+	if len(base) == 0 {
+		return override
+	}
+	merged := append([]queryTag(nil), base...)
+	for _, tag := range override {
+		if i := slices.IndexFunc(merged, func(t queryTag) bool { return t.key == tag.key }); i >= 0 {
+			merged[i].value = tag.value
+		} else {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// queryTagStatements returns the statements that replace the session's
+// query tags with tags.
+func queryTagStatements(tags []queryTag, reset bool) []string {
+	var stmts []string
+	if reset {
+		stmts = append(stmts, "RESET QUERY_TAGS")
+	}
+	for _, tag := range tags {
+		stmts = append(stmts, fmt.Sprintf("SET QUERY_TAGS[%s] = %s", stringLiteral(tag.key), stringLiteral(tag.value)))
+	}
+	return stmts
+}
+
+// applyQueryTags makes tags the query tags of the connection's session,
+// unless they already are. Without tags, the session's own are restored.
+func (c *connectionImpl) applyQueryTags(ctx context.Context, conn *sql.Conn, tags []queryTag) error {
+	formatted := formatQueryTags(tags)
+	c.connMu.Lock()
+	current := c.queryTags
+	c.connMu.Unlock()
+	if formatted == current {
+		return nil
+	}
+
+	for _, stmt := range queryTagStatements(tags, current != "") {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			c.connMu.Lock()
+			c.queryTags = queryTagsUnknown
+			c.connMu.Unlock()
+			return adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to set query tags: %v", err),
+			}
+		}
+	}
+
+	c.connMu.Lock()
+	c.queryTags = formatted
+	c.connMu.Unlock()
+	return nil
+}
+
+// session returns the connection's session with the statement's query tags
+// and timeout applied.
+func (s *statementImpl) session(ctx context.Context) (*sql.Conn, error) {
+	conn, err := s.conn.sqlConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags := mergeQueryTags(s.conn.lineageTags, s.queryTags)
+	if err := s.conn.applyQueryTags(ctx, conn, tags); err != nil {
+		return nil, err
+	}
+	if err := s.conn.applyStatementTimeout(ctx, conn, s.queryTimeout); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
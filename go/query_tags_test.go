@@ -0,0 +1,88 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryTags(t *testing.T) {
+	tags, err := parseQueryTags(OptionQueryTags, " team=analytics, dbt_model = orders,team=finance,,")
+	require.NoError(t, err)
+	assert.Equal(t, []queryTag{{"team", "finance"}, {"dbt_model", "orders"}}, tags)
+	assert.Equal(t, "team=finance,dbt_model=orders", formatQueryTags(tags))
+
+	tags, err = parseQueryTags(OptionQueryTags, "")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+	assert.Equal(t, "", formatQueryTags(tags))
+
+	tags, err = parseQueryTags(OptionQueryTags, "url=https://example.com/a=b,empty=")
+	require.NoError(t, err)
+	assert.Equal(t, []queryTag{{"url", "https://example.com/a=b"}, {"empty", ""}}, tags)
+
+	for _, invalid := range []string{"team", "=analytics", "team=a,model"} {
+		_, err := parseQueryTags(OptionQueryTags, invalid)
+		var adbcErr adbc.Error
+		require.ErrorAs(t, err, &adbcErr, invalid)
+		assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+		assert.Contains(t, adbcErr.Msg, OptionQueryTags)
+	}
+}
+
+func TestQueryTagStatements(t *testing.T) {
+	tags := []queryTag{{"team", "o'brien"}, {"model", `a\b`}}
+	assert.Equal(t, []string{
+		`SET QUERY_TAGS['team'] = 'o\'brien'`,
+		`SET QUERY_TAGS['model'] = 'a\\b'`,
+	}, queryTagStatements(tags, false))
+	assert.Equal(t, []string{"RESET QUERY_TAGS"}, queryTagStatements(nil, true))
+}
+
+func TestQueryTagsOption(t *testing.T) {
+	stmt, err := (&connectionImpl{}).NewStatement()
+	require.NoError(t, err)
+	s := stmt.(*statementImpl)
+
+	require.NoError(t, s.SetOption(OptionQueryTags, "team=analytics, model=orders"))
+	value, err := s.GetOption(OptionQueryTags)
+	require.NoError(t, err)
+	assert.Equal(t, "team=analytics,model=orders", value)
+
+	clone, err := s.Clone()
+	require.NoError(t, err)
+	value, err = clone.(*statementImpl).GetOption(OptionQueryTags)
+	require.NoError(t, err)
+	assert.Equal(t, "team=analytics,model=orders", value)
+}
+
+func TestLineageTags(t *testing.T) {
+	assert.Empty(t, lineageTags("", ""))
+	assert.Equal(t, []queryTag{{"adbc_run_id", "run-7"}}, lineageTags("", "run-7"))
+
+	lineage := lineageTags("dbt", "run-7")
+	assert.Equal(t, []queryTag{{"adbc_tool", "dbt"}, {"adbc_run_id", "run-7"}}, lineage)
+
+	// Statement tags come after the lineage tags and win on conflicts
+	merged := mergeQueryTags(lineage, []queryTag{{"team", "finance"}, {"adbc_run_id", "run-8"}})
+	assert.Equal(t, []queryTag{{"adbc_tool", "dbt"}, {"adbc_run_id", "run-8"}, {"team", "finance"}}, merged)
+	assert.Equal(t, []queryTag{{"adbc_tool", "dbt"}, {"adbc_run_id", "run-7"}}, lineage)
+	assert.Equal(t, lineage, mergeQueryTags(lineage, nil))
+	assert.Equal(t, []queryTag{{"team", "finance"}}, mergeQueryTags(nil, []queryTag{{"team", "finance"}}))
+}
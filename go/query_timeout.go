@@ -0,0 +1,123 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// ErrorDetailQueryID is the key of the error detail holding the ID of the
+// query an error is about, attached to the errors of queries that ran past
+// OptionStatementQueryTimeout. It is absent if the server never assigned
+// the query an ID.
+const ErrorDetailQueryID = "databricks.query_id"
+
+// errQueryTimeout is the cause of the context of an execution that ran past
+// OptionStatementQueryTimeout.
+var errQueryTimeout = errors.New("query timeout")
+
+// statementTimeoutUnknown stands for the session's STATEMENT_TIMEOUT after
+// failing to change it.
+const statementTimeoutUnknown = -1
+
+// startExecution starts collecting the warnings of a new execution and
+// returns its context, which Cancel cancels and which ends once
+// OptionStatementQueryTimeout has passed.
+// finish must be called with the execution's error when it returns: it
+// stops the timeout, so that reading the result isn't bounded by it, and
+// returns the error as a StatusTimeout error if the timeout ran out, on the
+// client or on the server.
+func (s *statementImpl) startExecution(ctx context.Context) (_ context.Context, finish func(queryID string, err error) error) {
+	s.warnings = &statementWarnings{}
+	ctx, cancel := s.cancellable(ctx)
+	timeout := s.queryTimeout
+	if timeout <= 0 || s.conn == nil {
+		return ctx, func(_ string, err error) error { return err }
+	}
+
+	clock := s.conn.clock
+	start := clock.Now()
+	stop := make(chan struct{})
+	s.conn.goroutines.Go(func(closing context.Context) {
+		select {
+		case <-clock.After(timeout):
+			cancel(errQueryTimeout)
+		case <-stop:
+		case <-ctx.Done():
+		case <-closing.Done():
+		}
+	})
+	return ctx, func(queryID string, err error) error {
+		close(stop)
+		if err == nil || (!errors.Is(context.Cause(ctx), errQueryTimeout) && clock.Since(start) < timeout) {
+			return err
+		}
+		return queryTimeoutError(queryID, timeout)
+	}
+}
+
+// queryTimeoutError is the error of a query that ran past timeout.
+func queryTimeoutError(queryID string, timeout time.Duration) error {
+	if queryID == "" {
+		return adbc.Error{Code: adbc.StatusTimeout, Msg: fmt.Sprintf("query timed out after %s", timeout)}
+	}
+	return adbc.Error{
+		Code:    adbc.StatusTimeout,
+		Msg:     fmt.Sprintf("query %s timed out after %s", queryID, timeout),
+		Details: []adbc.ErrorDetail{&adbc.TextErrorDetail{Name: ErrorDetailQueryID, Detail: queryID}},
+	}
+}
+
+// statementTimeoutStatement returns the statement that sets the session's
+// STATEMENT_TIMEOUT to timeout, or restores the server's default for zero.
+func statementTimeoutStatement(timeout time.Duration) string {
+	if timeout <= 0 {
+		return "RESET STATEMENT_TIMEOUT"
+	}
+	return fmt.Sprintf("SET STATEMENT_TIMEOUT = %d", int64(timeout/time.Second))
+}
+
+// applyStatementTimeout makes timeout the STATEMENT_TIMEOUT of the
+// connection's session, unless it already is. Zero restores the server's
+// default if the driver changed it.
+func (c *connectionImpl) applyStatementTimeout(ctx context.Context, conn *sql.Conn, timeout time.Duration) error {
+	c.connMu.Lock()
+	current := c.statementTimeout
+	c.connMu.Unlock()
+	if timeout == current {
+		return nil
+	}
+
+	if _, err := conn.ExecContext(ctx, statementTimeoutStatement(timeout)); err != nil {
+		c.connMu.Lock()
+		c.statementTimeout = statementTimeoutUnknown
+		c.connMu.Unlock()
+		return adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to set statement timeout: %v", err),
+		}
+	}
+
+	c.connMu.Lock()
+	c.statementTimeout = timeout
+	c.connMu.Unlock()
+	return nil
+}
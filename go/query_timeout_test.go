@@ -0,0 +1,72 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTimeoutOption(t *testing.T) {
+	s := &statementImpl{}
+	require.NoError(t, s.SetOption(OptionStatementQueryTimeout, "90"))
+	assert.Equal(t, 90*time.Second, s.queryTimeout)
+	value, err := s.GetOption(OptionStatementQueryTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, "90", value)
+	assert.Error(t, s.SetOption(OptionStatementQueryTimeout, "-1"))
+	assert.Error(t, s.SetOption(OptionStatementQueryTimeout, "1m"))
+
+	assert.Equal(t, "SET STATEMENT_TIMEOUT = 90", statementTimeoutStatement(s.queryTimeout))
+	assert.Equal(t, "RESET STATEMENT_TIMEOUT", statementTimeoutStatement(0))
+}
+
+func TestStartExecution(t *testing.T) {
+	queryErr := errors.New("query failed")
+
+	// Without a timeout errors are returned as they are
+	s := &statementImpl{conn: &connectionImpl{goroutines: checkGoroutines(t)}}
+	ctx, finish := s.startExecution(context.Background())
+	assert.NoError(t, ctx.Err())
+	assert.Equal(t, queryErr, finish("q-1", queryErr))
+
+	// Errors of queries that didn't run out of time aren't timeouts
+	s.queryTimeout = time.Hour
+	_, finish = s.startExecution(context.Background())
+	assert.Equal(t, queryErr, finish("q-1", queryErr))
+
+	// Running out of time cancels the execution
+	s.conn.clock = driverClock{newFakeClock()}
+	ctx, finish = s.startExecution(context.Background())
+	<-ctx.Done()
+	assert.ErrorIs(t, context.Cause(ctx), errQueryTimeout)
+	err := finish("q-1", queryErr)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusTimeout, adbcErr.Code)
+	assert.Equal(t, "query q-1 timed out after 1h0m0s", adbcErr.Msg)
+	require.Len(t, adbcErr.Details, 1)
+	assert.Equal(t, ErrorDetailQueryID, adbcErr.Details[0].Key())
+
+	ctx, finish = s.startExecution(context.Background())
+	<-ctx.Done()
+	assert.NoError(t, finish("q-1", nil))
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// StatementResetter is implemented by the statements of this driver.
+//
+// Reset readies the statement for an unrelated operation, as if it were
+// new but with its options kept: it clears the query and the ingestion
+// target, closes the prepared statement, releases bound parameters and
+// forgets the last result's query ID, update metrics, skipped rows,
+// protocol, extract predicate and warnings, and the query submitted with
+// OptionExecutionAsync. Readers returned by earlier executions stay valid
+// and must still be released. Reset fails while an ingest stream is open.
+//
+// SetSqlQuery does the same, except that it sets the new query.
+type StatementResetter interface {
+	Reset() error
+}
+
+func (s *statementImpl) Reset() error {
+	if s.conn == nil {
+		return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement already closed")
+	}
+	if err := s.resetQueryState(); err != nil {
+		return err
+	}
+	s.query = ""
+	return nil
+}
+
+// resetQueryState clears what belongs to the statement's current query or
+// ingestion, so that none of it carries over to the next one.
+func (s *statementImpl) resetQueryState() error {
+	if s.ingestStream != nil {
+		return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "an ingest stream is open on this statement")
+	}
+	if s.prepared != nil {
+		if err := s.prepared.release(); err != nil {
+			return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "failed to close previous prepared statement: %v", err)
+		}
+		s.prepared = nil
+	}
+	if s.boundStream != nil {
+		s.boundStream.Release()
+		s.boundStream = nil
+	}
+	s.bulkIngestOptions.Clear()
+	s.queryID = nil
+	s.asyncQuery = ""
+	s.updateMetrics = nil
+	s.skippedRows = nil
+	s.rejectedRows = nil
+	s.resultProtocol = ""
+	s.extractPredicate = ""
+	s.warnings = nil
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementReuse(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// A statement left with everything an ingestion and a query leave behind
+	used := func() *statementImpl {
+		s := &statementImpl{
+			conn:              &connectionImpl{},
+			query:             "SELECT 1",
+			bulkIngestOptions: driverbase.NewBulkIngestOptions(),
+			batchMetadata:     true,
+			queryID:           &queryIDTracker{},
+			updateMetrics:     map[string]int64{"num_inserted_rows": 1},
+			skippedRows:       &skippedRows{},
+			resultProtocol:    OptionValueProtocolREST,
+		}
+		require.NoError(t, s.SetOption(adbc.OptionKeyIngestTargetTable, "events"))
+		require.NoError(t, s.SetOption(adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeAppend))
+		schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+		rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(`[{"id": 1}]`))
+		require.NoError(t, err)
+		defer rec.Release()
+		require.NoError(t, s.Bind(t.Context(), rec))
+		return s
+	}
+	assertReset := func(s *statementImpl) {
+		assert.Nil(t, s.boundStream)
+		assert.False(t, s.bulkIngestOptions.IsSet())
+		assert.Equal(t, adbc.OptionValueIngestModeCreate, s.bulkIngestOptions.Mode)
+		assert.Nil(t, s.queryID)
+		assert.Nil(t, s.updateMetrics)
+		assert.Nil(t, s.skippedRows)
+		assert.Empty(t, s.resultProtocol)
+		// Options are kept
+		assert.True(t, s.batchMetadata)
+	}
+
+	s := used()
+	require.NoError(t, s.SetSqlQuery("SELECT 2"))
+	assertReset(s)
+	assert.Equal(t, "SELECT 2", s.query)
+
+	s = used()
+	require.NoError(t, s.Reset())
+	assertReset(s)
+	assert.Empty(t, s.query)
+
+	// An open ingest stream still needs the statement's ingestion target
+	s = used()
+	s.ingestStream = &ingestStream{}
+	var adbcErr adbc.Error
+	require.ErrorAs(t, s.Reset(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+	assert.Equal(t, "SELECT 1", s.query)
+	s.ingestStream = nil
+	require.NoError(t, s.Reset())
+
+	// A closed statement can't be reset
+	require.NoError(t, s.Close())
+	require.ErrorAs(t, s.Reset(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// checkResultSize fails with StatusInvalidState if the result of resp is
+// larger than OptionFetchRejectOverBytes allows, before any of it has been
+// downloaded. Results whose size the server doesn't report pass. A result
+// the server truncated is reported with WarningResultTruncated.
+//
+// Only the Statement Execution API reports sizes up front: for results
+// read through OptionValueProtocolREST, in lenient mode, with
+// OptionJobsHandoffAfter and for partitions. Arrow results read over
+// Thrift are checked as they are read instead (see checkReadSize), and
+// those read through OptionValueProtocolRows aren't checked.
+func (s *statementImpl) checkResultSize(resp *statementResponse) error {
+	if resp.Manifest != nil && resp.Manifest.Truncated {
+		s.warnings.add(WarningResultTruncated, "the server truncated the result of query %s to %d rows",
+			resp.StatementID, resp.Manifest.TotalRowCount)
+	}
+	if s.rejectOverBytes <= 0 || resp.Manifest == nil {
+		return nil
+	}
+	size := resp.Manifest.TotalByteCount
+	if size <= s.rejectOverBytes {
+		return nil
+	}
+	return adbc.Error{
+		Code: adbc.StatusInvalidState,
+		Msg: fmt.Sprintf("result of query %s is %s (%d bytes, %d rows), over the %s allowed by %s; narrow the query or raise the limit",
+			resp.StatementID, formatByteSize(size), size, resp.Manifest.TotalRowCount,
+			formatByteSize(s.rejectOverBytes), OptionFetchRejectOverBytes),
+	}
+}
+
+// formatByteSize renders n bytes in the largest binary unit it reaches,
+// e.g. "1.5 GiB".
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 5; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatByteSize(t *testing.T) {
+	assert.Equal(t, "0 B", formatByteSize(0))
+	assert.Equal(t, "1023 B", formatByteSize(1023))
+	assert.Equal(t, "1.0 KiB", formatByteSize(1024))
+	assert.Equal(t, "1.5 MiB", formatByteSize(3<<19))
+	assert.Equal(t, "50.0 GiB", formatByteSize(50<<30))
+}
+
+func TestCheckResultSize(t *testing.T) {
+	resp := &statementResponse{StatementID: "01f0", Manifest: &resultManifest{TotalByteCount: 50 << 30, TotalRowCount: 1000}}
+
+	s := &statementImpl{}
+	require.NoError(t, s.checkResultSize(resp))
+
+	s.rejectOverBytes = 1 << 30
+	err := s.checkResultSize(resp)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "01f0")
+	assert.Contains(t, adbcErr.Msg, "50.0 GiB (53687091200 bytes, 1000 rows)")
+	assert.Contains(t, adbcErr.Msg, "1.0 GiB allowed by "+OptionFetchRejectOverBytes)
+
+	s.rejectOverBytes = 50 << 30
+	require.NoError(t, s.checkResultSize(resp))
+	// Without a manifest, or a size in it, there is nothing to check
+	s.rejectOverBytes = 1
+	require.NoError(t, s.checkResultSize(&statementResponse{}))
+	require.NoError(t, s.checkResultSize(&statementResponse{Manifest: &resultManifest{}}))
+}
+
+func TestRejectOverBytesThrift(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	var streams [][]byte
+	for i := range int64(3) {
+		batch := makeConcatTestBatch(t, mem, []int64{2 * i, 2*i + 1}, []string{"a", "b"})
+		streams = append(streams, writeIPCStream(t, concatTestSchema, batch))
+		batch.Release()
+	}
+
+	read := func(limit int64) (int, error) {
+		rows := &mockRows{iterator: &mockIPCStreamIterator{streams: streams}}
+		reader, err := newIPCReaderAdapter(context.Background(), mem, rows)
+		require.NoError(t, err)
+		defer reader.Release()
+		reader.(*ipcReaderAdapter).rejectOverBytes = limit
+		batches := 0
+		for reader.Next() {
+			batches++
+		}
+		return batches, reader.Err()
+	}
+
+	// The batch taking the streams read over the limit fails the reader
+	batches, err := read(int64(len(streams[0]) + 1))
+	assert.Equal(t, 1, batches)
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidState, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, OptionFetchRejectOverBytes)
+
+	batches, err = read(int64(len(streams[0]) + len(streams[1]) + len(streams[2])))
+	require.NoError(t, err)
+	assert.Equal(t, 3, batches)
+	batches, err = read(0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, batches)
+}
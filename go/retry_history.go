@@ -0,0 +1,130 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// ErrorDetailRetryHistory is the key of the error detail attached to the
+// errors of operations that failed after the driver retried them. The
+// detail is a JSON object with the fields operation, attempts (one object
+// per attempt, with the fields attempt, status, kind, error and
+// backoff_ms, the wait before the next attempt), total_backoff_ms and
+// elapsed_ms, the time from the first attempt to the failure. Retries made
+// inside databricks-sql-go (OptionQueryRetryCount) aren't recorded.
+const ErrorDetailRetryHistory = "databricks.retry_history"
+
+// retryAttempt is a failed attempt of a retryHistory.
+type retryAttempt struct {
+	Attempt int `json:"attempt"`
+	// ADBC status of the error, and the errors package sentinel it
+	// matches, if any
+	Status    string `json:"status"`
+	Kind      string `json:"kind,omitempty"`
+	Error     string `json:"error"`
+	BackoffMs int64  `json:"backoff_ms"`
+}
+
+// retryHistory records the failed attempts of a retried operation.
+type retryHistory struct {
+	Operation      string         `json:"operation"`
+	Attempts       []retryAttempt `json:"attempts"`
+	TotalBackoffMs int64          `json:"total_backoff_ms"`
+	ElapsedMs      int64          `json:"elapsed_ms"`
+
+	clock driverClock
+	start time.Time
+}
+
+func newRetryHistory(operation string, clock driverClock) *retryHistory {
+	return &retryHistory{Operation: operation, clock: clock, start: clock.Now()}
+}
+
+// record adds an attempt that failed with err and is followed by a wait of
+// backoff, zero for the last attempt.
+func (h *retryHistory) record(err error, backoff time.Duration) {
+	attempt := retryAttempt{
+		Attempt:   len(h.Attempts) + 1,
+		Status:    adbc.StatusUnknown.String(),
+		Error:     err.Error(),
+		BackoffMs: backoff.Milliseconds(),
+	}
+	err = classifyError(err)
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) {
+		attempt.Status = adbcErr.Code.String()
+	}
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		attempt.Kind = strings.TrimPrefix(classified.kind.Error(), "databricks: ")
+	}
+	h.Attempts = append(h.Attempts, attempt)
+	h.TotalBackoffMs += attempt.BackoffMs
+}
+
+// fail returns err, the error the operation failed with, carrying the
+// history if the operation was retried.
+func (h *retryHistory) fail(err error) error {
+	if len(h.Attempts) < 2 {
+		return err
+	}
+	h.ElapsedMs = h.clock.Since(h.start).Milliseconds()
+	detail, jsonErr := json.Marshal(h)
+	if jsonErr != nil {
+		return err
+	}
+	return &retriedError{err: err, detail: &adbc.TextErrorDetail{Name: ErrorDetailRetryHistory, Detail: string(detail)}}
+}
+
+// retriedError is the error of a retried operation. As an adbc.Error it
+// carries the retry history among its details.
+type retriedError struct {
+	err    error
+	detail adbc.ErrorDetail
+}
+
+func (e *retriedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retriedError) Unwrap() error {
+	return e.err
+}
+
+func (e *retriedError) As(target any) bool {
+	t, ok := target.(*adbc.Error)
+	if !ok || !errors.As(e.err, t) {
+		return false
+	}
+	t.Details = append(slices.Clip(t.Details), e.detail)
+	return true
+}
+
+// retryDetails returns the retry history of err as error details, for
+// errors that wrap err into a new adbc.Error.
+func retryDetails(err error) []adbc.ErrorDetail {
+	var retried *retriedError
+	if errors.As(err, &retried) {
+		return []adbc.ErrorDetail{retried.detail}
+	}
+	return nil
+}
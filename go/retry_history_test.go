@@ -0,0 +1,69 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryHistory(t *testing.T) {
+	failed := adbc.Error{Code: adbc.StatusInternal, Msg: "failed to query catalogs: 429 Too Many Requests"}
+
+	// An operation that wasn't retried is returned unchanged
+	history := newRetryHistory("query catalogs", driverClock{})
+	history.record(failed, 0)
+	assert.Equal(t, error(failed), history.fail(failed))
+	assert.Nil(t, retryDetails(failed))
+
+	history = newRetryHistory("query catalogs", driverClock{})
+	history.record(errors.New("503 Service Unavailable"), 2*time.Second)
+	history.record(failed, 0)
+	err := history.fail(failed)
+	assert.EqualError(t, err, failed.Error())
+
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInternal, adbcErr.Code)
+	require.Len(t, adbcErr.Details, 1)
+	assert.Equal(t, ErrorDetailRetryHistory, adbcErr.Details[0].Key())
+	assert.Equal(t, adbcErr.Details, retryDetails(fmt.Errorf("wrapped: %w", err)))
+
+	detail, err := adbcErr.Details[0].Serialize()
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(detail, &decoded))
+	assert.Equal(t, "query catalogs", decoded["operation"])
+	assert.EqualValues(t, 2000, decoded["total_backoff_ms"])
+	assert.Contains(t, decoded, "elapsed_ms")
+	assert.Equal(t, []any{
+		map[string]any{"attempt": 1.0, "status": "I/O", "kind": "warehouse is starting", "error": "503 Service Unavailable", "backoff_ms": 2000.0},
+		map[string]any{"attempt": 2.0, "status": "Internal", "kind": "rate limited", "error": failed.Error(), "backoff_ms": 0.0},
+	}, decoded["attempts"])
+
+	// Classifying the error keeps the history
+	var classified adbc.Error
+	require.ErrorAs(t, classifyError(history.fail(failed)), &classified)
+	assert.Len(t, classified.Details, 1)
+	require.ErrorAs(t, classifyError(history.fail(errors.New("503 Service Unavailable"))), &classified)
+	assert.Len(t, classified.Details, 1)
+}
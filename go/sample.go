@@ -0,0 +1,57 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseSampleFraction parses OptionSampleFraction: zero, or a fraction
+// greater than zero and at most one.
+func parseSampleFraction(key, value string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || f < 0 || f > 1 {
+		return 0, invalidOption(key, value, "a fraction between 0 and 1")
+	}
+	return f, nil
+}
+
+// sampleQuery wraps query so that it returns a sample of its result, per
+// OptionSampleFraction, OptionSampleRows and OptionSampleSeed. Statements
+// other than queries are returned as they are. The query is closed on its
+// own line so that a trailing comment in it can't swallow the wrapper.
+func (s *statementImpl) sampleQuery(query string) string {
+	if (s.sampleFraction == 0 && s.sampleRows == 0) || !readsSnapshot(query) {
+		return query
+	}
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (\n")
+	b.WriteString(trimTerminator(query))
+	b.WriteString("\n)")
+	if s.sampleFraction > 0 {
+		b.WriteString(" TABLESAMPLE (")
+		b.WriteString(strconv.FormatFloat(s.sampleFraction*100, 'f', -1, 64))
+		b.WriteString(" PERCENT)")
+		if s.sampleSeed != "" {
+			b.WriteString(" REPEATABLE (" + s.sampleSeed + ")")
+		}
+	}
+	b.WriteString(" AS sampled")
+	if s.sampleRows > 0 {
+		b.WriteString(" LIMIT " + strconv.FormatInt(s.sampleRows, 10))
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleQuery(t *testing.T) {
+	const query = "SELECT * FROM sales -- all of them\n;"
+	for _, tc := range []struct {
+		name     string
+		fraction float64
+		rows     int64
+		seed     string
+		want     string
+	}{
+		{"disabled", 0, 0, "", query},
+		{"fraction", 0.1, 0, "", "SELECT * FROM (\nSELECT * FROM sales -- all of them\n) TABLESAMPLE (10 PERCENT) AS sampled"},
+		{"seeded", 0.005, 0, "42", "SELECT * FROM (\nSELECT * FROM sales -- all of them\n) TABLESAMPLE (0.5 PERCENT) REPEATABLE (42) AS sampled"},
+		{"rows", 0, 1000, "", "SELECT * FROM (\nSELECT * FROM sales -- all of them\n) AS sampled LIMIT 1000"},
+		{"both", 0.5, 10, "7", "SELECT * FROM (\nSELECT * FROM sales -- all of them\n) TABLESAMPLE (50 PERCENT) REPEATABLE (7) AS sampled LIMIT 10"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &statementImpl{sampleFraction: tc.fraction, sampleRows: tc.rows, sampleSeed: tc.seed}
+			assert.Equal(t, tc.want, s.sampleQuery(query))
+		})
+	}
+
+	// Only queries are sampled
+	s := &statementImpl{sampleRows: 10}
+	assert.Equal(t, "INSERT INTO t SELECT 1", s.sampleQuery("INSERT INTO t SELECT 1"))
+	assert.Equal(t, "SELECT * FROM (\nWITH a AS (SELECT 1) SELECT * FROM a\n) AS sampled LIMIT 10",
+		s.sampleQuery("WITH a AS (SELECT 1) SELECT * FROM a"))
+}
+
+func TestSampleOptions(t *testing.T) {
+	s := &statementImpl{}
+	require.NoError(t, s.SetOption(OptionSampleFraction, "0.25"))
+	require.NoError(t, s.SetOption(OptionSampleRows, "500"))
+	require.NoError(t, s.SetOption(OptionSampleSeed, "-3"))
+	for key, want := range map[string]string{
+		OptionSampleFraction: "0.25",
+		OptionSampleRows:     "500",
+		OptionSampleSeed:     "-3",
+	} {
+		got, err := s.GetOption(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, key)
+	}
+
+	assert.Error(t, s.SetOption(OptionSampleFraction, "1.5"))
+	assert.Error(t, s.SetOption(OptionSampleFraction, "-0.1"))
+	assert.Error(t, s.SetOption(OptionSampleRows, "-1"))
+	assert.Error(t, s.SetOption(OptionSampleSeed, "abc"))
+	require.NoError(t, s.SetOption(OptionSampleSeed, ""))
+	assert.Empty(t, s.sampleSeed)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// schemaCacheEntry is a table schema together with the Delta version it was
+// read at.
+type schemaCacheEntry struct {
+	schema  *arrow.Schema
+	version int64
+	// verified is when version was last confirmed to be current, or when
+	// the table was found to have no Delta history.
+	verified time.Time
+	// unversioned marks tables without Delta history, whose schema isn't
+	// cached.
+	unversioned bool
+}
+
+// schemaCache holds the schemas returned by GetTableSchema, keyed by the
+// table's fully-qualified, quoted name. It is shared by all connections of
+// a database.
+type schemaCache struct {
+	mu      sync.Mutex
+	entries map[string]schemaCacheEntry
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{entries: make(map[string]schemaCacheEntry)}
+}
+
+func (c *schemaCache) get(name string) (schemaCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[name]
+	return entry, ok
+}
+
+func (c *schemaCache) put(name string, entry schemaCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = entry
+}
+
+func (c *schemaCache) remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, name)
+}
+
+func (c *schemaCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// schemaDrift describes how a batch's schema differs from the result's.
+type schemaDrift struct {
+	added   []arrow.Field
+	missing []arrow.Field
+	// Fields present in both with different types, as "name: old -> new"
+	changed []string
+}
+
+// diffSchemas compares the fields of got against want by name.
+func diffSchemas(want, got *arrow.Schema) schemaDrift {
+	var drift schemaDrift
+	for _, field := range want.Fields() {
+		idx := got.FieldIndices(field.Name)
+		if len(idx) == 0 {
+			drift.missing = append(drift.missing, field)
+		} else if other := got.Field(idx[0]); !arrow.TypeEqual(field.Type, other.Type) {
+			drift.changed = append(drift.changed, fmt.Sprintf("%s: %s -> %s", field.Name, field.Type, other.Type))
+		}
+	}
+	for _, field := range got.Fields() {
+		if !want.HasField(field.Name) {
+			drift.added = append(drift.added, field)
+		}
+	}
+	return drift
+}
+
+func (d schemaDrift) String() string {
+	var parts []string
+	if len(d.added) > 0 {
+		parts = append(parts, "added fields "+describeFields(d.added))
+	}
+	if len(d.missing) > 0 {
+		parts = append(parts, "missing fields "+describeFields(d.missing))
+	}
+	if len(d.changed) > 0 {
+		parts = append(parts, "changed types "+strings.Join(d.changed, ", "))
+	}
+	if len(parts) == 0 {
+		// Same names and types; the order or nullability differs
+		return "fields reordered or nullability changed"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeFields(fields []arrow.Field) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = fmt.Sprintf("%s: %s", field.Name, field.Type)
+	}
+	return strings.Join(names, ", ")
+}
+
+// schemaDriftReader checks that every batch read from rdr has the schema
+// rdr reports. Batches that don't fail the read, or with
+// OptionValueSchemaDriftUnify are conformed to the schema: fields are
+// matched by name, missing ones are filled with nulls and added ones are
+// dropped. Fields whose type changed can't be unified.
+type schemaDriftReader struct {
+	refCount int64
+	mem      memory.Allocator
+	rdr      array.RecordReader
+	schema   *arrow.Schema
+	unify    bool
+	warnings *statementWarnings
+	unified  bool
+	batches  int
+	current  arrow.RecordBatch
+	err      error
+}
+
+// newSchemaDriftReader wraps rdr, taking ownership of it. The first
+// batch it conforms is reported to warnings.
+func newSchemaDriftReader(mem memory.Allocator, mode string, rdr array.RecordReader, warnings *statementWarnings) *schemaDriftReader {
+	return &schemaDriftReader{
+		refCount: 1,
+		mem:      mem,
+		rdr:      rdr,
+		schema:   rdr.Schema(),
+		unify:    mode == OptionValueSchemaDriftUnify,
+		warnings: warnings,
+	}
+}
+
+func (r *schemaDriftReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+func (r *schemaDriftReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		if r.current != nil {
+			r.current.Release()
+			r.current = nil
+		}
+		r.rdr.Release()
+	}
+}
+
+func (r *schemaDriftReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+func (r *schemaDriftReader) Next() bool {
+	if r.current != nil {
+		r.current.Release()
+		r.current = nil
+	}
+	if r.err != nil || !r.rdr.Next() {
+		return false
+	}
+	batch := r.rdr.RecordBatch()
+	r.batches++
+	if batch.Schema().Equal(r.schema) {
+		batch.Retain()
+		r.current = batch
+		return true
+	}
+
+	drift := diffSchemas(r.schema, batch.Schema())
+	switch {
+	case !r.unify:
+		r.err = adbc.Error{
+			Code: adbc.StatusInvalidData,
+			Msg: fmt.Sprintf("batch %d of the result doesn't match its schema: %s; set %s to %s to fill missing fields with nulls",
+				r.batches, drift, OptionFetchSchemaDrift, OptionValueSchemaDriftUnify),
+		}
+		return false
+	case len(drift.changed) > 0:
+		r.err = adbc.Error{
+			Code: adbc.StatusInvalidData,
+			Msg:  fmt.Sprintf("batch %d of the result doesn't match its schema and can't be unified: %s", r.batches, drift),
+		}
+		return false
+	}
+	if !r.unified {
+		r.unified = true
+		r.warnings.add(WarningSchemaUnified, "batch %d of the result didn't match its schema and was conformed to it: %s", r.batches, drift)
+	}
+	r.current = r.conform(batch)
+	return true
+}
+
+// conform returns batch with the columns of the reader's schema, taken by
+// name from batch or filled with nulls.
+func (r *schemaDriftReader) conform(batch arrow.RecordBatch) arrow.RecordBatch {
+	cols := make([]arrow.Array, r.schema.NumFields())
+	for i, field := range r.schema.Fields() {
+		if idx := batch.Schema().FieldIndices(field.Name); len(idx) > 0 {
+			cols[i] = batch.Column(idx[0])
+			cols[i].Retain()
+		} else {
+			cols[i] = array.MakeArrayOfNull(r.mem, field.Type, int(batch.NumRows()))
+		}
+	}
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	return array.NewRecordBatch(r.schema, cols, batch.NumRows())
+}
+
+func (r *schemaDriftReader) Record() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *schemaDriftReader) RecordBatch() arrow.RecordBatch {
+	return r.current
+}
+
+func (r *schemaDriftReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.rdr.Err()
+}
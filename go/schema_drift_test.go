@@ -0,0 +1,108 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeDriftTestReader returns a reader whose first chunk has the fields id
+// and name, and whose second chunk has the schema second.
+func makeDriftTestReader(t *testing.T, mem memory.Allocator, second *arrow.Schema, secondJSON string) array.RecordReader {
+	t.Helper()
+	first := makeConcatTestBatch(t, mem, []int64{1}, []string{"a"})
+	defer first.Release()
+	next, _, err := array.RecordFromJSON(mem, second, strings.NewReader(secondJSON))
+	require.NoError(t, err)
+	defer next.Release()
+
+	rdr, err := newIPCReaderAdapter(context.Background(), mem, &mockRows{iterator: &mockIPCStreamIterator{
+		streams: [][]byte{writeIPCStream(t, concatTestSchema, first), writeIPCStream(t, second, next)},
+	}})
+	require.NoError(t, err)
+	return rdr
+}
+
+func TestSchemaDriftFail(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	added := arrow.NewSchema(append(concatTestSchema.Fields(),
+		arrow.Field{Name: "extra", Type: arrow.PrimitiveTypes.Int32, Nullable: true}), nil)
+	rdr := newSchemaDriftReader(mem, OptionValueSchemaDriftFail,
+		makeDriftTestReader(t, mem, added, `[{"id": 2, "name": "b", "extra": 7}]`), nil)
+	defer rdr.Release()
+
+	require.True(t, rdr.Next())
+	assert.False(t, rdr.Next())
+	var adbcErr adbc.Error
+	require.ErrorAs(t, rdr.Err(), &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidData, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "batch 2")
+	assert.Contains(t, adbcErr.Msg, "added fields extra: int32")
+}
+
+func TestSchemaDriftUnify(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// The second chunk lacks name, has an extra field and puts id last
+	drifted := arrow.NewSchema([]arrow.Field{
+		{Name: "extra", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil)
+	warnings := &statementWarnings{}
+	rdr := newSchemaDriftReader(mem, OptionValueSchemaDriftUnify,
+		makeDriftTestReader(t, mem, drifted, `[{"extra": 7, "id": 2}, {"extra": 8, "id": 3}]`), warnings)
+	defer rdr.Release()
+
+	require.True(t, rdr.Next())
+	require.True(t, rdr.Next())
+	batch := rdr.RecordBatch()
+	assert.True(t, batch.Schema().Equal(concatTestSchema))
+	assert.Equal(t, []int64{2, 3}, batch.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, 2, batch.Column(1).NullN())
+	assert.False(t, rdr.Next())
+	assert.NoError(t, rdr.Err())
+	require.Len(t, warnings.warnings, 1)
+	assert.Equal(t, WarningSchemaUnified, warnings.warnings[0].Code)
+	assert.Contains(t, warnings.warnings[0].Message, "batch 2")
+}
+
+func TestSchemaDriftUnifyChangedType(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	changed := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	rdr := newSchemaDriftReader(mem, OptionValueSchemaDriftUnify,
+		makeDriftTestReader(t, mem, changed, `[{"id": "2", "name": "b"}]`), nil)
+	defer rdr.Release()
+
+	require.True(t, rdr.Next())
+	assert.False(t, rdr.Next())
+	assert.ErrorContains(t, rdr.Err(), "changed types id: int64 -> utf8")
+}
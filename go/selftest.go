@@ -0,0 +1,256 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// selfTestTimeout bounds a whole self-test run.
+const selfTestTimeout = 5 * time.Minute
+
+// selfTestRows is the number of rows the Arrow fetch check reads.
+const selfTestRows = 100_000
+
+// selfTestReport is the result of OptionSelfTest.
+type selfTestReport struct {
+	// Passed is whether no check failed; skipped checks don't count.
+	Passed bool            `json:"passed"`
+	Checks []selfTestCheck `json:"checks"`
+}
+
+// selfTestCheck is the outcome of one check.
+type selfTestCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	// Detail describes what was found, or why the check failed or was
+	// skipped.
+	Detail string `json:"detail,omitempty"`
+}
+
+const (
+	selfTestPassed  = "passed"
+	selfTestFailed  = "failed"
+	selfTestSkipped = "skipped"
+)
+
+// errSelfTestSkipped is returned by a check that doesn't apply.
+type errSelfTestSkipped struct {
+	reason string
+}
+
+func (e errSelfTestSkipped) Error() string {
+	return e.reason
+}
+
+// selfTest runs the checks of OptionSelfTest in order. Once the session
+// can't be opened the remaining checks are skipped, since each would fail
+// the same way.
+func (c *connectionImpl) selfTest(ctx context.Context) selfTestReport {
+	checks := []struct {
+		name string
+		run  func(context.Context) (string, error)
+	}{
+		{"auth", c.selfTestAuth},
+		{"query", c.selfTestQuery},
+		{"arrow_fetch", c.selfTestArrowFetch},
+		{"metadata", c.selfTestMetadata},
+		{"cloudfetch", c.selfTestCloudFetch},
+		{"write", c.selfTestWrite},
+	}
+
+	report := selfTestReport{Passed: true}
+	noSession := false
+	for _, check := range checks {
+		result := selfTestCheck{Name: check.name}
+		if noSession {
+			result.Status = selfTestSkipped
+			result.Detail = "no session"
+			report.Checks = append(report.Checks, result)
+			continue
+		}
+
+		start := time.Now()
+		detail, err := check.run(ctx)
+		result.DurationMs = time.Since(start).Milliseconds()
+		var skipped errSelfTestSkipped
+		switch {
+		case errors.As(err, &skipped):
+			result.Status = selfTestSkipped
+			result.Detail = skipped.reason
+		case err != nil:
+			result.Status = selfTestFailed
+			result.Detail = err.Error()
+			report.Passed = false
+			noSession = check.name == "auth"
+		default:
+			result.Status = selfTestPassed
+			result.Detail = detail
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+// selfTestJSON runs the self-test and encodes its report.
+func (c *connectionImpl) selfTestJSON() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), selfTestTimeout)
+	defer cancel()
+	data, err := json.Marshal(c.selfTest(ctx))
+	if err != nil {
+		return "", adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  "failed to encode self-test report: " + err.Error(),
+		}
+	}
+	return string(data), nil
+}
+
+// selfTestAuth opens the session, which authenticates, and reports the
+// user it belongs to.
+func (c *connectionImpl) selfTestAuth(ctx context.Context) (string, error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	var user string
+	if err := conn.QueryRowContext(ctx, "SELECT current_user()").Scan(&user); err != nil {
+		return "", err
+	}
+	return "authenticated as " + user, nil
+}
+
+func (c *connectionImpl) selfTestQuery(ctx context.Context) (string, error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	var one int64
+	if err := conn.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return "", err
+	}
+	if one != 1 {
+		return "", fmt.Errorf("SELECT 1 returned %d", one)
+	}
+	return "SELECT 1 returned 1", nil
+}
+
+// selfTestArrowFetch reads a result large enough to span several batches
+// through a statement, as an application would.
+func (c *connectionImpl) selfTestArrowFetch(ctx context.Context) (string, error) {
+	stmt, err := c.NewStatement()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stmt.Close() }()
+	if err := stmt.SetSqlQuery(fmt.Sprintf("SELECT id, CAST(id AS STRING) AS label FROM range(%d)", selfTestRows)); err != nil {
+		return "", err
+	}
+	rdr, _, err := stmt.ExecuteQuery(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer rdr.Release()
+
+	var rows, batches int64
+	for rdr.Next() {
+		rows += rdr.RecordBatch().NumRows()
+		batches++
+	}
+	if err := rdr.Err(); err != nil {
+		return "", err
+	}
+	if rows != selfTestRows {
+		return "", fmt.Errorf("read %d rows, expected %d", rows, selfTestRows)
+	}
+	protocol := "an unknown protocol"
+	if opts, ok := stmt.(adbc.GetSetOptions); ok {
+		protocol, _ = opts.GetOption(OptionFetchResultProtocol)
+	}
+	return fmt.Sprintf("read %d rows in %d batches through %s", rows, batches, protocol), nil
+}
+
+func (c *connectionImpl) selfTestMetadata(ctx context.Context) (string, error) {
+	catalog, err := c.GetCurrentCatalog()
+	if err != nil {
+		return "", err
+	}
+	schemas, err := c.GetDBSchemasForCatalog(ctx, catalog, nil)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("listed %d schemas in catalog %s", len(schemas), catalog), nil
+}
+
+// selfTestCloudFetch downloads a result chunk from cloud storage, which
+// fails if the storage account isn't reachable from this network.
+func (c *connectionImpl) selfTestCloudFetch(ctx context.Context) (string, error) {
+	if c.statementAPI == nil {
+		return "", errSelfTestSkipped{fmt.Sprintf("requires a SQL warehouse configured with %s and %s", OptionServerHostname, OptionHTTPPath)}
+	}
+	resp, err := c.statementAPI.execute(ctx, "SELECT 1 AS one", c.catalog, c.dbSchema)
+	if err != nil {
+		return "", err
+	}
+	if resp.Manifest == nil || resp.Manifest.TotalChunkCount == 0 {
+		return "", fmt.Errorf("statement %s returned no result chunks", resp.StatementID)
+	}
+	rdr, err := c.readChunk(ctx, c.Alloc, resp.StatementID, 0)
+	if err != nil {
+		return "", err
+	}
+	defer rdr.Release()
+	for rdr.Next() {
+	}
+	if err := rdr.Err(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("downloaded %d bytes from cloud storage", rdr.batchStats().bytes), nil
+}
+
+// selfTestWrite creates, fills and drops a table in OptionSelfTestSchema.
+func (c *connectionImpl) selfTestWrite(ctx context.Context) (string, error) {
+	if c.selfTestSchema == "" {
+		return "", errSelfTestSkipped{"set " + OptionSelfTestSchema + " to probe write permission"}
+	}
+	// Validated by SetOption
+	parts, _ := splitQualifiedName(c.selfTestSchema)
+	table := qualifiedTableName(parts[0], parts[1], "adbc_selftest_"+strings.ToLower(rand.Text()))
+
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE "+table+" (id INT)"); err != nil {
+		return "", err
+	}
+	_, err = conn.ExecContext(ctx, "INSERT INTO "+table+" VALUES (1)")
+	if _, dropErr := conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+table); dropErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to drop %s: %w", table, dropErr))
+	}
+	if err != nil {
+		return "", err
+	}
+	return "created, wrote to and dropped a table in " + c.selfTestSchema, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTestWithoutSession(t *testing.T) {
+	c := &connectionImpl{}
+	out, err := c.GetOption(OptionSelfTest)
+	require.NoError(t, err)
+
+	var report selfTestReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.False(t, report.Passed)
+	require.Len(t, report.Checks, 6)
+	assert.Equal(t, "auth", report.Checks[0].Name)
+	assert.Equal(t, selfTestFailed, report.Checks[0].Status)
+	assert.Contains(t, report.Checks[0].Detail, "connection is closed")
+	for _, check := range report.Checks[1:] {
+		assert.Equal(t, selfTestSkipped, check.Status, check.Name)
+		assert.Equal(t, "no session", check.Detail, check.Name)
+	}
+}
+
+func TestSelfTestSkippedChecks(t *testing.T) {
+	c := &connectionImpl{}
+	_, err := c.selfTestCloudFetch(t.Context())
+	assert.ErrorAs(t, err, &errSelfTestSkipped{})
+	_, err = c.selfTestWrite(t.Context())
+	var skipped errSelfTestSkipped
+	require.ErrorAs(t, err, &skipped)
+	assert.Contains(t, skipped.reason, OptionSelfTestSchema)
+}
+
+func TestSelfTestSchemaOption(t *testing.T) {
+	c := &connectionImpl{}
+	require.NoError(t, c.SetOption(OptionSelfTestSchema, "main.scratch"))
+	got, err := c.GetOption(OptionSelfTestSchema)
+	require.NoError(t, err)
+	assert.Equal(t, "main.scratch", got)
+
+	err = c.SetOption(OptionSelfTestSchema, "scratch")
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+	got, err = c.GetOption(OptionSelfTestSchema)
+	require.NoError(t, err)
+	assert.Equal(t, "main.scratch", got)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// sessionConfNamePattern matches the names of session configuration
+// parameters, such as ansi_mode or spark.sql.shuffle.partitions.
+var sessionConfNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// parseSessionConf returns the name of the session configuration parameter
+// an OptionSessionConfPrefix key sets. ok is false for other keys.
+func parseSessionConf(key, value string) (name string, ok bool, err error) {
+	name, ok = strings.CutPrefix(key, OptionSessionConfPrefix)
+	if !ok {
+		return "", false, nil
+	}
+	if !sessionConfNamePattern.MatchString(name) {
+		return "", true, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("invalid option %s: '%s' is not a session configuration parameter name", key, name),
+		}
+	}
+	if strings.ContainsAny(value, ";\r\n") {
+		return "", true, invalidOption(key, value, "a value without semicolons or line breaks")
+	}
+	return name, true, nil
+}
+
+// sessionConfStatement returns the statement that sets the session
+// configuration parameter name to value, or restores its default for an
+// empty value.
+func sessionConfStatement(name, value string) string {
+	if value == "" {
+		return "RESET " + name
+	}
+	return fmt.Sprintf("SET %s = %s", name, strings.TrimSpace(value))
+}
+
+// applySessionConfs sets confs on a newly opened session, in name order.
+func applySessionConfs(ctx context.Context, conn *sql.Conn, confs map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(confs)) {
+		if _, err := conn.ExecContext(ctx, sessionConfStatement(name, confs[name])); err != nil {
+			return adbc.Error{
+				Code: adbc.StatusInvalidArgument,
+				Msg:  fmt.Sprintf("failed to set session configuration %s: %v", name, err),
+			}
+		}
+	}
+	return nil
+}
+
+// setSessionConf makes value the session configuration parameter name of
+// the connection, setting it on its session if one is open. An empty
+// value drops the parameter. If the session rejects the value, the
+// connection keeps the previous one.
+func (c *connectionImpl) setSessionConf(name, value string) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	previous, had := c.sessionConfs[name]
+	if value == "" {
+		delete(c.sessionConfs, name)
+		if !had {
+			return nil
+		}
+	} else {
+		if c.sessionConfs == nil {
+			c.sessionConfs = map[string]string{}
+		}
+		c.sessionConfs[name] = value
+	}
+	if c.conn == nil {
+		return nil
+	}
+
+	if _, err := c.conn.ExecContext(context.Background(), sessionConfStatement(name, value)); err != nil {
+		if had {
+			c.sessionConfs[name] = previous
+		} else {
+			delete(c.sessionConfs, name)
+		}
+		return adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("failed to set session configuration %s: %v", name, err),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSessionConf(t *testing.T) {
+	name, ok, err := parseSessionConf(OptionSessionConfPrefix+"spark.sql.shuffle.partitions", "200")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "spark.sql.shuffle.partitions", name)
+
+	_, ok, err = parseSessionConf(OptionSessionTimezone, "UTC")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = parseSessionConf(OptionSessionConfPrefix+"ansi mode", "true")
+	assert.Error(t, err)
+	_, _, err = parseSessionConf(OptionSessionConfPrefix+"ansi_mode", "true; DROP TABLE t")
+	assert.Error(t, err)
+
+	assert.Equal(t, "SET ansi_mode = true", sessionConfStatement("ansi_mode", " true"))
+	assert.Equal(t, "RESET ansi_mode", sessionConfStatement("ansi_mode", ""))
+}
+
+func TestSessionConfOptions(t *testing.T) {
+	d := &databaseImpl{}
+	require.NoError(t, d.SetOption(OptionSessionConfPrefix+"ansi_mode", "true"))
+	value, err := d.GetOption(OptionSessionConfPrefix + "ansi_mode")
+	require.NoError(t, err)
+	assert.Equal(t, "true", value)
+	require.NoError(t, d.SetOption(OptionSessionConfPrefix+"ansi_mode", ""))
+	assert.Empty(t, d.sessionConfs)
+}
+
+func TestSessionConfsReapplied(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db, sessionConfs: map[string]string{"timezone": "UTC", "ansi_mode": "true"}}
+
+	// Set when the session is opened
+	_, err := cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SET ansi_mode = true", "SET timezone = UTC"}, connector.execs)
+
+	// Set on the open session
+	require.NoError(t, cnxn.SetOption(OptionSessionConfPrefix+"max_file_partition_bytes", "64m"))
+	require.NoError(t, cnxn.SetOption(OptionSessionConfPrefix+"ansi_mode", ""))
+	assert.Equal(t, []string{"SET max_file_partition_bytes = 64m", "RESET ansi_mode"}, connector.execs[2:])
+	value, err := cnxn.GetOption(OptionSessionConfPrefix + "max_file_partition_bytes")
+	require.NoError(t, err)
+	assert.Equal(t, "64m", value)
+
+	// Set again on a new session
+	cnxn.connMu.Lock()
+	discardSession(cnxn.conn)
+	cnxn.conn = nil
+	cnxn.connMu.Unlock()
+	connector.execs = nil
+	_, err = cnxn.sqlConn(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SET max_file_partition_bytes = 64m", "SET timezone = UTC"}, connector.execs)
+	require.NoError(t, cnxn.Close())
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// snapshot pins the Delta version of each table a connection's queries
+// read, from the first query that reads it, so that later queries read
+// the same version. See OptionReadSnapshot.
+type snapshot struct {
+	mu sync.Mutex
+	// Pinned versions by quoted, fully qualified table name; -1 for
+	// tables without a Delta version (views, non-Delta tables)
+	versions map[string]int64
+}
+
+func newSnapshot() *snapshot {
+	return &snapshot{versions: map[string]int64{}}
+}
+
+// tableReference is a table read by a query: a name following FROM or
+// JOIN, at query[start:end].
+type tableReference struct {
+	start, end int
+	parts      []string
+}
+
+// readsSnapshot reports whether query only reads tables, so that its
+// table references may be pinned to a version.
+func readsSnapshot(query string) bool {
+	switch leadingKeyword(query) {
+	case "SELECT", "WITH", "FROM":
+		return true
+	}
+	return false
+}
+
+// tableReferences finds the tables read by query, skipping literals,
+// comments, subqueries, table-valued functions, the names of common table
+// expressions and references that already travel in time. The FROM of
+// EXTRACT, TRIM and similar functions doesn't introduce a table.
+func tableReferences(query string) []tableReference {
+	var refs []tableReference
+	ctes := map[string]bool{}
+	// Whether each open parenthesis is the argument list of a function
+	// whose arguments may contain FROM
+	var calls []bool
+	prevWord := ""
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			i = skipQuoted(query, i)
+			prevWord = ""
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return refs
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return refs
+			}
+			i += end + 4
+		case c == '(':
+			switch strings.ToUpper(prevWord) {
+			case "EXTRACT", "TRIM", "SUBSTRING", "SUBSTR", "POSITION", "OVERLAY":
+				calls = append(calls, true)
+			default:
+				calls = append(calls, false)
+			}
+			prevWord = ""
+			i++
+		case c == ')':
+			if len(calls) > 0 {
+				calls = calls[:len(calls)-1]
+			}
+			prevWord = ""
+			i++
+		case c == '`' || isIdentStart(rune(c)):
+			start := i
+			parts, end := readQualifiedName(query, i)
+			i = end
+			word := ""
+			if c != '`' && len(parts) == 1 {
+				word = strings.ToUpper(parts[0])
+			}
+			if len(parts) == 1 && nextWordsAre(query[end:], "AS", "(") {
+				ctes[strings.ToLower(parts[0])] = true
+			}
+			inCall := len(calls) > 0 && calls[len(calls)-1]
+			if word == "JOIN" || (word == "FROM" && !inCall && !strings.EqualFold(prevWord, "DISTINCT")) {
+				// FROM may list several tables, separated by commas
+				for pos := end; ; {
+					ref, ok := tableReferenceAt(query, pos)
+					if !ok {
+						break
+					}
+					if len(ref.parts) > 1 || !ctes[strings.ToLower(ref.parts[0])] {
+						refs = append(refs, ref)
+					}
+					i = ref.end
+					if word == "JOIN" {
+						break
+					}
+					rest := skipAlias(query[ref.end:])
+					if !strings.HasPrefix(rest, ",") {
+						break
+					}
+					pos = len(query) - len(rest) + 1
+				}
+			}
+			prevWord = query[start:end]
+		default:
+			if !unicode.IsSpace(rune(c)) {
+				prevWord = ""
+			}
+			i++
+		}
+	}
+	return refs
+}
+
+// tableReferenceAt reads the table name following the FROM, JOIN or comma
+// that ends at query[pos]. It reports false if a subquery, a table-valued
+// function or an already time-travelling reference follows instead.
+func tableReferenceAt(query string, pos int) (tableReference, bool) {
+	start := pos + len(query[pos:]) - len(strings.TrimLeftFunc(query[pos:], unicode.IsSpace))
+	if start >= len(query) || (query[start] != '`' && !isIdentStart(rune(query[start]))) {
+		return tableReference{}, false
+	}
+	parts, end := readQualifiedName(query, start)
+	if len(parts) == 0 || len(parts) > 3 {
+		return tableReference{}, false
+	}
+	rest := strings.TrimLeftFunc(query[end:], unicode.IsSpace)
+	if strings.HasPrefix(rest, "(") || strings.HasPrefix(rest, "@") || nextWordsAre(rest, "VERSION") || nextWordsAre(rest, "TIMESTAMP") {
+		return tableReference{}, false
+	}
+	if len(parts) == 1 && query[start] != '`' {
+		// A keyword such as LATERAL or VALUES rather than a table
+		switch strings.ToUpper(parts[0]) {
+		case "LATERAL", "VALUES", "UNNEST", "STREAM", "IDENTIFIER", "READ_FILES":
+			return tableReference{}, false
+		}
+	}
+	return tableReference{start: start, end: end, parts: parts}, true
+}
+
+// skipAlias returns s, which follows a table reference, after white space
+// and the reference's alias, if it has one.
+func skipAlias(s string) string {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	if nextWordsAre(s, "AS") {
+		s = strings.TrimLeftFunc(s[2:], unicode.IsSpace)
+	} else if len(s) > 0 && isIdentStart(rune(s[0])) {
+		if parts, _ := readQualifiedName(s, 0); clauseKeywords[strings.ToUpper(parts[0])] {
+			return s
+		}
+	}
+	if len(s) > 0 && (s[0] == '`' || isIdentStart(rune(s[0]))) {
+		_, end := readQualifiedName(s, 0)
+		s = strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+	}
+	return s
+}
+
+// clauseKeywords are the words that may follow a table reference in
+// place of an alias.
+var clauseKeywords = map[string]bool{
+	"WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+	"JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "CROSS": true,
+	"NATURAL": true, "SEMI": true, "ANTI": true, "ON": true, "USING": true, "LATERAL": true,
+	"UNION": true, "INTERSECT": true, "EXCEPT": true, "MINUS": true, "WINDOW": true,
+	"QUALIFY": true, "PIVOT": true, "UNPIVOT": true, "TABLESAMPLE": true, "CLUSTER": true,
+	"DISTRIBUTE": true, "SORT": true,
+}
+
+// readQualifiedName reads a name of dot-separated parts, each a word or a
+// backquoted identifier, starting at query[start].
+func readQualifiedName(query string, start int) (parts []string, end int) {
+	i := start
+	for i < len(query) {
+		var part string
+		if query[i] == '`' {
+			close := skipQuoted(query, i)
+			part = strings.ReplaceAll(query[i+1:max(close-1, i+1)], "``", "`")
+			i = close
+		} else if isIdentStart(rune(query[i])) {
+			j := i
+			for j < len(query) && isIdentPart(rune(query[j])) {
+				j++
+			}
+			part = query[i:j]
+			i = j
+		} else {
+			break
+		}
+		parts = append(parts, part)
+		if i >= len(query) || query[i] != '.' {
+			break
+		}
+		i++
+	}
+	return parts, i
+}
+
+// nextWordsAre reports whether s continues, after white space, with the
+// given words (or punctuation), ignoring case.
+func nextWordsAre(s string, words ...string) bool {
+	for _, word := range words {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if len(s) < len(word) || !strings.EqualFold(s[:len(word)], word) {
+			return false
+		}
+		s = s[len(word):]
+		if isIdentStart(rune(word[0])) && len(s) > 0 && isIdentPart(rune(s[0])) {
+			return false
+		}
+	}
+	return true
+}
+
+// pinSnapshot rewrites the tables read by query to the versions pinned in
+// the connection's snapshot, pinning the current version of tables read
+// for the first time. Queries that write are returned as they are.
+func (c *connectionImpl) pinSnapshot(ctx context.Context, query string) (string, error) {
+	if !readsSnapshot(query) {
+		return query, nil
+	}
+	refs := tableReferences(query)
+	if len(refs) == 0 {
+		return query, nil
+	}
+	c.snapshot.mu.Lock()
+	defer c.snapshot.mu.Unlock()
+
+	var b strings.Builder
+	last := 0
+	for _, ref := range refs {
+		name, err := c.qualifyReference(ref.parts)
+		if err != nil {
+			return "", err
+		}
+		version, ok := c.snapshot.versions[name]
+		if !ok {
+			// Tables without Delta history, e.g. views, can't be pinned
+			// and are read as they are. Other failures aren't cached, so
+			// that the table is pinned by a later query.
+			if version, err = c.tableVersion(ctx, name); errors.Is(err, errNoTableHistory) {
+				version = -1
+			} else if err != nil {
+				return "", fmt.Errorf("failed to pin the version of %s: %w", name, err)
+			}
+			c.snapshot.versions[name] = version
+			c.Logger.DebugContext(ctx, "pinned table version", "table", name, "version", version)
+		}
+		if version < 0 {
+			continue
+		}
+		b.WriteString(query[last:ref.end])
+		b.WriteString(" VERSION AS OF ")
+		b.WriteString(strconv.FormatInt(version, 10))
+		last = ref.end
+	}
+	b.WriteString(query[last:])
+	return b.String(), nil
+}
+
+// qualifyReference quotes a table name, taking missing parts from the
+// current catalog and schema.
+func (c *connectionImpl) qualifyReference(parts []string) (string, error) {
+	var catalog, dbSchema *string
+	switch len(parts) {
+	case 3:
+		catalog, dbSchema = &parts[0], &parts[1]
+	case 2:
+		dbSchema = &parts[0]
+	}
+	return c.qualifyTableName(catalog, dbSchema, parts[len(parts)-1])
+}
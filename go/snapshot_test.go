@@ -0,0 +1,113 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableReferences(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected [][]string
+	}{
+		{"SELECT 1", nil},
+		{"SELECT * FROM orders", [][]string{{"orders"}}},
+		{"select o.id from main.sales.orders o join `my cat`.sales.`line``items` AS l on o.id = l.order_id",
+			[][]string{{"main", "sales", "orders"}, {"my cat", "sales", "line`items"}}},
+		{"SELECT * FROM a, b x, c AS y WHERE a.id = b.id", [][]string{{"a"}, {"b"}, {"c"}}},
+		{"SELECT * FROM (SELECT id FROM inner_t) s LEFT JOIN other USING (id)", [][]string{{"inner_t"}, {"other"}}},
+		{"WITH recent AS (SELECT * FROM orders) SELECT * FROM recent JOIN customers ON true",
+			[][]string{{"orders"}, {"customers"}}},
+		// Functions, time travel and literals are left alone
+		{"SELECT extract(YEAR FROM ts), trim(BOTH 'x' FROM s), a IS DISTINCT FROM b FROM t",
+			[][]string{{"t"}}},
+		{"SELECT * FROM range(10) JOIN read_files('/x') JOIN t VERSION AS OF 3 JOIN u@v2", nil},
+		{"SELECT 'FROM x', \"FROM y\" -- FROM z\n/* JOIN w */ FROM t", [][]string{{"t"}}},
+		{"SELECT * FROM LATERAL (SELECT 1) JOIN VALUES (1)", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			var parts [][]string
+			for _, ref := range tableReferences(tc.query) {
+				parts = append(parts, ref.parts)
+			}
+			assert.Equal(t, tc.expected, parts)
+		})
+	}
+}
+
+func TestPinSnapshot(t *testing.T) {
+	connector := &namespaceConnector{versions: map[string]int64{
+		"`main`.`sales`.`orders`":    7,
+		"`main`.`sales`.`customers`": 3,
+	}}
+	db := sql.OpenDB(connector)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	cnxn := &connectionImpl{db: db, namespaceCache: true}
+	cnxn.Logger = slog.New(slog.DiscardHandler)
+	defer func() { require.NoError(t, cnxn.Close()) }()
+	require.NoError(t, cnxn.SetOption(OptionReadSnapshot, "true"))
+	val, err := cnxn.GetOption(OptionReadSnapshot)
+	require.NoError(t, err)
+	assert.Equal(t, "true", val)
+
+	ctx := context.Background()
+	query, err := cnxn.pinSnapshot(ctx, "SELECT * FROM orders o JOIN main.sales.customers c ON o.cid = c.id JOIN my_view")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM orders VERSION AS OF 7 o JOIN main.sales.customers VERSION AS OF 3 c ON o.cid = c.id JOIN my_view", query)
+
+	// Later queries read the pinned versions without asking again
+	queries := connector.queryCount()
+	connector.versions["`main`.`sales`.`orders`"] = 8
+	query, err = cnxn.pinSnapshot(ctx, "SELECT count(*) FROM sales.orders")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT count(*) FROM sales.orders VERSION AS OF 7", query)
+	assert.Equal(t, queries, connector.queryCount())
+
+	// Writes are not rewritten
+	query, err = cnxn.pinSnapshot(ctx, "INSERT INTO archive SELECT * FROM orders")
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO archive SELECT * FROM orders", query)
+
+	// A new snapshot pins the versions current at its start
+	require.NoError(t, cnxn.SetOption(OptionReadSnapshot, "true"))
+	query, err = cnxn.pinSnapshot(ctx, "SELECT * FROM orders")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM orders VERSION AS OF 8", query)
+
+	// A table whose version can't be read is not pinned as unversioned:
+	// the query fails, and the next one pins it
+	errTimeout := errors.New("i/o timeout")
+	connector.failures = map[string]error{"`main`.`sales`.`returns`": errTimeout}
+	_, err = cnxn.pinSnapshot(ctx, "SELECT * FROM returns")
+	require.ErrorIs(t, err, errTimeout)
+	delete(connector.failures, "`main`.`sales`.`returns`")
+	connector.versions["`main`.`sales`.`returns`"] = 2
+	query, err = cnxn.pinSnapshot(ctx, "SELECT * FROM returns")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM returns VERSION AS OF 2", query)
+
+	require.NoError(t, cnxn.SetOption(OptionReadSnapshot, "false"))
+	assert.Nil(t, cnxn.snapshot)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// The fuzz targets below check that the SQL the driver builds around
+// user-supplied names and filters keeps its shape whatever they contain:
+// each value ends up in exactly one quoted identifier or string literal,
+// from which Databricks reads back the value itself. Without -fuzz they run
+// on their seeds only; for example
+//
+//	go test -run='^$' -fuzz=FuzzMetadataQueries -fuzztime=1m
+
+// sqlFuzzSeeds are inputs that have broken escaping in SQL builders.
+var sqlFuzzSeeds = []string{
+	"",
+	"plain",
+	"back`tick",
+	"``",
+	"it's",
+	`back\slash`,
+	`trailing\`,
+	`\'`,
+	`"double"`,
+	"new\nline",
+	"-- comment",
+	"/* comment */",
+	"a%b_c",
+	"ünïcødé 表 🦆",
+	"\x00nul",
+	"'; DROP TABLE t; --",
+	"`; DROP TABLE t; --",
+}
+
+// lexSQL splits query into its skeleton, with every quoted identifier
+// replaced by `?` and every string literal by '?', and the values those
+// held as Databricks reads them. Backslashes may only escape a backslash
+// or a quote, which is all the driver's literals use; anything else fails,
+// as does an unterminated quote.
+func lexSQL(query string) (skeleton string, values []string, err error) {
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; c {
+		case '`':
+			var value strings.Builder
+			for i++; ; i++ {
+				if i == len(query) {
+					return "", nil, fmt.Errorf("unterminated identifier in %q", query)
+				}
+				if query[i] == '`' {
+					if i+1 < len(query) && query[i+1] == '`' {
+						value.WriteByte('`')
+						i++
+						continue
+					}
+					break
+				}
+				value.WriteByte(query[i])
+			}
+			b.WriteString("`?`")
+			values = append(values, value.String())
+		case '\'':
+			var value strings.Builder
+			for i++; ; i++ {
+				if i == len(query) {
+					return "", nil, fmt.Errorf("unterminated string literal in %q", query)
+				}
+				if query[i] == '\\' {
+					if i+1 == len(query) || (query[i+1] != '\\' && query[i+1] != '\'') {
+						return "", nil, fmt.Errorf("unexpected escape in %q", query)
+					}
+					i++
+				} else if query[i] == '\'' {
+					break
+				}
+				value.WriteByte(query[i])
+			}
+			b.WriteString("'?'")
+			values = append(values, value.String())
+		case '"':
+			return "", nil, fmt.Errorf("unexpected double quote in %q", query)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), values, nil
+}
+
+// checkSQL fails t unless query has the given skeleton and values.
+func checkSQL(t *testing.T, query, skeleton string, values ...string) {
+	t.Helper()
+	gotSkeleton, gotValues, err := lexSQL(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSkeleton != skeleton {
+		t.Fatalf("%q has the shape %q, expected %q", query, gotSkeleton, skeleton)
+	}
+	if len(gotValues) != len(values) {
+		t.Fatalf("%q holds %d values, expected %d", query, len(gotValues), len(values))
+	}
+	for i := range values {
+		if gotValues[i] != values[i] {
+			t.Fatalf("%q holds %q, expected %q", query, gotValues[i], values[i])
+		}
+	}
+}
+
+func FuzzQuoteIdentifier(f *testing.F) {
+	for _, seed := range sqlFuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		checkSQL(t, quoteIdentifier(name), "`?`", name)
+	})
+}
+
+func FuzzStringLiteral(f *testing.F) {
+	for _, seed := range sqlFuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		checkSQL(t, stringLiteral(value), "'?'", value)
+	})
+}
+
+func FuzzUseStatements(f *testing.F) {
+	for _, seed := range sqlFuzzSeeds {
+		f.Add(seed, "schema")
+		f.Add("catalog", seed)
+	}
+	f.Fuzz(func(t *testing.T, catalog, schema string) {
+		checkSQL(t, useCatalogQuery(catalog), "USE CATALOG `?`", catalog)
+		checkSQL(t, useSchemaQuery("", schema), "USE SCHEMA `?`", schema)
+		if catalog != "" {
+			checkSQL(t, useSchemaQuery(catalog, schema), "USE SCHEMA `?`.`?`", catalog, schema)
+		}
+	})
+}
+
+func FuzzMetadataQueries(f *testing.F) {
+	for _, seed := range sqlFuzzSeeds {
+		f.Add(seed, "schema", "table", "column", false)
+		f.Add("main", seed, seed, seed, true)
+	}
+	f.Add("hive_metastore", "default", "it's", "a%", false)
+	f.Fuzz(func(t *testing.T, catalog, schema, table, column string, literal bool) {
+		c := &connectionImpl{metadataFilterMatch: OptionValueFilterMatchPattern}
+		if literal {
+			c.metadataFilterMatch = OptionValueFilterMatchLiteral
+		}
+
+		// The same query for harmless names has the expected shape
+		harmless := "main"
+		if c.useSystemInformationSchema(catalog) {
+			harmless = "system"
+		}
+		filter := "f"
+		skeleton, _, err := lexSQL(c.tablesWithColumnsQuery(harmless, "s", &filter, &filter))
+		if err != nil {
+			t.Fatal(err)
+		}
+		escape := string(likeEscape)
+		checkSQL(t, c.tablesWithColumnsQuery(catalog, schema, &table, &column), skeleton,
+			catalog, schema, c.escapeLike(table), escape, c.escapeLike(column), escape)
+
+		// A literal filter matches the name it spells out
+		if literal && !likeRegexp(c.escapeLike(table)).MatchString(table) {
+			t.Fatalf("literal filter %q doesn't match itself", table)
+		}
+	})
+}
@@ -0,0 +1,398 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// dbfsBlockSize is the most data the DBFS API accepts in one add-block
+// call.
+const dbfsBlockSize = 1 << 20
+
+// stagingLocation is a parsed OptionIngestStagingLocation.
+type stagingLocation struct {
+	// Whether the directory is on DBFS rather than in a volume
+	dbfs bool
+	// Absolute path of the directory, without a trailing slash or the
+	// dbfs: scheme
+	dir string
+}
+
+// parseStagingLocation parses the value of OptionIngestStagingLocation.
+func parseStagingLocation(key, value string) (stagingLocation, error) {
+	if path, ok := strings.CutPrefix(value, "dbfs:"); ok && strings.HasPrefix(path, "/") {
+		return stagingLocation{dbfs: true, dir: strings.TrimRight(path, "/")}, nil
+	}
+	// A volume directory is at least /Volumes/<catalog>/<schema>/<volume>
+	parts := strings.Split(strings.Trim(value, "/"), "/")
+	if !strings.HasPrefix(value, "/Volumes/") || len(parts) < 4 || slices.Contains(parts, "") {
+		return stagingLocation{}, invalidOption(key, value, "a /Volumes/<catalog>/<schema>/<volume> or dbfs:/ directory")
+	}
+	return stagingLocation{dir: "/" + strings.Join(parts, "/")}, nil
+}
+
+// file returns the path of the named file in the directory.
+func (l stagingLocation) file(name string) string {
+	return l.dir + "/" + name
+}
+
+// uri returns how COPY INTO refers to the file at path.
+func (l stagingLocation) uri(path string) string {
+	if l.dbfs {
+		return "dbfs:" + path
+	}
+	return path
+}
+
+// upload writes body to the file at path, replacing any file there.
+func (l stagingLocation) upload(ctx context.Context, api *statementAPI, path string, body io.Reader) error {
+	if !l.dbfs {
+		return api.send(ctx, http.MethodPut, "/api/2.0/fs/files"+escapePath(path)+"?overwrite=true",
+			"application/octet-stream", body, nil)
+	}
+
+	var created struct {
+		Handle int64 `json:"handle"`
+	}
+	if err := api.do(ctx, http.MethodPost, "/api/2.0/dbfs/create", map[string]any{"path": path, "overwrite": true}, &created); err != nil {
+		return err
+	}
+	closeHandle := func() error {
+		return api.do(ctx, http.MethodPost, "/api/2.0/dbfs/close", map[string]any{"handle": created.Handle}, nil)
+	}
+	block := make([]byte, dbfsBlockSize)
+	for {
+		n, err := io.ReadFull(body, block)
+		if n > 0 {
+			data := base64.StdEncoding.EncodeToString(block[:n])
+			if err := api.do(ctx, http.MethodPost, "/api/2.0/dbfs/add-block", map[string]any{"handle": created.Handle, "data": data}, nil); err != nil {
+				return errors.Join(err, closeHandle())
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return closeHandle()
+		}
+		if err != nil {
+			return errors.Join(adbc.Error{Code: adbc.StatusIO, Msg: "failed to read staged data: " + err.Error()}, closeHandle())
+		}
+	}
+}
+
+// remove deletes the file at path.
+func (l stagingLocation) remove(ctx context.Context, api *statementAPI, path string) error {
+	if l.dbfs {
+		return api.do(ctx, http.MethodPost, "/api/2.0/dbfs/delete", map[string]any{"path": path}, nil)
+	}
+	return api.send(ctx, http.MethodDelete, "/api/2.0/fs/files"+escapePath(path), "", nil, nil)
+}
+
+// removeDir deletes the directory dir holding files, the paths of the files
+// in it. A volume directory must be emptied first; on DBFS the directory
+// is deleted with whatever it holds.
+func (l stagingLocation) removeDir(ctx context.Context, api *statementAPI, dir string, files []string) error {
+	if l.dbfs {
+		return api.do(ctx, http.MethodPost, "/api/2.0/dbfs/delete", map[string]any{"path": dir, "recursive": true}, nil)
+	}
+	var errs []error
+	for _, path := range files {
+		errs = append(errs, l.remove(ctx, api, path))
+	}
+	errs = append(errs, api.send(ctx, http.MethodDelete, "/api/2.0/fs/directories"+escapePath(dir), "", nil, nil))
+	return errors.Join(errs...)
+}
+
+// escapePath escapes each segment of a slash-separated path for use in a
+// URL.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// copyIntoQuery returns the COPY INTO loading the Parquet files at source,
+// a file or a directory, into table. Loading is forced, since COPY INTO otherwise skips files it
+// has loaded before.
+func copyIntoQuery(table, source string) string {
+	return "COPY INTO " + table + " FROM " + stringLiteral(source) +
+		" FILEFORMAT = PARQUET COPY_OPTIONS ('force' = 'true')"
+}
+
+// newParquetWriter returns a writer of batches with schema to w as a
+// Parquet file. Timestamps are stored in microseconds, the finest unit
+// Databricks reads.
+func newParquetWriter(w io.Writer, schema *arrow.Schema) (*pqarrow.FileWriter, error) {
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	arrowProps := pqarrow.NewArrowWriterProperties(
+		pqarrow.WithCoerceTimestamps(arrow.Microsecond),
+		pqarrow.WithTruncatedTimestamps(true),
+	)
+	return pqarrow.NewFileWriter(schema, w, props, arrowProps)
+}
+
+// stagedUploads uploads the files of a staged ingest in the background, up
+// to a number at once, while the files after them are written.
+type stagedUploads struct {
+	// Cancelled when an upload fails, which stops the others
+	ctx    context.Context
+	cancel context.CancelFunc
+	api    *statementAPI
+	dest   stagingLocation
+	// One entry for each upload running
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu sync.Mutex
+	// Paths of the files whose upload started, and the first upload error
+	paths []string
+	err   error
+}
+
+func newStagedUploads(ctx context.Context, api *statementAPI, dest stagingLocation, maxParallel int) *stagedUploads {
+	ctx, cancel := context.WithCancel(ctx)
+	return &stagedUploads{ctx: ctx, cancel: cancel, api: api, dest: dest, slots: make(chan struct{}, maxParallel)}
+}
+
+// start uploads file to path once fewer than the maximum of uploads are
+// running, taking ownership of file, and calls done, if not nil, once the
+// upload has succeeded. It fails, without starting, once an upload has
+// failed.
+func (u *stagedUploads) start(file *tempFile, path string, done func()) error {
+	acquired := false
+	select {
+	case u.slots <- struct{}{}:
+		acquired = true
+	case <-u.ctx.Done():
+	}
+	if u.ctx.Err() != nil {
+		if acquired {
+			<-u.slots
+		}
+		_ = file.Close()
+		return u.wait()
+	}
+	u.mu.Lock()
+	u.paths = append(u.paths, path)
+	u.mu.Unlock()
+
+	u.wg.Add(1)
+	u.api.goroutines.Go(func(closing context.Context) {
+		defer u.wg.Done()
+		defer context.AfterFunc(closing, u.cancel)()
+		defer func() {
+			_ = file.Close()
+			<-u.slots
+		}()
+		// A section reader, unlike the file, gives the upload its length
+		// and can't be closed by it
+		if err := u.dest.upload(u.ctx, u.api, path, io.NewSectionReader(file, 0, file.size)); err != nil {
+			u.mu.Lock()
+			if u.err == nil {
+				u.err = err
+			}
+			u.mu.Unlock()
+			u.cancel()
+		} else if done != nil {
+			done()
+		}
+	})
+	return nil
+}
+
+// wait waits for the uploads started and returns the first error of any.
+func (u *stagedUploads) wait() error {
+	u.wg.Wait()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.err == nil {
+		// The context itself may have been cancelled
+		u.err = u.ctx.Err()
+	}
+	return u.err
+}
+
+// uploaded returns the paths of the files whose upload started.
+func (u *stagedUploads) uploaded() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return slices.Clone(u.paths)
+}
+
+// stageBoundData writes the bound data to temporary Parquet files of about
+// OptionIngestUploadFileSize bytes and uploads them to a new directory in
+// OptionIngestStagingLocation, each file as soon as it is written and up
+// to OptionIngestUploadMaxParallel at once. It returns the URI by which
+// queries read the directory and a function deleting it again.
+func (s *statementImpl) stageBoundData(ctx context.Context) (string, func(), error) {
+	location, err := parseStagingLocation(OptionIngestStagingLocation, s.ingestStaging)
+	if err != nil {
+		return "", nil, err
+	}
+	api := s.conn.workspaceAPI
+	if api == nil || s.conn.tempStore == nil {
+		return "", nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "%s requires %s", OptionIngestStagingLocation, OptionServerHostname)
+	}
+
+	dir := location.file("adbc_ingest_" + strings.ToLower(rand.Text()))
+	uploads := newStagedUploads(ctx, api, location, s.ingestUploadParallel)
+	remove := func() {
+		files := uploads.uploaded()
+		if len(files) == 0 {
+			return
+		}
+		removeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := location.removeDir(removeCtx, api, dir, files); err != nil {
+			s.conn.Logger.WarnContext(ctx, "failed to delete staged ingest data",
+				slog.String("path", dir), slog.Any("error", err))
+		}
+	}
+
+	err = s.writeStagedFiles(ctx, dir, func(file *tempFile, path string, _ []byte) error {
+		return uploads.start(file, path, nil)
+	})
+	if err != nil {
+		uploads.cancel()
+	}
+	if uploadErr := uploads.wait(); err == nil {
+		err = uploadErr
+	}
+	uploads.cancel()
+	if err != nil {
+		remove()
+		return "", nil, err
+	}
+	return location.uri(dir), remove, nil
+}
+
+// writeStagedFiles writes the bound data to Parquet files named
+// part-<n>.parquet in dir, handing each to staged, along with its SHA-256
+// sum, once it reaches s.ingestFileSize bytes. staged takes ownership of
+// the file. Without bound rows it still writes a file, which carries the
+// schema. The same data and file size always give the same files.
+func (s *statementImpl) writeStagedFiles(ctx context.Context, dir string, staged func(file *tempFile, path string, sum []byte) error) error {
+	var file *tempFile
+	var fw *pqarrow.FileWriter
+	var sum hash.Hash
+	defer func() {
+		if file != nil {
+			_ = fw.Close()
+			_ = file.Close()
+		}
+	}()
+
+	part := 0
+	open := func() (err error) {
+		if file, err = s.conn.tempStore.create("ingest"); err != nil {
+			return err
+		}
+		// The Parquet writer closes its sink if it can, but the file must
+		// stay open for the upload; a multi-writer, which also sums the
+		// data, can't be closed
+		sum = sha256.New()
+		if fw, err = newParquetWriter(io.MultiWriter(file, sum), s.boundStream.Schema()); err != nil {
+			_ = file.Close()
+			file = nil
+			return s.ErrorHelper.Errorf(adbc.StatusIO, "failed to write data to stage: %v", err)
+		}
+		return nil
+	}
+	finish := func() error {
+		written := file
+		file = nil
+		if err := fw.Close(); err != nil {
+			_ = written.Close()
+			return s.ErrorHelper.Errorf(adbc.StatusIO, "failed to write data to stage: %v", err)
+		}
+		path := fmt.Sprintf("%s/part-%05d.parquet", dir, part)
+		part++
+		s.logExecution(ctx, "staging ingest data", "path", path, "bytes", written.size)
+		return staged(written, path, sum.Sum(nil))
+	}
+
+	for s.boundStream.Next() {
+		if file == nil {
+			if err := open(); err != nil {
+				return err
+			}
+		}
+		if err := fw.Write(s.boundStream.RecordBatch()); err != nil {
+			return s.ErrorHelper.Errorf(adbc.StatusIO, "failed to write data to stage: %v", err)
+		}
+		// The size lags behind by what the writer buffers, which is at
+		// most the batch just written
+		if s.ingestFileSize > 0 && file.size >= s.ingestFileSize {
+			if err := finish(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := s.boundStream.Err(); err != nil {
+		return s.ErrorHelper.Errorf(adbc.StatusIO, "failed to read bound data: %v", err)
+	}
+	if file == nil && part == 0 {
+		if err := open(); err != nil {
+			return err
+		}
+	}
+	if file != nil {
+		return finish()
+	}
+	return nil
+}
+
+// copyIntoTable loads the bound data into table through
+// OptionIngestStagingLocation: it is staged, copied into the table and
+// deleted again. The counts COPY INTO reports become the statement's
+// update metrics.
+func (s *statementImpl) copyIntoTable(ctx context.Context, conn *sql.Conn, table string) (int64, error) {
+	uri, remove, err := s.stageBoundData(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer remove()
+
+	rows, err := conn.QueryContext(ctx, annotateQuery(ctx, copyIntoQuery(table, uri)))
+	if err != nil {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to copy staged data into %s: %v", table, err)
+	}
+	if s.updateMetrics, err = readUpdateMetrics(rows); err != nil {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to read update metrics: %v", err)
+	}
+	return metricsRowsAffected(s.updateMetrics), nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/databricks/databricks-sql-go/auth/pat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStagingLocation(t *testing.T) {
+	for value, want := range map[string]stagingLocation{
+		"/Volumes/main/default/staging":          {dir: "/Volumes/main/default/staging"},
+		"/Volumes/main/default/staging/adbc/":    {dir: "/Volumes/main/default/staging/adbc"},
+		"dbfs:/tmp/adbc/":                        {dbfs: true, dir: "/tmp/adbc"},
+		"dbfs:/":                                 {dbfs: true, dir: ""},
+		"/Volumes/main/default/staging/it's/dir": {dir: "/Volumes/main/default/staging/it's/dir"},
+	} {
+		got, err := parseStagingLocation(OptionIngestStagingLocation, value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+	for _, value := range []string{"/Volumes/main/default", "/Volumes//default/staging", "/tmp/staging", "dbfs:tmp", "s3://bucket/dir"} {
+		_, err := parseStagingLocation(OptionIngestStagingLocation, value)
+		assert.Error(t, err, value)
+	}
+
+	location := stagingLocation{dbfs: true, dir: "/tmp"}
+	assert.Equal(t, "dbfs:/tmp/data.parquet", location.uri(location.file("data.parquet")))
+	assert.Equal(t, "COPY INTO `main`.`default`.`t` FROM '/Volumes/main/default/it\\'s/data.parquet' FILEFORMAT = PARQUET COPY_OPTIONS ('force' = 'true')",
+		copyIntoQuery("`main`.`default`.`t`", "/Volumes/main/default/it's/data.parquet"))
+}
+
+func TestWriteParquet(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	var buf bytes.Buffer
+	fw, err := newParquetWriter(&buf, schema)
+	require.NoError(t, err)
+	for _, rows := range []string{`[{"id": 1, "name": "a"}, {"id": 2, "name": null}]`, `[{"id": 3, "name": "c"}]`} {
+		rec, _, err := array.RecordFromJSON(mem, schema, strings.NewReader(rows))
+		require.NoError(t, err)
+		require.NoError(t, fw.Write(rec))
+		rec.Release()
+	}
+	require.NoError(t, fw.Close())
+	table, err := pqarrow.ReadTable(t.Context(), bytes.NewReader(buf.Bytes()), nil, pqarrow.ArrowReadProperties{}, mem)
+	require.NoError(t, err)
+	defer table.Release()
+	assert.Equal(t, int64(3), table.NumRows())
+	assert.Equal(t, []string{"id", "name"}, []string{table.Schema().Field(0).Name, table.Schema().Field(1).Name})
+}
+
+func TestStagingUpload(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), dbfsBlockSize/4)
+
+	var files, dbfs bytes.Buffer
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/2.0/fs/files/Volumes/main/default/staging/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "put "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		assert.Equal(t, int64(len(data)), r.ContentLength)
+		_, _ = io.Copy(&files, r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /api/2.0/fs/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete "+r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /api/2.0/dbfs/{call}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Path      string `json:"path"`
+			Handle    int64  `json:"handle"`
+			Data      string `json:"data"`
+			Recursive bool   `json:"recursive"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		calls = append(calls, "dbfs "+r.PathValue("call")+" "+req.Path)
+		if req.Recursive {
+			calls[len(calls)-1] += " (recursive)"
+		}
+		switch r.PathValue("call") {
+		case "create":
+			_, _ = fmt.Fprint(w, `{"handle": 7}`)
+			return
+		case "add-block":
+			assert.Equal(t, int64(7), req.Handle)
+			block, err := base64.StdEncoding.DecodeString(req.Data)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(block), dbfsBlockSize)
+			dbfs.Write(block)
+		}
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api := &statementAPI{client: server.Client(), baseURL: server.URL, auth: &pat.PATAuth{AccessToken: "token"}}
+
+	volume := stagingLocation{dir: "/Volumes/main/default/staging"}
+	path := volume.file("a b.parquet")
+	require.NoError(t, volume.upload(t.Context(), api, path, io.NewSectionReader(bytes.NewReader(data), 0, int64(len(data)))))
+	require.NoError(t, volume.remove(t.Context(), api, path))
+	assert.Equal(t, data, files.Bytes())
+
+	onDBFS := stagingLocation{dbfs: true, dir: "/tmp"}
+	path = onDBFS.file("data.parquet")
+	require.NoError(t, onDBFS.upload(t.Context(), api, path, bytes.NewReader(data)))
+	require.NoError(t, onDBFS.remove(t.Context(), api, path))
+	assert.Equal(t, data, dbfs.Bytes())
+
+	require.NoError(t, volume.removeDir(t.Context(), api, volume.file("ingest"), []string{volume.file("ingest/part-00000.parquet")}))
+	require.NoError(t, onDBFS.removeDir(t.Context(), api, onDBFS.file("ingest"), nil))
+
+	assert.Equal(t, []string{
+		"put /api/2.0/fs/files/Volumes/main/default/staging/a%20b.parquet?overwrite=true",
+		"delete /api/2.0/fs/files/Volumes/main/default/staging/a%20b.parquet",
+		"dbfs create /tmp/data.parquet",
+		"dbfs add-block ",
+		"dbfs add-block ",
+		"dbfs add-block ",
+		"dbfs close ",
+		"dbfs delete /tmp/data.parquet",
+		"delete /api/2.0/fs/files/Volumes/main/default/staging/ingest/part-00000.parquet",
+		"delete /api/2.0/fs/directories/Volumes/main/default/staging/ingest",
+		"dbfs delete /tmp/ingest (recursive)",
+	}, calls)
+}
+
+func TestStagedUploads(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+	uploaded := map[string]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/2.0/fs/files/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		running--
+		uploaded[r.URL.Path] = string(body)
+		mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "fail.parquet") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api := &statementAPI{client: server.Client(), baseURL: server.URL, auth: &pat.PATAuth{AccessToken: "token"}, goroutines: checkGoroutines(t)}
+	store := newTempStore(t.TempDir(), 0, slog.Default())
+	defer func() {
+		_ = store.close()
+	}()
+	location := stagingLocation{dir: "/Volumes/main/default/staging"}
+
+	stage := func(uploads *stagedUploads, name string) error {
+		file, err := store.create("ingest")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(name))
+		require.NoError(t, err)
+		return uploads.start(file, location.file(name), nil)
+	}
+
+	uploads := newStagedUploads(t.Context(), api, location, 2)
+	var want []string
+	for i := range 6 {
+		name := fmt.Sprintf("part-%05d.parquet", i)
+		require.NoError(t, stage(uploads, name))
+		want = append(want, location.file(name))
+	}
+	require.NoError(t, uploads.wait())
+	assert.Equal(t, want, uploads.uploaded())
+	assert.Len(t, uploaded, 6)
+	assert.Equal(t, "part-00003.parquet", uploaded["/api/2.0/fs/files"+location.file("part-00003.parquet")])
+	assert.LessOrEqual(t, maxRunning, 2)
+	// Uploaded files are deleted locally
+	assert.Zero(t, store.Used())
+
+	uploads = newStagedUploads(t.Context(), api, location, 1)
+	require.NoError(t, stage(uploads, "fail.parquet"))
+	err := uploads.wait()
+	assert.Error(t, err)
+	assert.Error(t, stage(uploads, "after.parquet"))
+	assert.Zero(t, store.Used())
+}
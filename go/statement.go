@@ -27,6 +27,12 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/adbc-drivers/driverbase-go/driverbase"
 	"github.com/apache/arrow-adbc/go/adbc"
@@ -38,9 +44,111 @@ type statementImpl struct {
 	driverbase.StatementImplBase
 	conn              *connectionImpl
 	query             string
-	prepared          *sql.Stmt
+	prepared          *preparedStmt
 	boundStream       array.RecordReader
 	bulkIngestOptions driverbase.BulkIngestOptions
+
+	// Compile queries without running them
+	validateOnly bool
+	// Submit queries without waiting for them, and the ID of the query
+	// submitted last
+	async      bool
+	asyncQuery string
+	// How long the statement's queries may run
+	queryTimeout time.Duration
+	// Return query results as a single record batch, up to concatMaxBytes
+	concatResult   bool
+	concatMaxBytes int64
+	// When to return String and Binary columns as their large variants
+	largeTypes string
+	// How DECIMAL columns are returned, and what happens to those wider
+	// than the consumer accepts
+	decimals            string
+	decimalMaxPrecision int
+	decimalOverflow     string
+	// Allocator for results, and the live bytes at which fetching stops
+	alloc       *trackingAllocator
+	memoryLimit int64
+	// Bytes of batches decoded ahead of the caller
+	prefetchMaxBytes int64
+	// Share of the prefetched batches the reader must take before more are
+	// decoded; see OptionFetchPrefetchDrainPercent
+	prefetchDrainPercent int
+	// Size of results refused before they are fetched
+	rejectOverBytes int64
+	// Storage connections the statement's results are downloaded through
+	fetchLane string
+	// Sampling applied to the statement's queries
+	sampleFraction float64
+	sampleRows     int64
+	sampleSeed     string
+	// Table and time window of an extract, the column the window filters
+	// on, as set and as found, and the predicate added to the last query
+	extractTable        string
+	windowStart         *windowBound
+	windowEnd           *windowBound
+	extractWindowColumn string
+	extractColumn       *arrow.Field
+	extractPredicate    string
+	// Counts reported by the last COPY INTO or MERGE
+	updateMetrics map[string]int64
+	// Warnings of the last execution
+	warnings *statementWarnings
+	// Whether unreadable result chunks are skipped, and the rows skipped
+	// from the last result
+	resultMode  string
+	skippedRows *skippedRows
+	// What to do with result batches that don't match the result's schema
+	schemaDrift string
+	// Result paths in order of preference, and the one the last result
+	// was read through
+	protocols      []string
+	resultProtocol string
+	// Annotate result batches with their chunk, size and timings
+	batchMetadata bool
+	// Give SHOW, DESCRIBE and EXPLAIN results stable column names and types
+	normalizeCommands bool
+	// File receiving a manifest of each result's fetch
+	traceFile string
+	// Tags set on the session before the statement's queries run
+	queryTags []queryTag
+	// Directory bulk ingestion stages its data in for COPY INTO; empty to
+	// insert the rows instead
+	ingestStaging string
+	// Key columns of OptionValueIngestModeUpsert
+	ingestMergeKeys []string
+	// Size at which staged data continues in a new file, and how many
+	// files upload at once
+	ingestFileSize       int64
+	ingestUploadParallel int
+	// Local file recording the progress of a staged ingestion; empty for
+	// none
+	ingestManifest string
+	// Rules bound rows are checked against before ingestion, as set and
+	// parsed, how many rows they may reject, and the rows the last
+	// ingestion rejected
+	ingestRulesSpec   string
+	ingestRules       []validationRule
+	ingestMaxRejected int64
+	rejectedRows      *rejectedRows
+	// Micro-batch thresholds for ingest streams, and the open stream
+	ingestStreamMaxRows  int64
+	ingestStreamMaxBytes int64
+	ingestStreamInterval time.Duration
+	ingestStream         *ingestStream
+	// Renders bound values ahead of the driver's own rendering
+	paramSerializer ParameterSerializer
+	// Size beyond which IN lists of bound parameters are staged in
+	// temporary tables (zero for never), the prefix of the tables' names
+	// and the tables created so far
+	inListThreshold int
+	inListPrefix    string
+	inListTables    []string
+	// ID of the last query, which may be reported after it has returned
+	queryID *queryIDTracker
+	// Cancels the context of the latest execution, for Cancel
+	cancelMu        sync.Mutex
+	cancelExecution context.CancelCauseFunc
 }
 
 func (s *statementImpl) Close() error {
@@ -51,56 +159,447 @@ func (s *statementImpl) Close() error {
 		s.boundStream.Release()
 		s.boundStream = nil
 	}
+	if s.ingestStream != nil {
+		if err := s.ingestStream.Close(); err != nil {
+			return err
+		}
+	}
 	if s.prepared != nil {
-		if err := s.prepared.Close(); err != nil {
+		if err := s.prepared.release(); err != nil {
 			return err
 		}
 		s.prepared = nil
 	}
+	s.dropInLists()
 	s.conn = nil
 	return nil
 }
 
 func (s *statementImpl) SetOption(key, val string) error {
+	// driverbase only knows the standard ingest modes
+	if key == adbc.OptionKeyIngestMode && val == OptionValueIngestModeUpsert {
+		s.bulkIngestOptions.Mode = val
+		return nil
+	}
 	if handled, err := s.bulkIngestOptions.SetOption(&s.ErrorHelper, key, val); err != nil {
 		return err
 	} else if handled {
 		return nil
 	}
 
+	switch key {
+	case OptionValidateOnly:
+		validateOnly, err := parseBoolOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.validateOnly = validateOnly
+		return nil
+	case OptionExecutionAsync:
+		async, err := parseBoolOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.async = async
+		return nil
+	case OptionStatementQueryTimeout:
+		seconds, err := parseIntOption(key, val, 0, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		s.queryTimeout = time.Duration(seconds) * time.Second
+		return nil
+	case OptionFetchConcatResult:
+		concatResult, err := parseBoolOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.concatResult = concatResult
+		return nil
+	case OptionFetchConcatResultMaxBytes:
+		maxBytes, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.concatMaxBytes = int64(maxBytes)
+		return nil
+	case OptionFetchLargeTypes:
+		largeTypes, err := parseEnumOption(key, val,
+			OptionValueLargeTypesAuto, OptionValueLargeTypesAlways, OptionValueLargeTypesNever)
+		if err != nil {
+			return err
+		}
+		s.largeTypes = largeTypes
+		return nil
+	case OptionFetchDecimals:
+		decimals, err := parseEnumOption(key, val, OptionValueDecimalsString, OptionValueDecimalsNative)
+		if err != nil {
+			return err
+		}
+		s.decimals = decimals
+		return nil
+	case OptionFetchDecimalMaxPrecision:
+		precision, err := parseIntOption(key, val, 1, 38)
+		if err != nil {
+			return err
+		}
+		s.decimalMaxPrecision = precision
+		return nil
+	case OptionFetchDecimalOverflow:
+		overflow, err := parseEnumOption(key, val,
+			OptionValueDecimalOverflowError, OptionValueDecimalOverflowNull, OptionValueDecimalOverflowString)
+		if err != nil {
+			return err
+		}
+		s.decimalOverflow = overflow
+		return nil
+	case OptionFetchMemoryLimit:
+		limit, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.memoryLimit = int64(limit)
+		return nil
+	case OptionFetchPrefetchMaxBytes:
+		maxBytes, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.prefetchMaxBytes = int64(maxBytes)
+		return nil
+	case OptionFetchPrefetchDrainPercent:
+		percent, err := parseIntOption(key, val, 0, 100)
+		if err != nil {
+			return err
+		}
+		s.prefetchDrainPercent = percent
+		return nil
+	case OptionFetchRejectOverBytes:
+		limit, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.rejectOverBytes = int64(limit)
+		return nil
+	case OptionFetchLane:
+		lane, err := parseEnumOption(key, val,
+			OptionValueFetchLaneAuto, OptionValueFetchLaneInteractive, OptionValueFetchLaneBulk)
+		if err != nil {
+			return err
+		}
+		s.fetchLane = lane
+		return nil
+	case OptionSampleFraction:
+		fraction, err := parseSampleFraction(key, val)
+		if err != nil {
+			return err
+		}
+		s.sampleFraction = fraction
+		return nil
+	case OptionSampleRows:
+		rows, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.sampleRows = int64(rows)
+		return nil
+	case OptionSampleSeed:
+		if val == "" {
+			s.sampleSeed = ""
+			return nil
+		}
+		seed, err := parseIntOption(key, val, math.MinInt, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.sampleSeed = strconv.Itoa(seed)
+		return nil
+	case OptionExtractTable:
+		s.extractTable = strings.TrimSpace(val)
+		s.extractColumn = nil
+		return nil
+	case OptionExtractWindowStart:
+		bound, err := parseWindowBound(key, val)
+		if err != nil {
+			return err
+		}
+		s.windowStart = bound
+		return nil
+	case OptionExtractWindowEnd:
+		bound, err := parseWindowBound(key, val)
+		if err != nil {
+			return err
+		}
+		s.windowEnd = bound
+		return nil
+	case OptionExtractWindowColumn:
+		s.extractWindowColumn = strings.TrimSpace(val)
+		s.extractColumn = nil
+		return nil
+	case OptionFetchBatchMetadata:
+		batchMetadata, err := parseBoolOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.batchMetadata = batchMetadata
+		return nil
+	case OptionFetchNormalizeCommands:
+		normalize, err := parseBoolOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.normalizeCommands = normalize
+		return nil
+	case OptionFetchTraceFile:
+		s.traceFile = val
+		return nil
+	case OptionFetchResultMode:
+		mode, err := parseEnumOption(key, val, OptionValueResultModeStrict, OptionValueResultModeLenient)
+		if err != nil {
+			return err
+		}
+		s.resultMode = mode
+		return nil
+	case OptionFetchSchemaDrift:
+		mode, err := parseEnumOption(key, val, OptionValueSchemaDriftFail, OptionValueSchemaDriftUnify)
+		if err != nil {
+			return err
+		}
+		s.schemaDrift = mode
+		return nil
+	case OptionFetchProtocols:
+		protocols, err := parseProtocols(key, val)
+		if err != nil {
+			return err
+		}
+		s.protocols = protocols
+		return nil
+	case OptionQueryTags:
+		tags, err := parseQueryTags(key, val)
+		if err != nil {
+			return err
+		}
+		s.queryTags = tags
+		return nil
+	case OptionInListStageThreshold:
+		threshold, err := parseIntOption(key, val, 0, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		s.inListThreshold = threshold
+		return nil
+	case OptionIngestStagingLocation:
+		if _, err := parseStagingLocation(key, val); err != nil {
+			return err
+		}
+		s.ingestStaging = val
+		return nil
+	case OptionIngestMergeKeys:
+		keys, err := parseMergeKeys(key, val)
+		if err != nil {
+			return err
+		}
+		s.ingestMergeKeys = keys
+		return nil
+	case OptionIngestUploadFileSize:
+		fileSize, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.ingestFileSize = int64(fileSize)
+		return nil
+	case OptionIngestUploadMaxParallel:
+		parallel, err := parseIntOption(key, val, 1, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		s.ingestUploadParallel = parallel
+		return nil
+	case OptionIngestManifest:
+		s.ingestManifest = val
+		return nil
+	case OptionIngestValidationRules:
+		rules, err := parseValidationRules(key, val)
+		if err != nil {
+			return err
+		}
+		s.ingestRulesSpec = val
+		s.ingestRules = rules
+		return nil
+	case OptionIngestValidationMaxRejected:
+		maxRejected, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.ingestMaxRejected = int64(maxRejected)
+		return nil
+	case OptionIngestStreamMaxRows:
+		maxRows, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.ingestStreamMaxRows = int64(maxRows)
+		return nil
+	case OptionIngestStreamMaxBytes:
+		maxBytes, err := parseIntOption(key, val, 0, math.MaxInt)
+		if err != nil {
+			return err
+		}
+		s.ingestStreamMaxBytes = int64(maxBytes)
+		return nil
+	case OptionIngestStreamInterval:
+		interval, err := parseDurationOption(key, val)
+		if err != nil {
+			return err
+		}
+		s.ingestStreamInterval = interval
+		return nil
+	}
+
 	return s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "unsupported statement option: %s=%s", key, val)
 }
 
+func (s *statementImpl) GetOption(key string) (string, error) {
+	switch key {
+	case OptionValidateOnly:
+		return formatBoolOption(s.validateOnly), nil
+	case OptionExecutionAsync:
+		return formatBoolOption(s.async), nil
+	case OptionStatementQueryTimeout:
+		return strconv.FormatInt(int64(s.queryTimeout/time.Second), 10), nil
+	case OptionFetchConcatResult:
+		return formatBoolOption(s.concatResult), nil
+	case OptionFetchConcatResultMaxBytes:
+		return strconv.FormatInt(s.concatMaxBytes, 10), nil
+	case OptionFetchLargeTypes:
+		return s.largeTypes, nil
+	case OptionFetchDecimals:
+		return s.decimals, nil
+	case OptionFetchDecimalMaxPrecision:
+		return strconv.Itoa(s.decimalMaxPrecision), nil
+	case OptionFetchDecimalOverflow:
+		return s.decimalOverflow, nil
+	case OptionFetchMemoryLimit:
+		return strconv.FormatInt(s.memoryLimit, 10), nil
+	case OptionFetchPrefetchMaxBytes:
+		return strconv.FormatInt(s.prefetchMaxBytes, 10), nil
+	case OptionFetchPrefetchDrainPercent:
+		return strconv.Itoa(s.prefetchDrainPercent), nil
+	case OptionFetchRejectOverBytes:
+		return strconv.FormatInt(s.rejectOverBytes, 10), nil
+	case OptionFetchLane:
+		return s.fetchLane, nil
+	case OptionSampleFraction:
+		return strconv.FormatFloat(s.sampleFraction, 'f', -1, 64), nil
+	case OptionSampleRows:
+		return strconv.FormatInt(s.sampleRows, 10), nil
+	case OptionSampleSeed:
+		return s.sampleSeed, nil
+	case OptionExtractTable:
+		return s.extractTable, nil
+	case OptionExtractWindowStart:
+		return s.windowStart.String(), nil
+	case OptionExtractWindowEnd:
+		return s.windowEnd.String(), nil
+	case OptionExtractWindowColumn:
+		return s.extractWindowColumn, nil
+	case OptionExtractPredicate:
+		return s.extractPredicate, nil
+	case OptionStatementMemoryInUse:
+		return strconv.FormatInt(s.alloc.Live(), 10), nil
+	case OptionUpdateMetrics:
+		return formatUpdateMetrics(s.updateMetrics)
+	case OptionStatementWarnings:
+		return s.warnings.json()
+	case OptionStatementQueryID:
+		return s.queryID.get(), nil
+	case OptionFetchResultMode:
+		return s.resultMode, nil
+	case OptionFetchSkippedRows:
+		return s.skippedRows.json()
+	case OptionFetchSchemaDrift:
+		return s.schemaDrift, nil
+	case OptionFetchProtocols:
+		return strings.Join(s.protocols, ","), nil
+	case OptionFetchResultProtocol:
+		return s.resultProtocol, nil
+	case OptionFetchBatchMetadata:
+		return formatBoolOption(s.batchMetadata), nil
+	case OptionFetchNormalizeCommands:
+		return formatBoolOption(s.normalizeCommands), nil
+	case OptionFetchTraceFile:
+		return s.traceFile, nil
+	case OptionQueryTags:
+		return formatQueryTags(s.queryTags), nil
+	case OptionInListStageThreshold:
+		return strconv.Itoa(s.inListThreshold), nil
+	case OptionIngestStagingLocation:
+		return s.ingestStaging, nil
+	case OptionIngestMergeKeys:
+		return strings.Join(s.ingestMergeKeys, ","), nil
+	case OptionIngestUploadFileSize:
+		return strconv.FormatInt(s.ingestFileSize, 10), nil
+	case OptionIngestUploadMaxParallel:
+		return strconv.Itoa(s.ingestUploadParallel), nil
+	case OptionIngestManifest:
+		return s.ingestManifest, nil
+	case OptionIngestValidationRules:
+		return s.ingestRulesSpec, nil
+	case OptionIngestValidationMaxRejected:
+		return strconv.FormatInt(s.ingestMaxRejected, 10), nil
+	case OptionIngestRejectedRows:
+		return s.rejectedRows.json()
+	case OptionIngestStreamMaxRows:
+		return strconv.FormatInt(s.ingestStreamMaxRows, 10), nil
+	case OptionIngestStreamMaxBytes:
+		return strconv.FormatInt(s.ingestStreamMaxBytes, 10), nil
+	case OptionIngestStreamInterval:
+		return s.ingestStreamInterval.String(), nil
+	default:
+		return s.StatementImplBase.GetOption(key)
+	}
+}
+
 func (s *statementImpl) SetSqlQuery(query string) error {
-	s.query = query
-	// Reset prepared statement if query changes
-	if s.prepared != nil {
-		if err := s.prepared.Close(); err != nil {
-			return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "failed to close previous prepared statement: %v", err)
-		}
-		s.prepared = nil
+	// Nothing of the previous query or ingestion carries over to this one
+	if err := s.resetQueryState(); err != nil {
+		return err
 	}
+	s.query = query
 	return nil
 }
 
-func (s *statementImpl) Prepare(ctx context.Context) error {
+func (s *statementImpl) Prepare(ctx context.Context) (err error) {
+	defer func() { err = s.conn.recordError("Prepare", "", err) }()
+
 	if s.query == "" {
 		return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
 	}
 
-	stmt, err := s.conn.conn.PrepareContext(ctx, s.query)
+	conn, err := s.conn.sqlConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := conn.PrepareContext(ctx, annotateQuery(ctx, s.query))
 	if err != nil {
 		return s.ErrorHelper.Errorf(adbc.StatusInvalidState, "failed to prepare statement: %v", err)
 	}
 
-	s.prepared = stmt
+	if s.prepared != nil {
+		_ = s.prepared.release()
+	}
+	s.prepared = newPreparedStmt(stmt)
 	return nil
 }
 
-func (s *statementImpl) ExecuteQuery(ctx context.Context) (array.RecordReader, int64, error) {
-	if s.boundStream != nil {
-		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "parameterized queries not yet implemented")
-	}
+func (s *statementImpl) ExecuteQuery(ctx context.Context) (rdr array.RecordReader, rowsAffected int64, err error) {
+	ctx, finish := s.startExecution(ctx)
+	queryID := &queryIDTracker{}
+	s.queryID = queryID
+	ctx = queryID.attach(ctx)
+	defer func() { err = s.recordExecution(ctx, "ExecuteQuery", queryID.get(), finish(queryID.get(), err)) }()
+	defer s.conn.busy()()
 
 	if s.query == "" {
 		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
@@ -109,66 +608,232 @@ func (s *statementImpl) ExecuteQuery(ctx context.Context) (array.RecordReader, i
 	// Execute query using raw driver interface to get Arrow batches
 	// This works for both prepared and unprepared statements since
 	// databricks-sql-go doesn't do server-side preparation
-	var driverRows driver.Rows
-	var err error
-	err = s.conn.conn.Raw(func(driverConn interface{}) error {
-		// Use raw driver interface for direct Arrow access
-		queryerCtx := driverConn.(driver.QueryerContext)
-		var driverArgs []driver.NamedValue
-		driverRows, err = queryerCtx.QueryContext(ctx, s.query, driverArgs)
-		return err
-	})
-
+	conn, err := s.session(ctx)
 	if err != nil {
-		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute query: %v", err)
+		return nil, -1, err
+	}
+
+	if s.validateOnly {
+		s.logExecution(ctx, "validating query")
+		return s.executeValidateOnly(ctx, conn)
 	}
 
-	defer func() {
-		if driverRows == nil {
-			return
+	query := s.query
+	// With bound parameters the query runs once per row; the first
+	// execution happens here, the others as the result is read
+	var (
+		args    []driver.NamedValue
+		inLists []boundInList
+		pending []boundQuery
+	)
+	if s.boundStream != nil {
+		bound, err := s.boundQueries(query)
+		if err != nil {
+			return nil, -1, err
+		}
+		if len(bound) == 0 {
+			return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "no rows of bound parameters")
+		}
+		query, args, inLists, pending = bound[0].query, bound[0].args, bound[0].inLists, bound[1:]
+		// Staged IN lists are only readable in the session, like bound
+		// values
+		sessionOnly := len(args) > 0 || len(inLists) > 0 || len(pending) > 0
+		if sessionOnly && (s.resultMode == OptionValueResultModeLenient || s.conn.jobsHandoffAfter > 0) {
+			return nil, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented,
+				"bound parameters can't be used with %s=%s or %s", OptionFetchResultMode, OptionValueResultModeLenient, OptionJobsHandoffAfter)
 		}
-		if closeErr := driverRows.Close(); closeErr != nil {
-			err = errors.Join(err, closeErr)
+		if sessionOnly && s.async {
+			return nil, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "bound parameters can't be used with %s", OptionExecutionAsync)
 		}
-	}()
+		if err := s.writeInLists(ctx, conn, inLists); err != nil {
+			return nil, -1, err
+		}
+	}
+	if query, err = s.rewriteQuery(ctx, query); err != nil {
+		return nil, -1, err
+	}
+	s.skippedRows = nil
+	s.resultProtocol = ""
+	s.asyncQuery = ""
+	if s.async {
+		return s.executeAsync(ctx, query, args, pending)
+	}
 
-	// Use the IPC stream interface (zero-copy)
-	reader, err := newIPCReaderAdapter(ctx, driverRows)
-	if err != nil {
-		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create IPC reader adapter: %v", err)
+	var reader array.RecordReader
+	if s.resultMode == OptionValueResultModeLenient {
+		s.logExecution(ctx, "executing query with lenient result assembly")
+		s.skippedRows = &skippedRows{}
+		if reader, err = s.executeLenient(ctx, query, s.skippedRows); err != nil {
+			return nil, -1, err
+		}
+		s.resultProtocol = OptionValueProtocolREST
+	} else if s.conn.jobsHandoffAfter > 0 {
+		s.logExecution(ctx, "executing query with job hand-off")
+		if reader, err = s.executeHandoff(ctx, query); err != nil {
+			return nil, -1, err
+		}
+		s.resultProtocol = OptionValueProtocolREST
+	} else {
+		s.logExecution(ctx, "executing query")
+		if reader, s.resultProtocol, err = s.executeProtocols(ctx, conn, boundQuery{query: query, args: args, inLists: inLists}); err != nil {
+			return nil, -1, err
+		}
+		if len(pending) > 0 {
+			reader = newBoundResultReader(reader, pending, func(b boundQuery) (array.RecordReader, error) {
+				if s.conn == nil {
+					return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement closed before its result was read")
+				}
+				if err := s.writeInLists(ctx, conn, b.inLists); err != nil {
+					return nil, err
+				}
+				query, err := s.rewriteQuery(ctx, b.query)
+				if err != nil {
+					return nil, err
+				}
+				b.query = query
+				reader, _, err := s.executeProtocols(ctx, conn, b)
+				return reader, err
+			})
+		}
+	}
+	if changesNamespace(s.query) {
+		s.conn.invalidateNamespace()
+	}
+
+	// The wrappers below read batches one for one, so the stats of the
+	// source's current batch are those of the batch they return
+	stats, _ := reader.(batchStatsSource)
+	declared, _ := reader.(declaredTypesSource)
+	if s.traceFile != "" {
+		reader = newFetchTraceReader(reader, stats, s.traceFile, s.resultProtocol, queryID, s.conn.Logger)
+	}
+	reader = newSchemaDriftReader(s.alloc, s.schemaDrift, reader, s.warnings)
+	if s.memoryLimit > 0 {
+		reader = newMemoryLimitReader(s.alloc, s.memoryLimit, reader)
+	}
+	if s.decimals == OptionValueDecimalsNative && declared != nil {
+		reader = newDecimalReader(s.alloc, reader, declared.declaredTypes(), int32(s.decimalMaxPrecision), s.decimalOverflow, s.warnings)
+	}
+	if s.normalizeCommands && isCommand(s.query) {
+		reader = newCommandResultReader(s.alloc, reader)
+	}
+	if s.largeTypes == OptionValueLargeTypesAlways {
+		reader = newLargeTypesReader(s.alloc, reader)
+	}
+	if s.batchMetadata && !s.concatResult {
+		reader = newBatchMetadataReader(reader, stats)
+	}
+
+	if s.concatResult {
+		widen := s.largeTypes == OptionValueLargeTypesAuto
+		rec, err := concatRecordReader(s.alloc, reader, s.concatMaxBytes, widen)
+		if err != nil {
+			return nil, -1, err
+		}
+		defer rec.Release()
+		rdr, err := array.NewRecordReader(rec.Schema(), []arrow.RecordBatch{rec})
+		if err != nil {
+			return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create record reader: %v", err)
+		}
+		return rdr, rec.NumRows(), nil
 	}
-	driverRows = nil // Prevent double close in defer
 
 	// Return -1 for rowsAffected (unknown) since we can't count without consuming
 	// The ADBC spec allows -1 to indicate "unknown number of rows affected"
-	return reader, -1, nil
+	return s.conn.trackResult(reader), -1, nil
 }
 
-func (s *statementImpl) ExecuteUpdate(ctx context.Context) (int64, error) {
+// rewriteQuery applies the connection's snapshot, the statement's extract
+// window and sampling, and ctx's annotations to query.
+func (s *statementImpl) rewriteQuery(ctx context.Context, query string) (string, error) {
+	var err error
+	if s.conn.snapshot != nil {
+		if query, err = s.conn.pinSnapshot(ctx, query); err != nil {
+			return "", err
+		}
+	}
+	if query, err = s.windowQuery(ctx, query); err != nil {
+		return "", err
+	}
+	return annotateQuery(ctx, s.sampleQuery(query)), nil
+}
+
+func (s *statementImpl) ExecuteUpdate(ctx context.Context) (rowsAffected int64, err error) {
+	ctx, finish := s.startExecution(ctx)
+	queryID := &queryIDTracker{}
+	s.queryID = queryID
+	ctx = queryID.attach(ctx)
+	defer func() { err = s.recordExecution(ctx, "ExecuteUpdate", queryID.get(), finish(queryID.get(), err)) }()
+	defer s.conn.busy()()
+
+	s.updateMetrics = nil
 	if s.bulkIngestOptions.IsSet() {
 		return s.executeIngest(ctx)
 	}
 
-	if s.boundStream != nil {
-		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "bound data provided but no ingest target set")
+	if s.boundStream != nil && !s.validateOnly {
+		return s.executeBoundUpdate(ctx)
 	}
 
 	var result sql.Result
-	var err error
 
+	if s.validateOnly {
+		if s.query == "" {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
+		}
+		var conn *sql.Conn
+		if conn, err = s.conn.sqlConn(ctx); err != nil {
+			return -1, err
+		}
+		s.logExecution(ctx, "validating update")
+		return -1, s.validateQuery(ctx, conn)
+	}
+
+	if s.prepared == nil && s.query == "" {
+		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
+	}
+	conn, err := s.session(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if s.query != "" && reportsUpdateMetrics(s.query) {
+		// COPY INTO and MERGE report their counts as a result set
+		s.logExecution(ctx, "executing update")
+		var rows *sql.Rows
+		if s.prepared != nil {
+			rows, err = s.prepared.QueryContext(ctx)
+		}
+		if s.prepared == nil || errors.Is(err, sql.ErrConnDone) {
+			// Also when the prepared statement's session was closed while
+			// idle; preparation is client-side, so nothing is lost
+			rows, err = conn.QueryContext(ctx, annotateQuery(ctx, s.query))
+		}
+		if err != nil {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute update: %v", err)
+		}
+		if s.updateMetrics, err = readUpdateMetrics(rows); err != nil {
+			return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to read update metrics: %v", err)
+		}
+		return metricsRowsAffected(s.updateMetrics), nil
+	}
+
+	s.logExecution(ctx, "executing update")
 	if s.prepared != nil {
 		result, err = s.prepared.ExecContext(ctx)
-	} else if s.query != "" {
-		result, err = s.conn.conn.ExecContext(ctx, s.query)
-	} else {
-		return -1, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "no query set")
+	}
+	if s.prepared == nil || errors.Is(err, sql.ErrConnDone) {
+		result, err = conn.ExecContext(ctx, annotateQuery(ctx, s.query))
 	}
 
 	if err != nil {
 		return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to execute update: %v", err)
 	}
+	if changesNamespace(s.query) {
+		s.conn.invalidateNamespace()
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	rowsAffected, err = result.RowsAffected()
 	if err != nil {
 		return -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to get rows affected: %v", err)
 	}
@@ -176,6 +841,27 @@ func (s *statementImpl) ExecuteUpdate(ctx context.Context) (int64, error) {
 	return rowsAffected, nil
 }
 
+// logExecution writes a debug log line for the statement, including any
+// annotations carried by ctx.
+func (s *statementImpl) logExecution(ctx context.Context, msg string, attrs ...any) {
+	attrs = append(attrs, slog.Group("annotations", annotationAttrs(ctx)...))
+	s.conn.Logger.DebugContext(ctx, msg, attrs...)
+}
+
+// recordExecution logs a failed execution, adds it to the connection's
+// error history and returns the classified error.
+func (s *statementImpl) recordExecution(ctx context.Context, operation, queryID string, err error) error {
+	if err == nil || s.conn == nil {
+		return classifyError(err)
+	}
+	err = s.conn.recordError(operation, queryID, err)
+	s.logExecution(ctx, "statement failed",
+		slog.String("operation", operation),
+		slog.String("query_id", queryID),
+		slog.Any("error", err))
+	return err
+}
+
 func (s *statementImpl) Bind(ctx context.Context, values arrow.RecordBatch) error {
 	if s.boundStream != nil {
 		s.boundStream.Release()
@@ -197,18 +883,24 @@ func (s *statementImpl) BindStream(ctx context.Context, stream array.RecordReade
 	return nil
 }
 
+// GetParameterSchema describes the parameters of the prepared query, in
+// the order they appear. Databricks doesn't report parameter metadata, so
+// the schema comes from the query text: named parameters (:name) keep
+// their name and positional ones (?) have an empty name. A parameter's
+// type is the one it is cast to in the query, or Null when the query
+// doesn't say.
 func (s *statementImpl) GetParameterSchema() (*arrow.Schema, error) {
-	// This would require parsing the SQL query to determine parameter types
-	// For now, return nil to indicate unknown schema
-	return nil, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "parameter schema detection not implemented")
+	if s.prepared == nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInvalidState, "statement is not prepared")
+	}
+	params := queryParameters(s.query)
+	fields := make([]arrow.Field, len(params))
+	for i, p := range params {
+		fields[i] = arrow.Field{Name: p.name, Type: p.typ, Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil), nil
 }
 
 func (s *statementImpl) SetSubstraitPlan(plan []byte) error {
-	// Databricks SQL doesn't support Substrait plans
-	return s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "Substrait plans not supported")
-}
-
-func (s *statementImpl) ExecutePartitions(ctx context.Context) (*arrow.Schema, adbc.Partitions, int64, error) {
-	// Databricks SQL doesn't support partitioned result sets
-	return nil, adbc.Partitions{}, -1, s.ErrorHelper.Errorf(adbc.StatusNotImplemented, "partitioned result sets not supported")
+	return s.conn.unsupported(FeatureSubstrait)
 }
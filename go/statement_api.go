@@ -0,0 +1,392 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/databricks/databricks-sql-go/auth"
+)
+
+// statementPollInterval is how often a running statement is polled once
+// the initial wait has timed out. A variable so that tests can shorten it.
+var statementPollInterval = time.Second
+
+// Statement states reported by the Statement Execution API.
+const (
+	statementStatePending   = "PENDING"
+	statementStateRunning   = "RUNNING"
+	statementStateSucceeded = "SUCCEEDED"
+	statementStateClosed    = "CLOSED"
+)
+
+// statementAPI is a small client for the Databricks SQL Statement
+// Execution API, which (unlike the Thrift endpoint) can hand out fresh
+// download links for any chunk of a result long after the query ran.
+// Partitioned execution is built on it.
+type statementAPI struct {
+	client *http.Client
+	// storage downloads result chunks; its requests carry no credentials
+	storage     *http.Client
+	baseURL     string
+	auth        auth.Authenticator
+	warehouseID string
+	// Time source of polling
+	clock driverClock
+	// Owner of the goroutines awaiting submissions; the database's
+	goroutines *goroutineGroup
+}
+
+// warehouseIDFromHTTPPath extracts the warehouse ID from an HTTP path of
+// the form /sql/1.0/warehouses/<id>, or returns "" for other paths (e.g.
+// all-purpose clusters, which the Statement Execution API doesn't serve).
+func warehouseIDFromHTTPPath(httpPath string) string {
+	parts := strings.Split(strings.Trim(httpPath, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "warehouses" || parts[i] == "endpoints" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+type statementRequest struct {
+	Statement     string `json:"statement"`
+	WarehouseID   string `json:"warehouse_id"`
+	Catalog       string `json:"catalog,omitempty"`
+	Schema        string `json:"schema,omitempty"`
+	Disposition   string `json:"disposition"`
+	Format        string `json:"format"`
+	WaitTimeout   string `json:"wait_timeout"`
+	OnWaitTimeout string `json:"on_wait_timeout"`
+}
+
+type statementResponse struct {
+	StatementID string `json:"statement_id"`
+	Status      struct {
+		State string `json:"state"`
+		Error *struct {
+			ErrorCode string `json:"error_code"`
+			Message   string `json:"message"`
+		} `json:"error"`
+	} `json:"status"`
+	Manifest *resultManifest `json:"manifest"`
+}
+
+type resultManifest struct {
+	Schema struct {
+		Columns []struct {
+			Name     string `json:"name"`
+			TypeText string `json:"type_text"`
+		} `json:"columns"`
+	} `json:"schema"`
+	TotalChunkCount int64       `json:"total_chunk_count"`
+	TotalRowCount   int64       `json:"total_row_count"`
+	TotalByteCount  int64       `json:"total_byte_count"`
+	Chunks          []chunkInfo `json:"chunks"`
+	Truncated       bool        `json:"truncated"`
+}
+
+// chunkInfo locates one chunk of a result within its rows.
+type chunkInfo struct {
+	ChunkIndex int64 `json:"chunk_index"`
+	RowOffset  int64 `json:"row_offset"`
+	RowCount   int64 `json:"row_count"`
+}
+
+type externalLink struct {
+	ChunkIndex   int64             `json:"chunk_index"`
+	ExternalLink string            `json:"external_link"`
+	HTTPHeaders  map[string]string `json:"http_headers"`
+}
+
+type resultData struct {
+	ExternalLinks []externalLink `json:"external_links"`
+}
+
+// errStillRunning is returned by executeWithin for a statement that didn't
+// finish in time. The statement has been cancelled.
+type errStillRunning struct {
+	statementID string
+	limit       time.Duration
+}
+
+func (e errStillRunning) Error() string {
+	return fmt.Sprintf("statement %s still running after %s", e.statementID, e.limit)
+}
+
+// execute runs query and waits for it to finish, returning the finished
+// statement with its result manifest.
+func (a *statementAPI) execute(ctx context.Context, query, catalog, schema string) (*statementResponse, error) {
+	return a.executeWithin(ctx, query, catalog, schema, 0)
+}
+
+// executeWithin is execute, but cancels the statement and returns
+// errStillRunning if it hasn't finished after limit, unless limit is zero.
+func (a *statementAPI) executeWithin(ctx context.Context, query, catalog, schema string, limit time.Duration) (*statementResponse, error) {
+	var deadline <-chan time.Time
+	waitTimeout := "30s"
+	if limit > 0 {
+		deadline = a.clock.After(limit)
+		if limit < 30*time.Second {
+			// Return at once rather than wait past the limit
+			waitTimeout = "0s"
+		}
+	}
+	submitted, err := a.submit(ctx, query, catalog, schema, waitTimeout)
+	if err != nil {
+		return nil, err
+	}
+	resp := *submitted
+
+	for resp.running() {
+		select {
+		case <-ctx.Done():
+			return nil, a.cancelled(ctx, resp.StatementID)
+		case <-deadline:
+			a.cancel(resp.StatementID)
+			return nil, errStillRunning{statementID: resp.StatementID, limit: limit}
+		case <-a.clock.After(statementPollInterval):
+		}
+		next, err := a.get(ctx, resp.StatementID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, a.cancelled(ctx, resp.StatementID)
+			}
+			return nil, err
+		}
+		resp = *next
+	}
+
+	if err := resp.finished(); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// submit starts running query, waiting up to waitTimeout (such as "30s",
+// or "0s" to return at once) for it to finish, and returns the statement
+// in whatever state it has then reached.
+//
+// The statement's ID only arrives with the response, so the request isn't
+// abandoned when ctx is cancelled: submit returns at once, and the
+// statement is cancelled once the response names it.
+func (a *statementAPI) submit(ctx context.Context, query, catalog, schema, waitTimeout string) (*statementResponse, error) {
+	req := statementRequest{
+		Statement:     query,
+		WarehouseID:   a.warehouseID,
+		Catalog:       catalog,
+		Schema:        schema,
+		Disposition:   "EXTERNAL_LINKS",
+		Format:        "ARROW_STREAM",
+		WaitTimeout:   waitTimeout,
+		OnWaitTimeout: "CONTINUE",
+	}
+	type submitted struct {
+		resp *statementResponse
+		err  error
+	}
+	done := make(chan submitted, 1)
+	a.goroutines.Go(func(closing context.Context) {
+		// Only closing the database abandons the request
+		reqCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer context.AfterFunc(closing, cancel)()
+		defer cancel()
+		var resp statementResponse
+		err := a.do(reqCtx, http.MethodPost, "/api/2.0/sql/statements", req, &resp)
+		done <- submitted{&resp, err}
+	})
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return r.resp, nil
+	case <-ctx.Done():
+		a.goroutines.Go(func(context.Context) {
+			if r := <-done; r.err == nil && r.resp.running() {
+				a.cancel(r.resp.StatementID)
+			}
+		})
+		return nil, adbc.Error{Code: adbc.StatusCancelled, Msg: fmt.Sprintf("statement cancelled: %v", ctx.Err())}
+	}
+}
+
+// cancelled cancels a statement whose execution was abandoned because ctx
+// is done, and returns the error reporting it.
+func (a *statementAPI) cancelled(ctx context.Context, statementID string) error {
+	a.cancel(statementID)
+	return adbc.Error{Code: adbc.StatusCancelled, Msg: fmt.Sprintf("statement %s cancelled: %v", statementID, ctx.Err())}
+}
+
+// cancel cancels a statement. It is best effort; the statement may
+// already have finished.
+func (a *statementAPI) cancel(statementID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = a.cancelContext(ctx, statementID)
+}
+
+// cancelContext asks the server to cancel a statement, returning the error
+// of the request.
+func (a *statementAPI) cancelContext(ctx context.Context, statementID string) error {
+	return a.do(ctx, http.MethodPost, "/api/2.0/sql/statements/"+url.PathEscape(statementID)+"/cancel", nil, nil)
+}
+
+// running reports whether the statement has yet to finish.
+func (resp *statementResponse) running() bool {
+	return resp.Status.State == statementStatePending || resp.Status.State == statementStateRunning
+}
+
+// get returns the current state of a statement, with its result manifest
+// once it has succeeded.
+func (a *statementAPI) get(ctx context.Context, statementID string) (*statementResponse, error) {
+	var resp statementResponse
+	if err := a.do(ctx, http.MethodGet, "/api/2.0/sql/statements/"+url.PathEscape(statementID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// finished returns an error unless the statement succeeded and its result
+// can be read.
+func (resp *statementResponse) finished() error {
+	switch resp.Status.State {
+	case statementStateSucceeded:
+	case statementStatePending, statementStateRunning:
+		return adbc.Error{Code: adbc.StatusInvalidState, Msg: fmt.Sprintf("statement %s is still %s", resp.StatementID, strings.ToLower(resp.Status.State))}
+	case statementStateClosed:
+		return adbc.Error{Code: adbc.StatusNotFound, Msg: fmt.Sprintf("statement %s is closed; its result is no longer available", resp.StatementID)}
+	default:
+		msg := fmt.Sprintf("statement %s %s", resp.StatementID, strings.ToLower(resp.Status.State))
+		if resp.Status.Error != nil {
+			msg += ": " + resp.Status.Error.Message
+		}
+		return adbc.Error{Code: adbc.StatusInternal, Msg: msg}
+	}
+	if resp.Manifest == nil {
+		return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("statement %s returned no result manifest", resp.StatementID)}
+	}
+	return nil
+}
+
+// chunkLinks returns freshly issued download links for one chunk of a
+// finished statement's result.
+func (a *statementAPI) chunkLinks(ctx context.Context, statementID string, chunkIndex int64) ([]externalLink, error) {
+	var data resultData
+	path := "/api/2.0/sql/statements/" + url.PathEscape(statementID) + "/result/chunks/" + strconv.FormatInt(chunkIndex, 10)
+	if err := a.do(ctx, http.MethodGet, path, nil, &data); err != nil {
+		return nil, err
+	}
+	// The response may run on into the following chunks
+	links := data.ExternalLinks[:0]
+	for _, link := range data.ExternalLinks {
+		if link.ChunkIndex == chunkIndex {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return nil, adbc.Error{
+			Code: adbc.StatusNotFound,
+			Msg:  fmt.Sprintf("statement %s has no links for chunk %d", statementID, chunkIndex),
+		}
+	}
+	return links, nil
+}
+
+// do sends an authenticated request to the workspace and decodes the JSON
+// response into out, if given.
+func (a *statementAPI) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	contentType := ""
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to encode request: %v", err)}
+		}
+		body = bytes.NewReader(payload)
+		contentType = "application/json"
+	}
+	return a.send(ctx, method, path, contentType, body, out)
+}
+
+// send sends an authenticated request with the given body to the
+// workspace and decodes the JSON response into out, if given.
+func (a *statementAPI) send(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, body)
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create request: %v", err)}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if sized, ok := body.(interface{ Size() int64 }); ok {
+		req.ContentLength = sized.Size()
+	}
+	if err := a.auth.Authenticate(req); err != nil {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: fmt.Sprintf("failed to authenticate request: %v", err)}
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("%s %s failed: %v", method, path, err)}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// The Files API answers uploads and deletions with 204 No Content
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		msg := fmt.Sprintf("%s %s failed: %s: %s", method, path, resp.Status, strings.TrimSpace(string(detail)))
+		// Kept in the message for classifyError, which suggests it as the
+		// backoff of maintenance errors
+		if wait := resp.Header.Get("Retry-After"); wait != "" {
+			msg += fmt.Sprintf(" (Retry-After: %s)", wait)
+		}
+		return adbc.Error{Code: statusFromHTTP(resp.StatusCode), Msg: msg}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to decode %s response: %v", path, err)}
+	}
+	return nil
+}
+
+// statusFromHTTP maps an HTTP error status to an ADBC status code.
+func statusFromHTTP(code int) adbc.Status {
+	switch code {
+	case http.StatusBadRequest:
+		return adbc.StatusInvalidArgument
+	case http.StatusUnauthorized:
+		return adbc.StatusUnauthenticated
+	case http.StatusForbidden:
+		return adbc.StatusUnauthorized
+	case http.StatusNotFound, http.StatusGone:
+		return adbc.StatusNotFound
+	default:
+		return adbc.StatusIO
+	}
+}
@@ -0,0 +1,431 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adbc-drivers/driverbase-go/driverbase"
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
+)
+
+// Driver-specific statistics reported by GetStatistics, for sizing scans
+// before running them. They are read from DESCRIBE DETAIL and SHOW
+// PARTITIONS, so only Delta tables have them.
+const (
+	// StatisticSizeInBytesKey is the size of the table's current files
+	// in bytes (int64).
+	StatisticSizeInBytesKey  = 1024
+	StatisticSizeInBytesName = "databricks.statistic.size_in_bytes"
+	// StatisticNumFilesKey is the number of files in the table's current
+	// version (int64).
+	StatisticNumFilesKey  = 1025
+	StatisticNumFilesName = "databricks.statistic.num_files"
+	// StatisticNumPartitionsKey is the number of partitions of a
+	// partitioned table (int64).
+	StatisticNumPartitionsKey  = 1026
+	StatisticNumPartitionsName = "databricks.statistic.num_partitions"
+	// StatisticPartitionColumnKey is reported for each partition column,
+	// with the column's position in the partitioning (int64, from 0).
+	StatisticPartitionColumnKey  = 1027
+	StatisticPartitionColumnName = "databricks.statistic.partition_column"
+	// StatisticPartitionKey is reported once per partition of a
+	// partitioned table, as a path of the form "col1=value1/col2=value2"
+	// (binary). Partitions are only listed when exact statistics are
+	// requested.
+	StatisticPartitionKey  = 1028
+	StatisticPartitionName = "databricks.statistic.partition"
+)
+
+var statisticNames = []struct {
+	name string
+	key  int16
+}{
+	{StatisticSizeInBytesName, StatisticSizeInBytesKey},
+	{StatisticNumFilesName, StatisticNumFilesKey},
+	{StatisticNumPartitionsName, StatisticNumPartitionsKey},
+	{StatisticPartitionColumnName, StatisticPartitionColumnKey},
+	{StatisticPartitionName, StatisticPartitionKey},
+}
+
+// statistic is one row of a GetStatistics result. value is an int64 or a
+// []byte.
+type statistic struct {
+	table       string
+	column      *string
+	key         int16
+	value       any
+	approximate bool
+}
+
+// schemaStatistics holds the statistics of the tables in one schema.
+type schemaStatistics struct {
+	catalog    string
+	dbSchema   string
+	statistics []statistic
+}
+
+// tableDetail is the part of DESCRIBE DETAIL used for statistics and
+// extract windows.
+type tableDetail struct {
+	sizeInBytes       sql.NullInt64
+	numFiles          sql.NullInt64
+	partitionColumns  []string
+	clusteringColumns []string
+}
+
+// GetStatistics implements adbc.ConnectionGetStatistics. Besides the
+// driver-specific size, file and partition statistics, no standard
+// statistics are reported, as Databricks only has them for tables that
+// have been analyzed.
+func (c *connectionImpl) GetStatistics(ctx context.Context, catalog, dbSchema, tableName *string, approximate bool) (rdr array.RecordReader, err error) {
+	defer func() { err = c.recordError("GetStatistics", "", err) }()
+
+	catalogs, err := c.GetCatalogs(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	var results []schemaStatistics
+	for _, cat := range catalogs {
+		schemas, err := c.GetDBSchemasForCatalog(ctx, cat, dbSchema)
+		if err != nil {
+			return nil, err
+		}
+		for _, sch := range schemas {
+			tables, err := c.GetTablesForDBSchema(ctx, cat, sch, tableName, nil, false)
+			if err != nil {
+				return nil, err
+			}
+			result := schemaStatistics{catalog: cat, dbSchema: sch}
+			for _, table := range tables {
+				stats, err := c.tableStatistics(ctx, cat, sch, table.TableName, approximate)
+				if err != nil {
+					return nil, err
+				}
+				result.statistics = append(result.statistics, stats...)
+			}
+			results = append(results, result)
+		}
+	}
+
+	rec, err := buildStatistics(c.Alloc, results)
+	if err != nil {
+		return nil, c.ErrorHelper.Errorf(adbc.StatusInternal, "failed to build statistics: %v", err)
+	}
+	defer rec.Release()
+	return array.NewRecordReader(adbc.GetStatisticsSchema, []arrow.RecordBatch{rec})
+}
+
+// GetStatisticNames implements adbc.ConnectionGetStatistics.
+func (c *connectionImpl) GetStatisticNames(ctx context.Context) (array.RecordReader, error) {
+	bldr := array.NewRecordBuilder(c.Alloc, adbc.GetStatisticNamesSchema)
+	defer bldr.Release()
+	names := bldr.Field(0).(*array.StringBuilder)
+	keys := bldr.Field(1).(*array.Int16Builder)
+	for _, stat := range statisticNames {
+		names.Append(stat.name)
+		keys.Append(stat.key)
+	}
+	rec := bldr.NewRecordBatch()
+	defer rec.Release()
+	return array.NewRecordReader(adbc.GetStatisticNamesSchema, []arrow.RecordBatch{rec})
+}
+
+// tableStatistics returns the statistics of one table, or none if the
+// table has no details (e.g. it is a view or not a Delta table).
+func (c *connectionImpl) tableStatistics(ctx context.Context, catalog, dbSchema, tableName string, approximate bool) ([]statistic, error) {
+	name := qualifiedTableName(catalog, dbSchema, tableName)
+	detail, ok, err := c.describeDetail(ctx, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var stats []statistic
+	if detail.sizeInBytes.Valid {
+		stats = append(stats, statistic{table: tableName, key: StatisticSizeInBytesKey, value: detail.sizeInBytes.Int64})
+	}
+	if detail.numFiles.Valid {
+		stats = append(stats, statistic{table: tableName, key: StatisticNumFilesKey, value: detail.numFiles.Int64})
+	}
+	if len(detail.partitionColumns) == 0 {
+		return stats, nil
+	}
+	for i, col := range detail.partitionColumns {
+		stats = append(stats, statistic{table: tableName, column: &col, key: StatisticPartitionColumnKey, value: int64(i)})
+	}
+
+	if approximate {
+		// Listing partitions reads the whole transaction log
+		return stats, nil
+	}
+	partitions, err := c.showPartitions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	stats = append(stats, statistic{table: tableName, key: StatisticNumPartitionsKey, value: int64(len(partitions))})
+	for _, partition := range partitions {
+		stats = append(stats, statistic{table: tableName, key: StatisticPartitionKey, value: []byte(partition)})
+	}
+	return stats, nil
+}
+
+// describeDetail runs DESCRIBE DETAIL on a table. It reports false if the
+// server can't describe the table.
+func (c *connectionImpl) describeDetail(ctx context.Context, name string) (detail tableDetail, ok bool, err error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return detail, false, err
+	}
+	rows, err := conn.QueryContext(ctx, "DESCRIBE DETAIL "+name)
+	if err != nil {
+		var dbExecutionErr dbsqlerr.DBExecutionError
+		if errors.As(err, &dbExecutionErr) {
+			return detail, false, nil
+		}
+		return detail, false, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to describe table %s: %v", name, err),
+		}
+	}
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return detail, false, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to describe table %s: %v", name, err),
+		}
+	}
+	if !rows.Next() {
+		return detail, false, rows.Err()
+	}
+	var partitionColumns, clusteringColumns sql.NullString
+	dest := make([]any, len(columns))
+	for i, col := range columns {
+		switch col {
+		case "sizeInBytes":
+			dest[i] = &detail.sizeInBytes
+		case "numFiles":
+			dest[i] = &detail.numFiles
+		case "partitionColumns":
+			dest[i] = &partitionColumns
+		case "clusteringColumns":
+			dest[i] = &clusteringColumns
+		default:
+			dest[i] = new(any)
+		}
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return detail, false, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to scan details of %s: %v", name, err),
+		}
+	}
+	if detail.partitionColumns, err = parseStringArray(partitionColumns.String); err != nil {
+		return detail, false, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to parse partition columns of %s: %v", name, err),
+		}
+	}
+	if detail.clusteringColumns, err = parseStringArray(clusteringColumns.String); err != nil {
+		return detail, false, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to parse clustering columns of %s: %v", name, err),
+		}
+	}
+	return detail, true, nil
+}
+
+// showPartitions lists the partitions of a partitioned table as paths of
+// the form "col1=value1/col2=value2".
+func (c *connectionImpl) showPartitions(ctx context.Context, name string) (partitions []string, err error) {
+	conn, err := c.sqlConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, "SHOW PARTITIONS "+name)
+	if err != nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to list partitions of %s: %v", name, err),
+		}
+	}
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, adbc.Error{
+			Code: adbc.StatusInternal,
+			Msg:  fmt.Sprintf("failed to list partitions of %s: %v", name, err),
+		}
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, adbc.Error{
+				Code: adbc.StatusInternal,
+				Msg:  fmt.Sprintf("failed to scan partition of %s: %v", name, err),
+			}
+		}
+		partitions = append(partitions, partitionPath(columns, values))
+	}
+	return partitions, rows.Err()
+}
+
+// partitionPath renders a partition's values in the Hive layout, with
+// nulls as __HIVE_DEFAULT_PARTITION__.
+func partitionPath(columns []string, values []sql.NullString) string {
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		value := "__HIVE_DEFAULT_PARTITION__"
+		if values[i].Valid {
+			value = values[i].String
+		}
+		parts[i] = col + "=" + value
+	}
+	return strings.Join(parts, "/")
+}
+
+// parseStringArray parses an ARRAY<STRING> value, which the server sends
+// as JSON. An empty value is an empty array.
+func parseStringArray(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// buildStatistics builds a record in the GetStatistics schema.
+func buildStatistics(mem memory.Allocator, results []schemaStatistics) (arrow.RecordBatch, error) {
+	bldr := array.NewRecordBuilder(mem, adbc.GetStatisticsSchema)
+	defer bldr.Release()
+
+	catalogNames := bldr.Field(0).(*array.StringBuilder)
+	catalogSchemas := bldr.Field(1).(*array.ListBuilder)
+	schemaStruct := catalogSchemas.ValueBuilder().(*array.StructBuilder)
+	schemaNames := schemaStruct.FieldBuilder(0).(*array.StringBuilder)
+	schemaStats := schemaStruct.FieldBuilder(1).(*array.ListBuilder)
+	statStruct := schemaStats.ValueBuilder().(*array.StructBuilder)
+	tableNames := statStruct.FieldBuilder(0).(*array.StringBuilder)
+	columnNames := statStruct.FieldBuilder(1).(*array.StringBuilder)
+	keys := statStruct.FieldBuilder(2).(*array.Int16Builder)
+	values := statStruct.FieldBuilder(3).(*array.DenseUnionBuilder)
+	approximates := statStruct.FieldBuilder(4).(*array.BooleanBuilder)
+	int64Values := values.Child(0).(*array.Int64Builder)
+	binaryValues := values.Child(3).(*array.BinaryBuilder)
+
+	// Results are grouped by catalog, in the order the catalogs were listed
+	for i := 0; i < len(results); {
+		catalog := results[i].catalog
+		catalogNames.Append(catalog)
+		catalogSchemas.Append(true)
+		for ; i < len(results) && results[i].catalog == catalog; i++ {
+			schemaStruct.Append(true)
+			schemaNames.Append(results[i].dbSchema)
+			schemaStats.Append(true)
+			for _, stat := range results[i].statistics {
+				statStruct.Append(true)
+				tableNames.Append(stat.table)
+				if stat.column != nil {
+					columnNames.Append(*stat.column)
+				} else {
+					columnNames.AppendNull()
+				}
+				keys.Append(stat.key)
+				switch v := stat.value.(type) {
+				case int64:
+					values.Append(0)
+					int64Values.Append(v)
+				case []byte:
+					values.Append(3)
+					binaryValues.Append(v)
+				default:
+					return nil, fmt.Errorf("unsupported statistic value %T", v)
+				}
+				approximates.Append(stat.approximate)
+			}
+		}
+	}
+	return bldr.NewRecordBatch(), nil
+}
+
+// statisticsConnection adds adbc.ConnectionGetStatistics to the connection
+// built by driverbase, which only exposes the standard interfaces, along
+// with this driver's connection extensions (ResultAttacher, CommentEditor,
+// NamespaceSetter).
+// It also replaces driverbase's GetObjects, which builds the whole result
+// in memory, with a streaming one.
+type statisticsConnection struct {
+	baseConnection
+	impl *connectionImpl
+}
+
+type baseConnection interface {
+	driverbase.Connection
+	adbc.OTelTracing
+}
+
+func (c *statisticsConnection) GetStatistics(ctx context.Context, catalog, dbSchema, tableName *string, approximate bool) (array.RecordReader, error) {
+	return c.impl.GetStatistics(ctx, catalog, dbSchema, tableName, approximate)
+}
+
+func (c *statisticsConnection) GetObjects(ctx context.Context, depth adbc.ObjectDepth, catalog, dbSchema, tableName, columnName *string, tableType []string) (array.RecordReader, error) {
+	return c.impl.getObjects(ctx, depth, catalog, dbSchema, tableName, columnName)
+}
+
+func (c *statisticsConnection) GetStatisticNames(ctx context.Context) (array.RecordReader, error) {
+	return c.impl.GetStatisticNames(ctx)
+}
+
+func (c *statisticsConnection) AttachResult(ctx context.Context, queryID string) (array.RecordReader, error) {
+	return c.impl.AttachResult(ctx, queryID)
+}
+
+func (c *statisticsConnection) SetCurrentCatalogAndSchema(catalog, schema string) error {
+	return c.impl.SetCurrentCatalogAndSchema(catalog, schema)
+}
+
+func (c *statisticsConnection) Comments(ctx context.Context, catalog, dbSchema *string, tableName string) (*TableComments, error) {
+	return c.impl.Comments(ctx, catalog, dbSchema, tableName)
+}
+
+func (c *statisticsConnection) SetTableComment(ctx context.Context, catalog, dbSchema *string, tableName, comment string) error {
+	return c.impl.SetTableComment(ctx, catalog, dbSchema, tableName, comment)
+}
+
+func (c *statisticsConnection) SetColumnComment(ctx context.Context, catalog, dbSchema *string, tableName, columnName, comment string) error {
+	return c.impl.SetColumnComment(ctx, catalog, dbSchema, tableName, columnName, comment)
+}
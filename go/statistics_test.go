@@ -0,0 +1,89 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionPath(t *testing.T) {
+	assert.Equal(t, "region=us/day=2026-01-02", partitionPath(
+		[]string{"region", "day"},
+		[]sql.NullString{{String: "us", Valid: true}, {String: "2026-01-02", Valid: true}}))
+	assert.Equal(t, "region=__HIVE_DEFAULT_PARTITION__", partitionPath(
+		[]string{"region"}, []sql.NullString{{}}))
+}
+
+func TestParseStringArray(t *testing.T) {
+	values, err := parseStringArray(`["region","day"]`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"region", "day"}, values)
+
+	values, err = parseStringArray("")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = parseStringArray("region")
+	assert.Error(t, err)
+}
+
+func TestBuildStatistics(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	region := "region"
+	rec, err := buildStatistics(mem, []schemaStatistics{
+		{catalog: "main", dbSchema: "default", statistics: []statistic{
+			{table: "events", key: StatisticSizeInBytesKey, value: int64(4096)},
+			{table: "events", column: &region, key: StatisticPartitionColumnKey, value: int64(0)},
+			{table: "events", key: StatisticPartitionKey, value: []byte("region=us")},
+		}},
+		{catalog: "main", dbSchema: "empty"},
+		{catalog: "other", dbSchema: "default"},
+	})
+	require.NoError(t, err)
+	defer rec.Release()
+
+	require.Equal(t, int64(2), rec.NumRows())
+	assert.Equal(t, "main", rec.Column(0).(*array.String).Value(0))
+	assert.Equal(t, "other", rec.Column(0).(*array.String).Value(1))
+
+	schemas := rec.Column(1).(*array.List)
+	start, end := schemas.ValueOffsets(0)
+	assert.Equal(t, int64(2), end-start)
+	schemaStructs := schemas.ListValues().(*array.Struct)
+	assert.Equal(t, "empty", schemaStructs.Field(0).(*array.String).Value(1))
+
+	stats := schemaStructs.Field(1).(*array.List).ListValues().(*array.Struct)
+	require.Equal(t, 3, stats.Len())
+	assert.True(t, stats.Field(1).IsNull(0))
+	assert.Equal(t, "region", stats.Field(1).(*array.String).Value(1))
+	keys := stats.Field(2).(*array.Int16)
+	assert.Equal(t, []int16{StatisticSizeInBytesKey, StatisticPartitionColumnKey, StatisticPartitionKey}, keys.Int16Values())
+
+	values := stats.Field(3).(*array.DenseUnion)
+	assert.Equal(t, int64(4096), values.Field(0).(*array.Int64).Value(int(values.ValueOffset(0))))
+	assert.Equal(t, []byte("region=us"), values.Field(3).(*array.Binary).Value(int(values.ValueOffset(2))))
+	assert.False(t, stats.Field(4).(*array.Boolean).Value(0))
+
+	_, err = buildStatistics(mem, []schemaStatistics{{statistics: []statistic{{value: "x"}}}})
+	assert.Error(t, err)
+}
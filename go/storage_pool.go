@@ -0,0 +1,132 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+)
+
+// storageMaxConnsPerHost bounds the connections to one storage host.
+const storageMaxConnsPerHost = 32
+
+// storagePool is the transport for downloading result chunks from cloud
+// storage. Each storage host gets its own connection pool, separate from
+// the one for the SQL endpoint, so a slow host or a burst of downloads
+// doesn't hold up requests to the others. Downloads use HTTP/1.1, since
+// multiplexing them over one HTTP/2 connection serializes them behind the
+// largest.
+//
+// Downloads are further split by lane (see OptionFetchLane): each lane has
+// its own pool per host, so that a large extract using every connection it
+// may open to a host doesn't queue the downloads of interactive queries
+// behind it.
+//
+// When a connection to a host fails, the host's pool is dropped and the
+// request retried once on a fresh one, so that the host's name is resolved
+// again instead of reusing connections to an address that has gone away.
+//
+// Only downloads made by this driver go through the pool: results read
+// through the Statement Execution API (OptionValueProtocolREST, lenient
+// mode, jobs handoff) and partitions. CloudFetch results of the default
+// Thrift path are downloaded by databricks-sql-go, which uses
+// http.DefaultClient and offers no way to supply a transport, so they get
+// neither the per-host pools, the DNS re-resolution nor the lanes.
+type storagePool struct {
+	tlsConfig *tls.Config
+
+	mu         sync.Mutex
+	transports map[storagePoolKey]*http.Transport
+}
+
+// storagePoolKey identifies the connection pool of a lane to a host.
+type storagePoolKey struct {
+	lane string
+	host string
+}
+
+func newStoragePool(tlsConfig *tls.Config) *storagePool {
+	return &storagePool{tlsConfig: tlsConfig, transports: make(map[storagePoolKey]*http.Transport)}
+}
+
+func (p *storagePool) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := storagePoolKey{lane: fetchLane(req.Context()), host: req.URL.Host}
+	transport := p.transport(key)
+	resp, err := transport.RoundTrip(req)
+	if err == nil || !isConnectionError(err) || req.Context().Err() != nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	p.reset(key, transport)
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	return p.transport(key).RoundTrip(req)
+}
+
+// transport returns the pool for key, creating it if needed.
+func (p *storagePool) transport(key storagePoolKey) *http.Transport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	transport, ok := p.transports[key]
+	if !ok {
+		transport = newHTTPTransport(p.tlsConfig)
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		transport.MaxIdleConnsPerHost = storageMaxConnsPerHost
+		transport.MaxConnsPerHost = storageMaxConnsPerHost
+		p.transports[key] = transport
+	}
+	return transport
+}
+
+// reset drops the pool for key if it is still transport, which failed.
+// Connections in use are closed once their requests finish.
+func (p *storagePool) reset(key storagePoolKey, transport *http.Transport) {
+	p.mu.Lock()
+	if p.transports[key] == transport {
+		delete(p.transports, key)
+	}
+	p.mu.Unlock()
+	transport.CloseIdleConnections()
+}
+
+// CloseIdleConnections closes the idle connections of every host.
+func (p *storagePool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, transport := range p.transports {
+		transport.CloseIdleConnections()
+	}
+}
+
+// isConnectionError reports whether err means the connection to the server
+// could not be made or was lost, as opposed to an error from the server.
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	return errors.As(err, &dnsErr) || errors.As(err, &opErr) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
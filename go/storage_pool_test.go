@@ -0,0 +1,104 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoragePoolPerHost(t *testing.T) {
+	pool := newStoragePool(nil)
+	a := storagePoolKey{lane: OptionValueFetchLaneInteractive, host: "a.example.com"}
+	b := storagePoolKey{lane: OptionValueFetchLaneInteractive, host: "b.example.com"}
+	first := pool.transport(a)
+	assert.Same(t, first, pool.transport(a))
+	assert.NotSame(t, first, pool.transport(b))
+	assert.False(t, first.ForceAttemptHTTP2)
+	assert.NotNil(t, first.TLSNextProto)
+
+	// Each lane has its own connections to a host
+	assert.NotSame(t, first, pool.transport(storagePoolKey{lane: OptionValueFetchLaneBulk, host: "a.example.com"}))
+
+	pool.reset(a, first)
+	assert.NotSame(t, first, pool.transport(a))
+
+	// A stale reset doesn't drop the replacement
+	second := pool.transport(a)
+	pool.reset(a, first)
+	assert.Same(t, second, pool.transport(a))
+}
+
+func TestStoragePoolRoundTrip(t *testing.T) {
+	servers := make([]*httptest.Server, 2)
+	for i := range servers {
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, "server %d", i)
+		}))
+		defer servers[i].Close()
+	}
+
+	pool := newStoragePool(nil)
+	client := &http.Client{Transport: pool}
+	for i, server := range servers {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("server %d", i), string(body))
+	}
+	assert.Len(t, pool.transports, 2)
+
+	// Bulk downloads from the same hosts get their own pools
+	req, err := http.NewRequestWithContext(withFetchLane(context.Background(), OptionValueFetchLaneBulk), http.MethodGet, servers[0].URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Len(t, pool.transports, 3)
+}
+
+func TestStoragePoolConnectionError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	pool := newStoragePool(nil)
+	key := storagePoolKey{lane: OptionValueFetchLaneInteractive, host: addr}
+	failed := pool.transport(key)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://"+addr+"/chunk", nil)
+	require.NoError(t, err)
+	_, err = pool.RoundTrip(req)
+	require.Error(t, err)
+	assert.True(t, isConnectionError(err))
+	assert.NotSame(t, failed, pool.transport(key))
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert.True(t, isConnectionError(&net.DNSError{Err: "no such host", Name: "x"}))
+	assert.True(t, isConnectionError(fmt.Errorf("wrapped: %w", &net.OpError{Op: "dial", Err: io.EOF})))
+	assert.False(t, isConnectionError(io.ErrUnexpectedEOF))
+	assert.False(t, isConnectionError(context.Canceled))
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// tempDirPrefix starts the name of every directory the driver creates in
+// the temporary root. The owning process's ID follows it, so that the
+// directories of processes that exited without cleaning up can be found.
+const tempDirPrefix = "adbc-databricks-"
+
+// tempStore hands out the temporary files of a database: spilled results,
+// staged ingest data and cached results all live in one directory, private
+// to the current user, under the configured root. The files together may
+// hold at most maxBytes (zero for no limit). The directory is created on
+// first use and removed when the database is closed.
+type tempStore struct {
+	root     string
+	maxBytes int64
+	logger   *slog.Logger
+
+	mu   sync.Mutex
+	dir  string
+	used int64
+}
+
+func newTempStore(root string, maxBytes int64, logger *slog.Logger) *tempStore {
+	if root == "" {
+		root = os.TempDir()
+	}
+	return &tempStore{root: root, maxBytes: maxBytes, logger: logger}
+}
+
+// removeOrphans deletes the directories left in the root by processes that
+// are no longer running, e.g. after a crash. Failures are only logged.
+func (t *tempStore) removeOrphans() {
+	entries, err := os.ReadDir(t.root)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		pid, ok := tempDirOwner(entry)
+		if !ok || pid == os.Getpid() || processAlive(pid) {
+			continue
+		}
+		path := filepath.Join(t.root, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			t.logger.Warn("failed to remove orphaned temporary directory",
+				slog.String("path", path), slog.Any("error", err))
+		} else {
+			t.logger.Info("removed orphaned temporary directory", slog.String("path", path))
+		}
+	}
+}
+
+// tempDirOwner returns the ID of the process that created a temporary
+// directory, if entry is one.
+func tempDirOwner(entry os.DirEntry) (int, bool) {
+	name, ok := strings.CutPrefix(entry.Name(), tempDirPrefix)
+	if !ok || !entry.IsDir() {
+		return 0, false
+	}
+	pid, _, _ := strings.Cut(name, "-")
+	n, err := strconv.Atoi(pid)
+	return n, err == nil && n > 0
+}
+
+// create opens a new temporary file whose name starts with prefix. Only the
+// current user can read it.
+func (t *tempStore) create(prefix string) (*tempFile, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.dir == "" {
+		if err := os.MkdirAll(t.root, 0o700); err != nil {
+			return nil, adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to create temporary root %s: %v", t.root, err)}
+		}
+		t.removeOrphans()
+		dir, err := os.MkdirTemp(t.root, tempDirPrefix+strconv.Itoa(os.Getpid())+"-")
+		if err != nil {
+			return nil, adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to create temporary directory in %s: %v", t.root, err)}
+		}
+		t.dir = dir
+	}
+
+	// CreateTemp already restricts the file to the current user
+	f, err := os.CreateTemp(t.dir, prefix+"-*")
+	if err != nil {
+		return nil, adbc.Error{Code: adbc.StatusIO, Msg: fmt.Sprintf("failed to create temporary file: %v", err)}
+	}
+	return &tempFile{File: f, store: t}, nil
+}
+
+// reserve accounts for n more bytes on disk, failing if that would exceed
+// the limit.
+func (t *tempStore) reserve(n int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.maxBytes > 0 && t.used+n > t.maxBytes {
+		return adbc.Error{
+			Code: adbc.StatusIO,
+			Msg: fmt.Sprintf("temporary files would exceed %d bytes; raise %s or free space by releasing results",
+				t.maxBytes, OptionTempMaxBytes),
+		}
+	}
+	t.used += n
+	return nil
+}
+
+func (t *tempStore) unreserve(n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.used -= n
+}
+
+// Used returns the number of bytes held by open temporary files.
+func (t *tempStore) Used() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.used
+}
+
+// close removes the store's directory along with any files still in it.
+func (t *tempStore) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.dir == "" {
+		return nil
+	}
+	err := os.RemoveAll(t.dir)
+	t.dir = ""
+	t.used = 0
+	return err
+}
+
+// tempFile is a file of a tempStore. Writes count against the store's
+// limit, and Close deletes the file.
+type tempFile struct {
+	*os.File
+	store *tempStore
+	size  int64
+}
+
+func (f *tempFile) Write(p []byte) (int, error) {
+	if err := f.store.reserve(int64(len(p))); err != nil {
+		return 0, err
+	}
+	n, err := f.File.Write(p)
+	f.store.unreserve(int64(len(p) - n))
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes and deletes the file.
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+		err = removeErr
+	}
+	f.store.unreserve(f.size)
+	f.size = 0
+	return err
+}
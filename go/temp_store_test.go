@@ -0,0 +1,80 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTempStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "spill")
+	store := newTempStore(root, 16, slog.Default())
+
+	f, err := store.create("result")
+	require.NoError(t, err)
+	info, err := os.Stat(filepath.Dir(f.Name()))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	_, err = f.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), store.Used())
+
+	// The cap is shared by the store's files
+	g, err := store.create("ingest")
+	require.NoError(t, err)
+	_, err = g.Write([]byte("0123456789"))
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Contains(t, adbcErr.Msg, OptionTempMaxBytes)
+	assert.Equal(t, int64(10), store.Used())
+
+	require.NoError(t, f.Close())
+	assert.NoFileExists(t, f.Name())
+	assert.Equal(t, int64(0), store.Used())
+	require.NoError(t, g.Close())
+
+	require.NoError(t, store.close())
+	assert.NoDirExists(t, filepath.Dir(f.Name()))
+}
+
+func TestTempStoreRemovesOrphans(t *testing.T) {
+	root := t.TempDir()
+	// A process ID that can't belong to a running process on any platform
+	// we test on
+	orphan := filepath.Join(root, fmt.Sprintf("%s%d-1234", tempDirPrefix, 1<<30))
+	require.NoError(t, os.Mkdir(orphan, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(orphan, "result-1"), []byte("stale"), 0o600))
+	unrelated := filepath.Join(root, "other-app")
+	require.NoError(t, os.Mkdir(unrelated, 0o700))
+
+	store := newTempStore(root, 0, slog.Default())
+	f, err := store.create("result")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.close()) }()
+	defer func() { require.NoError(t, f.Close()) }()
+
+	assert.NoDirExists(t, orphan)
+	assert.DirExists(t, unrelated)
+	assert.DirExists(t, filepath.Dir(f.Name()))
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// parseDatabricksType converts a Databricks SQL type name, as printed by
+// DESCRIBE TABLE (e.g. "decimal(10,2)" or "map<string,array<int>>"), to the
+// corresponding Arrow type.
+//
+// Timestamps map to microsecond Arrow timestamps (UTC for TIMESTAMP,
+// timezone-naive for TIMESTAMP_NTZ). Types without an Arrow equivalent
+// (intervals, VARIANT, ...) are reported as strings, which is how they are
+// returned in query results.
+func parseDatabricksType(typeName string) (arrow.DataType, error) {
+	p := typeParser{input: typeName}
+	dt, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected trailing input")
+	}
+	return dt, nil
+}
+
+type typeParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid type %q at offset %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *typeParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space byte, or 0 at the end of input.
+func (p *typeParser) peek() byte {
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *typeParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+// word reads a run of identifier characters.
+func (p *typeParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// fieldName reads a struct field name, which may be backquoted.
+func (p *typeParser) fieldName() (string, error) {
+	if p.peek() != '`' {
+		name := p.word()
+		if name == "" {
+			return "", p.errorf("expected field name")
+		}
+		return name, nil
+	}
+
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		if c != '`' {
+			b.WriteByte(c)
+			continue
+		}
+		if p.pos < len(p.input) && p.input[p.pos] == '`' {
+			// Doubled backquote
+			b.WriteByte('`')
+			p.pos++
+			continue
+		}
+		return b.String(), nil
+	}
+	return "", p.errorf("unterminated quoted field name")
+}
+
+// integerArgs reads a parenthesized, comma-separated list of integers, if
+// present.
+func (p *typeParser) integerArgs() ([]int, error) {
+	if p.peek() != '(' {
+		return nil, nil
+	}
+	p.pos++
+	var args []int
+	for {
+		n, err := strconv.Atoi(p.word())
+		if err != nil {
+			return nil, p.errorf("expected integer")
+		}
+		args = append(args, n)
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		return args, p.expect(')')
+	}
+}
+
+func (p *typeParser) parseType() (arrow.DataType, error) {
+	name := strings.ToLower(p.word())
+	switch name {
+	case "boolean":
+		return arrow.FixedWidthTypes.Boolean, nil
+	case "tinyint", "byte":
+		return arrow.PrimitiveTypes.Int8, nil
+	case "smallint", "short":
+		return arrow.PrimitiveTypes.Int16, nil
+	case "int", "integer":
+		return arrow.PrimitiveTypes.Int32, nil
+	case "bigint", "long":
+		return arrow.PrimitiveTypes.Int64, nil
+	case "float", "real":
+		return arrow.PrimitiveTypes.Float32, nil
+	case "double":
+		return arrow.PrimitiveTypes.Float64, nil
+	case "date":
+		return arrow.FixedWidthTypes.Date32, nil
+	case "timestamp", "timestamp_ltz":
+		return arrow.FixedWidthTypes.Timestamp_us, nil
+	case "timestamp_ntz":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, nil
+	case "binary":
+		return arrow.BinaryTypes.Binary, nil
+	case "void", "null":
+		return arrow.Null, nil
+	case "string", "char", "varchar":
+		// CHAR(n) and VARCHAR(n) carry a length we have no use for
+		if _, err := p.integerArgs(); err != nil {
+			return nil, err
+		}
+		return arrow.BinaryTypes.String, nil
+	case "decimal", "dec", "numeric":
+		args, err := p.integerArgs()
+		if err != nil {
+			return nil, err
+		}
+		// Databricks defaults to DECIMAL(10, 0)
+		precision, scale := 10, 0
+		switch len(args) {
+		case 0:
+		case 1:
+			precision = args[0]
+		case 2:
+			precision, scale = args[0], args[1]
+		default:
+			return nil, p.errorf("decimal takes at most two arguments")
+		}
+		return arrow.NewDecimalType(arrow.DECIMAL128, int32(precision), int32(scale))
+	case "interval":
+		// e.g. "interval day to second"; consume the qualifiers
+		for {
+			save := p.pos
+			switch strings.ToLower(p.word()) {
+			case "year", "month", "day", "hour", "minute", "second", "to":
+				continue
+			}
+			p.pos = save
+			return arrow.BinaryTypes.String, nil
+		}
+	case "variant", "object", "geography", "geometry":
+		// GEOGRAPHY/GEOMETRY may carry an SRID argument
+		if _, err := p.integerArgs(); err != nil {
+			return nil, err
+		}
+		return arrow.BinaryTypes.String, nil
+	case "array":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elem), nil
+	case "map":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		key, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		value, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return arrow.MapOf(key, value), nil
+	case "struct":
+		return p.parseStruct()
+	case "":
+		return nil, p.errorf("expected type name")
+	default:
+		return nil, p.errorf("unsupported type %q", name)
+	}
+}
+
+func (p *typeParser) parseStruct() (arrow.DataType, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+	fields := []arrow.Field{}
+	if p.peek() == '>' {
+		p.pos++
+		return arrow.StructOf(fields...), nil
+	}
+	for {
+		name, err := p.fieldName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(':'); err != nil {
+			return nil, err
+		}
+		dt, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		field := arrow.Field{Name: name, Type: dt, Nullable: true}
+		if err := p.fieldModifiers(&field); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '>':
+			p.pos++
+			return arrow.StructOf(fields...), nil
+		default:
+			return nil, p.errorf("expected ',' or '>'")
+		}
+	}
+}
+
+// fieldModifiers consumes the NOT NULL and COMMENT clauses that may follow
+// a struct field's type.
+func (p *typeParser) fieldModifiers(field *arrow.Field) error {
+	for {
+		save := p.pos
+		switch strings.ToLower(p.word()) {
+		case "not":
+			if !strings.EqualFold(p.word(), "null") {
+				return p.errorf("expected NULL after NOT")
+			}
+			field.Nullable = false
+		case "comment":
+			if err := p.skipStringLiteral(); err != nil {
+				return err
+			}
+		default:
+			p.pos = save
+			return nil
+		}
+	}
+}
+
+func (p *typeParser) skipStringLiteral() error {
+	quote := p.peek()
+	if quote != '\'' && quote != '"' {
+		return p.errorf("expected string literal")
+	}
+	p.pos++
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		switch c {
+		case '\\':
+			p.pos++
+		case quote:
+			if p.pos < len(p.input) && p.input[p.pos] == quote {
+				// Doubled quote
+				p.pos++
+				continue
+			}
+			return nil
+		}
+	}
+	return p.errorf("unterminated string literal")
+}
+
+// thriftTypeToArrow returns the Arrow type of a result column given its
+// Thrift type name as reported by databricks-sql-go (e.g. "INT" or
+// "TIMESTAMP"). It matches the types of the Arrow batches the driver
+// receives: decimals and intervals arrive as strings. Complex types carry
+// no element types in the name and are reported as strings too.
+func thriftTypeToArrow(typeName string) arrow.DataType {
+	switch typeName {
+	case "BOOLEAN":
+		return arrow.FixedWidthTypes.Boolean
+	case "TINYINT":
+		return arrow.PrimitiveTypes.Int8
+	case "SMALLINT":
+		return arrow.PrimitiveTypes.Int16
+	case "INT":
+		return arrow.PrimitiveTypes.Int32
+	case "BIGINT":
+		return arrow.PrimitiveTypes.Int64
+	case "FLOAT":
+		return arrow.PrimitiveTypes.Float32
+	case "DOUBLE":
+		return arrow.PrimitiveTypes.Float64
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32
+	case "TIMESTAMP":
+		return arrow.FixedWidthTypes.Timestamp_us
+	case "BINARY":
+		return arrow.BinaryTypes.Binary
+	case "NULL":
+		return arrow.Null
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
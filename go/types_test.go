@@ -0,0 +1,135 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDatabricksType(t *testing.T) {
+	tests := []struct {
+		typeName string
+		expected arrow.DataType
+	}{
+		{"boolean", arrow.FixedWidthTypes.Boolean},
+		{"tinyint", arrow.PrimitiveTypes.Int8},
+		{"smallint", arrow.PrimitiveTypes.Int16},
+		{"int", arrow.PrimitiveTypes.Int32},
+		{"BIGINT", arrow.PrimitiveTypes.Int64},
+		{"float", arrow.PrimitiveTypes.Float32},
+		{"double", arrow.PrimitiveTypes.Float64},
+		{"string", arrow.BinaryTypes.String},
+		{"varchar(20)", arrow.BinaryTypes.String},
+		{"binary", arrow.BinaryTypes.Binary},
+		{"date", arrow.FixedWidthTypes.Date32},
+		{"timestamp", arrow.FixedWidthTypes.Timestamp_us},
+		{"timestamp_ntz", &arrow.TimestampType{Unit: arrow.Microsecond}},
+		{"void", arrow.Null},
+		{"decimal", &arrow.Decimal128Type{Precision: 10, Scale: 0}},
+		{"decimal(38, 18)", &arrow.Decimal128Type{Precision: 38, Scale: 18}},
+		{"interval day to second", arrow.BinaryTypes.String},
+		{"variant", arrow.BinaryTypes.String},
+		{"array<int>", arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+		{"map<string,array<decimal(5,2)>>", arrow.MapOf(
+			arrow.BinaryTypes.String,
+			arrow.ListOf(&arrow.Decimal128Type{Precision: 5, Scale: 2}),
+		)},
+		{"struct<a:int,`b c`:string NOT NULL COMMENT 'it''s, <odd>'>", arrow.StructOf(
+			arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			arrow.Field{Name: "b c", Type: arrow.BinaryTypes.String},
+		)},
+		{"struct<i:interval year to month,t:timestamp>", arrow.StructOf(
+			arrow.Field{Name: "i", Type: arrow.BinaryTypes.String, Nullable: true},
+			arrow.Field{Name: "t", Type: arrow.FixedWidthTypes.Timestamp_us, Nullable: true},
+		)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.typeName, func(t *testing.T) {
+			dt, err := parseDatabricksType(tc.typeName)
+			require.NoError(t, err)
+			assert.True(t, arrow.TypeEqual(tc.expected, dt), "expected %s, got %s", tc.expected, dt)
+		})
+	}
+}
+
+func TestParseDatabricksTypeInvalid(t *testing.T) {
+	for _, typeName := range []string{"", "hyperloglog", "array<int", "map<int>", "decimal(1,2,3)", "struct<a int>", "int extra"} {
+		_, err := parseDatabricksType(typeName)
+		assert.Error(t, err, typeName)
+	}
+}
+
+func TestSchemaCache(t *testing.T) {
+	cache := newSchemaCache()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+
+	_, ok := cache.get("`c`.`s`.`t`")
+	assert.False(t, ok)
+
+	cache.put("`c`.`s`.`t`", schemaCacheEntry{schema: schema, version: 3, verified: time.Now()})
+	entry, ok := cache.get("`c`.`s`.`t`")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), entry.version)
+	assert.Same(t, schema, entry.schema)
+
+	cache.remove("`c`.`s`.`t`")
+	_, ok = cache.get("`c`.`s`.`t`")
+	assert.False(t, ok)
+
+	cache.put("`c`.`s`.`t`", entry)
+	cache.clear()
+	_, ok = cache.get("`c`.`s`.`t`")
+	assert.False(t, ok)
+}
+
+func TestQualifiedTableName(t *testing.T) {
+	assert.Equal(t, "`main`.`my schema`.`odd``name`", qualifiedTableName("main", "my schema", "odd`name"))
+}
+
+func TestThriftTypeToArrow(t *testing.T) {
+	tests := []struct {
+		typeName string
+		expected arrow.DataType
+	}{
+		{"BOOLEAN", arrow.FixedWidthTypes.Boolean},
+		{"TINYINT", arrow.PrimitiveTypes.Int8},
+		{"SMALLINT", arrow.PrimitiveTypes.Int16},
+		{"INT", arrow.PrimitiveTypes.Int32},
+		{"BIGINT", arrow.PrimitiveTypes.Int64},
+		{"FLOAT", arrow.PrimitiveTypes.Float32},
+		{"DOUBLE", arrow.PrimitiveTypes.Float64},
+		{"DATE", arrow.FixedWidthTypes.Date32},
+		{"TIMESTAMP", arrow.FixedWidthTypes.Timestamp_us},
+		{"BINARY", arrow.BinaryTypes.Binary},
+		{"NULL", arrow.Null},
+		{"STRING", arrow.BinaryTypes.String},
+		{"DECIMAL", arrow.BinaryTypes.String},
+		{"INTERVAL_DAY_TIME", arrow.BinaryTypes.String},
+		{"ARRAY", arrow.BinaryTypes.String},
+		{"", arrow.BinaryTypes.String},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			assert.True(t, arrow.TypeEqual(tt.expected, thriftTypeToArrow(tt.typeName)))
+		})
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+const (
+	// u2mLoginTimeout bounds how long the driver waits for the user to
+	// sign in in the browser.
+	u2mLoginTimeout = 5 * time.Minute
+	// u2mRefreshMargin is how long before it expires an access token is
+	// refreshed.
+	u2mRefreshMargin = time.Minute
+)
+
+// u2mAuth authenticates requests as a user signed in through the browser
+// with the OAuth authorization code flow and PKCE. The refresh token is
+// kept in a cache file, if there is one, so that the user only signs in
+// again once it stops working.
+type u2mAuth struct {
+	client   *http.Client
+	baseURL  string
+	clientID string
+	// Port of the local redirect listener; zero picks a free one
+	port   int
+	cache  *u2mTokenCache
+	logger *slog.Logger
+	// Opens the authorization URL for the user
+	openBrowser func(string) error
+	clock       driverClock
+	// Owner of the redirect server's goroutine; the database's
+	goroutines *goroutineGroup
+
+	mu           sync.Mutex
+	token        string
+	refreshToken string
+	expiry       time.Time
+}
+
+// u2mTokens is the response of the token endpoint.
+type u2mTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (a *u2mAuth) Authenticate(r *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token == "" || a.clock.Now().Add(u2mRefreshMargin).After(a.expiry) {
+		if err := a.renew(r.Context()); err != nil {
+			return err
+		}
+	}
+	r.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+// renew obtains a new access token with the refresh token, falling back to
+// signing in through the browser if there is none or it was rejected.
+func (a *u2mAuth) renew(ctx context.Context) error {
+	if a.refreshToken == "" {
+		a.refreshToken = a.cache.load(a.cacheKey())
+	}
+	if a.refreshToken != "" {
+		tokens, err := a.requestTokens(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {a.refreshToken},
+			"client_id":     {a.clientID},
+		})
+		if err == nil {
+			return a.store(tokens)
+		}
+		a.logger.WarnContext(ctx, "OAuth refresh token rejected; signing in again", slog.Any("error", err))
+		a.refreshToken = ""
+	}
+	tokens, err := a.login(ctx)
+	if err != nil {
+		return err
+	}
+	return a.store(tokens)
+}
+
+// store keeps tokens and saves the refresh token to the cache.
+func (a *u2mAuth) store(tokens u2mTokens) error {
+	if tokens.AccessToken == "" {
+		return adbc.Error{Code: adbc.StatusUnauthenticated, Msg: "token endpoint returned no access token"}
+	}
+	a.token = tokens.AccessToken
+	a.expiry = a.clock.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	if tokens.RefreshToken != "" {
+		a.refreshToken = tokens.RefreshToken
+		if err := a.cache.save(a.cacheKey(), a.refreshToken); err != nil {
+			a.logger.Warn("failed to cache OAuth refresh token", slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (a *u2mAuth) cacheKey() string {
+	return a.baseURL + " " + a.clientID
+}
+
+// login has the user sign in through the browser and exchanges the
+// authorization code the browser is redirected back with for tokens.
+func (a *u2mAuth) login(ctx context.Context) (u2mTokens, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(a.port)))
+	if err != nil {
+		return u2mTokens{}, adbc.Error{
+			Code: adbc.StatusUnauthenticated,
+			Msg:  fmt.Sprintf("failed to listen for the OAuth redirect on port %d: %v", a.port, err),
+		}
+	}
+	defer func() { _ = listener.Close() }()
+	redirectURI := fmt.Sprintf("http://localhost:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	verifier := rand.Text() + rand.Text()
+	challenge := sha256.Sum256([]byte(verifier))
+	state := rand.Text()
+	authorizeURL := a.baseURL + "/oidc/v1/authorize?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.clientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"all-apis offline_access"},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+
+	type callback struct {
+		code string
+		err  error
+	}
+	callbacks := make(chan callback, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			var result callback
+			switch {
+			case q.Get("error") != "":
+				result.err = fmt.Errorf("%s: %s", q.Get("error"), q.Get("error_description"))
+			case q.Get("state") != state:
+				result.err = errors.New("state mismatch in OAuth redirect")
+			case q.Get("code") == "":
+				result.err = errors.New("no authorization code in OAuth redirect")
+			default:
+				result.code = q.Get("code")
+			}
+			if result.err != nil {
+				http.Error(w, "Sign-in failed: "+result.err.Error(), http.StatusBadRequest)
+			} else {
+				_, _ = fmt.Fprintln(w, "Signed in to Databricks. You can close this window.")
+			}
+			select {
+			case callbacks <- result:
+			default:
+			}
+		}),
+	}
+	a.goroutines.Go(func(context.Context) { _ = server.Serve(listener) })
+	defer func() { _ = server.Close() }()
+
+	a.logger.InfoContext(ctx, "opening the browser to sign in to Databricks", slog.String("url", authorizeURL))
+	if err := a.openBrowser(authorizeURL); err != nil {
+		// The user can still open the logged URL by hand
+		a.logger.WarnContext(ctx, "failed to open the browser; open the sign-in URL manually",
+			slog.String("url", authorizeURL), slog.Any("error", err))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u2mLoginTimeout)
+	defer cancel()
+	var result callback
+	select {
+	case result = <-callbacks:
+	case <-ctx.Done():
+		return u2mTokens{}, adbc.Error{
+			Code: adbc.StatusUnauthenticated,
+			Msg:  fmt.Sprintf("timed out waiting for browser sign-in: %v", ctx.Err()),
+		}
+	}
+	if result.err != nil {
+		return u2mTokens{}, adbc.Error{
+			Code: adbc.StatusUnauthenticated,
+			Msg:  fmt.Sprintf("browser sign-in failed: %v", result.err),
+		}
+	}
+	return a.requestTokens(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {a.clientID},
+		"code_verifier": {verifier},
+	})
+}
+
+func (a *u2mAuth) requestTokens(ctx context.Context, form url.Values) (u2mTokens, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/oidc/v1/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return u2mTokens{}, adbc.Error{Code: adbc.StatusInternal, Msg: fmt.Sprintf("failed to create token request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var tokens u2mTokens
+	if err := doTokenRequest(a.client, req, &tokens); err != nil {
+		return u2mTokens{}, err
+	}
+	return tokens, nil
+}
+
+// openBrowser opens u in the user's default browser.
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
+
+// u2mTokenCache keeps refresh tokens, by workspace and client, in a file
+// readable only by the user. A nil cache keeps nothing.
+type u2mTokenCache struct {
+	path string
+	mu   sync.Mutex
+}
+
+// newU2MTokenCache returns the cache of OptionOAuthU2MTokenCache: the file
+// at path, a file in the user's cache directory if path is empty, or no
+// cache for OptionValueTokenCacheNone.
+func newU2MTokenCache(path string) *u2mTokenCache {
+	switch path {
+	case OptionValueTokenCacheNone:
+		return nil
+	case "":
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "adbc-drivers", "databricks", "oauth-tokens.json")
+	}
+	return &u2mTokenCache{path: path}
+}
+
+func (c *u2mTokenCache) read() map[string]string {
+	tokens := map[string]string{}
+	data, err := os.ReadFile(c.path)
+	if err == nil {
+		_ = json.Unmarshal(data, &tokens)
+	}
+	return tokens
+}
+
+// load returns the refresh token cached under key, or "".
+func (c *u2mTokenCache) load(key string) string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.read()[key]
+}
+
+// save caches refreshToken under key.
+func (c *u2mTokenCache) save(key, refreshToken string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens := c.read()
+	tokens[key] = refreshToken
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	// Write and rename, so that a concurrent reader never sees a partial
+	// file
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".oauth-tokens-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
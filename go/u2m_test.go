@@ -0,0 +1,145 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOIDCServer is a workspace's OAuth endpoints, which sign in every
+// authorization request at once.
+type fakeOIDCServer struct {
+	t         *testing.T
+	challenge string
+	grants    []string
+}
+
+func (f *fakeOIDCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/oidc/v1/authorize":
+		q := r.URL.Query()
+		assert.Equal(f.t, "code", q.Get("response_type"))
+		assert.Equal(f.t, "S256", q.Get("code_challenge_method"))
+		assert.Contains(f.t, q.Get("scope"), "offline_access")
+		f.challenge = q.Get("code_challenge")
+		redirect, err := url.Parse(q.Get("redirect_uri"))
+		require.NoError(f.t, err)
+		redirect.RawQuery = url.Values{"code": {"auth-code"}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	case "/oidc/v1/token":
+		require.NoError(f.t, r.ParseForm())
+		grant := r.PostForm.Get("grant_type")
+		f.grants = append(f.grants, grant)
+		assert.Equal(f.t, "app-id", r.PostForm.Get("client_id"))
+		switch grant {
+		case "authorization_code":
+			assert.Equal(f.t, "auth-code", r.PostForm.Get("code"))
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			assert.Equal(f.t, f.challenge, base64.RawURLEncoding.EncodeToString(sum[:]))
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "user-token",
+			"refresh_token": "refresh-1",
+			"expires_in":    3600,
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestU2MAuth(t *testing.T) {
+	oidc := &fakeOIDCServer{t: t}
+	server := httptest.NewServer(oidc)
+	defer server.Close()
+	cache := &u2mTokenCache{path: filepath.Join(t.TempDir(), "tokens", "oauth-tokens.json")}
+
+	newAuth := func(openBrowser func(string) error) *u2mAuth {
+		return &u2mAuth{
+			client:      server.Client(),
+			baseURL:     server.URL,
+			clientID:    "app-id",
+			cache:       cache,
+			logger:      slog.Default(),
+			openBrowser: openBrowser,
+		}
+	}
+	// The browser signs in and follows the redirect back to the driver
+	browser := func(u string) error {
+		resp, err := http.Get(u)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	auth := newAuth(browser)
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+		require.NoError(t, auth.Authenticate(req))
+		assert.Equal(t, "Bearer user-token", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, []string{"authorization_code"}, oidc.grants)
+
+	info, err := os.Stat(cache.path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.Equal(t, "refresh-1", cache.load(server.URL+" app-id"))
+
+	// A later connection signs in with the cached refresh token
+	auth = newAuth(func(string) error { return errors.New("browser not expected") })
+	req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, auth.Authenticate(req))
+	assert.Equal(t, []string{"authorization_code", "refresh_token"}, oidc.grants)
+
+	// A rejected refresh token falls back to the browser
+	require.NoError(t, cache.save(server.URL+" app-id", "revoked"))
+	auth = newAuth(browser)
+	require.NoError(t, auth.Authenticate(httptest.NewRequest(http.MethodGet, "https://example.com", nil)))
+	assert.Equal(t, []string{"authorization_code", "refresh_token", "refresh_token", "authorization_code"}, oidc.grants)
+	assert.Equal(t, "refresh-1", cache.load(server.URL+" app-id"))
+}
+
+func TestU2MTokenCache(t *testing.T) {
+	assert.Nil(t, newU2MTokenCache(OptionValueTokenCacheNone))
+	var none *u2mTokenCache
+	require.NoError(t, none.save("key", "token"))
+	assert.Empty(t, none.load("key"))
+
+	path := filepath.Join(t.TempDir(), "cache.json")
+	cache := newU2MTokenCache(path)
+	require.NoError(t, cache.save("a", "1"))
+	require.NoError(t, cache.save("b", "2"))
+	assert.Equal(t, "1", cache.load("a"))
+	assert.Equal(t, "2", cache.load("b"))
+	assert.Empty(t, cache.load("c"))
+}
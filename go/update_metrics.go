@@ -0,0 +1,136 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Metric columns returned by COPY INTO and MERGE.
+const (
+	metricAffectedRows = "num_affected_rows"
+	metricInsertedRows = "num_inserted_rows"
+)
+
+// leadingKeyword returns the first word of query, upper-cased, skipping
+// whitespace and comments.
+func leadingKeyword(query string) string {
+	for {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(query, "--"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return ""
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			end := strings.Index(query[2:], "*/")
+			if end < 0 {
+				return ""
+			}
+			query = query[end+4:]
+		default:
+			end := strings.IndexFunc(query, func(r rune) bool {
+				return !unicode.IsLetter(r) && r != '_'
+			})
+			if end < 0 {
+				end = len(query)
+			}
+			return strings.ToUpper(query[:end])
+		}
+	}
+}
+
+// reportsUpdateMetrics is whether query is a statement whose result set
+// holds row and file counts (COPY INTO or MERGE).
+func reportsUpdateMetrics(query string) bool {
+	switch leadingKeyword(query) {
+	case "COPY", "MERGE":
+		return true
+	}
+	return false
+}
+
+// readUpdateMetrics reads the integer columns of the single row returned by
+// COPY INTO or MERGE, keyed by column name, and closes rows.
+func readUpdateMetrics(rows *sql.Rows) (metrics map[string]int64, err error) {
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	metrics = make(map[string]int64, len(columns))
+	if !rows.Next() {
+		return metrics, rows.Err()
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	for i, name := range columns {
+		switch v := values[i].(type) {
+		case int64:
+			metrics[name] = v
+		case int32:
+			metrics[name] = int64(v)
+		case int16:
+			metrics[name] = int64(v)
+		case int8:
+			metrics[name] = int64(v)
+		}
+	}
+	// Any further rows (e.g. from COPY INTO with VALIDATE) carry no counts
+	for rows.Next() {
+	}
+	return metrics, rows.Err()
+}
+
+// metricsRowsAffected returns the affected row count reported in metrics,
+// or -1 if there is none.
+func metricsRowsAffected(metrics map[string]int64) int64 {
+	if n, ok := metrics[metricAffectedRows]; ok {
+		return n
+	}
+	if n, ok := metrics[metricInsertedRows]; ok {
+		return n
+	}
+	return -1
+}
+
+// formatUpdateMetrics renders metrics as a JSON object.
+func formatUpdateMetrics(metrics map[string]int64) (string, error) {
+	if metrics == nil {
+		return "{}", nil
+	}
+	out, err := json.Marshal(metrics)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode update metrics: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadingKeyword(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"MERGE INTO t USING s ON t.id = s.id", "MERGE"},
+		{"  copy into t FROM '/path'", "COPY"},
+		{"-- load\nCOPY INTO t", "COPY"},
+		{"/* a */ /* b */merge into t", "MERGE"},
+		{"SELECT 1", "SELECT"},
+		{"(SELECT 1)", ""},
+		{"-- only a comment", ""},
+		{"/* unterminated", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, leadingKeyword(tt.query), tt.query)
+	}
+
+	assert.True(t, reportsUpdateMetrics("merge into t using s on true when matched then delete"))
+	assert.True(t, reportsUpdateMetrics("COPY INTO t FROM '/path' FILEFORMAT = CSV"))
+	assert.False(t, reportsUpdateMetrics("INSERT INTO t VALUES (1)"))
+}
+
+func TestMetricsRowsAffected(t *testing.T) {
+	assert.Equal(t, int64(7), metricsRowsAffected(map[string]int64{
+		"num_affected_rows": 7, "num_inserted_rows": 3, "num_updated_rows": 4,
+	}))
+	assert.Equal(t, int64(5), metricsRowsAffected(map[string]int64{
+		"num_inserted_rows": 5, "num_skipped_corrupt_files": 1,
+	}))
+	assert.Equal(t, int64(-1), metricsRowsAffected(map[string]int64{}))
+}
+
+func TestFormatUpdateMetrics(t *testing.T) {
+	out, err := formatUpdateMetrics(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "{}", out)
+
+	out, err = formatUpdateMetrics(map[string]int64{"num_inserted_rows": 2, "num_affected_rows": 2})
+	require.NoError(t, err)
+	assert.Equal(t, `{"num_affected_rows":2,"num_inserted_rows":2}`, out)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
+)
+
+// explainErrorPrefix starts the plan EXPLAIN returns for a query that
+// parses but fails analysis (unknown table, type mismatch, ...).
+const explainErrorPrefix = "Error occurred during query planning"
+
+// notDescribableSQLState is the SQLSTATE of DESCRIBE QUERY for statements
+// that aren't queries: DESCRIBE QUERY only accepts a query, so the
+// statement fails to parse. validateQuery has already parsed the statement
+// on its own, so this can't hide a syntax error of the statement.
+const notDescribableSQLState = "42601"
+
+// executeValidateOnly implements ExecuteQuery for OptionValidateOnly: the
+// query is compiled but not run, and an empty result with the schema the
+// query would produce is returned.
+func (s *statementImpl) executeValidateOnly(ctx context.Context, conn *sql.Conn) (array.RecordReader, int64, error) {
+	if err := s.validateQuery(ctx, conn); err != nil {
+		return nil, -1, err
+	}
+	schema, err := s.describeQuery(ctx, conn)
+	if err != nil {
+		return nil, -1, err
+	}
+	rdr, err := array.NewRecordReader(schema, nil)
+	if err != nil {
+		return nil, -1, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to create record reader: %v", err)
+	}
+	return rdr, 0, nil
+}
+
+// validateQuery compiles the statement's query with EXPLAIN, which uses no
+// warehouse compute, and reports compile errors as InvalidArgument.
+func (s *statementImpl) validateQuery(ctx context.Context, conn *sql.Conn) error {
+	var plan string
+	err := conn.QueryRowContext(ctx, "EXPLAIN "+annotateQuery(ctx, s.query)).Scan(&plan)
+	if err != nil {
+		var dbExecutionErr dbsqlerr.DBExecutionError
+		if errors.As(err, &dbExecutionErr) {
+			return s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "query failed validation: %v", err)
+		}
+		return s.ErrorHelper.Errorf(adbc.StatusIO, "failed to validate query: %v", err)
+	}
+	if strings.HasPrefix(plan, explainErrorPrefix) {
+		msg := strings.TrimSpace(strings.TrimPrefix(plan, explainErrorPrefix))
+		msg = strings.TrimSpace(strings.TrimPrefix(msg, ":"))
+		return s.ErrorHelper.Errorf(adbc.StatusInvalidArgument, "query failed validation: %s", msg)
+	}
+	return nil
+}
+
+// describeQuery returns the schema of the query's result as reported by
+// DESCRIBE QUERY. Statements that don't produce a result set (INSERT,
+// MERGE, DDL, ...) can't be described and get an empty schema; other
+// failures are returned.
+func (s *statementImpl) describeQuery(ctx context.Context, conn *sql.Conn) (schema *arrow.Schema, err error) {
+	rows, err := conn.QueryContext(ctx, "DESCRIBE QUERY "+s.query)
+	if err != nil {
+		if notDescribable(err) {
+			return arrow.NewSchema([]arrow.Field{}, nil), nil
+		}
+		return nil, s.ErrorHelper.Errorf(adbc.StatusIO, "failed to describe query: %v", err)
+	}
+	defer func() {
+		err = errors.Join(err, rows.Close())
+	}()
+
+	fields := []arrow.Field{}
+	for rows.Next() {
+		var colName string
+		var dataType, comment sql.NullString
+		if err := rows.Scan(&colName, &dataType, &comment); err != nil {
+			return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to scan query column: %v", err)
+		}
+		dt, err := parseDatabricksType(dataType.String)
+		if err != nil {
+			return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to convert type of column %s: %v", colName, err)
+		}
+		fields = append(fields, arrow.Field{
+			Name:     colName,
+			Type:     dt,
+			Nullable: true,
+			Metadata: arrow.MetadataFrom(map[string]string{"DATA_TYPE": displayTypeName(dataType.String)}),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, s.ErrorHelper.Errorf(adbc.StatusInternal, "failed to describe query: %v", err)
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// notDescribable reports whether err is DESCRIBE QUERY refusing a
+// statement that doesn't produce a result set.
+func notDescribable(err error) bool {
+	var dbExecutionErr dbsqlerr.DBExecutionError
+	return errors.As(err, &dbExecutionErr) && dbExecutionErr.SqlState() == notDescribableSQLState
+}
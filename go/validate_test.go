@@ -0,0 +1,43 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// sqlStateError is a DBExecutionError with the given SQLSTATE.
+type sqlStateError struct {
+	dbsqlerr.DBExecutionError
+	state string
+}
+
+func (e sqlStateError) Error() string    { return "SQLSTATE: " + e.state }
+func (e sqlStateError) SqlState() string { return e.state }
+
+func TestNotDescribable(t *testing.T) {
+	assert.True(t, notDescribable(sqlStateError{state: notDescribableSQLState}))
+	assert.True(t, notDescribable(fmt.Errorf("query failed: %w", sqlStateError{state: notDescribableSQLState})))
+
+	// Missing tables, permissions and transport errors are real failures
+	assert.False(t, notDescribable(sqlStateError{state: "42P01"}))
+	assert.False(t, notDescribable(sqlStateError{state: "42501"}))
+	assert.False(t, notDescribable(errors.New("connection reset")))
+}
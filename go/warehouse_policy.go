@@ -0,0 +1,151 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// warehouseInfo is a SQL warehouse as listed by the SQL Warehouses API.
+type warehouseInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ClusterSize string `json:"cluster_size"`
+	State       string `json:"state"`
+	Tags        struct {
+		CustomTags []warehouseTag `json:"custom_tags"`
+	} `json:"tags"`
+}
+
+type warehouseTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// warehouseSizes orders the cluster sizes of SQL warehouses.
+var warehouseSizes = map[string]int{
+	"2X-Small": 1, "X-Small": 2, "Small": 3, "Medium": 4, "Large": 5,
+	"X-Large": 6, "2X-Large": 7, "3X-Large": 8, "4X-Large": 9,
+}
+
+// warehouseStateRank orders warehouse states by how soon a warehouse in
+// them can run queries. Warehouses in other states (being deleted) are
+// never picked.
+var warehouseStateRank = map[string]int{
+	"RUNNING": 0, "STARTING": 1, "STOPPED": 2, "STOPPING": 3,
+}
+
+// hasTags reports whether w carries all of tags.
+func (w warehouseInfo) hasTags(tags []queryTag) bool {
+	for _, tag := range tags {
+		if !slices.Contains(w.Tags.CustomTags, warehouseTag{Key: tag.key, Value: tag.value}) {
+			return false
+		}
+	}
+	return true
+}
+
+// pickWarehouse chooses the smallest of the warehouses carrying tags,
+// preferring running warehouses over ones that would have to start. Ties
+// are broken by name, so that the choice is stable.
+func pickWarehouse(warehouses []warehouseInfo, tags []queryTag) (warehouseInfo, bool) {
+	var candidates []warehouseInfo
+	for _, w := range warehouses {
+		if _, ok := warehouseStateRank[w.State]; ok && w.hasTags(tags) {
+			candidates = append(candidates, w)
+		}
+	}
+	if len(candidates) == 0 {
+		return warehouseInfo{}, false
+	}
+	return slices.MinFunc(candidates, func(a, b warehouseInfo) int {
+		if c := cmp.Compare(warehouseStateRank[a.State], warehouseStateRank[b.State]); c != 0 {
+			return c
+		}
+		// Unknown sizes sort last
+		if c := cmp.Compare(sizeRank(a.ClusterSize), sizeRank(b.ClusterSize)); c != 0 {
+			return c
+		}
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	}), true
+}
+
+func sizeRank(size string) int {
+	if rank, ok := warehouseSizes[size]; ok {
+		return rank
+	}
+	return len(warehouseSizes) + 1
+}
+
+// selectWarehouse fills in the HTTP path of endpoint with a warehouse of
+// its workspace picked by OptionWarehousePolicy, if the policy is set and
+// no HTTP path was given. The pick is kept for the workspace's connection
+// pool, so it is only made again once the options change.
+func (d *databaseImpl) selectWarehouse(ctx context.Context, name string, endpoint workspaceEndpoint) (workspaceEndpoint, error) {
+	if d.warehousePolicy == "" || endpoint.HTTPPath != "" || d.uri != "" || endpoint.ServerHostname == "" {
+		return endpoint, nil
+	}
+	if d.warehousePolicy == OptionValueWarehousePolicyTagged && len(d.warehouseTags) == 0 {
+		return endpoint, adbc.Error{
+			Code: adbc.StatusInvalidArgument,
+			Msg:  fmt.Sprintf("%s=%s requires %s", OptionWarehousePolicy, d.warehousePolicy, OptionWarehouseTags),
+		}
+	}
+	if d.needsRefresh {
+		d.selectedWarehouses = nil
+	}
+	if path, ok := d.selectedWarehouses[name]; ok {
+		endpoint.HTTPPath = path
+		return endpoint, nil
+	}
+
+	api := &statementAPI{
+		client:  &http.Client{Transport: d.httpTransport()},
+		baseURL: endpoint.baseURL(),
+		auth:    d.restAuthenticator(endpoint),
+		clock:   d.clock,
+	}
+	var resp struct {
+		Warehouses []warehouseInfo `json:"warehouses"`
+	}
+	if err := api.do(ctx, http.MethodGet, "/api/2.0/sql/warehouses", nil, &resp); err != nil {
+		return endpoint, err
+	}
+	w, ok := pickWarehouse(resp.Warehouses, d.warehouseTags)
+	if !ok {
+		return endpoint, adbc.Error{
+			Code: adbc.StatusNotFound,
+			Msg: fmt.Sprintf("no SQL warehouse in %s matches %s=%s (%s=%s)", endpoint.ServerHostname,
+				OptionWarehousePolicy, d.warehousePolicy, OptionWarehouseTags, formatQueryTags(d.warehouseTags)),
+		}
+	}
+	d.Logger.InfoContext(ctx, "selected SQL warehouse",
+		slog.String("policy", d.warehousePolicy), slog.String("warehouse_id", w.ID),
+		slog.String("name", w.Name), slog.String("size", w.ClusterSize), slog.String("state", w.State))
+
+	endpoint.HTTPPath = "/sql/1.0/warehouses/" + w.ID
+	if d.selectedWarehouses == nil {
+		d.selectedWarehouses = map[string]string{}
+	}
+	d.selectedWarehouses[name] = endpoint.HTTPPath
+	return endpoint, nil
+}
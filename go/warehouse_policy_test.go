@@ -0,0 +1,69 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickWarehouse(t *testing.T) {
+	warehouse := func(id, size, state string, tags ...warehouseTag) warehouseInfo {
+		w := warehouseInfo{ID: id, Name: "wh-" + id, ClusterSize: size, State: state}
+		w.Tags.CustomTags = tags
+		return w
+	}
+	etl := warehouseTag{Key: "team", Value: "etl"}
+	prod := warehouseTag{Key: "env", Value: "prod"}
+	warehouses := []warehouseInfo{
+		warehouse("a", "Large", "RUNNING", etl, prod),
+		warehouse("b", "Small", "RUNNING", etl),
+		warehouse("c", "2X-Small", "STOPPED", etl, prod),
+		warehouse("d", "X-Small", "DELETED"),
+		warehouse("e", "Small", "RUNNING"),
+	}
+
+	w, ok := pickWarehouse(warehouses, nil)
+	require.True(t, ok)
+	assert.Equal(t, "b", w.ID, "smallest running, ties broken by name")
+
+	w, ok = pickWarehouse(warehouses, []queryTag{{key: "team", value: "etl"}, {key: "env", value: "prod"}})
+	require.True(t, ok)
+	assert.Equal(t, "a", w.ID, "running preferred over a smaller stopped warehouse")
+
+	w, ok = pickWarehouse(warehouses[2:4], nil)
+	require.True(t, ok)
+	assert.Equal(t, "c", w.ID, "deleted warehouses are never picked")
+
+	_, ok = pickWarehouse(warehouses, []queryTag{{key: "team", value: "bi"}})
+	assert.False(t, ok)
+	_, ok = pickWarehouse(nil, nil)
+	assert.False(t, ok)
+}
+
+func TestSelectWarehouseRequiresTags(t *testing.T) {
+	d := &databaseImpl{warehousePolicy: OptionValueWarehousePolicyTagged}
+	endpoint := workspaceEndpoint{ServerHostname: "example.cloud.databricks.com"}
+	_, err := d.selectWarehouse(t.Context(), "", endpoint)
+	assert.ErrorContains(t, err, OptionWarehouseTags)
+
+	// An explicit HTTP path wins over the policy
+	endpoint.HTTPPath = "/sql/1.0/warehouses/abc"
+	got, err := d.selectWarehouse(t.Context(), "", endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, endpoint, got)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Codes of the warnings listed in OptionStatementWarnings.
+const (
+	// WarningProtocolFallback: the result was read through a path other
+	// than the first of OptionFetchProtocols.
+	WarningProtocolFallback = "protocol_fallback"
+	// WarningResultTruncated: the server truncated the result.
+	WarningResultTruncated = "result_truncated"
+	// WarningRowsSkipped: rows were left out of the result in lenient mode
+	// (see OptionFetchSkippedRows).
+	WarningRowsSkipped = "rows_skipped"
+	// WarningSchemaUnified: result batches didn't match the result's
+	// schema and were conformed to it (OptionValueSchemaDriftUnify).
+	WarningSchemaUnified = "schema_unified"
+	// WarningDecimalNarrowed: a DECIMAL column is returned with less
+	// precision than it was declared with (OptionFetchDecimalMaxPrecision).
+	WarningDecimalNarrowed = "decimal_narrowed"
+	// WarningDecimalAsString: a DECIMAL column too wide for
+	// OptionFetchDecimalMaxPrecision is returned as strings.
+	WarningDecimalAsString = "decimal_as_string"
+	// WarningDecimalOverflowNull: values of a DECIMAL column that didn't
+	// fit OptionFetchDecimalMaxPrecision were returned as null.
+	WarningDecimalOverflowNull = "decimal_overflow_null"
+)
+
+// statementWarning is a condition that didn't fail an execution but that
+// the caller may want to show.
+type statementWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// statementWarnings collects the warnings of an execution, which may be
+// raised while its result is read. A nil statementWarnings drops them.
+type statementWarnings struct {
+	mu       sync.Mutex
+	warnings []statementWarning
+}
+
+func (w *statementWarnings) add(code, format string, args ...any) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, statementWarning{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// json formats the warnings as a JSON array; a nil statementWarnings has
+// none.
+func (w *statementWarnings) json() (string, error) {
+	warnings := []statementWarning{}
+	if w != nil {
+		w.mu.Lock()
+		warnings = append(warnings, w.warnings...)
+		w.mu.Unlock()
+	}
+	out, err := json.Marshal(warnings)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementWarnings(t *testing.T) {
+	var dropped *statementWarnings
+	dropped.add(WarningRowsSkipped, "skipped %d rows", 3)
+	out, err := dropped.json()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", out)
+
+	warnings := &statementWarnings{}
+	warnings.add(WarningResultTruncated, "truncated to %d rows", 10)
+	out, err = warnings.json()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"code": "result_truncated", "message": "truncated to 10 rows"}]`, out)
+}
+
+func TestStatementWarningsOption(t *testing.T) {
+	s := &statementImpl{}
+	value, err := s.GetOption(OptionStatementWarnings)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	// Each execution starts without warnings
+	_, finish := s.startExecution(context.Background())
+	require.NoError(t, finish("", nil))
+	s.warnings.add(WarningProtocolFallback, "read through thrift")
+	value, err = s.GetOption(OptionStatementWarnings)
+	require.NoError(t, err)
+	assert.Contains(t, value, WarningProtocolFallback)
+
+	_, finish = s.startExecution(context.Background())
+	require.NoError(t, finish("", nil))
+	value, err = s.GetOption(OptionStatementWarnings)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+)
+
+// workspaceEndpoint is where a connection pool opens its sessions.
+type workspaceEndpoint struct {
+	ServerHostname string `json:"server_hostname"`
+	HTTPPath       string `json:"http_path"`
+	Port           int    `json:"port,omitempty"`
+}
+
+// baseURL returns the URL of the endpoint's workspace for REST requests.
+func (e workspaceEndpoint) baseURL() string {
+	baseURL := "https://" + e.ServerHostname
+	if e.Port != 0 && e.Port != DEFAULT_PORT {
+		baseURL += ":" + strconv.Itoa(e.Port)
+	}
+	return baseURL
+}
+
+type workspaceKey struct{}
+
+// WithWorkspace returns a copy of ctx that makes the Open of a database
+// configured with OptionWorkspaces connect to the named workspace. An
+// empty name selects the workspace of OptionServerHostname and
+// OptionHTTPPath. It takes precedence over OptionWorkspace.
+func WithWorkspace(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, name)
+}
+
+// parseWorkspaces parses the value of OptionWorkspaces.
+func parseWorkspaces(value string) (map[string]workspaceEndpoint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var workspaces map[string]workspaceEndpoint
+	if err := json.Unmarshal([]byte(value), &workspaces); err != nil {
+		return nil, invalidOption(OptionWorkspaces, value, "a JSON object of workspaces")
+	}
+	for name, endpoint := range workspaces {
+		if name == "" || endpoint.ServerHostname == "" || endpoint.HTTPPath == "" {
+			return nil, invalidOption(OptionWorkspaces, value,
+				"named workspaces, each with a server_hostname and http_path")
+		}
+	}
+	return workspaces, nil
+}
+
+// formatWorkspaces formats workspaces as the value of OptionWorkspaces.
+func formatWorkspaces(workspaces map[string]workspaceEndpoint) (string, error) {
+	if len(workspaces) == 0 {
+		return "", nil
+	}
+	value, err := json.Marshal(workspaces)
+	if err != nil {
+		return "", adbc.Error{Code: adbc.StatusInternal, Msg: err.Error()}
+	}
+	return string(value), nil
+}
+
+// workspaceEndpoint returns the name and endpoint of the workspace Open
+// connects to, from ctx or else OptionWorkspace. The named workspaces
+// share the database's credentials and other options, and default to its
+// port.
+func (d *databaseImpl) workspaceEndpoint(ctx context.Context) (string, workspaceEndpoint, error) {
+	name, ok := ctx.Value(workspaceKey{}).(string)
+	if !ok {
+		name = d.workspace
+	}
+	if name == "" {
+		return "", workspaceEndpoint{ServerHostname: d.serverHostname, HTTPPath: d.httpPath, Port: d.port}, nil
+	}
+	if d.uri != "" {
+		return "", workspaceEndpoint{}, adbc.Error{
+			Code: adbc.StatusInvalidState,
+			Msg:  fmt.Sprintf("workspace %q requested, but workspaces can't be combined with %s", name, adbc.OptionKeyURI),
+		}
+	}
+	endpoint, ok := d.workspaces[name]
+	if !ok {
+		return "", workspaceEndpoint{}, adbc.Error{
+			Code: adbc.StatusNotFound,
+			Msg: fmt.Sprintf("unknown workspace %q; %s defines: %s", name, OptionWorkspaces,
+				strings.Join(slices.Sorted(maps.Keys(d.workspaces)), ", ")),
+		}
+	}
+	if endpoint.Port == 0 {
+		endpoint.Port = d.port
+	}
+	return name, endpoint, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 ADBC Drivers Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceEndpoint(t *testing.T) {
+	workspaces, err := parseWorkspaces(`{
+		"eu": {"server_hostname": "eu.cloud.databricks.com", "http_path": "/sql/1.0/warehouses/eu"},
+		"us": {"server_hostname": "us.cloud.databricks.com", "http_path": "/sql/1.0/warehouses/us", "port": 8443}
+	}`)
+	require.NoError(t, err)
+	d := &databaseImpl{
+		serverHostname: "main.cloud.databricks.com",
+		httpPath:       "/sql/1.0/warehouses/main",
+		port:           443,
+		workspaces:     workspaces,
+	}
+	ctx := context.Background()
+
+	name, endpoint, err := d.workspaceEndpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "", name)
+	assert.Equal(t, workspaceEndpoint{"main.cloud.databricks.com", "/sql/1.0/warehouses/main", 443}, endpoint)
+
+	name, endpoint, err = d.workspaceEndpoint(WithWorkspace(ctx, "eu"))
+	require.NoError(t, err)
+	assert.Equal(t, "eu", name)
+	assert.Equal(t, workspaceEndpoint{"eu.cloud.databricks.com", "/sql/1.0/warehouses/eu", 443}, endpoint)
+
+	d.workspace = "us"
+	name, endpoint, err = d.workspaceEndpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "us", name)
+	assert.Equal(t, 8443, endpoint.Port)
+
+	// The context overrides the option, including back to the default
+	name, _, err = d.workspaceEndpoint(WithWorkspace(ctx, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "", name)
+
+	_, _, err = d.workspaceEndpoint(WithWorkspace(ctx, "apac"))
+	var adbcErr adbc.Error
+	require.ErrorAs(t, err, &adbcErr)
+	assert.Equal(t, adbc.StatusNotFound, adbcErr.Code)
+	assert.Contains(t, adbcErr.Msg, "eu, us")
+}
+
+func TestParseWorkspaces(t *testing.T) {
+	for _, value := range []string{
+		`[]`,
+		`{"eu": {"http_path": "/sql/1.0/warehouses/eu"}}`,
+		`{"": {"server_hostname": "eu", "http_path": "/p"}}`,
+	} {
+		_, err := parseWorkspaces(value)
+		assert.Error(t, err, value)
+	}
+
+	workspaces, err := parseWorkspaces(`{"eu": {"server_hostname": "eu", "http_path": "/p"}}`)
+	require.NoError(t, err)
+	formatted, err := formatWorkspaces(workspaces)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"eu": {"server_hostname": "eu", "http_path": "/p"}}`, formatted)
+
+	workspaces, err = parseWorkspaces("")
+	require.NoError(t, err)
+	assert.Nil(t, workspaces)
+}
@@ -2,7 +2,7 @@
 /*
  * Thrift Protocol Test Proxy - Control API
  *
- * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
  *
  * The version of the OpenAPI document: 1.0.0
  * Generated by: https://github.com/openapitools/openapi-generator.git
@@ -35,6 +35,27 @@ namespace ProxyControlApi.Api
         /// </summary>
         DefaultApiEvents Events { get; }
 
+        /// <summary>
+        /// Remove the concurrency limit
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableConcurrencyLimitApiResponse"/>&gt;</returns>
+        Task<IDisableConcurrencyLimitApiResponse> DisableConcurrencyLimitAsync(System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Remove the concurrency limit
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableConcurrencyLimitApiResponse"/>?&gt;</returns>
+        Task<IDisableConcurrencyLimitApiResponse?> DisableConcurrencyLimitOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default);
+
         /// <summary>
         /// Disable a failure scenario
         /// </summary>
@@ -58,11 +79,57 @@ namespace ProxyControlApi.Api
         /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioApiResponse"/>?&gt;</returns>
         Task<IDisableScenarioApiResponse?> DisableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default);
 
+        /// <summary>
+        /// Disable every scenario in a group
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioGroupApiResponse"/>&gt;</returns>
+        Task<IDisableScenarioGroupApiResponse> DisableScenarioGroupAsync(string name, System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Disable every scenario in a group
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioGroupApiResponse"/>?&gt;</returns>
+        Task<IDisableScenarioGroupApiResponse?> DisableScenarioGroupOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Cap concurrent upstream connections
+        /// </summary>
+        /// <remarks>
+        /// Requests over the cap are queued until a connection frees up (mode `queue`, rejected with 503 after `queue_timeout_seconds`) or rejected with 503 right away (mode `reject`). Resets the stats.
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableConcurrencyLimitApiResponse"/>&gt;</returns>
+        Task<IEnableConcurrencyLimitApiResponse> EnableConcurrencyLimitAsync(ConcurrencyLimit concurrencyLimit, System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Cap concurrent upstream connections
+        /// </summary>
+        /// <remarks>
+        /// Requests over the cap are queued until a connection frees up (mode `queue`, rejected with 503 after `queue_timeout_seconds`) or rejected with 503 right away (mode `reject`). Resets the stats.
+        /// </remarks>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableConcurrencyLimitApiResponse"/>?&gt;</returns>
+        Task<IEnableConcurrencyLimitApiResponse?> EnableConcurrencyLimitOrDefaultAsync(ConcurrencyLimit concurrencyLimit, System.Threading.CancellationToken cancellationToken = default);
+
         /// <summary>
         /// Enable a failure scenario
         /// </summary>
         /// <remarks>
-        /// Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).
+        /// Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).  An optional JSON body overrides configurable parameters: `duration_seconds` for `delay` scenarios, and `remove_headers` (list of header names) and `set_headers` (header name to value) for `mutate_headers` scenarios.
         /// </remarks>
         /// <exception cref="ApiException">Thrown when fails to make API call</exception>
         /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
@@ -74,13 +141,78 @@ namespace ProxyControlApi.Api
         /// Enable a failure scenario
         /// </summary>
         /// <remarks>
-        /// Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).
+        /// Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).  An optional JSON body overrides configurable parameters: `duration_seconds` for `delay` scenarios, and `remove_headers` (list of header names) and `set_headers` (header name to value) for `mutate_headers` scenarios.
         /// </remarks>
         /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
         /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
         /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioApiResponse"/>?&gt;</returns>
         Task<IEnableScenarioApiResponse?> EnableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default);
 
+        /// <summary>
+        /// Enable every scenario in a group
+        /// </summary>
+        /// <remarks>
+        /// Enables all scenarios in a group and resets the call history.  **Note:** Members keep their one-shot behavior, so each matching request consumes the next enabled member.
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioGroupApiResponse"/>&gt;</returns>
+        Task<IEnableScenarioGroupApiResponse> EnableScenarioGroupAsync(string name, System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Enable every scenario in a group
+        /// </summary>
+        /// <remarks>
+        /// Enables all scenarios in a group and resets the call history.  **Note:** Members keep their one-shot behavior, so each matching request consumes the next enabled member.
+        /// </remarks>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioGroupApiResponse"/>?&gt;</returns>
+        Task<IEnableScenarioGroupApiResponse?> EnableScenarioGroupOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Get the concurrency limit and its stats
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IGetConcurrencyLimitApiResponse"/>&gt;</returns>
+        Task<IGetConcurrencyLimitApiResponse> GetConcurrencyLimitAsync(System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// Get the concurrency limit and its stats
+        /// </summary>
+        /// <remarks>
+        /// 
+        /// </remarks>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IGetConcurrencyLimitApiResponse"/>?&gt;</returns>
+        Task<IGetConcurrencyLimitApiResponse?> GetConcurrencyLimitOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// List all scenario groups
+        /// </summary>
+        /// <remarks>
+        /// Returns all scenario groups with their member scenarios. A group is reported as enabled when all of its members are enabled.
+        /// </remarks>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IListScenarioGroupsApiResponse"/>&gt;</returns>
+        Task<IListScenarioGroupsApiResponse> ListScenarioGroupsAsync(System.Threading.CancellationToken cancellationToken = default);
+
+        /// <summary>
+        /// List all scenario groups
+        /// </summary>
+        /// <remarks>
+        /// Returns all scenario groups with their member scenarios. A group is reported as enabled when all of its members are enabled.
+        /// </remarks>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IListScenarioGroupsApiResponse"/>?&gt;</returns>
+        Task<IListScenarioGroupsApiResponse?> ListScenarioGroupsOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default);
+
         /// <summary>
         /// List all available failure scenarios
         /// </summary>
@@ -103,6 +235,18 @@ namespace ProxyControlApi.Api
         Task<IListScenariosApiResponse?> ListScenariosOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default);
     }
 
+    /// <summary>
+    /// The <see cref="IDisableConcurrencyLimitApiResponse"/>
+    /// </summary>
+    public interface IDisableConcurrencyLimitApiResponse : ProxyControlApi.Client.IApiResponse
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+    }
+
     /// <summary>
     /// The <see cref="IDisableScenarioApiResponse"/>
     /// </summary>
@@ -121,6 +265,42 @@ namespace ProxyControlApi.Api
         bool IsNotFound { get; }
     }
 
+    /// <summary>
+    /// The <see cref="IDisableScenarioGroupApiResponse"/>
+    /// </summary>
+    public interface IDisableScenarioGroupApiResponse : ProxyControlApi.Client.IApiResponse
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+
+        /// <summary>
+        /// Returns true if the response is 404 NotFound
+        /// </summary>
+        /// <returns></returns>
+        bool IsNotFound { get; }
+    }
+
+    /// <summary>
+    /// The <see cref="IEnableConcurrencyLimitApiResponse"/>
+    /// </summary>
+    public interface IEnableConcurrencyLimitApiResponse : ProxyControlApi.Client.IApiResponse
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+
+        /// <summary>
+        /// Returns true if the response is 400 BadRequest
+        /// </summary>
+        /// <returns></returns>
+        bool IsBadRequest { get; }
+    }
+
     /// <summary>
     /// The <see cref="IEnableScenarioApiResponse"/>
     /// </summary>
@@ -132,6 +312,30 @@ namespace ProxyControlApi.Api
         /// <returns></returns>
         bool IsOk { get; }
 
+        /// <summary>
+        /// Returns true if the response is 400 BadRequest
+        /// </summary>
+        /// <returns></returns>
+        bool IsBadRequest { get; }
+
+        /// <summary>
+        /// Returns true if the response is 404 NotFound
+        /// </summary>
+        /// <returns></returns>
+        bool IsNotFound { get; }
+    }
+
+    /// <summary>
+    /// The <see cref="IEnableScenarioGroupApiResponse"/>
+    /// </summary>
+    public interface IEnableScenarioGroupApiResponse : ProxyControlApi.Client.IApiResponse
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+
         /// <summary>
         /// Returns true if the response is 404 NotFound
         /// </summary>
@@ -139,6 +343,30 @@ namespace ProxyControlApi.Api
         bool IsNotFound { get; }
     }
 
+    /// <summary>
+    /// The <see cref="IGetConcurrencyLimitApiResponse"/>
+    /// </summary>
+    public interface IGetConcurrencyLimitApiResponse : ProxyControlApi.Client.IApiResponse, IOk<ProxyControlApi.Model.ConcurrencyLimitStatus?>
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+    }
+
+    /// <summary>
+    /// The <see cref="IListScenarioGroupsApiResponse"/>
+    /// </summary>
+    public interface IListScenarioGroupsApiResponse : ProxyControlApi.Client.IApiResponse, IOk<ProxyControlApi.Model.ScenarioGroupList?>
+    {
+        /// <summary>
+        /// Returns true if the response is 200 Ok
+        /// </summary>
+        /// <returns></returns>
+        bool IsOk { get; }
+    }
+
     /// <summary>
     /// The <see cref="IListScenariosApiResponse"/>
     /// </summary>
@@ -156,6 +384,26 @@ namespace ProxyControlApi.Api
     /// </summary>
     public class DefaultApiEvents
     {
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnDisableConcurrencyLimit;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorDisableConcurrencyLimit;
+
+        internal void ExecuteOnDisableConcurrencyLimit(DefaultApi.DisableConcurrencyLimitApiResponse apiResponse)
+        {
+            OnDisableConcurrencyLimit?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorDisableConcurrencyLimit(Exception exception)
+        {
+            OnErrorDisableConcurrencyLimit?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
         /// <summary>
         /// The event raised after the server response
         /// </summary>
@@ -176,6 +424,46 @@ namespace ProxyControlApi.Api
             OnErrorDisableScenario?.Invoke(this, new ExceptionEventArgs(exception));
         }
 
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnDisableScenarioGroup;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorDisableScenarioGroup;
+
+        internal void ExecuteOnDisableScenarioGroup(DefaultApi.DisableScenarioGroupApiResponse apiResponse)
+        {
+            OnDisableScenarioGroup?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorDisableScenarioGroup(Exception exception)
+        {
+            OnErrorDisableScenarioGroup?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnEnableConcurrencyLimit;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorEnableConcurrencyLimit;
+
+        internal void ExecuteOnEnableConcurrencyLimit(DefaultApi.EnableConcurrencyLimitApiResponse apiResponse)
+        {
+            OnEnableConcurrencyLimit?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorEnableConcurrencyLimit(Exception exception)
+        {
+            OnErrorEnableConcurrencyLimit?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
         /// <summary>
         /// The event raised after the server response
         /// </summary>
@@ -196,6 +484,66 @@ namespace ProxyControlApi.Api
             OnErrorEnableScenario?.Invoke(this, new ExceptionEventArgs(exception));
         }
 
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnEnableScenarioGroup;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorEnableScenarioGroup;
+
+        internal void ExecuteOnEnableScenarioGroup(DefaultApi.EnableScenarioGroupApiResponse apiResponse)
+        {
+            OnEnableScenarioGroup?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorEnableScenarioGroup(Exception exception)
+        {
+            OnErrorEnableScenarioGroup?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnGetConcurrencyLimit;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorGetConcurrencyLimit;
+
+        internal void ExecuteOnGetConcurrencyLimit(DefaultApi.GetConcurrencyLimitApiResponse apiResponse)
+        {
+            OnGetConcurrencyLimit?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorGetConcurrencyLimit(Exception exception)
+        {
+            OnErrorGetConcurrencyLimit?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
+        /// <summary>
+        /// The event raised after the server response
+        /// </summary>
+        public event EventHandler<ApiResponseEventArgs>? OnListScenarioGroups;
+
+        /// <summary>
+        /// The event raised after an error querying the server
+        /// </summary>
+        public event EventHandler<ExceptionEventArgs>? OnErrorListScenarioGroups;
+
+        internal void ExecuteOnListScenarioGroups(DefaultApi.ListScenarioGroupsApiResponse apiResponse)
+        {
+            OnListScenarioGroups?.Invoke(this, new ApiResponseEventArgs(apiResponse));
+        }
+
+        internal void ExecuteOnErrorListScenarioGroups(Exception exception)
+        {
+            OnErrorListScenarioGroups?.Invoke(this, new ExceptionEventArgs(exception));
+        }
+
         /// <summary>
         /// The event raised after the server response
         /// </summary>
@@ -245,40 +593,1404 @@ namespace ProxyControlApi.Api
         public DefaultApiEvents Events { get; }
 
         /// <summary>
-        /// Initializes a new instance of the <see cref="DefaultApi"/> class.
+        /// Initializes a new instance of the <see cref="DefaultApi"/> class.
+        /// </summary>
+        /// <returns></returns>
+        public DefaultApi(ILogger<DefaultApi> logger, ILoggerFactory loggerFactory, HttpClient httpClient, JsonSerializerOptionsProvider jsonSerializerOptionsProvider, DefaultApiEvents defaultApiEvents)
+        {
+            _jsonSerializerOptions = jsonSerializerOptionsProvider.Options;
+            LoggerFactory = loggerFactory;
+            Logger = LoggerFactory.CreateLogger<DefaultApi>();
+            HttpClient = httpClient;
+            Events = defaultApiEvents;
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        private void AfterDisableConcurrencyLimitDefaultImplementation(IDisableConcurrencyLimitApiResponse apiResponseLocalVar)
+        {
+            bool suppressDefaultLog = false;
+            AfterDisableConcurrencyLimit(ref suppressDefaultLog, apiResponseLocalVar);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        partial void AfterDisableConcurrencyLimit(ref bool suppressDefaultLog, IDisableConcurrencyLimitApiResponse apiResponseLocalVar);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        private void OnErrorDisableConcurrencyLimitDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorDisableConcurrencyLimit(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        partial void OnErrorDisableConcurrencyLimit(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar);
+
+        /// <summary>
+        /// Remove the concurrency limit 
+        /// </summary>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IDisableConcurrencyLimitApiResponse?> DisableConcurrencyLimitOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await DisableConcurrencyLimitAsync(cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Remove the concurrency limit 
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IDisableConcurrencyLimitApiResponse> DisableConcurrencyLimitAsync(System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/concurrency-limit/disable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/concurrency-limit/disable");
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<DisableConcurrencyLimitApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<DisableConcurrencyLimitApiResponse>();
+                        DisableConcurrencyLimitApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/concurrency-limit/disable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterDisableConcurrencyLimitDefaultImplementation(apiResponseLocalVar);
+
+                        Events.ExecuteOnDisableConcurrencyLimit(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorDisableConcurrencyLimitDefaultImplementation(e, "/concurrency-limit/disable", uriBuilderLocalVar.Path);
+                Events.ExecuteOnErrorDisableConcurrencyLimit(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="DisableConcurrencyLimitApiResponse"/>
+        /// </summary>
+        public partial class DisableConcurrencyLimitApiResponse : ProxyControlApi.Client.ApiResponse, IDisableConcurrencyLimitApiResponse
+        {
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<DisableConcurrencyLimitApiResponse> Logger { get; }
+
+            /// <summary>
+            /// The <see cref="DisableConcurrencyLimitApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableConcurrencyLimitApiResponse(ILogger<DisableConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            /// <summary>
+            /// The <see cref="DisableConcurrencyLimitApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableConcurrencyLimitApiResponse(ILogger<DisableConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
+        }
+
+        partial void FormatDisableScenario(ref string name);
+
+        /// <summary>
+        /// Validates the request parameters
+        /// </summary>
+        /// <param name="name"></param>
+        /// <returns></returns>
+        private void ValidateDisableScenario(string name)
+        {
+            if (name == null)
+                throw new ArgumentNullException(nameof(name));
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        private void AfterDisableScenarioDefaultImplementation(IDisableScenarioApiResponse apiResponseLocalVar, string name)
+        {
+            bool suppressDefaultLog = false;
+            AfterDisableScenario(ref suppressDefaultLog, apiResponseLocalVar, name);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        partial void AfterDisableScenario(ref bool suppressDefaultLog, IDisableScenarioApiResponse apiResponseLocalVar, string name);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        private void OnErrorDisableScenarioDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorDisableScenario(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        partial void OnErrorDisableScenario(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+
+        /// <summary>
+        /// Disable a failure scenario Disables a failure scenario by name. Disabled scenarios will not trigger even if matching requests are made through the proxy.  **Note:** Scenarios auto-disable after injection, so manual disable is typically only needed to cancel a scenario before it triggers.
+        /// </summary>
+        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioApiResponse"/>&gt;</returns>
+        public async Task<IDisableScenarioApiResponse?> DisableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await DisableScenarioAsync(name, cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Disable a failure scenario Disables a failure scenario by name. Disabled scenarios will not trigger even if matching requests are made through the proxy.  **Note:** Scenarios auto-disable after injection, so manual disable is typically only needed to cancel a scenario before it triggers.
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioApiResponse"/>&gt;</returns>
+        public async Task<IDisableScenarioApiResponse> DisableScenarioAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                ValidateDisableScenario(name);
+
+                FormatDisableScenario(ref name);
+
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/scenarios/{name}/disable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/scenarios/{name}/disable");
+                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    string[] acceptLocalVars = new string[] {
+                        "application/json",
+                        "text/plain"
+                    };
+
+                    string? acceptLocalVar = ClientUtils.SelectHeaderAccept(acceptLocalVars);
+
+                    if (acceptLocalVar != null)
+                        httpRequestMessageLocalVar.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue(acceptLocalVar));
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<DisableScenarioApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<DisableScenarioApiResponse>();
+                        DisableScenarioApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/scenarios/{name}/disable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterDisableScenarioDefaultImplementation(apiResponseLocalVar, name);
+
+                        Events.ExecuteOnDisableScenario(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorDisableScenarioDefaultImplementation(e, "/scenarios/{name}/disable", uriBuilderLocalVar.Path, name);
+                Events.ExecuteOnErrorDisableScenario(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="DisableScenarioApiResponse"/>
+        /// </summary>
+        public partial class DisableScenarioApiResponse : ProxyControlApi.Client.ApiResponse, IDisableScenarioApiResponse
+        {
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<DisableScenarioApiResponse> Logger { get; }
+
+            /// <summary>
+            /// The <see cref="DisableScenarioApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableScenarioApiResponse(ILogger<DisableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            /// <summary>
+            /// The <see cref="DisableScenarioApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableScenarioApiResponse(ILogger<DisableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            /// <summary>
+            /// Deserializes the response if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public ProxyControlApi.Model.ScenarioStatus? Ok()
+            {
+                // This logic may be modified with the AsModel.mustache template
+                return IsOk
+                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ScenarioStatus>(RawContent, _jsonSerializerOptions)
+                    : null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok and the deserialized response is not null
+            /// </summary>
+            /// <param name="result"></param>
+            /// <returns></returns>
+            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ScenarioStatus? result)
+            {
+                result = null;
+
+                try
+                {
+                    result = Ok();
+                } catch (Exception e)
+                {
+                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)200);
+                }
+
+                return result != null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public bool IsNotFound => 404 == (int)StatusCode;
+
+            /// <summary>
+            /// Deserializes the response if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public string? NotFound()
+            {
+                // This logic may be modified with the AsModel.mustache template
+                return IsNotFound
+                    ? System.Text.Json.JsonSerializer.Deserialize<string>(RawContent, _jsonSerializerOptions)
+                    : null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound and the deserialized response is not null
+            /// </summary>
+            /// <param name="result"></param>
+            /// <returns></returns>
+            public bool TryNotFound([NotNullWhen(true)]out string? result)
+            {
+                result = null;
+
+                try
+                {
+                    result = NotFound();
+                } catch (Exception e)
+                {
+                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)404);
+                }
+
+                return result != null;
+            }
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
+        }
+
+        partial void FormatDisableScenarioGroup(ref string name);
+
+        /// <summary>
+        /// Validates the request parameters
+        /// </summary>
+        /// <param name="name"></param>
+        /// <returns></returns>
+        private void ValidateDisableScenarioGroup(string name)
+        {
+            if (name == null)
+                throw new ArgumentNullException(nameof(name));
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        private void AfterDisableScenarioGroupDefaultImplementation(IDisableScenarioGroupApiResponse apiResponseLocalVar, string name)
+        {
+            bool suppressDefaultLog = false;
+            AfterDisableScenarioGroup(ref suppressDefaultLog, apiResponseLocalVar, name);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        partial void AfterDisableScenarioGroup(ref bool suppressDefaultLog, IDisableScenarioGroupApiResponse apiResponseLocalVar, string name);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        private void OnErrorDisableScenarioGroupDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorDisableScenarioGroup(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        partial void OnErrorDisableScenarioGroup(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+
+        /// <summary>
+        /// Disable every scenario in a group 
+        /// </summary>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioGroupApiResponse"/>&gt;</returns>
+        public async Task<IDisableScenarioGroupApiResponse?> DisableScenarioGroupOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await DisableScenarioGroupAsync(name, cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Disable every scenario in a group 
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioGroupApiResponse"/>&gt;</returns>
+        public async Task<IDisableScenarioGroupApiResponse> DisableScenarioGroupAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                ValidateDisableScenarioGroup(name);
+
+                FormatDisableScenarioGroup(ref name);
+
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/groups/{name}/disable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/groups/{name}/disable");
+                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<DisableScenarioGroupApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<DisableScenarioGroupApiResponse>();
+                        DisableScenarioGroupApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/groups/{name}/disable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterDisableScenarioGroupDefaultImplementation(apiResponseLocalVar, name);
+
+                        Events.ExecuteOnDisableScenarioGroup(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorDisableScenarioGroupDefaultImplementation(e, "/groups/{name}/disable", uriBuilderLocalVar.Path, name);
+                Events.ExecuteOnErrorDisableScenarioGroup(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="DisableScenarioGroupApiResponse"/>
+        /// </summary>
+        public partial class DisableScenarioGroupApiResponse : ProxyControlApi.Client.ApiResponse, IDisableScenarioGroupApiResponse
+        {
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<DisableScenarioGroupApiResponse> Logger { get; }
+
+            /// <summary>
+            /// The <see cref="DisableScenarioGroupApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableScenarioGroupApiResponse(ILogger<DisableScenarioGroupApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            /// <summary>
+            /// The <see cref="DisableScenarioGroupApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public DisableScenarioGroupApiResponse(ILogger<DisableScenarioGroupApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public bool IsNotFound => 404 == (int)StatusCode;
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
+        }
+
+        partial void FormatEnableConcurrencyLimit(ConcurrencyLimit concurrencyLimit);
+
+        /// <summary>
+        /// Validates the request parameters
+        /// </summary>
+        /// <param name="concurrencyLimit"></param>
+        /// <returns></returns>
+        private void ValidateEnableConcurrencyLimit(ConcurrencyLimit concurrencyLimit)
+        {
+            if (concurrencyLimit == null)
+                throw new ArgumentNullException(nameof(concurrencyLimit));
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="concurrencyLimit"></param>
+        private void AfterEnableConcurrencyLimitDefaultImplementation(IEnableConcurrencyLimitApiResponse apiResponseLocalVar, ConcurrencyLimit concurrencyLimit)
+        {
+            bool suppressDefaultLog = false;
+            AfterEnableConcurrencyLimit(ref suppressDefaultLog, apiResponseLocalVar, concurrencyLimit);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="concurrencyLimit"></param>
+        partial void AfterEnableConcurrencyLimit(ref bool suppressDefaultLog, IEnableConcurrencyLimitApiResponse apiResponseLocalVar, ConcurrencyLimit concurrencyLimit);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="concurrencyLimit"></param>
+        private void OnErrorEnableConcurrencyLimitDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, ConcurrencyLimit concurrencyLimit)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorEnableConcurrencyLimit(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, concurrencyLimit);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="concurrencyLimit"></param>
+        partial void OnErrorEnableConcurrencyLimit(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, ConcurrencyLimit concurrencyLimit);
+
+        /// <summary>
+        /// Cap concurrent upstream connections Requests over the cap are queued until a connection frees up (mode `queue`, rejected with 503 after `queue_timeout_seconds`) or rejected with 503 right away (mode `reject`). Resets the stats.
+        /// </summary>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IEnableConcurrencyLimitApiResponse?> EnableConcurrencyLimitOrDefaultAsync(ConcurrencyLimit concurrencyLimit, System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await EnableConcurrencyLimitAsync(concurrencyLimit, cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Cap concurrent upstream connections Requests over the cap are queued until a connection frees up (mode `queue`, rejected with 503 after `queue_timeout_seconds`) or rejected with 503 right away (mode `reject`). Resets the stats.
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IEnableConcurrencyLimitApiResponse> EnableConcurrencyLimitAsync(ConcurrencyLimit concurrencyLimit, System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                ValidateEnableConcurrencyLimit(concurrencyLimit);
+
+                FormatEnableConcurrencyLimit(concurrencyLimit);
+
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/concurrency-limit/enable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/concurrency-limit/enable");
+
+                    httpRequestMessageLocalVar.Content = (concurrencyLimit as object) is System.IO.Stream stream
+                        ? httpRequestMessageLocalVar.Content = new StreamContent(stream)
+                        : httpRequestMessageLocalVar.Content = new StringContent(JsonSerializer.Serialize(concurrencyLimit, _jsonSerializerOptions));
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    string[] contentTypes = new string[] {
+                        "application/json"
+                    };
+
+                    string? contentTypeLocalVar = ClientUtils.SelectHeaderContentType(contentTypes);
+
+                    if (contentTypeLocalVar != null && httpRequestMessageLocalVar.Content != null)
+                        httpRequestMessageLocalVar.Content.Headers.ContentType = new MediaTypeHeaderValue(contentTypeLocalVar);
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<EnableConcurrencyLimitApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<EnableConcurrencyLimitApiResponse>();
+                        EnableConcurrencyLimitApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/concurrency-limit/enable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterEnableConcurrencyLimitDefaultImplementation(apiResponseLocalVar, concurrencyLimit);
+
+                        Events.ExecuteOnEnableConcurrencyLimit(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorEnableConcurrencyLimitDefaultImplementation(e, "/concurrency-limit/enable", uriBuilderLocalVar.Path, concurrencyLimit);
+                Events.ExecuteOnErrorEnableConcurrencyLimit(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="EnableConcurrencyLimitApiResponse"/>
+        /// </summary>
+        public partial class EnableConcurrencyLimitApiResponse : ProxyControlApi.Client.ApiResponse, IEnableConcurrencyLimitApiResponse
+        {
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<EnableConcurrencyLimitApiResponse> Logger { get; }
+
+            /// <summary>
+            /// The <see cref="EnableConcurrencyLimitApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableConcurrencyLimitApiResponse(ILogger<EnableConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            /// <summary>
+            /// The <see cref="EnableConcurrencyLimitApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableConcurrencyLimitApiResponse(ILogger<EnableConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            /// <summary>
+            /// Returns true if the response is 400 BadRequest
+            /// </summary>
+            /// <returns></returns>
+            public bool IsBadRequest => 400 == (int)StatusCode;
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
+        }
+
+        partial void FormatEnableScenario(ref string name);
+
+        /// <summary>
+        /// Validates the request parameters
+        /// </summary>
+        /// <param name="name"></param>
+        /// <returns></returns>
+        private void ValidateEnableScenario(string name)
+        {
+            if (name == null)
+                throw new ArgumentNullException(nameof(name));
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        private void AfterEnableScenarioDefaultImplementation(IEnableScenarioApiResponse apiResponseLocalVar, string name)
+        {
+            bool suppressDefaultLog = false;
+            AfterEnableScenario(ref suppressDefaultLog, apiResponseLocalVar, name);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        partial void AfterEnableScenario(ref bool suppressDefaultLog, IEnableScenarioApiResponse apiResponseLocalVar, string name);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        private void OnErrorEnableScenarioDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorEnableScenario(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        partial void OnErrorEnableScenario(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+
+        /// <summary>
+        /// Enable a failure scenario Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).  An optional JSON body overrides configurable parameters: `duration_seconds` for `delay` scenarios, and `remove_headers` (list of header names) and `set_headers` (header name to value) for `mutate_headers` scenarios.
+        /// </summary>
+        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioApiResponse"/>&gt;</returns>
+        public async Task<IEnableScenarioApiResponse?> EnableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await EnableScenarioAsync(name, cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Enable a failure scenario Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).  An optional JSON body overrides configurable parameters: `duration_seconds` for `delay` scenarios, and `remove_headers` (list of header names) and `set_headers` (header name to value) for `mutate_headers` scenarios.
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioApiResponse"/>&gt;</returns>
+        public async Task<IEnableScenarioApiResponse> EnableScenarioAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                ValidateEnableScenario(name);
+
+                FormatEnableScenario(ref name);
+
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/scenarios/{name}/enable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/scenarios/{name}/enable");
+                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    string[] acceptLocalVars = new string[] {
+                        "application/json",
+                        "text/plain"
+                    };
+
+                    string? acceptLocalVar = ClientUtils.SelectHeaderAccept(acceptLocalVars);
+
+                    if (acceptLocalVar != null)
+                        httpRequestMessageLocalVar.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue(acceptLocalVar));
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<EnableScenarioApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<EnableScenarioApiResponse>();
+                        EnableScenarioApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/scenarios/{name}/enable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterEnableScenarioDefaultImplementation(apiResponseLocalVar, name);
+
+                        Events.ExecuteOnEnableScenario(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorEnableScenarioDefaultImplementation(e, "/scenarios/{name}/enable", uriBuilderLocalVar.Path, name);
+                Events.ExecuteOnErrorEnableScenario(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="EnableScenarioApiResponse"/>
+        /// </summary>
+        public partial class EnableScenarioApiResponse : ProxyControlApi.Client.ApiResponse, IEnableScenarioApiResponse
+        {
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<EnableScenarioApiResponse> Logger { get; }
+
+            /// <summary>
+            /// The <see cref="EnableScenarioApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableScenarioApiResponse(ILogger<EnableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            /// <summary>
+            /// The <see cref="EnableScenarioApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableScenarioApiResponse(ILogger<EnableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            /// <summary>
+            /// Deserializes the response if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public ProxyControlApi.Model.ScenarioStatus? Ok()
+            {
+                // This logic may be modified with the AsModel.mustache template
+                return IsOk
+                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ScenarioStatus>(RawContent, _jsonSerializerOptions)
+                    : null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok and the deserialized response is not null
+            /// </summary>
+            /// <param name="result"></param>
+            /// <returns></returns>
+            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ScenarioStatus? result)
+            {
+                result = null;
+
+                try
+                {
+                    result = Ok();
+                } catch (Exception e)
+                {
+                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)200);
+                }
+
+                return result != null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 400 BadRequest
+            /// </summary>
+            /// <returns></returns>
+            public bool IsBadRequest => 400 == (int)StatusCode;
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public bool IsNotFound => 404 == (int)StatusCode;
+
+            /// <summary>
+            /// Deserializes the response if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public string? NotFound()
+            {
+                // This logic may be modified with the AsModel.mustache template
+                return IsNotFound
+                    ? System.Text.Json.JsonSerializer.Deserialize<string>(RawContent, _jsonSerializerOptions)
+                    : null;
+            }
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound and the deserialized response is not null
+            /// </summary>
+            /// <param name="result"></param>
+            /// <returns></returns>
+            public bool TryNotFound([NotNullWhen(true)]out string? result)
+            {
+                result = null;
+
+                try
+                {
+                    result = NotFound();
+                } catch (Exception e)
+                {
+                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)404);
+                }
+
+                return result != null;
+            }
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
+        }
+
+        partial void FormatEnableScenarioGroup(ref string name);
+
+        /// <summary>
+        /// Validates the request parameters
+        /// </summary>
+        /// <param name="name"></param>
+        /// <returns></returns>
+        private void ValidateEnableScenarioGroup(string name)
+        {
+            if (name == null)
+                throw new ArgumentNullException(nameof(name));
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        private void AfterEnableScenarioGroupDefaultImplementation(IEnableScenarioGroupApiResponse apiResponseLocalVar, string name)
+        {
+            bool suppressDefaultLog = false;
+            AfterEnableScenarioGroup(ref suppressDefaultLog, apiResponseLocalVar, name);
+            if (!suppressDefaultLog)
+                Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
+        }
+
+        /// <summary>
+        /// Processes the server response
+        /// </summary>
+        /// <param name="suppressDefaultLog"></param>
+        /// <param name="apiResponseLocalVar"></param>
+        /// <param name="name"></param>
+        partial void AfterEnableScenarioGroup(ref bool suppressDefaultLog, IEnableScenarioGroupApiResponse apiResponseLocalVar, string name);
+
+        /// <summary>
+        /// Logs exceptions that occur while retrieving the server response
+        /// </summary>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        private void OnErrorEnableScenarioGroupDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        {
+            bool suppressDefaultLogLocalVar = false;
+            OnErrorEnableScenarioGroup(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            if (!suppressDefaultLogLocalVar)
+                Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
+        }
+
+        /// <summary>
+        /// A partial method that gives developers a way to provide customized exception handling
+        /// </summary>
+        /// <param name="suppressDefaultLogLocalVar"></param>
+        /// <param name="exceptionLocalVar"></param>
+        /// <param name="pathFormatLocalVar"></param>
+        /// <param name="pathLocalVar"></param>
+        /// <param name="name"></param>
+        partial void OnErrorEnableScenarioGroup(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+
+        /// <summary>
+        /// Enable every scenario in a group Enables all scenarios in a group and resets the call history.  **Note:** Members keep their one-shot behavior, so each matching request consumes the next enabled member.
+        /// </summary>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioGroupApiResponse"/>&gt;</returns>
+        public async Task<IEnableScenarioGroupApiResponse?> EnableScenarioGroupOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            try
+            {
+                return await EnableScenarioGroupAsync(name, cancellationToken).ConfigureAwait(false);
+            }
+            catch (Exception)
+            {
+                return null;
+            }
+        }
+
+        /// <summary>
+        /// Enable every scenario in a group Enables all scenarios in a group and resets the call history.  **Note:** Members keep their one-shot behavior, so each matching request consumes the next enabled member.
+        /// </summary>
+        /// <exception cref="ApiException">Thrown when fails to make API call</exception>
+        /// <param name="name">The unique name of the scenario group</param>
+        /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
+        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioGroupApiResponse"/>&gt;</returns>
+        public async Task<IEnableScenarioGroupApiResponse> EnableScenarioGroupAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        {
+            UriBuilder uriBuilderLocalVar = new UriBuilder();
+
+            try
+            {
+                ValidateEnableScenarioGroup(name);
+
+                FormatEnableScenarioGroup(ref name);
+
+                using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
+                {
+                    uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
+                    uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
+                    uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
+                    uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
+                        ? "/groups/{name}/enable"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/groups/{name}/enable");
+                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+
+                    httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
+
+                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+
+                    DateTime requestedAtLocalVar = DateTime.UtcNow;
+
+                    using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
+                    {
+                        ILogger<EnableScenarioGroupApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<EnableScenarioGroupApiResponse>();
+                        EnableScenarioGroupApiResponse apiResponseLocalVar;
+
+                        switch ((int)httpResponseMessageLocalVar.StatusCode) {
+                            default: {
+                                string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/groups/{name}/enable", requestedAtLocalVar, _jsonSerializerOptions);
+
+                                break;
+                            }
+                        }
+
+                        AfterEnableScenarioGroupDefaultImplementation(apiResponseLocalVar, name);
+
+                        Events.ExecuteOnEnableScenarioGroup(apiResponseLocalVar);
+
+                        return apiResponseLocalVar;
+                    }
+                }
+            }
+            catch(Exception e)
+            {
+                OnErrorEnableScenarioGroupDefaultImplementation(e, "/groups/{name}/enable", uriBuilderLocalVar.Path, name);
+                Events.ExecuteOnErrorEnableScenarioGroup(e);
+                throw;
+            }
+        }
+
+        /// <summary>
+        /// The <see cref="EnableScenarioGroupApiResponse"/>
         /// </summary>
-        /// <returns></returns>
-        public DefaultApi(ILogger<DefaultApi> logger, ILoggerFactory loggerFactory, HttpClient httpClient, JsonSerializerOptionsProvider jsonSerializerOptionsProvider, DefaultApiEvents defaultApiEvents)
+        public partial class EnableScenarioGroupApiResponse : ProxyControlApi.Client.ApiResponse, IEnableScenarioGroupApiResponse
         {
-            _jsonSerializerOptions = jsonSerializerOptionsProvider.Options;
-            LoggerFactory = loggerFactory;
-            Logger = LoggerFactory.CreateLogger<DefaultApi>();
-            HttpClient = httpClient;
-            Events = defaultApiEvents;
-        }
+            /// <summary>
+            /// The logger
+            /// </summary>
+            public ILogger<EnableScenarioGroupApiResponse> Logger { get; }
 
-        partial void FormatDisableScenario(ref string name);
+            /// <summary>
+            /// The <see cref="EnableScenarioGroupApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="rawContent"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableScenarioGroupApiResponse(ILogger<EnableScenarioGroupApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
 
-        /// <summary>
-        /// Validates the request parameters
-        /// </summary>
-        /// <param name="name"></param>
-        /// <returns></returns>
-        private void ValidateDisableScenario(string name)
-        {
-            if (name == null)
-                throw new ArgumentNullException(nameof(name));
+            /// <summary>
+            /// The <see cref="EnableScenarioGroupApiResponse"/>
+            /// </summary>
+            /// <param name="logger"></param>
+            /// <param name="httpRequestMessage"></param>
+            /// <param name="httpResponseMessage"></param>
+            /// <param name="contentStream"></param>
+            /// <param name="path"></param>
+            /// <param name="requestedAt"></param>
+            /// <param name="jsonSerializerOptions"></param>
+            public EnableScenarioGroupApiResponse(ILogger<EnableScenarioGroupApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            {
+                Logger = logger;
+                OnCreated(httpRequestMessage, httpResponseMessage);
+            }
+
+            partial void OnCreated(global::System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage);
+
+            /// <summary>
+            /// Returns true if the response is 200 Ok
+            /// </summary>
+            /// <returns></returns>
+            public bool IsOk => 200 == (int)StatusCode;
+
+            /// <summary>
+            /// Returns true if the response is 404 NotFound
+            /// </summary>
+            /// <returns></returns>
+            public bool IsNotFound => 404 == (int)StatusCode;
+
+            private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
+            {
+                bool suppressDefaultLog = false;
+                OnDeserializationError(ref suppressDefaultLog, exception, httpStatusCode);
+                if (!suppressDefaultLog)
+                    Logger.LogError(exception, "An error occurred while deserializing the {code} response.", httpStatusCode);
+            }
+
+            partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
         }
 
         /// <summary>
         /// Processes the server response
         /// </summary>
         /// <param name="apiResponseLocalVar"></param>
-        /// <param name="name"></param>
-        private void AfterDisableScenarioDefaultImplementation(IDisableScenarioApiResponse apiResponseLocalVar, string name)
+        private void AfterGetConcurrencyLimitDefaultImplementation(IGetConcurrencyLimitApiResponse apiResponseLocalVar)
         {
             bool suppressDefaultLog = false;
-            AfterDisableScenario(ref suppressDefaultLog, apiResponseLocalVar, name);
+            AfterGetConcurrencyLimit(ref suppressDefaultLog, apiResponseLocalVar);
             if (!suppressDefaultLog)
                 Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
         }
@@ -288,8 +2000,7 @@ namespace ProxyControlApi.Api
         /// </summary>
         /// <param name="suppressDefaultLog"></param>
         /// <param name="apiResponseLocalVar"></param>
-        /// <param name="name"></param>
-        partial void AfterDisableScenario(ref bool suppressDefaultLog, IDisableScenarioApiResponse apiResponseLocalVar, string name);
+        partial void AfterGetConcurrencyLimit(ref bool suppressDefaultLog, IGetConcurrencyLimitApiResponse apiResponseLocalVar);
 
         /// <summary>
         /// Logs exceptions that occur while retrieving the server response
@@ -297,11 +2008,10 @@ namespace ProxyControlApi.Api
         /// <param name="exceptionLocalVar"></param>
         /// <param name="pathFormatLocalVar"></param>
         /// <param name="pathLocalVar"></param>
-        /// <param name="name"></param>
-        private void OnErrorDisableScenarioDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        private void OnErrorGetConcurrencyLimitDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar)
         {
             bool suppressDefaultLogLocalVar = false;
-            OnErrorDisableScenario(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            OnErrorGetConcurrencyLimit(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar);
             if (!suppressDefaultLogLocalVar)
                 Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
         }
@@ -313,20 +2023,18 @@ namespace ProxyControlApi.Api
         /// <param name="exceptionLocalVar"></param>
         /// <param name="pathFormatLocalVar"></param>
         /// <param name="pathLocalVar"></param>
-        /// <param name="name"></param>
-        partial void OnErrorDisableScenario(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+        partial void OnErrorGetConcurrencyLimit(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar);
 
         /// <summary>
-        /// Disable a failure scenario Disables a failure scenario by name. Disabled scenarios will not trigger even if matching requests are made through the proxy.  **Note:** Scenarios auto-disable after injection, so manual disable is typically only needed to cancel a scenario before it triggers.
+        /// Get the concurrency limit and its stats 
         /// </summary>
-        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
         /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
-        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioApiResponse"/>&gt;</returns>
-        public async Task<IDisableScenarioApiResponse?> DisableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        /// <returns><see cref="Task"/>&lt;<see cref="IGetConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IGetConcurrencyLimitApiResponse?> GetConcurrencyLimitOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default)
         {
             try
             {
-                return await DisableScenarioAsync(name, cancellationToken).ConfigureAwait(false);
+                return await GetConcurrencyLimitAsync(cancellationToken).ConfigureAwait(false);
             }
             catch (Exception)
             {
@@ -335,37 +2043,30 @@ namespace ProxyControlApi.Api
         }
 
         /// <summary>
-        /// Disable a failure scenario Disables a failure scenario by name. Disabled scenarios will not trigger even if matching requests are made through the proxy.  **Note:** Scenarios auto-disable after injection, so manual disable is typically only needed to cancel a scenario before it triggers.
+        /// Get the concurrency limit and its stats 
         /// </summary>
         /// <exception cref="ApiException">Thrown when fails to make API call</exception>
-        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
         /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
-        /// <returns><see cref="Task"/>&lt;<see cref="IDisableScenarioApiResponse"/>&gt;</returns>
-        public async Task<IDisableScenarioApiResponse> DisableScenarioAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        /// <returns><see cref="Task"/>&lt;<see cref="IGetConcurrencyLimitApiResponse"/>&gt;</returns>
+        public async Task<IGetConcurrencyLimitApiResponse> GetConcurrencyLimitAsync(System.Threading.CancellationToken cancellationToken = default)
         {
             UriBuilder uriBuilderLocalVar = new UriBuilder();
 
             try
             {
-                ValidateDisableScenario(name);
-
-                FormatDisableScenario(ref name);
-
                 using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
                 {
                     uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
                     uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
                     uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
                     uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
-                        ? "/scenarios/{name}/disable"
-                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/scenarios/{name}/disable");
-                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+                        ? "/concurrency-limit"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/concurrency-limit");
 
                     httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
 
                     string[] acceptLocalVars = new string[] {
-                        "application/json",
-                        "text/plain"
+                        "application/json"
                     };
 
                     string? acceptLocalVar = ClientUtils.SelectHeaderAccept(acceptLocalVars);
@@ -373,27 +2074,27 @@ namespace ProxyControlApi.Api
                     if (acceptLocalVar != null)
                         httpRequestMessageLocalVar.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue(acceptLocalVar));
 
-                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+                    httpRequestMessageLocalVar.Method = HttpMethod.Get;
 
                     DateTime requestedAtLocalVar = DateTime.UtcNow;
 
                     using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
                     {
-                        ILogger<DisableScenarioApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<DisableScenarioApiResponse>();
-                        DisableScenarioApiResponse apiResponseLocalVar;
+                        ILogger<GetConcurrencyLimitApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<GetConcurrencyLimitApiResponse>();
+                        GetConcurrencyLimitApiResponse apiResponseLocalVar;
 
                         switch ((int)httpResponseMessageLocalVar.StatusCode) {
                             default: {
                                 string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
-                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/scenarios/{name}/disable", requestedAtLocalVar, _jsonSerializerOptions);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/concurrency-limit", requestedAtLocalVar, _jsonSerializerOptions);
 
                                 break;
                             }
                         }
 
-                        AfterDisableScenarioDefaultImplementation(apiResponseLocalVar, name);
+                        AfterGetConcurrencyLimitDefaultImplementation(apiResponseLocalVar);
 
-                        Events.ExecuteOnDisableScenario(apiResponseLocalVar);
+                        Events.ExecuteOnGetConcurrencyLimit(apiResponseLocalVar);
 
                         return apiResponseLocalVar;
                     }
@@ -401,24 +2102,24 @@ namespace ProxyControlApi.Api
             }
             catch(Exception e)
             {
-                OnErrorDisableScenarioDefaultImplementation(e, "/scenarios/{name}/disable", uriBuilderLocalVar.Path, name);
-                Events.ExecuteOnErrorDisableScenario(e);
+                OnErrorGetConcurrencyLimitDefaultImplementation(e, "/concurrency-limit", uriBuilderLocalVar.Path);
+                Events.ExecuteOnErrorGetConcurrencyLimit(e);
                 throw;
             }
         }
 
         /// <summary>
-        /// The <see cref="DisableScenarioApiResponse"/>
+        /// The <see cref="GetConcurrencyLimitApiResponse"/>
         /// </summary>
-        public partial class DisableScenarioApiResponse : ProxyControlApi.Client.ApiResponse, IDisableScenarioApiResponse
+        public partial class GetConcurrencyLimitApiResponse : ProxyControlApi.Client.ApiResponse, IGetConcurrencyLimitApiResponse
         {
             /// <summary>
             /// The logger
             /// </summary>
-            public ILogger<DisableScenarioApiResponse> Logger { get; }
+            public ILogger<GetConcurrencyLimitApiResponse> Logger { get; }
 
             /// <summary>
-            /// The <see cref="DisableScenarioApiResponse"/>
+            /// The <see cref="GetConcurrencyLimitApiResponse"/>
             /// </summary>
             /// <param name="logger"></param>
             /// <param name="httpRequestMessage"></param>
@@ -427,14 +2128,14 @@ namespace ProxyControlApi.Api
             /// <param name="path"></param>
             /// <param name="requestedAt"></param>
             /// <param name="jsonSerializerOptions"></param>
-            public DisableScenarioApiResponse(ILogger<DisableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            public GetConcurrencyLimitApiResponse(ILogger<GetConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
             {
                 Logger = logger;
                 OnCreated(httpRequestMessage, httpResponseMessage);
             }
 
             /// <summary>
-            /// The <see cref="DisableScenarioApiResponse"/>
+            /// The <see cref="GetConcurrencyLimitApiResponse"/>
             /// </summary>
             /// <param name="logger"></param>
             /// <param name="httpRequestMessage"></param>
@@ -443,7 +2144,7 @@ namespace ProxyControlApi.Api
             /// <param name="path"></param>
             /// <param name="requestedAt"></param>
             /// <param name="jsonSerializerOptions"></param>
-            public DisableScenarioApiResponse(ILogger<DisableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            public GetConcurrencyLimitApiResponse(ILogger<GetConcurrencyLimitApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
             {
                 Logger = logger;
                 OnCreated(httpRequestMessage, httpResponseMessage);
@@ -461,11 +2162,11 @@ namespace ProxyControlApi.Api
             /// Deserializes the response if the response is 200 Ok
             /// </summary>
             /// <returns></returns>
-            public ProxyControlApi.Model.ScenarioStatus? Ok()
+            public ProxyControlApi.Model.ConcurrencyLimitStatus? Ok()
             {
                 // This logic may be modified with the AsModel.mustache template
                 return IsOk
-                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ScenarioStatus>(RawContent, _jsonSerializerOptions)
+                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ConcurrencyLimitStatus>(RawContent, _jsonSerializerOptions)
                     : null;
             }
 
@@ -474,7 +2175,7 @@ namespace ProxyControlApi.Api
             /// </summary>
             /// <param name="result"></param>
             /// <returns></returns>
-            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ScenarioStatus? result)
+            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ConcurrencyLimitStatus? result)
             {
                 result = null;
 
@@ -489,44 +2190,6 @@ namespace ProxyControlApi.Api
                 return result != null;
             }
 
-            /// <summary>
-            /// Returns true if the response is 404 NotFound
-            /// </summary>
-            /// <returns></returns>
-            public bool IsNotFound => 404 == (int)StatusCode;
-
-            /// <summary>
-            /// Deserializes the response if the response is 404 NotFound
-            /// </summary>
-            /// <returns></returns>
-            public string? NotFound()
-            {
-                // This logic may be modified with the AsModel.mustache template
-                return IsNotFound
-                    ? System.Text.Json.JsonSerializer.Deserialize<string>(RawContent, _jsonSerializerOptions)
-                    : null;
-            }
-
-            /// <summary>
-            /// Returns true if the response is 404 NotFound and the deserialized response is not null
-            /// </summary>
-            /// <param name="result"></param>
-            /// <returns></returns>
-            public bool TryNotFound([NotNullWhen(true)]out string? result)
-            {
-                result = null;
-
-                try
-                {
-                    result = NotFound();
-                } catch (Exception e)
-                {
-                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)404);
-                }
-
-                return result != null;
-            }
-
             private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
             {
                 bool suppressDefaultLog = false;
@@ -538,28 +2201,14 @@ namespace ProxyControlApi.Api
             partial void OnDeserializationError(ref bool suppressDefaultLog, Exception exception, HttpStatusCode httpStatusCode);
         }
 
-        partial void FormatEnableScenario(ref string name);
-
-        /// <summary>
-        /// Validates the request parameters
-        /// </summary>
-        /// <param name="name"></param>
-        /// <returns></returns>
-        private void ValidateEnableScenario(string name)
-        {
-            if (name == null)
-                throw new ArgumentNullException(nameof(name));
-        }
-
         /// <summary>
         /// Processes the server response
         /// </summary>
         /// <param name="apiResponseLocalVar"></param>
-        /// <param name="name"></param>
-        private void AfterEnableScenarioDefaultImplementation(IEnableScenarioApiResponse apiResponseLocalVar, string name)
+        private void AfterListScenarioGroupsDefaultImplementation(IListScenarioGroupsApiResponse apiResponseLocalVar)
         {
             bool suppressDefaultLog = false;
-            AfterEnableScenario(ref suppressDefaultLog, apiResponseLocalVar, name);
+            AfterListScenarioGroups(ref suppressDefaultLog, apiResponseLocalVar);
             if (!suppressDefaultLog)
                 Logger.LogInformation("{0,-9} | {1} | {3}", (apiResponseLocalVar.DownloadedAt - apiResponseLocalVar.RequestedAt).TotalSeconds, apiResponseLocalVar.StatusCode, apiResponseLocalVar.Path);
         }
@@ -569,8 +2218,7 @@ namespace ProxyControlApi.Api
         /// </summary>
         /// <param name="suppressDefaultLog"></param>
         /// <param name="apiResponseLocalVar"></param>
-        /// <param name="name"></param>
-        partial void AfterEnableScenario(ref bool suppressDefaultLog, IEnableScenarioApiResponse apiResponseLocalVar, string name);
+        partial void AfterListScenarioGroups(ref bool suppressDefaultLog, IListScenarioGroupsApiResponse apiResponseLocalVar);
 
         /// <summary>
         /// Logs exceptions that occur while retrieving the server response
@@ -578,11 +2226,10 @@ namespace ProxyControlApi.Api
         /// <param name="exceptionLocalVar"></param>
         /// <param name="pathFormatLocalVar"></param>
         /// <param name="pathLocalVar"></param>
-        /// <param name="name"></param>
-        private void OnErrorEnableScenarioDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name)
+        private void OnErrorListScenarioGroupsDefaultImplementation(Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar)
         {
             bool suppressDefaultLogLocalVar = false;
-            OnErrorEnableScenario(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar, name);
+            OnErrorListScenarioGroups(ref suppressDefaultLogLocalVar, exceptionLocalVar, pathFormatLocalVar, pathLocalVar);
             if (!suppressDefaultLogLocalVar)
                 Logger.LogError(exceptionLocalVar, "An error occurred while sending the request to the server.");
         }
@@ -594,20 +2241,18 @@ namespace ProxyControlApi.Api
         /// <param name="exceptionLocalVar"></param>
         /// <param name="pathFormatLocalVar"></param>
         /// <param name="pathLocalVar"></param>
-        /// <param name="name"></param>
-        partial void OnErrorEnableScenario(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar, string name);
+        partial void OnErrorListScenarioGroups(ref bool suppressDefaultLogLocalVar, Exception exceptionLocalVar, string pathFormatLocalVar, string pathLocalVar);
 
         /// <summary>
-        /// Enable a failure scenario Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).
+        /// List all scenario groups Returns all scenario groups with their member scenarios. A group is reported as enabled when all of its members are enabled.
         /// </summary>
-        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
         /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
-        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioApiResponse"/>&gt;</returns>
-        public async Task<IEnableScenarioApiResponse?> EnableScenarioOrDefaultAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        /// <returns><see cref="Task"/>&lt;<see cref="IListScenarioGroupsApiResponse"/>&gt;</returns>
+        public async Task<IListScenarioGroupsApiResponse?> ListScenarioGroupsOrDefaultAsync(System.Threading.CancellationToken cancellationToken = default)
         {
             try
             {
-                return await EnableScenarioAsync(name, cancellationToken).ConfigureAwait(false);
+                return await ListScenarioGroupsAsync(cancellationToken).ConfigureAwait(false);
             }
             catch (Exception)
             {
@@ -616,37 +2261,30 @@ namespace ProxyControlApi.Api
         }
 
         /// <summary>
-        /// Enable a failure scenario Enables a failure scenario by name. Once enabled, the scenario will trigger on the next matching request (CloudFetch download or Thrift operation).  **Note:** Scenarios auto-disable after first injection (one-shot behavior).
+        /// List all scenario groups Returns all scenario groups with their member scenarios. A group is reported as enabled when all of its members are enabled.
         /// </summary>
         /// <exception cref="ApiException">Thrown when fails to make API call</exception>
-        /// <param name="name">The unique name of the failure scenario (from proxy-config.yaml)</param>
         /// <param name="cancellationToken">Cancellation Token to cancel the request.</param>
-        /// <returns><see cref="Task"/>&lt;<see cref="IEnableScenarioApiResponse"/>&gt;</returns>
-        public async Task<IEnableScenarioApiResponse> EnableScenarioAsync(string name, System.Threading.CancellationToken cancellationToken = default)
+        /// <returns><see cref="Task"/>&lt;<see cref="IListScenarioGroupsApiResponse"/>&gt;</returns>
+        public async Task<IListScenarioGroupsApiResponse> ListScenarioGroupsAsync(System.Threading.CancellationToken cancellationToken = default)
         {
             UriBuilder uriBuilderLocalVar = new UriBuilder();
 
             try
             {
-                ValidateEnableScenario(name);
-
-                FormatEnableScenario(ref name);
-
                 using (HttpRequestMessage httpRequestMessageLocalVar = new HttpRequestMessage())
                 {
                     uriBuilderLocalVar.Host = HttpClient.BaseAddress!.Host;
                     uriBuilderLocalVar.Port = HttpClient.BaseAddress.Port;
                     uriBuilderLocalVar.Scheme = HttpClient.BaseAddress.Scheme;
                     uriBuilderLocalVar.Path = HttpClient.BaseAddress.AbsolutePath == "/"
-                        ? "/scenarios/{name}/enable"
-                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/scenarios/{name}/enable");
-                    uriBuilderLocalVar.Path = uriBuilderLocalVar.Path.Replace("%7Bname%7D", Uri.EscapeDataString(name.ToString()));
+                        ? "/groups"
+                        : string.Concat(HttpClient.BaseAddress.AbsolutePath, "/groups");
 
                     httpRequestMessageLocalVar.RequestUri = uriBuilderLocalVar.Uri;
 
                     string[] acceptLocalVars = new string[] {
-                        "application/json",
-                        "text/plain"
+                        "application/json"
                     };
 
                     string? acceptLocalVar = ClientUtils.SelectHeaderAccept(acceptLocalVars);
@@ -654,27 +2292,27 @@ namespace ProxyControlApi.Api
                     if (acceptLocalVar != null)
                         httpRequestMessageLocalVar.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue(acceptLocalVar));
 
-                    httpRequestMessageLocalVar.Method = HttpMethod.Post;
+                    httpRequestMessageLocalVar.Method = HttpMethod.Get;
 
                     DateTime requestedAtLocalVar = DateTime.UtcNow;
 
                     using (HttpResponseMessage httpResponseMessageLocalVar = await HttpClient.SendAsync(httpRequestMessageLocalVar, cancellationToken).ConfigureAwait(false))
                     {
-                        ILogger<EnableScenarioApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<EnableScenarioApiResponse>();
-                        EnableScenarioApiResponse apiResponseLocalVar;
+                        ILogger<ListScenarioGroupsApiResponse> apiResponseLoggerLocalVar = LoggerFactory.CreateLogger<ListScenarioGroupsApiResponse>();
+                        ListScenarioGroupsApiResponse apiResponseLocalVar;
 
                         switch ((int)httpResponseMessageLocalVar.StatusCode) {
                             default: {
                                 string responseContentLocalVar = await httpResponseMessageLocalVar.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
-                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/scenarios/{name}/enable", requestedAtLocalVar, _jsonSerializerOptions);
+                                apiResponseLocalVar = new(apiResponseLoggerLocalVar, httpRequestMessageLocalVar, httpResponseMessageLocalVar, responseContentLocalVar, "/groups", requestedAtLocalVar, _jsonSerializerOptions);
 
                                 break;
                             }
                         }
 
-                        AfterEnableScenarioDefaultImplementation(apiResponseLocalVar, name);
+                        AfterListScenarioGroupsDefaultImplementation(apiResponseLocalVar);
 
-                        Events.ExecuteOnEnableScenario(apiResponseLocalVar);
+                        Events.ExecuteOnListScenarioGroups(apiResponseLocalVar);
 
                         return apiResponseLocalVar;
                     }
@@ -682,24 +2320,24 @@ namespace ProxyControlApi.Api
             }
             catch(Exception e)
             {
-                OnErrorEnableScenarioDefaultImplementation(e, "/scenarios/{name}/enable", uriBuilderLocalVar.Path, name);
-                Events.ExecuteOnErrorEnableScenario(e);
+                OnErrorListScenarioGroupsDefaultImplementation(e, "/groups", uriBuilderLocalVar.Path);
+                Events.ExecuteOnErrorListScenarioGroups(e);
                 throw;
             }
         }
 
         /// <summary>
-        /// The <see cref="EnableScenarioApiResponse"/>
+        /// The <see cref="ListScenarioGroupsApiResponse"/>
         /// </summary>
-        public partial class EnableScenarioApiResponse : ProxyControlApi.Client.ApiResponse, IEnableScenarioApiResponse
+        public partial class ListScenarioGroupsApiResponse : ProxyControlApi.Client.ApiResponse, IListScenarioGroupsApiResponse
         {
             /// <summary>
             /// The logger
             /// </summary>
-            public ILogger<EnableScenarioApiResponse> Logger { get; }
+            public ILogger<ListScenarioGroupsApiResponse> Logger { get; }
 
             /// <summary>
-            /// The <see cref="EnableScenarioApiResponse"/>
+            /// The <see cref="ListScenarioGroupsApiResponse"/>
             /// </summary>
             /// <param name="logger"></param>
             /// <param name="httpRequestMessage"></param>
@@ -708,14 +2346,14 @@ namespace ProxyControlApi.Api
             /// <param name="path"></param>
             /// <param name="requestedAt"></param>
             /// <param name="jsonSerializerOptions"></param>
-            public EnableScenarioApiResponse(ILogger<EnableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
+            public ListScenarioGroupsApiResponse(ILogger<ListScenarioGroupsApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, string rawContent, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, rawContent, path, requestedAt, jsonSerializerOptions)
             {
                 Logger = logger;
                 OnCreated(httpRequestMessage, httpResponseMessage);
             }
 
             /// <summary>
-            /// The <see cref="EnableScenarioApiResponse"/>
+            /// The <see cref="ListScenarioGroupsApiResponse"/>
             /// </summary>
             /// <param name="logger"></param>
             /// <param name="httpRequestMessage"></param>
@@ -724,7 +2362,7 @@ namespace ProxyControlApi.Api
             /// <param name="path"></param>
             /// <param name="requestedAt"></param>
             /// <param name="jsonSerializerOptions"></param>
-            public EnableScenarioApiResponse(ILogger<EnableScenarioApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
+            public ListScenarioGroupsApiResponse(ILogger<ListScenarioGroupsApiResponse> logger, System.Net.Http.HttpRequestMessage httpRequestMessage, System.Net.Http.HttpResponseMessage httpResponseMessage, System.IO.Stream contentStream, string path, DateTime requestedAt, System.Text.Json.JsonSerializerOptions jsonSerializerOptions) : base(httpRequestMessage, httpResponseMessage, contentStream, path, requestedAt, jsonSerializerOptions)
             {
                 Logger = logger;
                 OnCreated(httpRequestMessage, httpResponseMessage);
@@ -742,11 +2380,11 @@ namespace ProxyControlApi.Api
             /// Deserializes the response if the response is 200 Ok
             /// </summary>
             /// <returns></returns>
-            public ProxyControlApi.Model.ScenarioStatus? Ok()
+            public ProxyControlApi.Model.ScenarioGroupList? Ok()
             {
                 // This logic may be modified with the AsModel.mustache template
                 return IsOk
-                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ScenarioStatus>(RawContent, _jsonSerializerOptions)
+                    ? System.Text.Json.JsonSerializer.Deserialize<ProxyControlApi.Model.ScenarioGroupList>(RawContent, _jsonSerializerOptions)
                     : null;
             }
 
@@ -755,7 +2393,7 @@ namespace ProxyControlApi.Api
             /// </summary>
             /// <param name="result"></param>
             /// <returns></returns>
-            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ScenarioStatus? result)
+            public bool TryOk([NotNullWhen(true)]out ProxyControlApi.Model.ScenarioGroupList? result)
             {
                 result = null;
 
@@ -770,44 +2408,6 @@ namespace ProxyControlApi.Api
                 return result != null;
             }
 
-            /// <summary>
-            /// Returns true if the response is 404 NotFound
-            /// </summary>
-            /// <returns></returns>
-            public bool IsNotFound => 404 == (int)StatusCode;
-
-            /// <summary>
-            /// Deserializes the response if the response is 404 NotFound
-            /// </summary>
-            /// <returns></returns>
-            public string? NotFound()
-            {
-                // This logic may be modified with the AsModel.mustache template
-                return IsNotFound
-                    ? System.Text.Json.JsonSerializer.Deserialize<string>(RawContent, _jsonSerializerOptions)
-                    : null;
-            }
-
-            /// <summary>
-            /// Returns true if the response is 404 NotFound and the deserialized response is not null
-            /// </summary>
-            /// <param name="result"></param>
-            /// <returns></returns>
-            public bool TryNotFound([NotNullWhen(true)]out string? result)
-            {
-                result = null;
-
-                try
-                {
-                    result = NotFound();
-                } catch (Exception e)
-                {
-                    OnDeserializationErrorDefaultImplementation(e, (HttpStatusCode)404);
-                }
-
-                return result != null;
-            }
-
             private void OnDeserializationErrorDefaultImplementation(Exception exception, HttpStatusCode httpStatusCode)
             {
                 bool suppressDefaultLog = false;
@@ -2,7 +2,7 @@
 /*
  * Thrift Protocol Test Proxy - Control API
  *
- * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
  *
  * The version of the OpenAPI document: 1.0.0
  * Generated by: https://github.com/openapitools/openapi-generator.git
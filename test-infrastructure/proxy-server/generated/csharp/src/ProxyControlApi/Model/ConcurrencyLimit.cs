@@ -0,0 +1,417 @@
+// <auto-generated>
+/*
+ * Thrift Protocol Test Proxy - Control API
+ *
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ *
+ * The version of the OpenAPI document: 1.0.0
+ * Generated by: https://github.com/openapitools/openapi-generator.git
+ */
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.ObjectModel;
+using System.Linq;
+using System.IO;
+using System.Text;
+using System.Text.RegularExpressions;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.ComponentModel.DataAnnotations;
+using ProxyControlApi.Client;
+
+namespace ProxyControlApi.Model
+{
+    /// <summary>
+    /// ConcurrencyLimit
+    /// </summary>
+    public partial class ConcurrencyLimit : IValidatableObject
+    {
+        /// <summary>
+        /// Initializes a new instance of the <see cref="ConcurrencyLimit" /> class.
+        /// </summary>
+        /// <param name="maxConnections">Maximum number of concurrent upstream connections</param>
+        /// <param name="mode"></param>
+        /// <param name="scope">Requests the limit applies to</param>
+        /// <param name="queueTimeoutSeconds">How long a queued request waits before it is rejected</param>
+        /// <param name="retryAfterSeconds">Retry-After header value for rejected requests</param>
+        [JsonConstructor]
+        public ConcurrencyLimit(int maxConnections, Option<ModeEnum?> mode = default, Option<ScopeEnum?> scope = default, Option<decimal?> queueTimeoutSeconds = default, Option<int?> retryAfterSeconds = default)
+        {
+            MaxConnections = maxConnections;
+            ModeOption = mode;
+            ScopeOption = scope;
+            QueueTimeoutSecondsOption = queueTimeoutSeconds;
+            RetryAfterSecondsOption = retryAfterSeconds;
+            OnCreated();
+        }
+
+        partial void OnCreated();
+
+        /// <summary>
+        /// Defines Mode
+        /// </summary>
+        public enum ModeEnum
+        {
+            /// <summary>
+            /// Enum Queue for value: queue
+            /// </summary>
+            Queue = 1,
+
+            /// <summary>
+            /// Enum Reject for value: reject
+            /// </summary>
+            Reject = 2
+        }
+
+        /// <summary>
+        /// Returns a <see cref="ModeEnum"/>
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        /// <exception cref="NotImplementedException"></exception>
+        public static ModeEnum ModeEnumFromString(string value)
+        {
+            if (value.Equals("queue"))
+                return ModeEnum.Queue;
+
+            if (value.Equals("reject"))
+                return ModeEnum.Reject;
+
+            throw new NotImplementedException($"Could not convert value to type ModeEnum: '{value}'");
+        }
+
+        /// <summary>
+        /// Returns a <see cref="ModeEnum"/>
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        public static ModeEnum? ModeEnumFromStringOrDefault(string value)
+        {
+            if (value.Equals("queue"))
+                return ModeEnum.Queue;
+
+            if (value.Equals("reject"))
+                return ModeEnum.Reject;
+
+            return null;
+        }
+
+        /// <summary>
+        /// Converts the <see cref="ModeEnum"/> to the json value
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        /// <exception cref="NotImplementedException"></exception>
+        public static string ModeEnumToJsonValue(ModeEnum? value)
+        {
+            if (value == ModeEnum.Queue)
+                return "queue";
+
+            if (value == ModeEnum.Reject)
+                return "reject";
+
+            throw new NotImplementedException($"Value could not be handled: '{value}'");
+        }
+
+        /// <summary>
+        /// Used to track the state of Mode
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<ModeEnum?> ModeOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets Mode
+        /// </summary>
+        [JsonPropertyName("mode")]
+        public ModeEnum? Mode { get { return this.ModeOption; } set { this.ModeOption = new(value); } }
+
+        /// <summary>
+        /// Requests the limit applies to
+        /// </summary>
+        public enum ScopeEnum
+        {
+            /// <summary>
+            /// Enum Cloudfetch for value: cloudfetch
+            /// </summary>
+            Cloudfetch = 1,
+
+            /// <summary>
+            /// Enum All for value: all
+            /// </summary>
+            All = 2
+        }
+
+        /// <summary>
+        /// Returns a <see cref="ScopeEnum"/>
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        /// <exception cref="NotImplementedException"></exception>
+        public static ScopeEnum ScopeEnumFromString(string value)
+        {
+            if (value.Equals("cloudfetch"))
+                return ScopeEnum.Cloudfetch;
+
+            if (value.Equals("all"))
+                return ScopeEnum.All;
+
+            throw new NotImplementedException($"Could not convert value to type ScopeEnum: '{value}'");
+        }
+
+        /// <summary>
+        /// Returns a <see cref="ScopeEnum"/>
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        public static ScopeEnum? ScopeEnumFromStringOrDefault(string value)
+        {
+            if (value.Equals("cloudfetch"))
+                return ScopeEnum.Cloudfetch;
+
+            if (value.Equals("all"))
+                return ScopeEnum.All;
+
+            return null;
+        }
+
+        /// <summary>
+        /// Converts the <see cref="ScopeEnum"/> to the json value
+        /// </summary>
+        /// <param name="value"></param>
+        /// <returns></returns>
+        /// <exception cref="NotImplementedException"></exception>
+        public static string ScopeEnumToJsonValue(ScopeEnum? value)
+        {
+            if (value == ScopeEnum.Cloudfetch)
+                return "cloudfetch";
+
+            if (value == ScopeEnum.All)
+                return "all";
+
+            throw new NotImplementedException($"Value could not be handled: '{value}'");
+        }
+
+        /// <summary>
+        /// Used to track the state of Scope
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<ScopeEnum?> ScopeOption { get; private set; }
+
+        /// <summary>
+        /// Requests the limit applies to
+        /// </summary>
+        /// <value>Requests the limit applies to</value>
+        [JsonPropertyName("scope")]
+        public ScopeEnum? Scope { get { return this.ScopeOption; } set { this.ScopeOption = new(value); } }
+
+        /// <summary>
+        /// Maximum number of concurrent upstream connections
+        /// </summary>
+        /// <value>Maximum number of concurrent upstream connections</value>
+        /* <example>2</example> */
+        [JsonPropertyName("max_connections")]
+        public int MaxConnections { get; set; }
+
+        /// <summary>
+        /// Used to track the state of QueueTimeoutSeconds
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<decimal?> QueueTimeoutSecondsOption { get; private set; }
+
+        /// <summary>
+        /// How long a queued request waits before it is rejected
+        /// </summary>
+        /// <value>How long a queued request waits before it is rejected</value>
+        [JsonPropertyName("queue_timeout_seconds")]
+        public decimal? QueueTimeoutSeconds { get { return this.QueueTimeoutSecondsOption; } set { this.QueueTimeoutSecondsOption = new(value); } }
+
+        /// <summary>
+        /// Used to track the state of RetryAfterSeconds
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<int?> RetryAfterSecondsOption { get; private set; }
+
+        /// <summary>
+        /// Retry-After header value for rejected requests
+        /// </summary>
+        /// <value>Retry-After header value for rejected requests</value>
+        [JsonPropertyName("retry_after_seconds")]
+        public int? RetryAfterSeconds { get { return this.RetryAfterSecondsOption; } set { this.RetryAfterSecondsOption = new(value); } }
+
+        /// <summary>
+        /// Returns the string presentation of the object
+        /// </summary>
+        /// <returns>String presentation of the object</returns>
+        public override string ToString()
+        {
+            StringBuilder sb = new StringBuilder();
+            sb.Append("class ConcurrencyLimit {\n");
+            sb.Append("  MaxConnections: ").Append(MaxConnections).Append("\n");
+            sb.Append("  Mode: ").Append(Mode).Append("\n");
+            sb.Append("  Scope: ").Append(Scope).Append("\n");
+            sb.Append("  QueueTimeoutSeconds: ").Append(QueueTimeoutSeconds).Append("\n");
+            sb.Append("  RetryAfterSeconds: ").Append(RetryAfterSeconds).Append("\n");
+            sb.Append("}\n");
+            return sb.ToString();
+        }
+
+        /// <summary>
+        /// To validate all properties of the instance
+        /// </summary>
+        /// <param name="validationContext">Validation context</param>
+        /// <returns>Validation Result</returns>
+        IEnumerable<ValidationResult> IValidatableObject.Validate(ValidationContext validationContext)
+        {
+            // MaxConnections (int) minimum
+            if (this.MaxConnections < (int)1)
+            {
+                yield return new ValidationResult("Invalid value for MaxConnections, must be a value greater than or equal to 1.", new [] { "MaxConnections" });
+            }
+
+            yield break;
+        }
+    }
+
+    /// <summary>
+    /// A Json converter for type <see cref="ConcurrencyLimit" />
+    /// </summary>
+    public class ConcurrencyLimitJsonConverter : JsonConverter<ConcurrencyLimit>
+    {
+        /// <summary>
+        /// Deserializes json to <see cref="ConcurrencyLimit" />
+        /// </summary>
+        /// <param name="utf8JsonReader"></param>
+        /// <param name="typeToConvert"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <returns></returns>
+        /// <exception cref="JsonException"></exception>
+        public override ConcurrencyLimit Read(ref Utf8JsonReader utf8JsonReader, Type typeToConvert, JsonSerializerOptions jsonSerializerOptions)
+        {
+            int currentDepth = utf8JsonReader.CurrentDepth;
+
+            if (utf8JsonReader.TokenType != JsonTokenType.StartObject && utf8JsonReader.TokenType != JsonTokenType.StartArray)
+                throw new JsonException();
+
+            JsonTokenType startingTokenType = utf8JsonReader.TokenType;
+
+            Option<int?> maxConnections = default;
+            Option<ConcurrencyLimit.ModeEnum?> mode = default;
+            Option<ConcurrencyLimit.ScopeEnum?> scope = default;
+            Option<decimal?> queueTimeoutSeconds = default;
+            Option<int?> retryAfterSeconds = default;
+
+            while (utf8JsonReader.Read())
+            {
+                if (startingTokenType == JsonTokenType.StartObject && utf8JsonReader.TokenType == JsonTokenType.EndObject && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (startingTokenType == JsonTokenType.StartArray && utf8JsonReader.TokenType == JsonTokenType.EndArray && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (utf8JsonReader.TokenType == JsonTokenType.PropertyName && currentDepth == utf8JsonReader.CurrentDepth - 1)
+                {
+                    string? localVarJsonPropertyName = utf8JsonReader.GetString();
+                    utf8JsonReader.Read();
+
+                    switch (localVarJsonPropertyName)
+                    {
+                        case "max_connections":
+                            maxConnections = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        case "mode":
+                            string? modeRawValue = utf8JsonReader.GetString();
+                            if (modeRawValue != null)
+                                mode = new Option<ConcurrencyLimit.ModeEnum?>(ConcurrencyLimit.ModeEnumFromStringOrDefault(modeRawValue));
+                            break;
+                        case "scope":
+                            string? scopeRawValue = utf8JsonReader.GetString();
+                            if (scopeRawValue != null)
+                                scope = new Option<ConcurrencyLimit.ScopeEnum?>(ConcurrencyLimit.ScopeEnumFromStringOrDefault(scopeRawValue));
+                            break;
+                        case "queue_timeout_seconds":
+                            queueTimeoutSeconds = new Option<decimal?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (decimal?)null : utf8JsonReader.GetDecimal());
+                            break;
+                        case "retry_after_seconds":
+                            retryAfterSeconds = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        default:
+                            break;
+                    }
+                }
+            }
+
+            if (!maxConnections.IsSet)
+                throw new ArgumentException("Property is required for class ConcurrencyLimit.", nameof(maxConnections));
+
+            if (maxConnections.IsSet && maxConnections.Value == null)
+                throw new ArgumentNullException(nameof(maxConnections), "Property is not nullable for class ConcurrencyLimit.");
+
+            if (mode.IsSet && mode.Value == null)
+                throw new ArgumentNullException(nameof(mode), "Property is not nullable for class ConcurrencyLimit.");
+
+            if (scope.IsSet && scope.Value == null)
+                throw new ArgumentNullException(nameof(scope), "Property is not nullable for class ConcurrencyLimit.");
+
+            if (queueTimeoutSeconds.IsSet && queueTimeoutSeconds.Value == null)
+                throw new ArgumentNullException(nameof(queueTimeoutSeconds), "Property is not nullable for class ConcurrencyLimit.");
+
+            return new ConcurrencyLimit(maxConnections.Value!.Value!, mode, scope, queueTimeoutSeconds, retryAfterSeconds);
+        }
+
+        /// <summary>
+        /// Serializes a <see cref="ConcurrencyLimit" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public override void Write(Utf8JsonWriter writer, ConcurrencyLimit concurrencyLimit, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteStartObject();
+
+            WriteProperties(writer, concurrencyLimit, jsonSerializerOptions);
+            writer.WriteEndObject();
+        }
+
+        /// <summary>
+        /// Serializes the properties of <see cref="ConcurrencyLimit" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimit"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public void WriteProperties(Utf8JsonWriter writer, ConcurrencyLimit concurrencyLimit, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteNumber("max_connections", concurrencyLimit.MaxConnections);
+
+            if (concurrencyLimit.ModeOption.IsSet)
+            {
+                var modeRawValue = ConcurrencyLimit.ModeEnumToJsonValue(concurrencyLimit.ModeOption.Value!.Value);
+                writer.WriteString("mode", modeRawValue);
+            }
+
+            if (concurrencyLimit.ScopeOption.IsSet)
+            {
+                var scopeRawValue = ConcurrencyLimit.ScopeEnumToJsonValue(concurrencyLimit.ScopeOption.Value!.Value);
+                writer.WriteString("scope", scopeRawValue);
+            }
+
+            if (concurrencyLimit.QueueTimeoutSecondsOption.IsSet)
+                writer.WriteNumber("queue_timeout_seconds", concurrencyLimit.QueueTimeoutSecondsOption.Value!.Value);
+
+            if (concurrencyLimit.RetryAfterSecondsOption.IsSet)
+                if (concurrencyLimit.RetryAfterSecondsOption.Value != null)
+                    writer.WriteNumber("retry_after_seconds", concurrencyLimit.RetryAfterSecondsOption.Value!.Value);
+                else
+                    writer.WriteNull("retry_after_seconds");
+        }
+    }
+}
@@ -0,0 +1,214 @@
+// <auto-generated>
+/*
+ * Thrift Protocol Test Proxy - Control API
+ *
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ *
+ * The version of the OpenAPI document: 1.0.0
+ * Generated by: https://github.com/openapitools/openapi-generator.git
+ */
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.ObjectModel;
+using System.Linq;
+using System.IO;
+using System.Text;
+using System.Text.RegularExpressions;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.ComponentModel.DataAnnotations;
+using ProxyControlApi.Client;
+
+namespace ProxyControlApi.Model
+{
+    /// <summary>
+    /// ConcurrencyLimitStatus
+    /// </summary>
+    public partial class ConcurrencyLimitStatus : IValidatableObject
+    {
+        /// <summary>
+        /// Initializes a new instance of the <see cref="ConcurrencyLimitStatus" /> class.
+        /// </summary>
+        /// <param name="enabled"></param>
+        /// <param name="stats"></param>
+        /// <param name="limit"></param>
+        [JsonConstructor]
+        public ConcurrencyLimitStatus(bool enabled, ConcurrencyLimitStatusStats stats, Option<ConcurrencyLimit?> limit = default)
+        {
+            Enabled = enabled;
+            LimitOption = limit;
+            Stats = stats;
+            OnCreated();
+        }
+
+        partial void OnCreated();
+
+        /// <summary>
+        /// Gets or Sets Enabled
+        /// </summary>
+        [JsonPropertyName("enabled")]
+        public bool Enabled { get; set; }
+
+        /// <summary>
+        /// Used to track the state of Limit
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<ConcurrencyLimit?> LimitOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets Limit
+        /// </summary>
+        [JsonPropertyName("limit")]
+        public ConcurrencyLimit? Limit { get { return this.LimitOption; } set { this.LimitOption = new(value); } }
+
+        /// <summary>
+        /// Gets or Sets Stats
+        /// </summary>
+        [JsonPropertyName("stats")]
+        public ConcurrencyLimitStatusStats Stats { get; set; }
+
+        /// <summary>
+        /// Returns the string presentation of the object
+        /// </summary>
+        /// <returns>String presentation of the object</returns>
+        public override string ToString()
+        {
+            StringBuilder sb = new StringBuilder();
+            sb.Append("class ConcurrencyLimitStatus {\n");
+            sb.Append("  Enabled: ").Append(Enabled).Append("\n");
+            sb.Append("  Limit: ").Append(Limit).Append("\n");
+            sb.Append("  Stats: ").Append(Stats).Append("\n");
+            sb.Append("}\n");
+            return sb.ToString();
+        }
+
+        /// <summary>
+        /// To validate all properties of the instance
+        /// </summary>
+        /// <param name="validationContext">Validation context</param>
+        /// <returns>Validation Result</returns>
+        IEnumerable<ValidationResult> IValidatableObject.Validate(ValidationContext validationContext)
+        {
+            yield break;
+        }
+    }
+
+    /// <summary>
+    /// A Json converter for type <see cref="ConcurrencyLimitStatus" />
+    /// </summary>
+    public class ConcurrencyLimitStatusJsonConverter : JsonConverter<ConcurrencyLimitStatus>
+    {
+        /// <summary>
+        /// Deserializes json to <see cref="ConcurrencyLimitStatus" />
+        /// </summary>
+        /// <param name="utf8JsonReader"></param>
+        /// <param name="typeToConvert"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <returns></returns>
+        /// <exception cref="JsonException"></exception>
+        public override ConcurrencyLimitStatus Read(ref Utf8JsonReader utf8JsonReader, Type typeToConvert, JsonSerializerOptions jsonSerializerOptions)
+        {
+            int currentDepth = utf8JsonReader.CurrentDepth;
+
+            if (utf8JsonReader.TokenType != JsonTokenType.StartObject && utf8JsonReader.TokenType != JsonTokenType.StartArray)
+                throw new JsonException();
+
+            JsonTokenType startingTokenType = utf8JsonReader.TokenType;
+
+            Option<bool?> enabled = default;
+            Option<ConcurrencyLimit?> limit = default;
+            Option<ConcurrencyLimitStatusStats?> stats = default;
+
+            while (utf8JsonReader.Read())
+            {
+                if (startingTokenType == JsonTokenType.StartObject && utf8JsonReader.TokenType == JsonTokenType.EndObject && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (startingTokenType == JsonTokenType.StartArray && utf8JsonReader.TokenType == JsonTokenType.EndArray && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (utf8JsonReader.TokenType == JsonTokenType.PropertyName && currentDepth == utf8JsonReader.CurrentDepth - 1)
+                {
+                    string? localVarJsonPropertyName = utf8JsonReader.GetString();
+                    utf8JsonReader.Read();
+
+                    switch (localVarJsonPropertyName)
+                    {
+                        case "enabled":
+                            enabled = new Option<bool?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (bool?)null : utf8JsonReader.GetBoolean());
+                            break;
+                        case "limit":
+                            limit = new Option<ConcurrencyLimit?>(JsonSerializer.Deserialize<ConcurrencyLimit>(ref utf8JsonReader, jsonSerializerOptions));
+                            break;
+                        case "stats":
+                            stats = new Option<ConcurrencyLimitStatusStats?>(JsonSerializer.Deserialize<ConcurrencyLimitStatusStats>(ref utf8JsonReader, jsonSerializerOptions)!);
+                            break;
+                        default:
+                            break;
+                    }
+                }
+            }
+
+            if (!enabled.IsSet)
+                throw new ArgumentException("Property is required for class ConcurrencyLimitStatus.", nameof(enabled));
+
+            if (!stats.IsSet)
+                throw new ArgumentException("Property is required for class ConcurrencyLimitStatus.", nameof(stats));
+
+            if (enabled.IsSet && enabled.Value == null)
+                throw new ArgumentNullException(nameof(enabled), "Property is not nullable for class ConcurrencyLimitStatus.");
+
+            if (stats.IsSet && stats.Value == null)
+                throw new ArgumentNullException(nameof(stats), "Property is not nullable for class ConcurrencyLimitStatus.");
+
+            return new ConcurrencyLimitStatus(enabled.Value!.Value!, stats.Value!, limit);
+        }
+
+        /// <summary>
+        /// Serializes a <see cref="ConcurrencyLimitStatus" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimitStatus"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public override void Write(Utf8JsonWriter writer, ConcurrencyLimitStatus concurrencyLimitStatus, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteStartObject();
+
+            WriteProperties(writer, concurrencyLimitStatus, jsonSerializerOptions);
+            writer.WriteEndObject();
+        }
+
+        /// <summary>
+        /// Serializes the properties of <see cref="ConcurrencyLimitStatus" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimitStatus"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public void WriteProperties(Utf8JsonWriter writer, ConcurrencyLimitStatus concurrencyLimitStatus, JsonSerializerOptions jsonSerializerOptions)
+        {
+            if (concurrencyLimitStatus.Stats == null)
+                throw new ArgumentNullException(nameof(concurrencyLimitStatus.Stats), "Property is required for class ConcurrencyLimitStatus.");
+
+            writer.WriteBoolean("enabled", concurrencyLimitStatus.Enabled);
+
+            if (concurrencyLimitStatus.LimitOption.IsSet)
+                if (concurrencyLimitStatus.LimitOption.Value != null)
+                {
+                    writer.WritePropertyName("limit");
+                    JsonSerializer.Serialize(writer, concurrencyLimitStatus.Limit, jsonSerializerOptions);
+                }
+                else
+                    writer.WriteNull("limit");
+
+            writer.WritePropertyName("stats");
+            JsonSerializer.Serialize(writer, concurrencyLimitStatus.Stats, jsonSerializerOptions);
+        }
+    }
+}
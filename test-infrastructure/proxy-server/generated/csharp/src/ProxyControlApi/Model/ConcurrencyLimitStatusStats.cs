@@ -0,0 +1,243 @@
+// <auto-generated>
+/*
+ * Thrift Protocol Test Proxy - Control API
+ *
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ *
+ * The version of the OpenAPI document: 1.0.0
+ * Generated by: https://github.com/openapitools/openapi-generator.git
+ */
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.ObjectModel;
+using System.Linq;
+using System.IO;
+using System.Text;
+using System.Text.RegularExpressions;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.ComponentModel.DataAnnotations;
+using ProxyControlApi.Client;
+
+namespace ProxyControlApi.Model
+{
+    /// <summary>
+    /// ConcurrencyLimitStatusStats
+    /// </summary>
+    public partial class ConcurrencyLimitStatusStats : IValidatableObject
+    {
+        /// <summary>
+        /// Initializes a new instance of the <see cref="ConcurrencyLimitStatusStats" /> class.
+        /// </summary>
+        /// <param name="inFlight"></param>
+        /// <param name="peakInFlight"></param>
+        /// <param name="queued"></param>
+        /// <param name="rejected"></param>
+        [JsonConstructor]
+        public ConcurrencyLimitStatusStats(Option<int?> inFlight = default, Option<int?> peakInFlight = default, Option<int?> queued = default, Option<int?> rejected = default)
+        {
+            InFlightOption = inFlight;
+            PeakInFlightOption = peakInFlight;
+            QueuedOption = queued;
+            RejectedOption = rejected;
+            OnCreated();
+        }
+
+        partial void OnCreated();
+
+        /// <summary>
+        /// Used to track the state of InFlight
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<int?> InFlightOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets InFlight
+        /// </summary>
+        [JsonPropertyName("in_flight")]
+        public int? InFlight { get { return this.InFlightOption; } set { this.InFlightOption = new(value); } }
+
+        /// <summary>
+        /// Used to track the state of PeakInFlight
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<int?> PeakInFlightOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets PeakInFlight
+        /// </summary>
+        [JsonPropertyName("peak_in_flight")]
+        public int? PeakInFlight { get { return this.PeakInFlightOption; } set { this.PeakInFlightOption = new(value); } }
+
+        /// <summary>
+        /// Used to track the state of Queued
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<int?> QueuedOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets Queued
+        /// </summary>
+        [JsonPropertyName("queued")]
+        public int? Queued { get { return this.QueuedOption; } set { this.QueuedOption = new(value); } }
+
+        /// <summary>
+        /// Used to track the state of Rejected
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<int?> RejectedOption { get; private set; }
+
+        /// <summary>
+        /// Gets or Sets Rejected
+        /// </summary>
+        [JsonPropertyName("rejected")]
+        public int? Rejected { get { return this.RejectedOption; } set { this.RejectedOption = new(value); } }
+
+        /// <summary>
+        /// Returns the string presentation of the object
+        /// </summary>
+        /// <returns>String presentation of the object</returns>
+        public override string ToString()
+        {
+            StringBuilder sb = new StringBuilder();
+            sb.Append("class ConcurrencyLimitStatusStats {\n");
+            sb.Append("  InFlight: ").Append(InFlight).Append("\n");
+            sb.Append("  PeakInFlight: ").Append(PeakInFlight).Append("\n");
+            sb.Append("  Queued: ").Append(Queued).Append("\n");
+            sb.Append("  Rejected: ").Append(Rejected).Append("\n");
+            sb.Append("}\n");
+            return sb.ToString();
+        }
+
+        /// <summary>
+        /// To validate all properties of the instance
+        /// </summary>
+        /// <param name="validationContext">Validation context</param>
+        /// <returns>Validation Result</returns>
+        IEnumerable<ValidationResult> IValidatableObject.Validate(ValidationContext validationContext)
+        {
+            yield break;
+        }
+    }
+
+    /// <summary>
+    /// A Json converter for type <see cref="ConcurrencyLimitStatusStats" />
+    /// </summary>
+    public class ConcurrencyLimitStatusStatsJsonConverter : JsonConverter<ConcurrencyLimitStatusStats>
+    {
+        /// <summary>
+        /// Deserializes json to <see cref="ConcurrencyLimitStatusStats" />
+        /// </summary>
+        /// <param name="utf8JsonReader"></param>
+        /// <param name="typeToConvert"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <returns></returns>
+        /// <exception cref="JsonException"></exception>
+        public override ConcurrencyLimitStatusStats Read(ref Utf8JsonReader utf8JsonReader, Type typeToConvert, JsonSerializerOptions jsonSerializerOptions)
+        {
+            int currentDepth = utf8JsonReader.CurrentDepth;
+
+            if (utf8JsonReader.TokenType != JsonTokenType.StartObject && utf8JsonReader.TokenType != JsonTokenType.StartArray)
+                throw new JsonException();
+
+            JsonTokenType startingTokenType = utf8JsonReader.TokenType;
+
+            Option<int?> inFlight = default;
+            Option<int?> peakInFlight = default;
+            Option<int?> queued = default;
+            Option<int?> rejected = default;
+
+            while (utf8JsonReader.Read())
+            {
+                if (startingTokenType == JsonTokenType.StartObject && utf8JsonReader.TokenType == JsonTokenType.EndObject && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (startingTokenType == JsonTokenType.StartArray && utf8JsonReader.TokenType == JsonTokenType.EndArray && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (utf8JsonReader.TokenType == JsonTokenType.PropertyName && currentDepth == utf8JsonReader.CurrentDepth - 1)
+                {
+                    string? localVarJsonPropertyName = utf8JsonReader.GetString();
+                    utf8JsonReader.Read();
+
+                    switch (localVarJsonPropertyName)
+                    {
+                        case "in_flight":
+                            inFlight = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        case "peak_in_flight":
+                            peakInFlight = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        case "queued":
+                            queued = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        case "rejected":
+                            rejected = new Option<int?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (int?)null : utf8JsonReader.GetInt32());
+                            break;
+                        default:
+                            break;
+                    }
+                }
+            }
+
+            if (inFlight.IsSet && inFlight.Value == null)
+                throw new ArgumentNullException(nameof(inFlight), "Property is not nullable for class ConcurrencyLimitStatusStats.");
+
+            if (peakInFlight.IsSet && peakInFlight.Value == null)
+                throw new ArgumentNullException(nameof(peakInFlight), "Property is not nullable for class ConcurrencyLimitStatusStats.");
+
+            if (queued.IsSet && queued.Value == null)
+                throw new ArgumentNullException(nameof(queued), "Property is not nullable for class ConcurrencyLimitStatusStats.");
+
+            if (rejected.IsSet && rejected.Value == null)
+                throw new ArgumentNullException(nameof(rejected), "Property is not nullable for class ConcurrencyLimitStatusStats.");
+
+            return new ConcurrencyLimitStatusStats(inFlight, peakInFlight, queued, rejected);
+        }
+
+        /// <summary>
+        /// Serializes a <see cref="ConcurrencyLimitStatusStats" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimitStatusStats"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public override void Write(Utf8JsonWriter writer, ConcurrencyLimitStatusStats concurrencyLimitStatusStats, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteStartObject();
+
+            WriteProperties(writer, concurrencyLimitStatusStats, jsonSerializerOptions);
+            writer.WriteEndObject();
+        }
+
+        /// <summary>
+        /// Serializes the properties of <see cref="ConcurrencyLimitStatusStats" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="concurrencyLimitStatusStats"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public void WriteProperties(Utf8JsonWriter writer, ConcurrencyLimitStatusStats concurrencyLimitStatusStats, JsonSerializerOptions jsonSerializerOptions)
+        {
+            if (concurrencyLimitStatusStats.InFlightOption.IsSet)
+                writer.WriteNumber("in_flight", concurrencyLimitStatusStats.InFlightOption.Value!.Value);
+
+            if (concurrencyLimitStatusStats.PeakInFlightOption.IsSet)
+                writer.WriteNumber("peak_in_flight", concurrencyLimitStatusStats.PeakInFlightOption.Value!.Value);
+
+            if (concurrencyLimitStatusStats.QueuedOption.IsSet)
+                writer.WriteNumber("queued", concurrencyLimitStatusStats.QueuedOption.Value!.Value);
+
+            if (concurrencyLimitStatusStats.RejectedOption.IsSet)
+                writer.WriteNumber("rejected", concurrencyLimitStatusStats.RejectedOption.Value!.Value);
+        }
+    }
+}
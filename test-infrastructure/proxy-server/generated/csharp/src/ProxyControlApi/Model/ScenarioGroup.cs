@@ -0,0 +1,247 @@
+// <auto-generated>
+/*
+ * Thrift Protocol Test Proxy - Control API
+ *
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ *
+ * The version of the OpenAPI document: 1.0.0
+ * Generated by: https://github.com/openapitools/openapi-generator.git
+ */
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.ObjectModel;
+using System.Linq;
+using System.IO;
+using System.Text;
+using System.Text.RegularExpressions;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.ComponentModel.DataAnnotations;
+using ProxyControlApi.Client;
+
+namespace ProxyControlApi.Model
+{
+    /// <summary>
+    /// ScenarioGroup
+    /// </summary>
+    public partial class ScenarioGroup : IValidatableObject
+    {
+        /// <summary>
+        /// Initializes a new instance of the <see cref="ScenarioGroup" /> class.
+        /// </summary>
+        /// <param name="name">Unique group identifier</param>
+        /// <param name="scenarios">Names of the scenarios in the group</param>
+        /// <param name="enabled">Whether all scenarios in the group are enabled</param>
+        /// <param name="enabledScenarios">Names of the group's scenarios that are currently enabled</param>
+        [JsonConstructor]
+        public ScenarioGroup(string name, List<string> scenarios, bool enabled, Option<List<string>?> enabledScenarios = default)
+        {
+            Name = name;
+            Scenarios = scenarios;
+            Enabled = enabled;
+            EnabledScenariosOption = enabledScenarios;
+            OnCreated();
+        }
+
+        partial void OnCreated();
+
+        /// <summary>
+        /// Unique group identifier
+        /// </summary>
+        /// <value>Unique group identifier</value>
+        /* <example>cloudfetch_http_errors</example> */
+        [JsonPropertyName("name")]
+        public string Name { get; set; }
+
+        /// <summary>
+        /// Names of the scenarios in the group
+        /// </summary>
+        /// <value>Names of the scenarios in the group</value>
+        [JsonPropertyName("scenarios")]
+        public List<string> Scenarios { get; set; }
+
+        /// <summary>
+        /// Whether all scenarios in the group are enabled
+        /// </summary>
+        /// <value>Whether all scenarios in the group are enabled</value>
+        /* <example>false</example> */
+        [JsonPropertyName("enabled")]
+        public bool Enabled { get; set; }
+
+        /// <summary>
+        /// Used to track the state of EnabledScenarios
+        /// </summary>
+        [JsonIgnore]
+        [global::System.ComponentModel.EditorBrowsable(global::System.ComponentModel.EditorBrowsableState.Never)]
+        public Option<List<string>?> EnabledScenariosOption { get; private set; }
+
+        /// <summary>
+        /// Names of the group's scenarios that are currently enabled
+        /// </summary>
+        /// <value>Names of the group's scenarios that are currently enabled</value>
+        [JsonPropertyName("enabled_scenarios")]
+        public List<string>? EnabledScenarios { get { return this.EnabledScenariosOption; } set { this.EnabledScenariosOption = new(value); } }
+
+        /// <summary>
+        /// Returns the string presentation of the object
+        /// </summary>
+        /// <returns>String presentation of the object</returns>
+        public override string ToString()
+        {
+            StringBuilder sb = new StringBuilder();
+            sb.Append("class ScenarioGroup {\n");
+            sb.Append("  Name: ").Append(Name).Append("\n");
+            sb.Append("  Scenarios: ").Append(Scenarios).Append("\n");
+            sb.Append("  Enabled: ").Append(Enabled).Append("\n");
+            sb.Append("  EnabledScenarios: ").Append(EnabledScenarios).Append("\n");
+            sb.Append("}\n");
+            return sb.ToString();
+        }
+
+        /// <summary>
+        /// To validate all properties of the instance
+        /// </summary>
+        /// <param name="validationContext">Validation context</param>
+        /// <returns>Validation Result</returns>
+        IEnumerable<ValidationResult> IValidatableObject.Validate(ValidationContext validationContext)
+        {
+            yield break;
+        }
+    }
+
+    /// <summary>
+    /// A Json converter for type <see cref="ScenarioGroup" />
+    /// </summary>
+    public class ScenarioGroupJsonConverter : JsonConverter<ScenarioGroup>
+    {
+        /// <summary>
+        /// Deserializes json to <see cref="ScenarioGroup" />
+        /// </summary>
+        /// <param name="utf8JsonReader"></param>
+        /// <param name="typeToConvert"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <returns></returns>
+        /// <exception cref="JsonException"></exception>
+        public override ScenarioGroup Read(ref Utf8JsonReader utf8JsonReader, Type typeToConvert, JsonSerializerOptions jsonSerializerOptions)
+        {
+            int currentDepth = utf8JsonReader.CurrentDepth;
+
+            if (utf8JsonReader.TokenType != JsonTokenType.StartObject && utf8JsonReader.TokenType != JsonTokenType.StartArray)
+                throw new JsonException();
+
+            JsonTokenType startingTokenType = utf8JsonReader.TokenType;
+
+            Option<string?> name = default;
+            Option<List<string>?> scenarios = default;
+            Option<bool?> enabled = default;
+            Option<List<string>?> enabledScenarios = default;
+
+            while (utf8JsonReader.Read())
+            {
+                if (startingTokenType == JsonTokenType.StartObject && utf8JsonReader.TokenType == JsonTokenType.EndObject && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (startingTokenType == JsonTokenType.StartArray && utf8JsonReader.TokenType == JsonTokenType.EndArray && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (utf8JsonReader.TokenType == JsonTokenType.PropertyName && currentDepth == utf8JsonReader.CurrentDepth - 1)
+                {
+                    string? localVarJsonPropertyName = utf8JsonReader.GetString();
+                    utf8JsonReader.Read();
+
+                    switch (localVarJsonPropertyName)
+                    {
+                        case "name":
+                            name = new Option<string?>(utf8JsonReader.GetString()!);
+                            break;
+                        case "scenarios":
+                            scenarios = new Option<List<string>?>(JsonSerializer.Deserialize<List<string>>(ref utf8JsonReader, jsonSerializerOptions)!);
+                            break;
+                        case "enabled":
+                            enabled = new Option<bool?>(utf8JsonReader.TokenType == JsonTokenType.Null ? (bool?)null : utf8JsonReader.GetBoolean());
+                            break;
+                        case "enabled_scenarios":
+                            enabledScenarios = new Option<List<string>?>(JsonSerializer.Deserialize<List<string>>(ref utf8JsonReader, jsonSerializerOptions)!);
+                            break;
+                        default:
+                            break;
+                    }
+                }
+            }
+
+            if (!name.IsSet)
+                throw new ArgumentException("Property is required for class ScenarioGroup.", nameof(name));
+
+            if (!scenarios.IsSet)
+                throw new ArgumentException("Property is required for class ScenarioGroup.", nameof(scenarios));
+
+            if (!enabled.IsSet)
+                throw new ArgumentException("Property is required for class ScenarioGroup.", nameof(enabled));
+
+            if (name.IsSet && name.Value == null)
+                throw new ArgumentNullException(nameof(name), "Property is not nullable for class ScenarioGroup.");
+
+            if (scenarios.IsSet && scenarios.Value == null)
+                throw new ArgumentNullException(nameof(scenarios), "Property is not nullable for class ScenarioGroup.");
+
+            if (enabled.IsSet && enabled.Value == null)
+                throw new ArgumentNullException(nameof(enabled), "Property is not nullable for class ScenarioGroup.");
+
+            if (enabledScenarios.IsSet && enabledScenarios.Value == null)
+                throw new ArgumentNullException(nameof(enabledScenarios), "Property is not nullable for class ScenarioGroup.");
+
+            return new ScenarioGroup(name.Value!, scenarios.Value!, enabled.Value!.Value!, enabledScenarios);
+        }
+
+        /// <summary>
+        /// Serializes a <see cref="ScenarioGroup" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="scenarioGroup"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public override void Write(Utf8JsonWriter writer, ScenarioGroup scenarioGroup, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteStartObject();
+
+            WriteProperties(writer, scenarioGroup, jsonSerializerOptions);
+            writer.WriteEndObject();
+        }
+
+        /// <summary>
+        /// Serializes the properties of <see cref="ScenarioGroup" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="scenarioGroup"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public void WriteProperties(Utf8JsonWriter writer, ScenarioGroup scenarioGroup, JsonSerializerOptions jsonSerializerOptions)
+        {
+            if (scenarioGroup.Name == null)
+                throw new ArgumentNullException(nameof(scenarioGroup.Name), "Property is required for class ScenarioGroup.");
+
+            if (scenarioGroup.Scenarios == null)
+                throw new ArgumentNullException(nameof(scenarioGroup.Scenarios), "Property is required for class ScenarioGroup.");
+
+            if (scenarioGroup.EnabledScenariosOption.IsSet && scenarioGroup.EnabledScenarios == null)
+                throw new ArgumentNullException(nameof(scenarioGroup.EnabledScenarios), "Property is required for class ScenarioGroup.");
+
+            writer.WriteString("name", scenarioGroup.Name);
+
+            writer.WritePropertyName("scenarios");
+            JsonSerializer.Serialize(writer, scenarioGroup.Scenarios, jsonSerializerOptions);
+
+            writer.WriteBoolean("enabled", scenarioGroup.Enabled);
+
+            if (scenarioGroup.EnabledScenariosOption.IsSet)
+            {
+                writer.WritePropertyName("enabled_scenarios");
+                JsonSerializer.Serialize(writer, scenarioGroup.EnabledScenarios, jsonSerializerOptions);
+            }
+        }
+    }
+}
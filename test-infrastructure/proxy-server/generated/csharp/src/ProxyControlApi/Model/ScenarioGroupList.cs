@@ -0,0 +1,165 @@
+// <auto-generated>
+/*
+ * Thrift Protocol Test Proxy - Control API
+ *
+ * Control API for the Thrift Protocol Test Proxy Server.  Enables runtime control of failure injection scenarios for testing ADBC drivers. Tests can enable/disable scenarios to inject failures like connection resets, timeouts, and error responses during CloudFetch downloads or Thrift operations.  ## Usage Pattern  1. List available scenarios with `GET /scenarios` 2. Enable a scenario with `POST /scenarios/{name}/enable` 3. Trigger the scenario by making a request through the proxy 4. Scenario auto-disables after first injection (one-shot behavior) 5. Optionally disable manually with `POST /scenarios/{name}/disable`  Related scenarios are organized in groups (`GET /groups`) that can be enabled or disabled as a unit with `POST /groups/{name}/enable` and `POST /groups/{name}/disable`.  ## Base URL  Default: `http://localhost:8081` (configurable via `proxy.api_port` in config)
+ *
+ * The version of the OpenAPI document: 1.0.0
+ * Generated by: https://github.com/openapitools/openapi-generator.git
+ */
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Collections.ObjectModel;
+using System.Linq;
+using System.IO;
+using System.Text;
+using System.Text.RegularExpressions;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.ComponentModel.DataAnnotations;
+using ProxyControlApi.Client;
+
+namespace ProxyControlApi.Model
+{
+    /// <summary>
+    /// ScenarioGroupList
+    /// </summary>
+    public partial class ScenarioGroupList : IValidatableObject
+    {
+        /// <summary>
+        /// Initializes a new instance of the <see cref="ScenarioGroupList" /> class.
+        /// </summary>
+        /// <param name="groups">List of all scenario groups</param>
+        [JsonConstructor]
+        public ScenarioGroupList(List<ScenarioGroup> groups)
+        {
+            Groups = groups;
+            OnCreated();
+        }
+
+        partial void OnCreated();
+
+        /// <summary>
+        /// List of all scenario groups
+        /// </summary>
+        /// <value>List of all scenario groups</value>
+        [JsonPropertyName("groups")]
+        public List<ScenarioGroup> Groups { get; set; }
+
+        /// <summary>
+        /// Returns the string presentation of the object
+        /// </summary>
+        /// <returns>String presentation of the object</returns>
+        public override string ToString()
+        {
+            StringBuilder sb = new StringBuilder();
+            sb.Append("class ScenarioGroupList {\n");
+            sb.Append("  Groups: ").Append(Groups).Append("\n");
+            sb.Append("}\n");
+            return sb.ToString();
+        }
+
+        /// <summary>
+        /// To validate all properties of the instance
+        /// </summary>
+        /// <param name="validationContext">Validation context</param>
+        /// <returns>Validation Result</returns>
+        IEnumerable<ValidationResult> IValidatableObject.Validate(ValidationContext validationContext)
+        {
+            yield break;
+        }
+    }
+
+    /// <summary>
+    /// A Json converter for type <see cref="ScenarioGroupList" />
+    /// </summary>
+    public class ScenarioGroupListJsonConverter : JsonConverter<ScenarioGroupList>
+    {
+        /// <summary>
+        /// Deserializes json to <see cref="ScenarioGroupList" />
+        /// </summary>
+        /// <param name="utf8JsonReader"></param>
+        /// <param name="typeToConvert"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <returns></returns>
+        /// <exception cref="JsonException"></exception>
+        public override ScenarioGroupList Read(ref Utf8JsonReader utf8JsonReader, Type typeToConvert, JsonSerializerOptions jsonSerializerOptions)
+        {
+            int currentDepth = utf8JsonReader.CurrentDepth;
+
+            if (utf8JsonReader.TokenType != JsonTokenType.StartObject && utf8JsonReader.TokenType != JsonTokenType.StartArray)
+                throw new JsonException();
+
+            JsonTokenType startingTokenType = utf8JsonReader.TokenType;
+
+            Option<List<ScenarioGroup>?> groups = default;
+
+            while (utf8JsonReader.Read())
+            {
+                if (startingTokenType == JsonTokenType.StartObject && utf8JsonReader.TokenType == JsonTokenType.EndObject && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (startingTokenType == JsonTokenType.StartArray && utf8JsonReader.TokenType == JsonTokenType.EndArray && currentDepth == utf8JsonReader.CurrentDepth)
+                    break;
+
+                if (utf8JsonReader.TokenType == JsonTokenType.PropertyName && currentDepth == utf8JsonReader.CurrentDepth - 1)
+                {
+                    string? localVarJsonPropertyName = utf8JsonReader.GetString();
+                    utf8JsonReader.Read();
+
+                    switch (localVarJsonPropertyName)
+                    {
+                        case "groups":
+                            groups = new Option<List<ScenarioGroup>?>(JsonSerializer.Deserialize<List<ScenarioGroup>>(ref utf8JsonReader, jsonSerializerOptions)!);
+                            break;
+                        default:
+                            break;
+                    }
+                }
+            }
+
+            if (!groups.IsSet)
+                throw new ArgumentException("Property is required for class ScenarioGroupList.", nameof(groups));
+
+            if (groups.IsSet && groups.Value == null)
+                throw new ArgumentNullException(nameof(groups), "Property is not nullable for class ScenarioGroupList.");
+
+            return new ScenarioGroupList(groups.Value!);
+        }
+
+        /// <summary>
+        /// Serializes a <see cref="ScenarioGroupList" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="scenarioGroupList"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public override void Write(Utf8JsonWriter writer, ScenarioGroupList scenarioGroupList, JsonSerializerOptions jsonSerializerOptions)
+        {
+            writer.WriteStartObject();
+
+            WriteProperties(writer, scenarioGroupList, jsonSerializerOptions);
+            writer.WriteEndObject();
+        }
+
+        /// <summary>
+        /// Serializes the properties of <see cref="ScenarioGroupList" />
+        /// </summary>
+        /// <param name="writer"></param>
+        /// <param name="scenarioGroupList"></param>
+        /// <param name="jsonSerializerOptions"></param>
+        /// <exception cref="NotImplementedException"></exception>
+        public void WriteProperties(Utf8JsonWriter writer, ScenarioGroupList scenarioGroupList, JsonSerializerOptions jsonSerializerOptions)
+        {
+            if (scenarioGroupList.Groups == null)
+                throw new ArgumentNullException(nameof(scenarioGroupList.Groups), "Property is required for class ScenarioGroupList.");
+
+            writer.WritePropertyName("groups");
+            JsonSerializer.Serialize(writer, scenarioGroupList.Groups, jsonSerializerOptions);
+        }
+    }
+}
@@ -17,33 +17,54 @@
 mitmproxy addon for Databricks ADBC driver testing.
 Implements failure injection for CloudFetch and Thrift protocol testing.
 
-Control API runs on port 18081 (compatible with existing test infrastructure).
+Control API runs on port 18081 by default (compatible with existing test
+infrastructure; override with --set control_api_port=N).
 Proxy listens on port 18080.
+
+All runtime state lives in a ProxyServer instance, so the module can also be
+used as a library: tests running in parallel can each create their own
+ProxyServer (optionally with their own scenarios and groups) and serve its
+control API with serve_control_api().
 """
 
+import asyncio
+import fnmatch
+import logging
 import threading
 import time
-from typing import Any, Dict, List
+from typing import Any, Dict, Iterable, List, Optional, Sequence, Tuple
 
 from flask import Flask, jsonify, request
 from mitmproxy import ctx, http
 from thrift_decoder import decode_thrift_message, format_thrift_message
+from werkzeug.serving import BaseWSGIServer, make_server
 
-# Flask app for control API
-app = Flask(__name__)
-
-# Global state for enabled scenarios (thread-safe with lock)
-state_lock = threading.Lock()
-enabled_scenarios: Dict[str, bool] = {}
+logger = logging.getLogger(__name__)
 
-# Call count tracking for trigger_after_count scenarios
-scenario_call_counts: Dict[str, int] = {}
+DEFAULT_API_HOST = "0.0.0.0"
+DEFAULT_API_PORT = 18081
 
-# Call tracking state (thread-safe with lock)
 MAX_CALL_HISTORY = 1000
-call_history: List[Dict[str, Any]] = []
 
-# Load scenario definitions from YAML (we'll parse the existing config)
+# Requests to these hosts are treated as CloudFetch downloads. Each pattern is
+# "host_glob" or "host_glob/path_glob"; globs use fnmatch syntax and host
+# globs are matched case-insensitively against the host without the port.
+DEFAULT_CLOUDFETCH_PATTERNS = [
+    # Azure Blob Storage
+    "*.blob.core.windows.net",
+    # Amazon S3, global and regional endpoints
+    "*s3*.amazonaws.com",
+    # Google Cloud Storage
+    "storage.googleapis.com",
+    "*.storage.googleapis.com",
+    # Cloudflare R2
+    "*.r2.cloudflarestorage.com",
+    # MinIO as typically named in docker-compose based test setups
+    "minio",
+    "minio.*",
+]
+
+# Default scenario definitions
 SCENARIOS = {
     "cloudfetch_expired_link": {
         "description": "CloudFetch link expires, driver should retry via FetchResults",
@@ -185,244 +206,730 @@ SCENARIOS = {
         "action": "delay",
         "duration_seconds": 15,  # Default 15 seconds, can be overridden via API
     },
+    # Header Mutation Scenarios
+    "cloudfetch_strip_content_length": {
+        "description": "CloudFetch response arrives without a Content-Length header",
+        "operation": "CloudFetchDownload",
+        "action": "mutate_headers",
+        "remove_headers": ["Content-Length"],
+    },
+    "cloudfetch_bogus_content_encoding": {
+        "description": "CloudFetch response claims a gzip Content-Encoding for an uncompressed body",
+        "operation": "CloudFetchDownload",
+        "action": "mutate_headers",
+        "set_headers": {"Content-Encoding": "gzip"},
+    },
+    "cloudfetch_strip_etag": {
+        "description": "CloudFetch response arrives without an ETag header",
+        "operation": "CloudFetchDownload",
+        "action": "mutate_headers",
+        "remove_headers": ["ETag"],
+    },
 }
 
+# Groups of scenarios that can be enabled/disabled as a unit. Scenarios stay
+# one-shot when enabled through a group, so each matching request consumes
+# the next enabled member.
+SCENARIO_GROUPS = {
+    "cloudfetch_http_errors": [
+        "cloudfetch_400",
+        "cloudfetch_403",
+        "cloudfetch_404",
+        "cloudfetch_405",
+        "cloudfetch_412",
+        "cloudfetch_500",
+        "cloudfetch_503",
+    ],
+    "open_session_transient_errors": [
+        "service_unavailable_503_open_session",
+        "request_timeout_408_open_session",
+        "bad_gateway_502_open_session",
+        "gateway_timeout_504_open_session",
+        "too_many_requests_429_open_session",
+    ],
+    "cloudfetch_header_mutations": [
+        "cloudfetch_strip_content_length",
+        "cloudfetch_bogus_content_encoding",
+        "cloudfetch_strip_etag",
+    ],
+    "session_lifecycle": [
+        "invalid_session_handle",
+        "session_timeout_premature",
+        "expired_credentials",
+        "network_timeout_open_session",
+        "network_failure_close_session",
+    ],
+}
 
-# ===== Control API Endpoints =====
+# flow.metadata key for the mutate_headers config to apply to the response
+MUTATE_HEADERS_KEY = "failure_injection.mutate_headers"
 
+# flow.metadata key marking flows that hold a concurrency limit slot
+CONCURRENCY_SLOT_KEY = "failure_injection.concurrency_slot"
+
+CONCURRENCY_LIMIT_MODES = ("queue", "reject")
+CONCURRENCY_LIMIT_SCOPES = ("cloudfetch", "all")
+
+# Actions that stay enabled after they trigger
+PASSIVE_ACTIONS = {"track_active_operations"}
+
+
+# ===== Server State =====
 
-@app.route("/scenarios", methods=["GET"])
-def list_scenarios():
-    """List all available scenarios with their status."""
-    with state_lock:
-        scenarios_list = [
-            {
-                "name": name,
-                "description": config["description"],
-                "enabled": name in enabled_scenarios
-                and enabled_scenarios[name] is not False,
-            }
-            for name, config in SCENARIOS.items()
-        ]
-    return jsonify({"scenarios": scenarios_list})
 
+def parse_cloudfetch_pattern(pattern: str) -> Tuple[str, str]:
+    """Split "host_glob[/path_glob]" into host and path globs."""
+    host, sep, path = pattern.strip().partition("/")
+    if not host:
+        raise ValueError(f"Invalid CloudFetch pattern, missing host: {pattern!r}")
+    return host.lower(), "/" + path if sep else "*"
 
-@app.route("/scenarios/<scenario_name>/enable", methods=["POST"])
-def enable_scenario(scenario_name):
+
+class ScenarioState:
+    """Runtime state of a single scenario, guarded by its own lock."""
+
+    def __init__(self, definition: Dict[str, Any]):
+        self.definition = definition
+        self.lock = threading.Lock()
+        # Effective config (definition plus runtime overrides) while enabled
+        self.config: Optional[Dict[str, Any]] = None
+        # Call counts per Thrift method for trigger_after_count scenarios
+        self.call_counts: Dict[str, int] = {}
+
+
+class ProxyServer:
     """
-    Enable a failure scenario and auto-reset call history.
+    Failure injection state: scenario definitions, scenario groups, enabled
+    scenarios and call history.
 
-    Optional request body for configurable scenarios:
-    {
-        "duration_seconds": 30  // For delay scenarios (overrides default)
-    }
+    Each scenario has its own lock, so requests that touch different
+    scenarios never contend; the call history has a separate lock.
     """
-    if scenario_name not in SCENARIOS:
-        return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
 
-    # Check for runtime configuration
-    try:
-        data = request.get_json(force=True, silent=True)
-    except Exception:
-        data = None
-
-    scenario_config = SCENARIOS[scenario_name].copy()
-
-    # Apply runtime overrides for configurable parameters
-    if data:
-        if "duration_seconds" in data and scenario_config.get("action") == "delay":
-            scenario_config["duration_seconds"] = int(data["duration_seconds"])
-            ctx.log.info(f"[API] Override delay duration: {data['duration_seconds']}s")
-
-    with state_lock:
-        # Store the potentially modified config
-        enabled_scenarios[scenario_name] = scenario_config
-        # Auto-reset call history when scenario is enabled (new test scenario)
-        call_history.clear()
-        # Reset call counts for trigger_after_count scenarios
-        scenario_call_counts.clear()
-
-    ctx.log.info(f"[API] Enabled scenario: {scenario_name}, reset call history")
-    return jsonify(
-        {
-            "scenario": scenario_name,
-            "enabled": True,
-            "config": scenario_config,
-            "call_history_reset": True,
+    def __init__(
+        self,
+        scenarios: Optional[Dict[str, Dict[str, Any]]] = None,
+        groups: Optional[Dict[str, List[str]]] = None,
+        max_call_history: int = MAX_CALL_HISTORY,
+        cloudfetch_patterns: Optional[Sequence[str]] = None,
+    ):
+        scenarios = SCENARIOS if scenarios is None else scenarios
+        groups = SCENARIO_GROUPS if groups is None else groups
+
+        self._scenarios = {
+            name: ScenarioState(dict(definition))
+            for name, definition in scenarios.items()
         }
-    )
+        for group, members in groups.items():
+            unknown = [name for name in members if name not in self._scenarios]
+            if unknown:
+                raise ValueError(
+                    f"Scenario group {group} references unknown scenarios: {unknown}"
+                )
+        self._groups = {group: list(members) for group, members in groups.items()}
 
+        self.set_cloudfetch_patterns(
+            DEFAULT_CLOUDFETCH_PATTERNS
+            if cloudfetch_patterns is None
+            else cloudfetch_patterns
+        )
 
-@app.route("/scenarios/<scenario_name>/disable", methods=["POST"])
-def disable_scenario(scenario_name):
-    """Disable a failure scenario."""
-    if scenario_name not in SCENARIOS:
-        return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
+        self.max_call_history = max_call_history
+        self._history_lock = threading.Lock()
+        self._call_history: List[Dict[str, Any]] = []
 
-    with state_lock:
-        enabled_scenarios[scenario_name] = False
+        self._concurrency_lock = threading.Lock()
+        self._concurrency_limit: Optional[Dict[str, Any]] = None
+        self._concurrency_stats = self._new_concurrency_stats()
 
-    ctx.log.info(f"[API] Disabled scenario: {scenario_name}")
-    return jsonify({"scenario": scenario_name, "enabled": False})
+    # --- Scenarios ---
 
+    def has_scenario(self, name: str) -> bool:
+        return name in self._scenarios
 
-@app.route("/scenarios/<scenario_name>/status", methods=["GET"])
-def get_scenario_status(scenario_name):
-    """Get status of a specific scenario."""
-    if scenario_name not in SCENARIOS:
-        return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
+    def list_scenarios(self) -> List[Dict[str, Any]]:
+        return [self.scenario_status(name) for name in self._scenarios]
 
-    with state_lock:
-        enabled_config = enabled_scenarios.get(scenario_name, False)
-        enabled = enabled_config is not False
+    def scenario_status(self, name: str) -> Dict[str, Any]:
+        state = self._scenarios[name]
+        with state.lock:
+            config = state.config
+        return {
+            "name": name,
+            "description": state.definition["description"],
+            "enabled": config is not None,
+            "config": config,
+        }
 
-    return jsonify(
-        {
-            "name": scenario_name,
-            "description": SCENARIOS[scenario_name]["description"],
-            "enabled": enabled,
-            "config": enabled_config if enabled else None,
+    def enable_scenario(
+        self, name: str, overrides: Optional[Dict[str, Any]] = None
+    ) -> Dict[str, Any]:
+        """Enable a scenario and reset the call history. Returns its config."""
+        config = self._enable(self._scenarios[name], overrides)
+        self.reset_calls()
+        return config
+
+    def disable_scenario(self, name: str) -> None:
+        state = self._scenarios[name]
+        with state.lock:
+            state.config = None
+
+    def disable_all(self) -> None:
+        for state in self._scenarios.values():
+            with state.lock:
+                state.config = None
+
+    def _enable(
+        self, state: ScenarioState, overrides: Optional[Dict[str, Any]]
+    ) -> Dict[str, Any]:
+        config = dict(state.definition)
+        if overrides:
+            if "duration_seconds" in overrides and config.get("action") == "delay":
+                config["duration_seconds"] = int(overrides["duration_seconds"])
+            if config.get("action") == "mutate_headers":
+                if "remove_headers" in overrides:
+                    remove_headers = overrides["remove_headers"]
+                    if not isinstance(remove_headers, list) or not all(
+                        isinstance(name, str) for name in remove_headers
+                    ):
+                        raise ValueError("remove_headers must be a list of strings")
+                    config["remove_headers"] = remove_headers
+                if "set_headers" in overrides:
+                    set_headers = overrides["set_headers"]
+                    if not isinstance(set_headers, dict) or not all(
+                        isinstance(name, str) and isinstance(value, str)
+                        for name, value in set_headers.items()
+                    ):
+                        raise ValueError(
+                            "set_headers must be an object of string values"
+                        )
+                    config["set_headers"] = set_headers
+        with state.lock:
+            state.config = config
+            state.call_counts.clear()
+        return config
+
+    # --- Groups ---
+
+    def has_group(self, group: str) -> bool:
+        return group in self._groups
+
+    def list_groups(self) -> List[Dict[str, Any]]:
+        return [self.group_status(group) for group in self._groups]
+
+    def group_status(self, group: str) -> Dict[str, Any]:
+        members = self._groups[group]
+        enabled = [
+            name for name in members if self.scenario_status(name)["enabled"]
+        ]
+        return {
+            "name": group,
+            "scenarios": list(members),
+            "enabled": len(enabled) == len(members),
+            "enabled_scenarios": enabled,
         }
-    )
 
+    def enable_group(
+        self, group: str, overrides: Optional[Dict[str, Any]] = None
+    ) -> Dict[str, Dict[str, Any]]:
+        """Enable every scenario in a group and reset the call history."""
+        configs = {
+            name: self._enable(self._scenarios[name], overrides)
+            for name in self._groups[group]
+        }
+        self.reset_calls()
+        return configs
 
-@app.route("/scenarios/disable-all", methods=["POST"])
-def disable_all_scenarios():
-    """Disable all failure scenarios."""
-    with state_lock:
-        for scenario_name in SCENARIOS.keys():
-            enabled_scenarios[scenario_name] = False
+    def disable_group(self, group: str) -> None:
+        for name in self._groups[group]:
+            self.disable_scenario(name)
 
-    ctx.log.info("[API] Disabled all scenarios")
-    return jsonify({"message": "All scenarios disabled"})
+    # --- Injection ---
 
+    def claim_scenario(
+        self, operations: Iterable[str], method_name: str = ""
+    ) -> Optional[Tuple[str, Dict[str, Any]]]:
+        """
+        Find the first enabled scenario for one of operations and claim it.
 
-@app.route("/thrift/calls", methods=["GET"])
-def get_thrift_calls():
-    """Get history of Thrift method calls."""
-    with state_lock:
-        return jsonify(
-            {
-                "calls": call_history.copy(),
-                "count": len(call_history),
-                "max_history": MAX_CALL_HISTORY,
+        One-shot scenarios are disabled as part of the claim, so concurrent
+        requests can't trigger the same scenario twice. Scenarios with a
+        trigger_after_count only match once they have seen that many calls
+        to method_name.
+        """
+        operations = set(operations)
+        for name, state in self._scenarios.items():
+            if state.definition.get("operation") not in operations:
+                continue
+            with state.lock:
+                if state.config is None:
+                    continue
+                trigger_after = state.definition.get("trigger_after_count", 0)
+                if trigger_after > 0:
+                    count = state.call_counts.get(method_name, 0) + 1
+                    state.call_counts[method_name] = count
+                    if count <= trigger_after:
+                        continue
+                config = state.config
+                if config.get("action") not in PASSIVE_ACTIONS:
+                    state.config = None
+                    logger.info(f"[INJECT] Auto-disabled scenario: {name}")
+            return name, config
+        return None
+
+    # --- Concurrency limit ---
+
+    @staticmethod
+    def _new_concurrency_stats() -> Dict[str, int]:
+        return {"in_flight": 0, "peak_in_flight": 0, "queued": 0, "rejected": 0}
+
+    def set_concurrency_limit(
+        self,
+        max_connections: int,
+        mode: str = "queue",
+        scope: str = "cloudfetch",
+        queue_timeout_seconds: float = 30,
+        retry_after_seconds: Optional[int] = None,
+    ) -> Dict[str, Any]:
+        """
+        Cap the number of concurrent upstream requests in scope. Requests over
+        the cap wait for a free slot (mode "queue", rejected with 503 after
+        queue_timeout_seconds) or are rejected with 503 right away (mode
+        "reject"). Resets the concurrency stats.
+        """
+        if max_connections < 1:
+            raise ValueError("max_connections must be at least 1")
+        if mode not in CONCURRENCY_LIMIT_MODES:
+            raise ValueError(f"mode must be one of {list(CONCURRENCY_LIMIT_MODES)}")
+        if scope not in CONCURRENCY_LIMIT_SCOPES:
+            raise ValueError(f"scope must be one of {list(CONCURRENCY_LIMIT_SCOPES)}")
+        if queue_timeout_seconds < 0:
+            raise ValueError("queue_timeout_seconds must not be negative")
+        limit = {
+            "max_connections": max_connections,
+            "mode": mode,
+            "scope": scope,
+            "queue_timeout_seconds": queue_timeout_seconds,
+            "retry_after_seconds": retry_after_seconds,
+        }
+        with self._concurrency_lock:
+            self._concurrency_limit = limit
+            in_flight = self._concurrency_stats["in_flight"]
+            self._concurrency_stats = self._new_concurrency_stats()
+            self._concurrency_stats["in_flight"] = in_flight
+        return dict(limit)
+
+    def clear_concurrency_limit(self) -> None:
+        with self._concurrency_lock:
+            self._concurrency_limit = None
+
+    def concurrency_limit(self) -> Optional[Dict[str, Any]]:
+        with self._concurrency_lock:
+            limit = self._concurrency_limit
+        return dict(limit) if limit is not None else None
+
+    def concurrency_status(self) -> Dict[str, Any]:
+        with self._concurrency_lock:
+            limit = self._concurrency_limit
+            return {
+                "enabled": limit is not None,
+                "limit": dict(limit) if limit is not None else None,
+                "stats": dict(self._concurrency_stats),
             }
-        )
 
+    def try_acquire_connection(self, cloudfetch: bool) -> Optional[bool]:
+        """
+        Take a connection slot if the limit allows it. Returns None when no
+        limit applies to the request (no slot is taken), True when a slot was
+        taken and False when the limit is reached.
+        """
+        with self._concurrency_lock:
+            limit = self._concurrency_limit
+            if limit is None or (limit["scope"] == "cloudfetch" and not cloudfetch):
+                return None
+            stats = self._concurrency_stats
+            if stats["in_flight"] >= limit["max_connections"]:
+                return False
+            stats["in_flight"] += 1
+            stats["peak_in_flight"] = max(stats["peak_in_flight"], stats["in_flight"])
+            return True
+
+    def release_connection(self) -> None:
+        with self._concurrency_lock:
+            stats = self._concurrency_stats
+            stats["in_flight"] = max(0, stats["in_flight"] - 1)
+
+    def record_concurrency_event(self, event: str) -> None:
+        """Count a "queued" or "rejected" request."""
+        with self._concurrency_lock:
+            self._concurrency_stats[event] += 1
+
+    # --- CloudFetch detection ---
+
+    def cloudfetch_patterns(self) -> List[str]:
+        return [
+            host if path == "*" else host + path
+            for host, path in self._cloudfetch_patterns
+        ]
 
-@app.route("/thrift/calls/reset", methods=["POST"])
-def reset_thrift_calls():
-    """Reset Thrift call history."""
-    with state_lock:
-        call_history.clear()
+    def set_cloudfetch_patterns(self, patterns: Sequence[str]) -> None:
+        """Replace the host/path patterns that identify CloudFetch downloads."""
+        parsed = [parse_cloudfetch_pattern(pattern) for pattern in patterns]
+        # Swapped in one assignment, so readers never see a partial list
+        self._cloudfetch_patterns = parsed
+
+    def is_cloudfetch_download(self, host: str, path: str) -> bool:
+        """Whether a GET to host and path is a CloudFetch download."""
+        host = host.lower()
+        path = path.split("?", 1)[0]
+        return any(
+            fnmatch.fnmatchcase(host, host_glob)
+            and fnmatch.fnmatchcase(path, path_glob)
+            for host_glob, path_glob in self._cloudfetch_patterns
+        )
 
-    ctx.log.info("[API] Reset Thrift call history")
-    return jsonify({"message": "Call history reset", "count": 0})
+    # --- Call history ---
 
+    def record_call(self, call_record: Dict[str, Any]) -> None:
+        with self._history_lock:
+            self._call_history.append(call_record)
+            # Enforce max history limit
+            if len(self._call_history) > self.max_call_history:
+                del self._call_history[
+                    : len(self._call_history) - self.max_call_history
+                ]
 
-@app.route("/thrift/calls/verify", methods=["POST"])
-def verify_thrift_calls():
-    """
-    Verify that Thrift calls match expected patterns.
+    def calls(self) -> List[Dict[str, Any]]:
+        with self._history_lock:
+            return list(self._call_history)
 
-    Request body examples:
+    def reset_calls(self) -> None:
+        with self._history_lock:
+            self._call_history.clear()
 
-    1. Exact sequence match:
-    {
-        "type": "exact_sequence",
-        "methods": ["ExecuteStatement", "FetchResults", "CloseOperation"]
-    }
 
-    2. Contains sequence (in order):
-    {
-        "type": "contains_sequence",
-        "methods": ["ExecuteStatement", "FetchResults"]
-    }
+# ===== Control API Endpoints =====
 
-    3. Method count:
-    {
-        "type": "method_count",
-        "method": "FetchResults",
-        "count": 2
-    }
 
-    4. Method exists:
+def _request_overrides() -> Optional[Dict[str, Any]]:
+    """
+    Runtime configuration from the request body, if any:
     {
-        "type": "method_exists",
-        "method": "ExecuteStatement"
+        "duration_seconds": 30,  // For delay scenarios (overrides default)
+        // For mutate_headers scenarios (override defaults):
+        "remove_headers": ["Content-Length"],
+        "set_headers": {"Content-Encoding": "gzip"}
     }
     """
     try:
-        data = request.get_json(force=True, silent=True)
+        return request.get_json(force=True, silent=True)
     except Exception:
-        data = None
+        return None
 
-    if not data:
-        return jsonify({"error": "Request body required"}), 400
 
-    verification_type = data.get("type")
-    if not verification_type:
-        return jsonify({"error": "Verification type required"}), 400
+def create_app(server: ProxyServer) -> Flask:
+    """Create the control API Flask app for a ProxyServer."""
+    app = Flask(__name__)
 
-    with state_lock:
-        methods = [call["method"] for call in call_history]
+    @app.route("/scenarios", methods=["GET"])
+    def list_scenarios():
+        """List all available scenarios with their status."""
+        scenarios_list = [
+            {
+                "name": status["name"],
+                "description": status["description"],
+                "enabled": status["enabled"],
+            }
+            for status in server.list_scenarios()
+        ]
+        return jsonify({"scenarios": scenarios_list})
 
-    try:
-        if verification_type == "exact_sequence":
-            expected = data.get("methods", [])
-            if methods == expected:
+    @app.route("/scenarios/<scenario_name>/enable", methods=["POST"])
+    def enable_scenario(scenario_name):
+        """Enable a failure scenario and auto-reset call history."""
+        if not server.has_scenario(scenario_name):
+            return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
+
+        try:
+            scenario_config = server.enable_scenario(
+                scenario_name, _request_overrides()
+            )
+        except ValueError as e:
+            return jsonify({"error": str(e)}), 400
+
+        logger.info(f"[API] Enabled scenario: {scenario_name}, reset call history")
+        return jsonify(
+            {
+                "scenario": scenario_name,
+                "enabled": True,
+                "config": scenario_config,
+                "call_history_reset": True,
+            }
+        )
+
+    @app.route("/scenarios/<scenario_name>/disable", methods=["POST"])
+    def disable_scenario(scenario_name):
+        """Disable a failure scenario."""
+        if not server.has_scenario(scenario_name):
+            return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
+
+        server.disable_scenario(scenario_name)
+
+        logger.info(f"[API] Disabled scenario: {scenario_name}")
+        return jsonify({"scenario": scenario_name, "enabled": False})
+
+    @app.route("/scenarios/<scenario_name>/status", methods=["GET"])
+    def get_scenario_status(scenario_name):
+        """Get status of a specific scenario."""
+        if not server.has_scenario(scenario_name):
+            return jsonify({"error": f"Scenario not found: {scenario_name}"}), 404
+
+        return jsonify(server.scenario_status(scenario_name))
+
+    @app.route("/scenarios/disable-all", methods=["POST"])
+    def disable_all_scenarios():
+        """Disable all failure scenarios."""
+        server.disable_all()
+
+        logger.info("[API] Disabled all scenarios")
+        return jsonify({"message": "All scenarios disabled"})
+
+    @app.route("/groups", methods=["GET"])
+    def list_groups():
+        """List all scenario groups with their members and status."""
+        return jsonify({"groups": server.list_groups()})
+
+    @app.route("/groups/<group_name>/enable", methods=["POST"])
+    def enable_group(group_name):
+        """
+        Enable every scenario in a group and auto-reset call history.
+        Accepts the same optional request body as enabling a scenario.
+        """
+        if not server.has_group(group_name):
+            return jsonify({"error": f"Group not found: {group_name}"}), 404
+
+        try:
+            configs = server.enable_group(group_name, _request_overrides())
+        except ValueError as e:
+            return jsonify({"error": str(e)}), 400
+
+        logger.info(f"[API] Enabled group: {group_name}, reset call history")
+        return jsonify(
+            {
+                "group": group_name,
+                "enabled": True,
+                "scenarios": configs,
+                "call_history_reset": True,
+            }
+        )
+
+    @app.route("/groups/<group_name>/disable", methods=["POST"])
+    def disable_group(group_name):
+        """Disable every scenario in a group."""
+        if not server.has_group(group_name):
+            return jsonify({"error": f"Group not found: {group_name}"}), 404
+
+        server.disable_group(group_name)
+
+        logger.info(f"[API] Disabled group: {group_name}")
+        return jsonify({"group": group_name, "enabled": False})
+
+    @app.route("/groups/<group_name>/status", methods=["GET"])
+    def get_group_status(group_name):
+        """Get status of a scenario group."""
+        if not server.has_group(group_name):
+            return jsonify({"error": f"Group not found: {group_name}"}), 404
+
+        return jsonify(server.group_status(group_name))
+
+    @app.route("/concurrency-limit", methods=["GET"])
+    def get_concurrency_limit():
+        """Get the concurrency limit and its stats."""
+        return jsonify(server.concurrency_status())
+
+    @app.route("/concurrency-limit/enable", methods=["POST"])
+    def enable_concurrency_limit():
+        """
+        Cap concurrent upstream connections.
+
+        Request body:
+        {
+            "max_connections": 2,           // Required
+            "mode": "queue",                // "queue" (default) or "reject"
+            "scope": "cloudfetch",          // "cloudfetch" (default) or "all"
+            "queue_timeout_seconds": 30,    // Queue mode: 503 after waiting this long
+            "retry_after_seconds": 1        // Optional Retry-After header on 503s
+        }
+        """
+        data = _request_overrides()
+        if not data or "max_connections" not in data:
+            return jsonify({"error": "max_connections required"}), 400
+
+        try:
+            retry_after = data.get("retry_after_seconds")
+            limit = server.set_concurrency_limit(
+                int(data["max_connections"]),
+                mode=data.get("mode", "queue"),
+                scope=data.get("scope", "cloudfetch"),
+                queue_timeout_seconds=float(data.get("queue_timeout_seconds", 30)),
+                retry_after_seconds=(
+                    int(retry_after) if retry_after is not None else None
+                ),
+            )
+        except (TypeError, ValueError) as e:
+            return jsonify({"error": str(e)}), 400
+
+        logger.info(f"[API] Enabled concurrency limit: {limit}")
+        return jsonify({"enabled": True, "limit": limit})
+
+    @app.route("/concurrency-limit/disable", methods=["POST"])
+    def disable_concurrency_limit():
+        """Remove the concurrency limit; queued requests proceed."""
+        server.clear_concurrency_limit()
+
+        logger.info("[API] Disabled concurrency limit")
+        return jsonify({"enabled": False})
+
+    @app.route("/thrift/calls", methods=["GET"])
+    def get_thrift_calls():
+        """Get history of Thrift method calls."""
+        calls = server.calls()
+        return jsonify(
+            {
+                "calls": calls,
+                "count": len(calls),
+                "max_history": server.max_call_history,
+            }
+        )
+
+    @app.route("/thrift/calls/reset", methods=["POST"])
+    def reset_thrift_calls():
+        """Reset Thrift call history."""
+        server.reset_calls()
+
+        logger.info("[API] Reset Thrift call history")
+        return jsonify({"message": "Call history reset", "count": 0})
+
+    @app.route("/thrift/calls/verify", methods=["POST"])
+    def verify_thrift_calls():
+        """
+        Verify that Thrift calls match expected patterns.
+
+        Request body examples:
+
+        1. Exact sequence match:
+        {
+            "type": "exact_sequence",
+            "methods": ["ExecuteStatement", "FetchResults", "CloseOperation"]
+        }
+
+        2. Contains sequence (in order):
+        {
+            "type": "contains_sequence",
+            "methods": ["ExecuteStatement", "FetchResults"]
+        }
+
+        3. Method count:
+        {
+            "type": "method_count",
+            "method": "FetchResults",
+            "count": 2
+        }
+
+        4. Method exists:
+        {
+            "type": "method_exists",
+            "method": "ExecuteStatement"
+        }
+        """
+        try:
+            data = request.get_json(force=True, silent=True)
+        except Exception:
+            data = None
+
+        if not data:
+            return jsonify({"error": "Request body required"}), 400
+
+        verification_type = data.get("type")
+        if not verification_type:
+            return jsonify({"error": "Verification type required"}), 400
+
+        methods = [call["method"] for call in server.calls()]
+
+        try:
+            if verification_type == "exact_sequence":
+                expected = data.get("methods", [])
+                if methods == expected:
+                    return jsonify(
+                        {"verified": True, "actual": methods, "expected": expected}
+                    )
+                else:
+                    return jsonify(
+                        {"verified": False, "actual": methods, "expected": expected}
+                    )
+
+            elif verification_type == "contains_sequence":
+                expected = data.get("methods", [])
+                # Check if expected sequence appears in order (but not necessarily consecutive)
+                idx = 0
+                for method in methods:
+                    if idx < len(expected) and method == expected[idx]:
+                        idx += 1
+                verified = idx == len(expected)
                 return jsonify(
-                    {"verified": True, "actual": methods, "expected": expected}
+                    {"verified": verified, "actual": methods, "expected": expected}
                 )
-            else:
+
+            elif verification_type == "method_count":
+                method_name = data.get("method")
+                expected_count = data.get("count")
+                if not method_name or expected_count is None:
+                    return jsonify({"error": "method and count required"}), 400
+                actual_count = methods.count(method_name)
+                verified = actual_count == expected_count
                 return jsonify(
-                    {"verified": False, "actual": methods, "expected": expected}
+                    {
+                        "verified": verified,
+                        "method": method_name,
+                        "actual_count": actual_count,
+                        "expected_count": expected_count,
+                    }
                 )
 
-        elif verification_type == "contains_sequence":
-            expected = data.get("methods", [])
-            # Check if expected sequence appears in order (but not necessarily consecutive)
-            idx = 0
-            for method in methods:
-                if idx < len(expected) and method == expected[idx]:
-                    idx += 1
-            verified = idx == len(expected)
-            return jsonify(
-                {"verified": verified, "actual": methods, "expected": expected}
-            )
+            elif verification_type == "method_exists":
+                method_name = data.get("method")
+                if not method_name:
+                    return jsonify({"error": "method required"}), 400
+                verified = method_name in methods
+                return jsonify(
+                    {"verified": verified, "method": method_name, "actual": methods}
+                )
 
-        elif verification_type == "method_count":
-            method_name = data.get("method")
-            expected_count = data.get("count")
-            if not method_name or expected_count is None:
-                return jsonify({"error": "method and count required"}), 400
-            actual_count = methods.count(method_name)
-            verified = actual_count == expected_count
-            return jsonify(
-                {
-                    "verified": verified,
-                    "method": method_name,
-                    "actual_count": actual_count,
-                    "expected_count": expected_count,
-                }
-            )
+            else:
+                return jsonify(
+                    {"error": f"Unknown verification type: {verification_type}"}
+                ), 400
 
-        elif verification_type == "method_exists":
-            method_name = data.get("method")
-            if not method_name:
-                return jsonify({"error": "method required"}), 400
-            verified = method_name in methods
-            return jsonify(
-                {"verified": verified, "method": method_name, "actual": methods}
-            )
+        except Exception as e:
+            return jsonify({"error": str(e)}), 500
 
-        else:
-            return jsonify(
-                {"error": f"Unknown verification type: {verification_type}"}
-            ), 400
+    return app
 
-    except Exception as e:
-        return jsonify({"error": str(e)}), 500
+
+def serve_control_api(
+    server: ProxyServer, host: str = DEFAULT_API_HOST, port: int = DEFAULT_API_PORT
+) -> BaseWSGIServer:
+    """
+    Serve the control API for server from a daemon thread.
+
+    Pass port=0 to pick a free port (read it back from .server_port); call
+    .shutdown() on the result to stop serving.
+    """
+    api_server = make_server(host, port, create_app(server), threaded=True)
+    threading.Thread(
+        target=api_server.serve_forever, daemon=True, name="ControlAPI"
+    ).start()
+    return api_server
 
 
 # ===== mitmproxy Addon Class =====
@@ -431,24 +938,52 @@ def verify_thrift_calls():
 class FailureInjectionAddon:
     """mitmproxy addon that injects failures based on enabled scenarios."""
 
-    def __init__(self):
-        """Initialize addon and start control API server."""
-        ctx.log.info("Starting FailureInjectionAddon")
-
-        # Start Flask control API in background thread
-        def run_api():
-            # Disable reloader and use production mode for faster startup
-            app.run(
-                host="0.0.0.0",
-                port=18081,
-                threaded=True,
-                use_reloader=False,
-                debug=False,
+    def __init__(self, server: Optional[ProxyServer] = None):
+        self.server = server if server is not None else ProxyServer()
+        self._api_server: Optional[BaseWSGIServer] = None
+        # Patterns from the --set cloudfetch_patterns option extend these
+        self._base_cloudfetch_patterns = self.server.cloudfetch_patterns()
+        # Notified whenever a concurrency limit slot is released
+        self._slot_released = asyncio.Condition()
+
+    def load(self, loader) -> None:
+        """Register addon options with mitmproxy."""
+        loader.add_option(
+            name="control_api_port",
+            typespec=int,
+            default=DEFAULT_API_PORT,
+            help="Port for the failure injection control API",
+        )
+        loader.add_option(
+            name="cloudfetch_patterns",
+            typespec=Sequence[str],
+            default=[],
+            help=(
+                "Additional host_glob[/path_glob] patterns identifying "
+                "CloudFetch downloads, e.g. for on-prem object stores"
+            ),
+        )
+
+    def configure(self, updated) -> None:
+        """Apply option changes."""
+        if "cloudfetch_patterns" in updated:
+            self.server.set_cloudfetch_patterns(
+                self._base_cloudfetch_patterns + list(ctx.options.cloudfetch_patterns)
             )
 
-        api_thread = threading.Thread(target=run_api, daemon=True, name="ControlAPI")
-        api_thread.start()
-        ctx.log.info("Control API started on http://0.0.0.0:18081")
+    def running(self) -> None:
+        """Start the control API once mitmproxy is up."""
+        if self._api_server is not None:
+            return
+        port = ctx.options.control_api_port
+        self._api_server = serve_control_api(self.server, DEFAULT_API_HOST, port)
+        logger.info(f"Control API started on http://{DEFAULT_API_HOST}:{port}")
+
+    def done(self) -> None:
+        """Stop the control API when mitmproxy shuts down."""
+        if self._api_server is not None:
+            self._api_server.shutdown()
+            self._api_server = None
 
     async def request(self, flow: http.HTTPFlow) -> None:
         """
@@ -457,19 +992,16 @@ class FailureInjectionAddon:
         Made async to support non-blocking delays.
         """
         # Detect request type
-        if self._is_cloudfetch_download(flow.request):
+        cloudfetch = self._is_cloudfetch_download(flow.request)
+        if cloudfetch:
             # Track cloud fetch download
-            with state_lock:
-                call_record = {
+            self.server.record_call(
+                {
                     "timestamp": time.time(),
                     "type": "cloud_download",
                     "url": flow.request.pretty_url,
                 }
-                call_history.append(call_record)
-
-                # Enforce max history limit
-                if len(call_history) > MAX_CALL_HISTORY:
-                    del call_history[: len(call_history) - MAX_CALL_HISTORY]
+            )
 
             await self._handle_cloudfetch_request(flow)
         elif self._is_thrift_request(flow.request):
@@ -477,17 +1009,80 @@ class FailureInjectionAddon:
             # Check for session-related failure scenarios
             await self._handle_thrift_session_scenarios(flow)
 
+        # Requests answered by an injected failure never reach upstream
+        if flow.response is None and flow.error is None:
+            await self._acquire_connection_slot(flow, cloudfetch)
+
+    async def _acquire_connection_slot(
+        self, flow: http.HTTPFlow, cloudfetch: bool
+    ) -> None:
+        """Enforce the concurrency limit, queueing or rejecting the request."""
+        acquired = self.server.try_acquire_connection(cloudfetch)
+        if acquired is None:
+            return
+
+        if not acquired:
+            limit = self.server.concurrency_limit()
+            if limit is None:
+                return  # Limit removed concurrently
+            if limit["mode"] == "reject":
+                self._reject_over_limit(flow, limit)
+                return
+
+            self.server.record_concurrency_event("queued")
+            logger.info(
+                "[INJECT] Queueing request over concurrency limit: "
+                f"{flow.request.pretty_url}"
+            )
+            deadline = time.monotonic() + limit["queue_timeout_seconds"]
+            while True:
+                remaining = deadline - time.monotonic()
+                if remaining <= 0:
+                    self._reject_over_limit(flow, limit)
+                    return
+                # Wake on released slots, and poll so that limit changes made
+                # through the control API take effect for queued requests
+                try:
+                    async with self._slot_released:
+                        await asyncio.wait_for(
+                            self._slot_released.wait(), timeout=min(remaining, 0.1)
+                        )
+                except asyncio.TimeoutError:
+                    pass
+                acquired = self.server.try_acquire_connection(cloudfetch)
+                if acquired is None:
+                    return  # Limit removed while queued
+                if acquired:
+                    break
+
+        flow.metadata[CONCURRENCY_SLOT_KEY] = True
+
+    def _reject_over_limit(self, flow: http.HTTPFlow, limit: Dict[str, Any]) -> None:
+        self.server.record_concurrency_event("rejected")
+        logger.info(
+            "[INJECT] Rejecting request over concurrency limit: "
+            f"{flow.request.pretty_url}"
+        )
+        headers = {"Content-Type": "text/plain"}
+        if limit.get("retry_after_seconds") is not None:
+            headers["Retry-After"] = str(limit["retry_after_seconds"])
+        flow.response = http.Response.make(
+            503, b"Service Unavailable: too many concurrent connections", headers
+        )
+
+    async def _release_connection_slot(self, flow: http.HTTPFlow) -> None:
+        if not flow.metadata.pop(CONCURRENCY_SLOT_KEY, False):
+            return
+        self.server.release_connection()
+        async with self._slot_released:
+            self._slot_released.notify_all()
+
     def _is_cloudfetch_download(self, request: http.Request) -> bool:
         """Detect if this is a CloudFetch download to cloud storage."""
         if request.method != "GET":
             return False
 
-        host = request.pretty_host.lower()
-        return (
-            "blob.core.windows.net" in host
-            or "s3.amazonaws.com" in host
-            or "storage.googleapis.com" in host
-        )
+        return self.server.is_cloudfetch_download(request.pretty_host, request.path)
 
     def _is_thrift_request(self, request: http.Request) -> bool:
         """
@@ -509,23 +1104,12 @@ class FailureInjectionAddon:
 
     async def _handle_cloudfetch_request(self, flow: http.HTTPFlow) -> None:
         """Handle CloudFetch requests and inject failures if scenario is enabled."""
-        with state_lock:
-            # Find first enabled CloudFetch scenario
-            enabled_scenario = None
-            for name in enabled_scenarios:
-                scenario_config = enabled_scenarios[name]
-                if scenario_config is not False:
-                    # scenario_config is now the full config dict
-                    base_config = SCENARIOS.get(name, {})
-                    if base_config.get("operation") == "CloudFetchDownload":
-                        enabled_scenario = (name, scenario_config)
-                        break
-
+        enabled_scenario = self.server.claim_scenario(["CloudFetchDownload"])
         if not enabled_scenario:
             return  # No scenario enabled, let request proceed normally
 
         scenario_name, scenario_config = enabled_scenario
-        ctx.log.info(
+        logger.info(
             f"[INJECT] Triggering scenario: {scenario_name} for {flow.request.pretty_url}"
         )
 
@@ -539,7 +1123,6 @@ class FailureInjectionAddon:
                 b"AuthorizationQueryParametersError: Query Parameters are not supported for this operation",
                 {"Content-Type": "text/plain"},
             )
-            self._disable_scenario(scenario_name)
 
         elif action == "return_error":
             # Return HTTP error with specified code and message
@@ -552,20 +1135,15 @@ class FailureInjectionAddon:
                 error_message.encode("utf-8"),
                 {"Content-Type": "text/plain"},
             )
-            self._disable_scenario(scenario_name)
 
         elif action == "delay":
             # Inject delay using asyncio.sleep() to avoid blocking the event loop
-            import asyncio
-
             duration_seconds = scenario_config.get("duration_seconds", 5)
-            ctx.log.info(
+            logger.info(
                 f"[INJECT] Delaying {duration_seconds}s for scenario: {scenario_name}"
             )
-            # Disable BEFORE the delay so new requests don't trigger this scenario
-            self._disable_scenario(scenario_name)
             await asyncio.sleep(duration_seconds)
-            ctx.log.info(f"[INJECT] Delay complete for scenario: {scenario_name}")
+            logger.info(f"[INJECT] Delay complete for scenario: {scenario_name}")
             # Let request continue after delay
 
         elif action == "close_connection":
@@ -574,7 +1152,10 @@ class FailureInjectionAddon:
                 500, b"Connection reset by peer", {"Content-Type": "text/plain"}
             )
             flow.kill()
-            self._disable_scenario(scenario_name)
+
+        elif action == "mutate_headers":
+            # Applied to the upstream response in response()
+            flow.metadata[MUTATE_HEADERS_KEY] = scenario_config
 
     async def _handle_thrift_session_scenarios(self, flow: http.HTTPFlow) -> None:
         """Handle Thrift session-related failure scenarios."""
@@ -589,38 +1170,16 @@ class FailureInjectionAddon:
         method_name = decoded.get("method", "")
 
         # Find enabled scenario that matches this Thrift operation
-        with state_lock:
-            enabled_scenario = None
-            for name in enabled_scenarios:
-                scenario_config = enabled_scenarios[name]
-                if scenario_config is not False:
-                    base_config = SCENARIOS.get(name, {})
-                    operation = base_config.get("operation", "")
-
-                    # Check if this scenario matches the operation
-                    if operation == "ThriftOperation" or operation == method_name:
-                        # Check trigger_after_count if specified
-                        trigger_after = base_config.get("trigger_after_count", 0)
-                        if trigger_after > 0:
-                            # Track call count for this scenario + method combination
-                            key = f"{name}:{method_name}"
-                            current_count = scenario_call_counts.get(key, 0)
-                            scenario_call_counts[key] = current_count + 1
-
-                            # Only trigger if we've reached the threshold
-                            if scenario_call_counts[key] <= trigger_after:
-                                continue  # Skip this scenario for now
-
-                        enabled_scenario = (name, scenario_config, base_config)
-                        break
-
+        enabled_scenario = self.server.claim_scenario(
+            ["ThriftOperation", method_name], method_name
+        )
         if not enabled_scenario:
             return  # No matching scenario enabled
 
-        scenario_name, scenario_config, base_config = enabled_scenario
-        action = base_config.get("action", "")
+        scenario_name, scenario_config = enabled_scenario
+        action = scenario_config.get("action", "")
 
-        ctx.log.info(
+        logger.info(
             f"[INJECT] Triggering Thrift scenario: {scenario_name} for method: {method_name}"
         )
 
@@ -628,52 +1187,52 @@ class FailureInjectionAddon:
             # Create a Thrift error response
             # For simplicity, return HTTP 500 with error message
             # A full implementation would construct proper Thrift error response
-            error_message = base_config.get("error_message", "Thrift operation failed")
-            error_type = base_config.get("error_type", "UNKNOWN_ERROR")
+            error_message = scenario_config.get(
+                "error_message", "Thrift operation failed"
+            )
+            error_type = scenario_config.get("error_type", "UNKNOWN_ERROR")
 
             flow.response = http.Response.make(
                 500,
                 f"Thrift Error [{error_type}]: {error_message}".encode("utf-8"),
                 {"Content-Type": "application/x-thrift"},
             )
-            self._disable_scenario(scenario_name)
 
         elif action == "return_auth_error":
             # Return HTTP 401 for authentication failures (non-retryable)
-            error_message = base_config.get("error_message", "Authentication failed")
-            error_type = base_config.get("error_type", "UNAUTHORIZED")
+            error_message = scenario_config.get(
+                "error_message", "Authentication failed"
+            )
+            error_type = scenario_config.get("error_type", "UNAUTHORIZED")
 
             flow.response = http.Response.make(
                 401,
                 f"Authentication Error [{error_type}]: {error_message}".encode("utf-8"),
                 {"Content-Type": "application/x-thrift"},
             )
-            self._disable_scenario(scenario_name)
 
         elif action == "delay":
             # Inject delay for slow operations
-            import asyncio
-
-            duration_seconds = base_config.get("duration_seconds", 5)
-            ctx.log.info(
+            duration_seconds = scenario_config.get("duration_seconds", 5)
+            logger.info(
                 f"[INJECT] Delaying {duration_seconds}s for Thrift scenario: {scenario_name}"
             )
-            self._disable_scenario(scenario_name)
             await asyncio.sleep(duration_seconds)
-            ctx.log.info(
+            logger.info(
                 f"[INJECT] Delay complete for Thrift scenario: {scenario_name}"
             )
 
         elif action == "return_error":
             # Return HTTP error with specified code and message
-            error_code = base_config.get("error_code", 500)
-            error_message = base_config.get("error_message", "Internal Server Error")
+            error_code = scenario_config.get("error_code", 500)
+            error_message = scenario_config.get(
+                "error_message", "Internal Server Error"
+            )
             flow.response = http.Response.make(
                 error_code,
                 error_message.encode("utf-8"),
                 {"Content-Type": "text/plain"},
             )
-            self._disable_scenario(scenario_name)
 
         elif action == "close_connection":
             # Kill the connection abruptly
@@ -681,15 +1240,18 @@ class FailureInjectionAddon:
                 500, b"Connection reset by peer", {"Content-Type": "text/plain"}
             )
             flow.kill()
-            self._disable_scenario(scenario_name)
+
+        elif action == "mutate_headers":
+            # Applied to the upstream response in response()
+            flow.metadata[MUTATE_HEADERS_KEY] = scenario_config
 
         elif action == "track_active_operations":
             # For CloseSession with active operations
             # This is a passive tracking scenario - log but don't block
-            ctx.log.info(
+            logger.info(
                 f"[INJECT] Tracking scenario: {scenario_name} - CloseSession called"
             )
-            # Don't disable - let the request proceed normally
+            # Passive scenario, stays enabled - let the request proceed normally
             # Tests can verify behavior via call tracking
 
     def _handle_thrift_request(self, flow: http.HTTPFlow) -> None:
@@ -699,11 +1261,11 @@ class FailureInjectionAddon:
             decoded = decode_thrift_message(flow.request.content)
             if decoded and "error" not in decoded:
                 formatted = format_thrift_message(decoded, max_field_length=100)
-                ctx.log.info(f"[THRIFT REQUEST]\n{formatted}")
+                logger.info(f"[THRIFT REQUEST]\n{formatted}")
 
                 # Track call in history
-                with state_lock:
-                    call_record = {
+                self.server.record_call(
+                    {
                         "timestamp": time.time(),
                         "type": "thrift",
                         "method": decoded.get("method", "unknown"),
@@ -711,37 +1273,52 @@ class FailureInjectionAddon:
                         "sequence_id": decoded.get("sequence_id", 0),
                         "fields": decoded.get("fields", {}),
                     }
-                    call_history.append(call_record)
-
-                    # Enforce max history limit
-                    if len(call_history) > MAX_CALL_HISTORY:
-                        # Remove oldest calls to stay within limit
-                        del call_history[: len(call_history) - MAX_CALL_HISTORY]
+                )
 
             elif decoded:
-                ctx.log.warn(f"[THRIFT REQUEST] Decode error: {decoded.get('error')}")
+                logger.warning(f"[THRIFT REQUEST] Decode error: {decoded.get('error')}")
 
-    def response(self, flow: http.HTTPFlow) -> None:
+    async def response(self, flow: http.HTTPFlow) -> None:
         """
-        Intercept responses to log Thrift messages.
+        Intercept responses to release concurrency limit slots, apply header
+        mutations and log Thrift messages.
         Called by mitmproxy for each HTTP response.
         """
+        await self._release_connection_slot(flow)
+
+        mutation = flow.metadata.pop(MUTATE_HEADERS_KEY, None)
+        if mutation and flow.response:
+            self._mutate_headers(flow.response, mutation)
+
         if self._is_thrift_request(flow.request) and flow.response:
             if flow.response.content:
                 decoded = decode_thrift_message(flow.response.content)
                 if decoded and "error" not in decoded:
                     formatted = format_thrift_message(decoded, max_field_length=100)
-                    ctx.log.info(f"[THRIFT RESPONSE]\n{formatted}")
+                    logger.info(f"[THRIFT RESPONSE]\n{formatted}")
                 elif decoded:
-                    ctx.log.warn(
+                    logger.warning(
                         f"[THRIFT RESPONSE] Decode error: {decoded.get('error')}"
                     )
 
-    def _disable_scenario(self, scenario_name: str) -> None:
-        """Disable a scenario after one-shot injection."""
-        with state_lock:
-            enabled_scenarios[scenario_name] = False
-        ctx.log.info(f"[INJECT] Auto-disabled scenario: {scenario_name}")
+    async def error(self, flow: http.HTTPFlow) -> None:
+        """Release the concurrency limit slot of a failed request."""
+        await self._release_connection_slot(flow)
+
+    def _mutate_headers(
+        self, response: http.Response, config: Dict[str, Any]
+    ) -> None:
+        """Remove and then set response headers as configured by a scenario."""
+        for name in config.get("remove_headers", []):
+            if name in response.headers:
+                del response.headers[name]
+        for name, value in config.get("set_headers", {}).items():
+            response.headers[name] = value
+        logger.info(
+            "[INJECT] Mutated response headers: "
+            f"removed {config.get('remove_headers', [])}, "
+            f"set {config.get('set_headers', {})}"
+        )
 
 
 # Register addon with mitmproxy
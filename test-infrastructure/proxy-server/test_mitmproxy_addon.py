@@ -0,0 +1,407 @@
+# Copyright (c) 2025 ADBC Drivers Contributors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Unit tests for the proxy's ProxyServer state and control API."""
+
+import threading
+
+import pytest
+
+from mitmproxy_addon import (
+    SCENARIO_GROUPS,
+    SCENARIOS,
+    ProxyServer,
+    create_app,
+    parse_cloudfetch_pattern,
+)
+
+TEST_SCENARIOS = {
+    "download_error": {
+        "description": "CloudFetch download fails",
+        "operation": "CloudFetchDownload",
+        "action": "return_error",
+        "error_code": 500,
+    },
+    "download_delay": {
+        "description": "CloudFetch download is delayed",
+        "operation": "CloudFetchDownload",
+        "action": "delay",
+        "duration_seconds": 5,
+    },
+    "strip_headers": {
+        "description": "CloudFetch response loses its ETag",
+        "operation": "CloudFetchDownload",
+        "action": "mutate_headers",
+        "remove_headers": ["ETag"],
+    },
+    "third_fetch_fails": {
+        "description": "The third FetchResults fails",
+        "operation": "FetchResults",
+        "action": "return_thrift_error",
+        "trigger_after_count": 2,
+    },
+    "track_operations": {
+        "description": "Track active operations",
+        "operation": "ExecuteStatement",
+        "action": "track_active_operations",
+    },
+}
+
+TEST_GROUPS = {
+    "downloads": ["download_error", "download_delay"],
+}
+
+
+@pytest.fixture
+def server() -> ProxyServer:
+    return ProxyServer(scenarios=TEST_SCENARIOS, groups=TEST_GROUPS)
+
+
+@pytest.fixture
+def client(server: ProxyServer):
+    return create_app(server).test_client()
+
+
+# ===== ProxyServer =====
+
+
+def test_default_definitions():
+    server = ProxyServer()
+    assert [s["name"] for s in server.list_scenarios()] == list(SCENARIOS)
+    assert [g["name"] for g in server.list_groups()] == list(SCENARIO_GROUPS)
+    assert not any(s["enabled"] for s in server.list_scenarios())
+
+
+def test_group_with_unknown_scenario():
+    with pytest.raises(ValueError, match="unknown scenarios"):
+        ProxyServer(
+            scenarios=TEST_SCENARIOS, groups={"bad": ["download_error", "nope"]}
+        )
+
+
+def test_servers_are_independent():
+    first = ProxyServer(scenarios=TEST_SCENARIOS, groups=TEST_GROUPS)
+    second = ProxyServer(scenarios=TEST_SCENARIOS, groups=TEST_GROUPS)
+    first.enable_scenario("download_error")
+    assert first.scenario_status("download_error")["enabled"]
+    assert not second.scenario_status("download_error")["enabled"]
+
+
+def test_enable_and_disable(server: ProxyServer):
+    server.record_call({"method": "ExecuteStatement"})
+    config = server.enable_scenario("download_error")
+    assert config["error_code"] == 500
+    status = server.scenario_status("download_error")
+    assert status["enabled"]
+    assert status["config"] == config
+    # Enabling a scenario resets the call history
+    assert server.calls() == []
+
+    server.disable_scenario("download_error")
+    assert not server.scenario_status("download_error")["enabled"]
+
+    server.enable_scenario("download_error")
+    server.enable_scenario("download_delay")
+    server.disable_all()
+    assert not any(s["enabled"] for s in server.list_scenarios())
+
+
+def test_delay_override(server: ProxyServer):
+    config = server.enable_scenario("download_delay", {"duration_seconds": "2"})
+    assert config["duration_seconds"] == 2
+    # Overrides don't change the definition
+    assert server.enable_scenario("download_delay")["duration_seconds"] == 5
+    # Only delay scenarios take a duration
+    config = server.enable_scenario("download_error", {"duration_seconds": 2})
+    assert "duration_seconds" not in config
+
+
+def test_call_history_limit():
+    server = ProxyServer(
+        scenarios=TEST_SCENARIOS, groups=TEST_GROUPS, max_call_history=3
+    )
+    for i in range(5):
+        server.record_call({"method": str(i)})
+    assert [call["method"] for call in server.calls()] == ["2", "3", "4"]
+    server.reset_calls()
+    assert server.calls() == []
+
+
+# ===== claim_scenario =====
+
+
+def test_claim_is_one_shot(server: ProxyServer):
+    assert server.claim_scenario(["CloudFetchDownload"]) is None
+
+    server.enable_scenario("download_error")
+    name, config = server.claim_scenario(["CloudFetchDownload"])
+    assert name == "download_error"
+    assert config["action"] == "return_error"
+    assert not server.scenario_status("download_error")["enabled"]
+    assert server.claim_scenario(["CloudFetchDownload"]) is None
+
+
+def test_claim_matches_operation(server: ProxyServer):
+    server.enable_scenario("download_error")
+    assert server.claim_scenario(["FetchResults", "ExecuteStatement"]) is None
+    assert server.scenario_status("download_error")["enabled"]
+
+
+def test_claim_passive_scenario(server: ProxyServer):
+    server.enable_scenario("track_operations")
+    for _ in range(3):
+        name, _ = server.claim_scenario(["ExecuteStatement"])
+        assert name == "track_operations"
+    assert server.scenario_status("track_operations")["enabled"]
+
+
+def test_claim_after_count(server: ProxyServer):
+    server.enable_scenario("third_fetch_fails")
+    assert server.claim_scenario(["FetchResults"], "FetchResults") is None
+    assert server.claim_scenario(["FetchResults"], "FetchResults") is None
+    name, _ = server.claim_scenario(["FetchResults"], "FetchResults")
+    assert name == "third_fetch_fails"
+
+    # Re-enabling starts counting again
+    server.enable_scenario("third_fetch_fails")
+    assert server.claim_scenario(["FetchResults"], "FetchResults") is None
+
+
+def test_concurrent_claims(server: ProxyServer):
+    server.enable_scenario("download_error")
+    threads = 16
+    barrier = threading.Barrier(threads)
+    claims = []
+    lock = threading.Lock()
+
+    def claim():
+        barrier.wait()
+        result = server.claim_scenario(["CloudFetchDownload"])
+        with lock:
+            claims.append(result)
+
+    workers = [threading.Thread(target=claim) for _ in range(threads)]
+    for worker in workers:
+        worker.start()
+    for worker in workers:
+        worker.join()
+
+    won = [result for result in claims if result is not None]
+    assert len(claims) == threads
+    assert [name for name, _ in won] == ["download_error"]
+
+
+# ===== Groups =====
+
+
+def test_groups(server: ProxyServer):
+    assert server.has_group("downloads")
+    assert not server.has_group("nope")
+    assert server.group_status("downloads") == {
+        "name": "downloads",
+        "scenarios": ["download_error", "download_delay"],
+        "enabled": False,
+        "enabled_scenarios": [],
+    }
+
+    server.record_call({"method": "ExecuteStatement"})
+    configs = server.enable_group("downloads", {"duration_seconds": 1})
+    assert set(configs) == {"download_error", "download_delay"}
+    assert configs["download_delay"]["duration_seconds"] == 1
+    assert server.calls() == []
+    assert server.group_status("downloads")["enabled"]
+
+    # Members stay one-shot: each claim consumes the next enabled member
+    name, _ = server.claim_scenario(["CloudFetchDownload"])
+    assert name == "download_error"
+    status = server.group_status("downloads")
+    assert not status["enabled"]
+    assert status["enabled_scenarios"] == ["download_delay"]
+
+    server.disable_group("downloads")
+    assert server.group_status("downloads")["enabled_scenarios"] == []
+
+
+def test_default_groups_reference_scenarios():
+    for members in SCENARIO_GROUPS.values():
+        assert all(name in SCENARIOS for name in members)
+
+
+# ===== CloudFetch detection =====
+
+
+@pytest.mark.parametrize(
+    "host,path,expected",
+    [
+        ("myaccount.blob.core.windows.net", "/results/chunk-0", True),
+        ("MyAccount.Blob.Core.Windows.Net", "/results/chunk-0", True),
+        ("bucket.s3.us-west-2.amazonaws.com", "/chunk?X-Amz-Signature=x", True),
+        ("s3.amazonaws.com", "/bucket/chunk", True),
+        ("storage.googleapis.com", "/bucket/chunk", True),
+        ("bucket.storage.googleapis.com", "/chunk", True),
+        ("acct.r2.cloudflarestorage.com", "/chunk", True),
+        ("minio", "/bucket/chunk", True),
+        ("minio.local", "/bucket/chunk", True),
+        ("workspace.cloud.databricks.com", "/sql/1.0/warehouses/abc", False),
+        ("blob.core.windows.net.example.com", "/chunk", False),
+    ],
+)
+def test_default_cloudfetch_patterns(host: str, path: str, expected: bool):
+    assert ProxyServer().is_cloudfetch_download(host, path) == expected
+
+
+def test_cloudfetch_path_patterns():
+    server = ProxyServer(cloudfetch_patterns=["Storage.Internal/results/*"])
+    assert server.cloudfetch_patterns() == ["storage.internal/results/*"]
+    assert server.is_cloudfetch_download("storage.internal", "/results/chunk-0")
+    assert server.is_cloudfetch_download("storage.internal", "/results/chunk-0?sig=1")
+    assert not server.is_cloudfetch_download("storage.internal", "/other/chunk-0")
+    assert not server.is_cloudfetch_download("myaccount.blob.core.windows.net", "/x")
+
+    server.set_cloudfetch_patterns(["*.blob.core.windows.net"])
+    assert server.cloudfetch_patterns() == ["*.blob.core.windows.net"]
+    assert not server.is_cloudfetch_download("storage.internal", "/results/chunk-0")
+
+
+def test_parse_cloudfetch_pattern():
+    assert parse_cloudfetch_pattern("Host.Example") == ("host.example", "*")
+    assert parse_cloudfetch_pattern(" host/a/* ") == ("host", "/a/*")
+    with pytest.raises(ValueError, match="missing host"):
+        parse_cloudfetch_pattern("/results/*")
+
+
+# ===== mutate_headers overrides =====
+
+
+def test_mutate_headers_overrides(server: ProxyServer):
+    config = server.enable_scenario(
+        "strip_headers",
+        {"remove_headers": ["Content-Length"], "set_headers": {"X-Test": "1"}},
+    )
+    assert config["remove_headers"] == ["Content-Length"]
+    assert config["set_headers"] == {"X-Test": "1"}
+    # Only mutate_headers scenarios take header overrides
+    config = server.enable_scenario("download_error", {"remove_headers": "ETag"})
+    assert "remove_headers" not in config
+
+
+@pytest.mark.parametrize(
+    "overrides,message",
+    [
+        ({"remove_headers": "ETag"}, "remove_headers"),
+        ({"remove_headers": ["ETag", 1]}, "remove_headers"),
+        ({"set_headers": ["X-Test"]}, "set_headers"),
+        ({"set_headers": {"X-Test": 1}}, "set_headers"),
+    ],
+)
+def test_invalid_mutate_headers_overrides(server: ProxyServer, overrides, message):
+    with pytest.raises(ValueError, match=message):
+        server.enable_scenario("strip_headers", overrides)
+    assert not server.scenario_status("strip_headers")["enabled"]
+
+
+# ===== Control API =====
+
+
+def test_api_scenarios(client, server: ProxyServer):
+    response = client.get("/scenarios")
+    assert response.status_code == 200
+    scenarios = response.get_json()["scenarios"]
+    assert [s["name"] for s in scenarios] == list(TEST_SCENARIOS)
+
+    response = client.post(
+        "/scenarios/download_delay/enable", json={"duration_seconds": 1}
+    )
+    assert response.status_code == 200
+    body = response.get_json()
+    assert body["enabled"]
+    assert body["config"]["duration_seconds"] == 1
+    assert client.get("/scenarios/download_delay/status").get_json()["enabled"]
+
+    response = client.post("/scenarios/download_delay/disable")
+    assert response.status_code == 200
+    assert response.get_json() == {"scenario": "download_delay", "enabled": False}
+
+    server.enable_scenario("download_error")
+    assert client.post("/scenarios/disable-all").status_code == 200
+    assert not any(s["enabled"] for s in server.list_scenarios())
+
+
+def test_api_enable_without_body(client, server: ProxyServer):
+    response = client.post("/scenarios/download_error/enable")
+    assert response.status_code == 200
+    assert server.scenario_status("download_error")["enabled"]
+
+
+@pytest.mark.parametrize(
+    "method,path",
+    [
+        ("post", "/scenarios/nope/enable"),
+        ("post", "/scenarios/nope/disable"),
+        ("get", "/scenarios/nope/status"),
+        ("post", "/groups/nope/enable"),
+        ("post", "/groups/nope/disable"),
+        ("get", "/groups/nope/status"),
+    ],
+)
+def test_api_not_found(client, method: str, path: str):
+    response = getattr(client, method)(path)
+    assert response.status_code == 404
+    assert "not found" in response.get_json()["error"]
+
+
+def test_api_invalid_override(client, server: ProxyServer):
+    response = client.post(
+        "/scenarios/strip_headers/enable", json={"set_headers": {"X-Test": 1}}
+    )
+    assert response.status_code == 400
+    assert "set_headers" in response.get_json()["error"]
+    assert not server.scenario_status("strip_headers")["enabled"]
+
+
+def test_api_groups(client, server: ProxyServer):
+    response = client.get("/groups")
+    assert response.status_code == 200
+    assert response.get_json()["groups"] == server.list_groups()
+
+    response = client.post("/groups/downloads/enable")
+    assert response.status_code == 200
+    scenarios = set(response.get_json()["scenarios"])
+    assert scenarios == {"download_error", "download_delay"}
+    assert client.get("/groups/downloads/status").get_json()["enabled"]
+
+    response = client.post("/groups/downloads/disable")
+    assert response.status_code == 200
+    assert not server.group_status("downloads")["enabled_scenarios"]
+
+
+def test_api_thrift_calls(client, server: ProxyServer):
+    for method in ["OpenSession", "ExecuteStatement", "FetchResults", "FetchResults"]:
+        server.record_call({"method": method})
+
+    body = client.get("/thrift/calls").get_json()
+    assert body["count"] == 4
+
+    def verify(**body):
+        return client.post("/thrift/calls/verify", json=body).get_json()["verified"]
+
+    assert verify(type="contains_sequence", methods=["OpenSession", "FetchResults"])
+    assert not verify(type="exact_sequence", methods=["OpenSession", "FetchResults"])
+    assert verify(type="method_count", method="FetchResults", count=2)
+    assert verify(type="method_exists", method="ExecuteStatement")
+    response = client.post("/thrift/calls/verify", json={"type": "nope"})
+    assert response.status_code == 400
+
+    assert client.post("/thrift/calls/reset").status_code == 200
+    assert server.calls() == []
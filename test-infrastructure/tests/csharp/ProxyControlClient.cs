@@ -142,6 +142,31 @@ namespace AdbcDrivers.Databricks.Tests.ThriftProtocol
             }
         }
 
+        /// <summary>
+        /// Enables every scenario in a scenario group.
+        /// Call history is automatically reset.
+        /// </summary>
+        public async Task EnableScenarioGroupAsync(string groupName, CancellationToken cancellationToken = default)
+        {
+            var response = await _httpClient.PostAsync($"/groups/{Uri.EscapeDataString(groupName)}/enable", null, cancellationToken);
+            if (!response.IsSuccessStatusCode)
+            {
+                throw new InvalidOperationException($"Failed to enable scenario group '{groupName}'. Status: {response.StatusCode}");
+            }
+        }
+
+        /// <summary>
+        /// Disables every scenario in a scenario group.
+        /// </summary>
+        public async Task DisableScenarioGroupAsync(string groupName, CancellationToken cancellationToken = default)
+        {
+            var response = await _httpClient.PostAsync($"/groups/{Uri.EscapeDataString(groupName)}/disable", null, cancellationToken);
+            if (!response.IsSuccessStatusCode)
+            {
+                throw new InvalidOperationException($"Failed to disable scenario group '{groupName}'. Status: {response.StatusCode}");
+            }
+        }
+
         /// <summary>
         /// Gets the history of Thrift method calls recorded by the proxy.
         /// Call history is automatically reset when a scenario is enabled.
@@ -91,6 +91,7 @@ namespace AdbcDrivers.Databricks.Tests.ThriftProtocol
             // mitmdump: headless version of mitmproxy (no UI)
             // -s: load addon script
             // --listen-port: proxy port
+            // --set control_api_port: port for the addon's control API
             // --set confdir: certificate directory (expand ~ to actual home directory)
             var homeDirectory = Environment.GetFolderPath(Environment.SpecialFolder.UserProfile);
             var mitmproxyConfigDir = Path.Combine(homeDirectory, ".mitmproxy");
@@ -100,7 +101,7 @@ namespace AdbcDrivers.Databricks.Tests.ThriftProtocol
                 StartInfo = new ProcessStartInfo
                 {
                     FileName = "mitmdump",
-                    Arguments = $"-s \"{_addonScriptPath}\" --listen-port {_proxyPort} --set confdir=\"{mitmproxyConfigDir}\"",
+                    Arguments = $"-s \"{_addonScriptPath}\" --listen-port {_proxyPort} --set control_api_port={_apiPort} --set confdir=\"{mitmproxyConfigDir}\"",
                     UseShellExecute = false,
                     RedirectStandardOutput = true,
                     RedirectStandardError = true,